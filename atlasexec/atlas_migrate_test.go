@@ -1183,3 +1183,17 @@ func TestMigrate_Diff(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, output.Files, 0)
 }
+
+func TestSummaryReport_Findings(t *testing.T) {
+	var r atlasexec.SummaryReport
+	require.NoError(t, json.Unmarshal([]byte(`{"Env":{"Dir":"migrations"},"Steps":[{"Name":"Analyze 1.sql","Result":{"Name":"1.sql","Reports":[{"Text":"destructive changes detected"}]}},{"Name":"Analyze migration directory","Result":{"Reports":[{"Text":"naming violations detected","Diagnostics":[{"Text":"Table \"T\" violates the naming policy","Code":"NM102"}]}]}}],"Files":[{"Name":"1.sql","Text":"CREATE TABLE t(c int);\nDROP TABLE t;","Reports":[{"Text":"destructive changes detected","Diagnostics":[{"Pos":23,"Text":"Dropping table \"t\"","Code":"DS102"}]}],"Error":"destructive changes detected"},{"Name":"2.sql","Error":"executing statement; checking statement"}]}`), &r))
+	require.Equal(t, sqlcheck.Findings{
+		Files: []string{filepath.Join("migrations", "1.sql"), filepath.Join("migrations", "2.sql"), "migrations"},
+		List: []sqlcheck.Finding{
+			{Path: filepath.Join("migrations", "1.sql"), Line: 2, Analyzer: "destructive changes detected", Code: "DS102", Text: `Dropping table "t"`, Error: true},
+			{Path: filepath.Join("migrations", "2.sql"), Text: "executing statement", Error: true},
+			{Path: filepath.Join("migrations", "2.sql"), Text: "checking statement", Error: true},
+			{Path: "migrations", Analyzer: "naming violations detected", Code: "NM102", Text: `Table "T" violates the naming policy`},
+		},
+	}, r.Findings())
+}
@@ -6,6 +6,9 @@ package atlasexec
 
 import (
 	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"ariga.io/atlas/sql/schema"
@@ -66,6 +69,8 @@ type (
 		Steps []*StepReport `json:"Steps,omitempty"`
 		// Files reports. Non-empty in case there are findings.
 		Files []*FileReport `json:"Files,omitempty"`
+		// Names of the analyzers that ran on the files.
+		Analyzers []string `json:"Analyzers,omitempty"`
 	}
 	// StepReport contains a summary of the analysis of a single step.
 	StepReport struct {
@@ -159,6 +164,42 @@ func (r *SummaryReport) DiagnosticsCount() int {
 	return n
 }
 
+// NonFileReports returns the step reports that are not related to a file,
+// e.g., reports of analyzers that run on the entire migration directory.
+func (r *SummaryReport) NonFileReports() (rs []*StepReport) {
+	for _, s := range r.Steps {
+		if r1 := s.Result; r1 != nil && len(r1.Reports) > 0 && !slices.ContainsFunc(r.Files, func(f *FileReport) bool { return f.Name == r1.Name }) {
+			rs = append(rs, s)
+		}
+	}
+	return rs
+}
+
+// Findings returns the findings of the summary report, with the diagnostics resolved
+// to file lines. Use it with the sqlcheck.WriteSARIF, WriteJUnit, WriteGitHub and
+// WriteGitLab functions to encode the report in one of the standard CI formats.
+func (r *SummaryReport) Findings() sqlcheck.Findings {
+	var (
+		files   = make([]sqlcheck.LintFile, 0, len(r.Files))
+		reports []sqlcheck.Report
+	)
+	for _, f := range r.Files {
+		files = append(files, sqlcheck.LintFile{Path: filepath.Join(r.Env.Dir, f.Name), Reports: f.Reports, Error: f.Error, Line: f.Line})
+	}
+	for _, s := range r.NonFileReports() {
+		reports = append(reports, s.Result.Reports...)
+	}
+	return sqlcheck.LintFindings(r.Env.Dir, r.Analyzers, files, reports)
+}
+
+// Line returns the line number from a position, or 0 if the position is out of range.
+func (f *FileReport) Line(pos int) int {
+	if pos < 0 || pos > len(f.Text) {
+		return 0
+	}
+	return strings.Count(f.Text[:pos], "\n") + 1
+}
+
 // Errors returns the errors in the summary report, if exists.
 func (r *SummaryReport) Errors() []error {
 	var errs []error
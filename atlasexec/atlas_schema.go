@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqlcheck"
 )
 
 type (
@@ -803,6 +804,20 @@ func (c *Client) SchemaLint(ctx context.Context, params *SchemaLintParams) (*Sch
 	return firstResult(jsonDecode[SchemaLintReport](c.runCommand(ctx, args)))
 }
 
+// Findings returns the findings of the schema lint report, located by the positions
+// of the schema elements. Diagnostics without a position are attached to the current
+// directory. Use it with the sqlcheck.WriteSARIF, WriteJUnit, WriteGitHub and WriteGitLab
+// functions to encode the report in one of the standard CI formats.
+func (r *SchemaLintReport) Findings() sqlcheck.Findings {
+	var fs sqlcheck.Findings
+	for _, s := range r.Steps {
+		for _, d := range s.Diagnostics {
+			fs.AddObject(d.Pos, sqlcheck.Finding{Analyzer: s.Text, Code: d.Code, Text: d.Text, Error: s.Error})
+		}
+	}
+	return fs
+}
+
 // SchemaStatsInspect runs the 'schema stats inspect' command.
 func (c *Client) SchemaStatsInspect(ctx context.Context, params *SchemaStatsInspectParams) (string, error) {
 	args := []string{"schema", "stats", "inspect", "--format", "{{ json .Realm }}"}
@@ -6,6 +6,7 @@ package atlasexec_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 	"testing"
 
 	"ariga.io/atlas/atlasexec"
+	"ariga.io/atlas/sql/sqlcheck"
 	"github.com/stretchr/testify/require"
 )
 
@@ -981,3 +983,15 @@ func TestSchema_Lint(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaLintReport_Findings(t *testing.T) {
+	var r atlasexec.SchemaLintReport
+	require.NoError(t, json.Unmarshal([]byte(`{"Steps":[{"Text":"naming violations detected","Error":true,"Diagnostics":[{"Pos":{"Filename":"schema.hcl","Start":{"Line":3}},"Text":"Table \"T\" violates the naming policy","Code":"NM102"},{"Text":"Schema violates the naming policy"}]}]}`), &r))
+	require.Equal(t, sqlcheck.Findings{
+		Files: []string{"schema.hcl", "."},
+		List: []sqlcheck.Finding{
+			{Path: "schema.hcl", Line: 3, Analyzer: "naming violations detected", Code: "NM102", Text: `Table "T" violates the naming policy`, Error: true},
+			{Path: ".", Analyzer: "naming violations detected", Text: "Schema violates the naming policy", Error: true},
+		},
+	}, r.Findings())
+}
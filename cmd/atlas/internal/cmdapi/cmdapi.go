@@ -273,6 +273,7 @@ func (v *Vars) Type() string {
 
 const (
 	flagAllowDirty     = "allow-dirty"
+	flagAmount         = "amount"
 	flagEdit           = "edit"
 	flagAutoApprove    = "auto-approve"
 	flagBaseline       = "baseline"
@@ -286,6 +287,7 @@ const (
 	flagExclude        = "exclude"
 	flagInclude        = "include"
 	flagFile           = "file"
+	flagFix            = "fix"
 	flagFrom           = "from"
 	flagFromShort      = "f"
 	flagFormat         = "format"
@@ -296,9 +298,11 @@ const (
 	flagLog            = "log"
 	flagPlan           = "plan"
 	flagRevisionSchema = "revisions-schema"
+	flagRun            = "run"
 	flagSchema         = "schema"
 	flagSchemaShort    = "s"
 	flagTo             = "to"
+	flagToVersion      = "to-version"
 	flagTxMode         = "tx-mode"
 	flagExecOrder      = "exec-order"
 	flagURL            = "url"
@@ -387,6 +391,10 @@ func addFlagRevisionSchema(set *pflag.FlagSet, target *string) {
 	set.StringVar(target, flagRevisionSchema, "", "name of the schema the revisions table resides in")
 }
 
+func addFlagRun(set *pflag.FlagSet, target *string) {
+	set.StringVar(target, flagRun, "", "run only tests matching the given regexp")
+}
+
 func addFlagSchemas(set *pflag.FlagSet, target *[]string) {
 	set.StringSliceVarP(
 		target,
@@ -630,7 +638,8 @@ func init() {
 		schemaDiffCmd(),
 		schemaFmtCmd(),
 		schemaInspectCmd(),
-		unsupportedCommand("schema", "test"),
+		schemaLintCmd(),
+		schemaTestCmd(),
 		unsupportedCommand("schema", "plan"),
 		unsupportedCommand("schema", "push"),
 	)
@@ -638,21 +647,21 @@ func init() {
 	migrateCmd := migrateCmd()
 	migrateCmd.AddCommand(
 		migrateApplyCmd(),
+		migrateCheckpointCmd(),
 		migrateDiffCmd(),
+		migrateDownCmd(),
+		migrateEditCmd(),
 		migrateHashCmd(),
 		migrateImportCmd(),
 		migrateLintCmd(),
 		migrateNewCmd(),
+		migrateRebaseCmd(),
+		migrateRmCmd(),
 		migrateSetCmd(),
 		migrateStatusCmd(),
+		migrateTestCmd(),
 		migrateValidateCmd(),
-		unsupportedCommand("migrate", "checkpoint"),
-		unsupportedCommand("migrate", "down"),
-		unsupportedCommand("migrate", "rebase"),
-		unsupportedCommand("migrate", "rm"),
-		unsupportedCommand("migrate", "edit"),
 		unsupportedCommand("migrate", "push"),
-		unsupportedCommand("migrate", "test"),
 	)
 	Root.AddCommand(migrateCmd)
 }
@@ -749,6 +758,10 @@ func migrateLintSetFlags(*cobra.Command, *migrateLintFlags) {}
 
 // migrateLintRun is the run command for 'migrate lint'.
 func migrateLintRun(cmd *cobra.Command, _ []string, flags migrateLintFlags, env *Env) error {
+	fix, err := lintFixer(cmd, flags.fix)
+	if err != nil {
+		return err
+	}
 	dev, err := sqlclient.Open(cmd.Context(), flags.devURL)
 	if err != nil {
 		return err
@@ -777,12 +790,9 @@ func migrateLintRun(cmd *cobra.Command, _ []string, flags migrateLintFlags, env
 			return err
 		}
 	}
-	format := migratelint.DefaultTemplate
-	if f := flags.logFormat; f != "" {
-		format, err = template.New("format").Funcs(migratelint.TemplateFuncs).Parse(f)
-		if err != nil {
-			return fmt.Errorf("parse format: %w", err)
-		}
+	format, err := lintFormat(flags.logFormat)
+	if err != nil {
+		return err
 	}
 	az, err := sqlcheck.AnalyzerFor(dev.Name, env.Lint.Remain())
 	if err != nil {
@@ -797,6 +807,7 @@ func migrateLintRun(cmd *cobra.Command, _ []string, flags migrateLintFlags, env
 			W: cmd.OutOrStdout(),
 		},
 		Analyzers: az,
+		Fix:       fix,
 	}
 	err = r.Run(cmd.Context())
 	// Print the error in case it was not printed before.
@@ -805,6 +816,23 @@ func migrateLintRun(cmd *cobra.Command, _ []string, flags migrateLintFlags, env
 	return err
 }
 
+// lintFormat returns the template of the lint report for the given
+// format flag. It is either a named format (e.g., sarif), a custom
+// template, or the default template if the flag was not set.
+func lintFormat(format string) (*template.Template, error) {
+	if f, ok := migratelint.Formats[format]; ok {
+		return f, nil
+	}
+	if format == "" {
+		return migratelint.DefaultTemplate, nil
+	}
+	f, err := template.New("format").Funcs(migratelint.TemplateFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("parse format: %w", err)
+	}
+	return f, nil
+}
+
 func migrateDiffRun(cmd *cobra.Command, args []string, flags migrateDiffFlags, env *Env) error {
 	if flags.dryRun {
 		return errors.New("'--dry-run' is not supported in the community version")
@@ -910,8 +938,6 @@ func schemaApplyRunE(cmd *cobra.Command, _ []string, flags *schemaApplyFlags) er
 		return AbortErrorf("%s", unsupportedMessage("schema", "apply --edit"))
 	case flags.planURL != "":
 		return AbortErrorf("%s", unsupportedMessage("schema", "apply --plan"))
-	case len(flags.include) > 0:
-		return AbortErrorf("%s", unsupportedMessage("schema", "apply --include"))
 	case GlobalFlags.SelectedEnv == "":
 		env, err := selectEnv(cmd)
 		if err != nil {
@@ -961,6 +987,7 @@ func schemaApplyRun(cmd *cobra.Command, flags schemaApplyFlags, env *Env) error
 		urls:    []string{flags.url},
 		schemas: flags.schemas,
 		exclude: flags.exclude,
+		include: flags.include,
 	})
 	if err != nil {
 		return err
@@ -976,6 +1003,7 @@ func schemaApplyRun(cmd *cobra.Command, flags schemaApplyFlags, env *Env) error
 		client:  client,
 		schemas: flags.schemas,
 		exclude: flags.exclude,
+		include: flags.include,
 		vars:    env.Vars(),
 	})
 	if err != nil {
@@ -1054,9 +1082,6 @@ func schemaDiffRun(cmd *cobra.Command, _ []string, flags schemaDiffFlags, env *E
 		ctx = cmd.Context()
 		c   *sqlclient.Client
 	)
-	if len(flags.include) > 0 {
-		return AbortErrorf("%s", unsupportedMessage("schema", "diff --include"))
-	}
 	// We need a driver for diffing and planning. If given, dev database has precedence.
 	if flags.devURL != "" {
 		var err error
@@ -1072,6 +1097,7 @@ func schemaDiffRun(cmd *cobra.Command, _ []string, flags schemaDiffFlags, env *E
 		vars:    env.Vars(),
 		schemas: flags.schemas,
 		exclude: flags.exclude,
+		include: flags.include,
 	})
 	if err != nil {
 		return err
@@ -1083,6 +1109,7 @@ func schemaDiffRun(cmd *cobra.Command, _ []string, flags schemaDiffFlags, env *E
 		vars:    env.Vars(),
 		schemas: flags.schemas,
 		exclude: flags.exclude,
+		include: flags.include,
 	})
 	if err != nil {
 		return err
@@ -1152,6 +1179,7 @@ type schemaInspectFlags struct {
 	logFormat string   // Format of the log output.
 	schemas   []string // Schemas to take into account when diffing.
 	exclude   []string // List of glob patterns used to filter resources from applying (see schema.InspectOptions).
+	include   []string // List of glob patterns used to select which resources to keep in inspection (see schema.InspectOptions).
 }
 
 // schemaInspectCmd represents the 'atlas schema inspect' subcommand.
@@ -1200,6 +1228,7 @@ flag.
 	addFlagDevURL(cmd.Flags(), &flags.devURL)
 	addFlagSchemas(cmd.Flags(), &flags.schemas)
 	addFlagExclude(cmd.Flags(), &flags.exclude)
+	addFlagInclude(cmd.Flags(), &flags.include)
 	addFlagLog(cmd.Flags(), &flags.logFormat)
 	addFlagFormat(cmd.Flags(), &flags.logFormat)
 	cobra.CheckErr(cmd.MarkFlagRequired(flagURL))
@@ -1228,6 +1257,7 @@ func schemaInspectRun(cmd *cobra.Command, _ []string, flags schemaInspectFlags,
 		vars:    env.Vars(),
 		schemas: flags.schemas,
 		exclude: flags.exclude,
+		include: flags.include,
 	})
 	if err != nil {
 		return err
@@ -876,6 +876,44 @@ env "local" {
 	})
 }
 
+func TestMigrate_Checkpoint(t *testing.T) {
+	p := t.TempDir()
+	for _, n := range []string{"20220318104614_initial.sql", "20220318104615_second.sql", migrate.HashFileName} {
+		require.NoError(t, copyFile(filepath.Join("testdata", "sqlite", n), filepath.Join(p, n)))
+	}
+	s, err := runCmd(
+		migrateCheckpointCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", "sqlite://dev?mode=memory",
+		"v1",
+	)
+	require.NoError(t, err)
+	require.Empty(t, s)
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, migrate.Validate(dir))
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	ck, ok := files[2].(migrate.CheckpointFile)
+	require.True(t, ok)
+	require.True(t, ck.IsCheckpoint())
+	tag, err := ck.CheckpointTag()
+	require.NoError(t, err)
+	require.Equal(t, "v1", tag)
+	require.Equal(t, "checkpoint", files[2].Desc())
+	require.Equal(t, "-- atlas:checkpoint v1\n\n-- Create \"tbl\" table\nCREATE TABLE `tbl` (`col` int NOT NULL, `col_2` bigint NULL);\n", string(files[2].Bytes()))
+
+	// Applying on a clean database starts from the checkpoint.
+	s, err = runCmd(
+		migrateApplyCmd(),
+		"--dir", "file://"+p,
+		"--url", fmt.Sprintf("sqlite://file:%s?_fk=1", filepath.Join(t.TempDir(), "test.db")),
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, fmt.Sprintf("Migrating to version %s (1 migrations in total):", files[2].Version()))
+}
+
 func TestMigrate_Diff(t *testing.T) {
 	p := t.TempDir()
 	to := hclURL(t)
@@ -1264,6 +1302,116 @@ func TestMigrate_StatusJSON(t *testing.T) {
 	require.Equal(t, `"sqlite"`, s)
 }
 
+func TestMigrate_Rebase(t *testing.T) {
+	p := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(p, "1_init.sql"), []byte("CREATE TABLE t (c int NOT NULL);\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(p, "3_c3.sql"), []byte("ALTER TABLE t ADD COLUMN c3 int;\n"), 0600))
+	_, err := runCmd(migrateHashCmd(), "--dir", "file://"+p)
+	require.NoError(t, err)
+	// A file merged from another branch with a preceding version.
+	require.NoError(t, os.WriteFile(filepath.Join(p, "2_c2.sql"), []byte("ALTER TABLE t ADD COLUMN c2 int;\n"), 0600))
+
+	_, err = runCmd(migrateRebaseCmd(), "--dir", "file://"+p, "2")
+	require.EqualError(t, err, `required flag(s) "dev-url" not set`)
+	require.FileExists(t, filepath.Join(p, "2_c2.sql"))
+
+	_, err = runCmd(migrateRebaseCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory", "5")
+	require.EqualError(t, err, `migration file "5" was not found in the migration directory`)
+
+	// Replaying fails, as the first file is moved after the others.
+	_, err = runCmd(migrateRebaseCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory", "1_init.sql")
+	require.ErrorContains(t, err, "replaying the migration directory")
+	require.FileExists(t, filepath.Join(p, "1_init.sql"))
+
+	_, err = runCmd(migrateRebaseCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory", "2")
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(p, "2_c2.sql"))
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, migrate.Validate(dir))
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Equal(t, "1_init.sql", files[0].Name())
+	require.Equal(t, "3_c3.sql", files[1].Name())
+	require.Equal(t, "4_c2.sql", files[2].Name())
+	require.Equal(t, "ALTER TABLE t ADD COLUMN c2 int;\n", string(files[2].Bytes()))
+
+	// Rebasing multiple files keeps their order.
+	_, err = runCmd(migrateRebaseCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory", "3_c3.sql", "4")
+	require.NoError(t, err)
+	files, err = dir.Files()
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	require.Equal(t, "1_init.sql", files[0].Name())
+	require.Equal(t, "2_c3.sql", files[1].Name())
+	require.Equal(t, "3_c2.sql", files[2].Name())
+	require.NoError(t, migrate.Validate(dir))
+}
+
+func TestMigrate_Rm(t *testing.T) {
+	p := t.TempDir()
+	for _, n := range []string{"20220318104614_initial.sql", "20220318104615_second.sql", migrate.HashFileName} {
+		require.NoError(t, copyFile(filepath.Join("testdata", "sqlite", n), filepath.Join(p, n)))
+	}
+	u := fmt.Sprintf("sqlite://file:%s?_fk=1", filepath.Join(t.TempDir(), "test.db"))
+	// Database without revisions table.
+	_, err := runCmd(migrateRmCmd(), "--dir", "file://"+p, "--url", u, "20220318104613")
+	require.EqualError(t, err, `migration file "20220318104613" was not found in the migration directory`)
+	_, err = runCmd(
+		migrateApplyCmd(),
+		"--dir", "file://"+p,
+		"--url", u,
+		"1",
+	)
+	require.NoError(t, err)
+	_, err = runCmd(migrateRmCmd(), "--dir", "file://"+p, "--url", u, "20220318104614")
+	require.EqualError(t, err, `migration file "20220318104614_initial.sql" was applied to the connected database and cannot be removed`)
+	require.FileExists(t, filepath.Join(p, "20220318104614_initial.sql"))
+
+	// The latest file is removed by default.
+	s, err := runCmd(migrateRmCmd(), "--dir", "file://"+p, "--url", u)
+	require.NoError(t, err)
+	require.Empty(t, s)
+	require.NoFileExists(t, filepath.Join(p, "20220318104615_second.sql"))
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, migrate.Validate(dir))
+
+	// Without a database connection, no check is done.
+	_, err = runCmd(migrateRmCmd(), "--dir", "file://"+p, "20220318104614_initial.sql")
+	require.NoError(t, err)
+	files, err := dir.Files()
+	require.NoError(t, err)
+	require.Empty(t, files)
+	require.NoError(t, migrate.Validate(dir))
+	_, err = runCmd(migrateRmCmd(), "--dir", "file://"+p)
+	require.EqualError(t, err, "migration directory is empty")
+}
+
+func TestMigrate_Edit(t *testing.T) {
+	p := t.TempDir()
+	for _, n := range []string{"20220318104614_initial.sql", "20220318104615_second.sql", migrate.HashFileName} {
+		require.NoError(t, copyFile(filepath.Join("testdata", "sqlite", n), filepath.Join(p, n)))
+	}
+	t.Setenv("EDITOR", "sed -i -e 's/col_2/col_3/'")
+	s, err := runCmd(migrateEditCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory")
+	require.NoError(t, err)
+	require.Empty(t, s)
+	b, err := os.ReadFile(filepath.Join(p, "20220318104615_second.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE `tbl` ADD `col_3` bigint;\n", string(b))
+	dir, err := migrate.NewLocalDir(p)
+	require.NoError(t, err)
+	require.NoError(t, migrate.Validate(dir))
+
+	// Invalid statements are reported after editing.
+	t.Setenv("EDITOR", "sed -i -e 's/tbl/unknown/'")
+	_, err = runCmd(migrateEditCmd(), "--dir", "file://"+p, "--dev-url", "sqlite://dev?mode=memory", "20220318104615")
+	require.ErrorContains(t, err, "replaying the migration directory")
+	require.NoError(t, migrate.Validate(dir), "sum file is updated")
+}
+
 func TestMigrate_Set(t *testing.T) {
 	u := fmt.Sprintf("sqlite://file:%s?_fk=1", filepath.Join(t.TempDir(), "test.db"))
 	_, err := runCmd(
@@ -1347,6 +1495,150 @@ func TestMigrate_Set(t *testing.T) {
 `, s)
 }
 
+func TestMigrate_Down(t *testing.T) {
+	var (
+		p   = filepath.Join(t.TempDir(), "test.db")
+		u   = fmt.Sprintf("sqlite://file:%s?_fk=1", p)
+		dev = "sqlite://dev?mode=memory"
+	)
+	s, err := runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "No migration files to revert\n", s)
+
+	_, err = runCmd(
+		migrateApplyCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+	)
+	require.NoError(t, err)
+
+	// Dry run does not revert the revision.
+	s, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+		"--dry-run",
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, "Migrating down from version 20220318104615 to version 20220318104614 (1 migrations in total):")
+	require.Contains(t, s, "-> ALTER TABLE `tbl` DROP COLUMN `col_2`;")
+	revs := readRevisions(t, u)
+	require.Len(t, revs, 2)
+
+	// Column contains data.
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_fk=1", p))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	_, err = db.Exec("INSERT INTO `tbl` (`col`, `col_2`) VALUES (1, 1)")
+	require.NoError(t, err)
+	_, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+	)
+	require.EqualError(t, err, `cannot revert: column "col_2" of table "tbl" contains data`)
+	require.Len(t, readRevisions(t, u), 2)
+
+	_, err = db.Exec("UPDATE `tbl` SET `col_2` = NULL")
+	require.NoError(t, err)
+	s, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+		"--format", "{{ json . }}",
+	)
+	require.NoError(t, err)
+	var r struct {
+		Planned  []struct{ Version string }
+		Reverted []struct {
+			Version string
+			Applied []string
+			Scope   string
+		}
+		Current, Target string
+		Total           int
+		Message         string
+	}
+	require.NoError(t, json.Unmarshal([]byte(s), &r))
+	require.Equal(t, "20220318104615", r.Current)
+	require.Equal(t, "20220318104614", r.Target)
+	require.Equal(t, 1, r.Total)
+	require.Len(t, r.Reverted, 1)
+	require.Equal(t, "20220318104615", r.Reverted[0].Version)
+	require.Equal(t, []string{"ALTER TABLE `tbl` DROP COLUMN `col_2`;"}, r.Reverted[0].Applied)
+	require.Equal(t, cmdlog.RevertScopeVersion, r.Reverted[0].Scope)
+	require.Equal(t, "Migrated down to version 20220318104614 from 20220318104615 (1 migrations in total)", r.Message)
+	revs = readRevisions(t, u)
+	require.Len(t, revs, 1)
+	require.Equal(t, "20220318104614", revs[0].Version)
+
+	// Table is not empty.
+	_, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+		"--to-version", "20220318104614",
+	)
+	require.NoError(t, err, "nothing to revert")
+	_, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+	)
+	require.EqualError(t, err, `cannot revert: table "tbl" is not empty`)
+
+	_, err = db.Exec("DELETE FROM `tbl`")
+	require.NoError(t, err)
+	_, err = runCmd(
+		migrateApplyCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+	)
+	require.NoError(t, err)
+	require.Len(t, readRevisions(t, u), 2)
+	_, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+		"--to-version", "20220318104613",
+	)
+	require.EqualError(t, err, `target version "20220318104613" was not found in the revisions table`)
+	s, err = runCmd(
+		migrateDownCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--url", u,
+		"--dev-url", dev,
+		"2",
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, "Migrating down from version 20220318104615 (2 migrations in total):")
+	require.Contains(t, s, "-> DROP TABLE `tbl`;")
+	require.Empty(t, readRevisions(t, u))
+}
+
+// readRevisions returns the revisions stored in the database of the given URL.
+func readRevisions(t *testing.T, u string) []*migrate.Revision {
+	c, err := sqlclient.Open(context.Background(), u)
+	require.NoError(t, err)
+	defer c.Close()
+	rrw, err := migrate2.NewEntRevisions(context.Background(), c)
+	require.NoError(t, err)
+	revs, err := rrw.ReadRevisions(context.Background())
+	require.NoError(t, err)
+	return revs
+}
+
 func TestMigrate_New(t *testing.T) {
 	var (
 		p = t.TempDir()
@@ -1426,6 +1718,132 @@ func TestMigrate_New(t *testing.T) {
 	})
 }
 
+func TestMigrate_Test(t *testing.T) {
+	p := t.TempDir()
+	err := os.WriteFile(filepath.Join(p, "migrate.test.hcl"), []byte(`
+test "migrate" "seed" {
+  migrate {
+    to = "20220318104614"
+  }
+  exec {
+    sql = "INSERT INTO tbl (col) VALUES (1), (2)"
+  }
+  migrate {}
+  exec {
+    sql    = "SELECT col, col_2 FROM tbl ORDER BY col"
+    output = "1, NULL\n2, NULL"
+  }
+  assert {
+    sql = "SELECT count(*) = 2 FROM tbl"
+  }
+  expect {
+    sql  = "SELECT col, col_2 FROM tbl ORDER BY col"
+    rows = ["1, NULL", "2, NULL"]
+  }
+  expect {
+    sql  = "SELECT col FROM tbl WHERE col > 2"
+    rows = []
+  }
+  catch {
+    sql   = "INSERT INTO tbl (col) VALUES (NULL)"
+    error = "NOT NULL constraint failed"
+  }
+  log {
+    message = "seeded"
+  }
+}
+
+test "migrate" "empty" {
+  assert {
+    sql           = "SELECT count(*) = 1 FROM sqlite_master WHERE name = 'tbl'"
+    error_message = "table tbl was not created"
+  }
+}
+
+test "migrate" "order" {
+  migrate {
+    to = "20220318104614"
+  }
+  assert {
+    sql = "SELECT count(*) = 0 FROM pragma_table_info('tbl') WHERE name = 'col_2'"
+  }
+  exec {
+    sql = "INSERT INTO tbl (col) VALUES (1)"
+  }
+  migrate {}
+  assert {
+    sql = "SELECT count(*) = 1 FROM tbl WHERE col_2 IS NULL"
+  }
+  exec {
+    sql = "UPDATE tbl SET col_2 = 2"
+  }
+  assert {
+    sql = "SELECT count(*) = 1 FROM tbl WHERE col_2 = 2"
+  }
+}
+
+test "migrate" "skipped" {
+  skip = true
+}
+
+test "migrate" "mismatch" {
+  expect {
+    sql  = "SELECT 1, 'a8m'"
+    rows = ["1, a8m", "2, NULL"]
+  }
+}
+
+test "schema" "ignored" {}
+`), 0644)
+	require.NoError(t, err)
+	s, err := runCmd(
+		migrateTestCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--dev-url", "sqlite://test?mode=memory",
+		"--run", "seed|order|skipped",
+		p,
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, "running test seed")
+	require.Contains(t, s, "-> seeded")
+	require.Contains(t, s, "running test order")
+	require.Contains(t, s, "skipping test skipped")
+	require.NotContains(t, s, "empty")
+	require.Contains(t, s, "2 tests passed")
+	require.Contains(t, s, "1 test skipped")
+
+	// Failures are reported with their positions.
+	s, err = runCmd(
+		migrateTestCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "{{ json .Tests }}",
+		"--run", "empty",
+		filepath.Join(p, "migrate.test.hcl"),
+	)
+	require.EqualError(t, err, "1 of 1 tests failed")
+	var tests []*cmdlog.TestResult
+	require.NoError(t, json.Unmarshal([]byte(s), &tests))
+	require.Len(t, tests, 1)
+	require.Equal(t, "empty", tests[0].Name)
+	require.Equal(t, "table tbl was not created", tests[0].Error.Text)
+	require.Equal(t, filepath.Join(p, "migrate.test.hcl")+":35:3", tests[0].Error.Pos.String())
+
+	s, err = runCmd(
+		migrateTestCmd(),
+		"--dir", "file://testdata/sqlite",
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "{{ json .Tests }}",
+		"--run", "mismatch",
+		filepath.Join(p, "migrate.test.hcl"),
+	)
+	require.EqualError(t, err, "1 of 1 tests failed")
+	require.NoError(t, json.Unmarshal([]byte(s), &tests))
+	require.Len(t, tests, 1)
+	require.Equal(t, "unexpected result set of \"SELECT 1, 'a8m'\":\nexpected: [\"1, a8m\" \"2, NULL\"]\nactual: [\"1, a8m\"]", tests[0].Error.Text)
+	require.Equal(t, filepath.Join(p, "migrate.test.hcl")+":68:3", tests[0].Error.Pos.String())
+}
+
 func TestMigrate_Validate(t *testing.T) {
 	// Without re-playing.
 	s, err := runCmd(migrateValidateCmd(), "--dir", "file://testdata/mysql")
@@ -1561,6 +1979,50 @@ func TestMigrate_Lint(t *testing.T) {
 	)
 	require.Error(t, err)
 	require.Equal(t, "2.sql", s)
+	s, err = runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "1",
+		"--format", "github",
+	)
+	require.Error(t, err)
+	require.Equal(t, fmt.Sprintf("::error file=%s,line=1,title=DS102::Dropping table \"t\" https://atlasgo.io/lint/analyzers#DS102\n", filepath.Join(p, "2.sql")), s)
+	s, err = runCmd(
+		migrateLintCmd(),
+		"--dir", "file://"+p,
+		"--dev-url", openSQLite(t, ""),
+		"--latest", "1",
+		"--format", "{{ junit . }}",
+	)
+	require.Error(t, err)
+	require.Contains(t, s, `<testcase name="destructive" classname="`+filepath.Join(p, "2.sql")+`">`)
+	require.Contains(t, s, `<testcase name="naming" classname="`+filepath.Join(p, "2.sql")+`"></testcase>`)
+
+	t.Run("Fix", func(t *testing.T) {
+		_, err := runCmd(
+			migrateLintCmd(),
+			"--dir", "file://"+p,
+			"--dev-url", openSQLite(t, ""),
+			"--latest", "1",
+			"--fix=unknown",
+		)
+		require.EqualError(t, err, `unknown --fix mode "unknown", expect true or interactive`)
+		// Suggested fixes without text edits are not applied.
+		s, err := runCmd(
+			migrateLintCmd(),
+			"--dir", "file://"+p,
+			"--dev-url", openSQLite(t, ""),
+			"--latest", "1",
+			"--fix",
+			"--format", "{{ range .Files }}{{ .Name }}{{ end }}",
+		)
+		require.Error(t, err)
+		require.Equal(t, "2.sql", s)
+		b, err := os.ReadFile(filepath.Join(p, "2.sql"))
+		require.NoError(t, err)
+		require.Equal(t, "DROP TABLE t;", string(b))
+	})
 
 	t.Run("FromConfig", func(t *testing.T) {
 		cfg := filepath.Join(p, "atlas.hcl")
@@ -1630,6 +2092,34 @@ lint {
 `, s)
 	})
 
+	t.Run("Naming", func(t *testing.T) {
+		p := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(p, "1.sql"), []byte("CREATE TABLE `Users` (`ID` int);"), 0600))
+		cfg := filepath.Join(p, "atlas.hcl")
+		require.NoError(t, os.WriteFile(cfg, []byte(`
+lint {
+  latest = 1
+  naming {
+    error = true
+    match = "^[a-z_]+$"
+  }
+}
+`), 0600))
+		cmd := migrateCmd()
+		cmd.AddCommand(migrateLintCmd())
+		s, err := runCmd(
+			cmd, "lint",
+			"--dir", "file://"+p,
+			"--dev-url", openSQLite(t, ""),
+			"-c", "file://"+cfg,
+			"--format", "{{ range .Files }}{{ range .Reports }}{{ range .Diagnostics }}{{ .Code }}: {{ .Text }}\n{{ end }}{{ end }}{{ end }}",
+		)
+		require.Error(t, err)
+		require.Equal(t, `NM101: Name of table "Users" does not match the naming convention "^[a-z_]+$"
+NM102: Name of column "ID" does not match the naming convention "^[a-z_]+$"
+`, s)
+	})
+
 	// Change files to golang-migrate format.
 	require.NoError(t, os.Rename(filepath.Join(p, "1.sql"), filepath.Join(p, "1.up.sql")))
 	require.NoError(t, os.Rename(filepath.Join(p, "2.sql"), filepath.Join(p, "1.down.sql")))
@@ -186,6 +186,8 @@ type (
 			Diff string `spec:"diff"`
 			// Push configures the formatting for 'schema push'.
 			Push string `spec:"push"`
+			// Lint configures the formatting for 'schema lint'.
+			Lint string `spec:"lint"`
 		} `spec:"schema"`
 		schemahcl.DefaultExtension
 	}
@@ -13,12 +13,17 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
 	"ariga.io/atlas/cmd/atlas/internal/cmdext"
+	"ariga.io/atlas/cmd/atlas/internal/cmdlog"
+	"ariga.io/atlas/cmd/atlas/internal/migratelint"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlclient"
 
 	"github.com/1lann/promptui"
@@ -332,6 +337,290 @@ func schemaFmtRun(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+type schemaLintFlags struct {
+	urls      []string // URLs of the schema state to lint.
+	devURL    string   // URL of the dev database.
+	schemas   []string // Schemas to take into account when linting.
+	exclude   []string // List of glob patterns used to filter resources from linting.
+	include   []string // List of glob patterns used to include (only) resources in linting.
+	logFormat string   // Log format.
+}
+
+// schemaLintCmd represents the 'atlas schema lint' subcommand.
+func schemaLintCmd() *cobra.Command {
+	var (
+		flags schemaLintFlags
+		cmd   = &cobra.Command{
+			Use:   "lint",
+			Short: "Run schema-quality checks on the desired schema.",
+			Long: `'atlas schema lint' loads the schema state described by the given URLs (HCL or SQL
+files, a migration directory or a database) and reports common design issues, such as tables
+without a primary key, foreign keys without a supporting index or redundant indexes.
+
+The analyzers are configured by the "lint" block of the project file. If the schema is loaded
+from HCL files, the reported diagnostics point to the definitions of the schema elements. The
+"--format" flag accepts a template or one of the output formats of 'atlas migrate lint'
+(e.g., "junit" or "sarif").`,
+			Example: `  atlas schema lint --url "file://schema.hcl" --dev-url "docker://postgres/15/dev"
+  atlas schema lint --url "mysql://localhost/dbname"
+  atlas schema lint --env dev --format "{{ json . }}"
+  atlas schema lint --env dev --format sarif`,
+			PreRunE: func(cmd *cobra.Command, _ []string) error {
+				return schemaFlagsFromConfig(cmd)
+			},
+			RunE: RunE(func(cmd *cobra.Command, args []string) error {
+				env, err := selectEnv(cmd)
+				if err != nil {
+					return err
+				}
+				return schemaLintRun(cmd, args, flags, env)
+			}),
+		}
+	)
+	cmd.Flags().SortFlags = false
+	addFlagURLs(cmd.Flags(), &flags.urls)
+	addFlagDevURL(cmd.Flags(), &flags.devURL)
+	addFlagSchemas(cmd.Flags(), &flags.schemas)
+	addFlagExclude(cmd.Flags(), &flags.exclude)
+	addFlagInclude(cmd.Flags(), &flags.include)
+	addFlagFormat(cmd.Flags(), &flags.logFormat)
+	cobra.CheckErr(cmd.MarkFlagRequired(flagURL))
+	return cmd
+}
+
+func schemaLintRun(cmd *cobra.Command, _ []string, flags schemaLintFlags, env *Env) error {
+	var (
+		err error
+		dev *sqlclient.Client
+		ctx = cmd.Context()
+	)
+	if flags.devURL != "" {
+		if dev, err = sqlclient.Open(ctx, flags.devURL); err != nil {
+			return err
+		}
+		defer dev.Close()
+	}
+	r, err := stateReader(ctx, env, &stateReaderConfig{
+		urls:    flags.urls,
+		dev:     dev,
+		vars:    env.Vars(),
+		schemas: flags.schemas,
+		exclude: flags.exclude,
+		include: flags.include,
+		withPos: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	client, ok := r.Closer.(*sqlclient.Client)
+	if !ok && dev != nil {
+		client = dev
+	}
+	if client == nil {
+		return errors.New("--dev-url is required to lint a schema that is not loaded from a database")
+	}
+	realm, err := r.ReadState(ctx)
+	if err != nil {
+		return err
+	}
+	azs, err := sqlcheck.RealmAnalyzerFor(client.Name, env.Lint.Remain())
+	if err != nil {
+		return err
+	}
+	var (
+		errs   []error
+		report = cmdlog.NewSchemaLint(client)
+		pass   = &sqlcheck.RealmPass{Realm: realm, Dev: dev, Reporter: report}
+	)
+	for _, az := range azs {
+		if err := az.AnalyzeRealm(ctx, pass); err != nil {
+			errs = append(errs, err)
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+	f := cmdlog.SchemaLintTemplate
+	switch _, ok := migratelint.Formats[flags.logFormat]; {
+	// Named formats (e.g., "sarif" or "junit") encode
+	// the findings of the report, as in 'migrate lint'.
+	case ok:
+		if f, err = lintFormat(flags.logFormat); err != nil {
+			return err
+		}
+	case flags.logFormat != "":
+		if f, err = template.New("format").Funcs(cmdlog.ApplyTemplateFuncs).Parse(flags.logFormat); err != nil {
+			return fmt.Errorf("parse format: %w", err)
+		}
+	}
+	if err := f.Execute(cmd.OutOrStdout(), report); err != nil {
+		return fmt.Errorf("execute log template: %w", err)
+	}
+	if len(errs) > 0 {
+		// Errors were already reported by the template.
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+type schemaTestFlags struct {
+	urls      []string // URLs of the desired state.
+	devURL    string   // URL of the dev-database to run the tests on.
+	run       string   // Regexp to filter the tests to run.
+	logFormat string   // Log format.
+}
+
+// schemaTestCmd represents the 'atlas schema test' subcommand.
+func schemaTestCmd() *cobra.Command {
+	var (
+		flags schemaTestFlags
+		cmd   = &cobra.Command{
+			Use:   "test [flags] [paths]",
+			Short: "Run schema tests against the desired schema.",
+			Long: `'atlas schema test' applies the desired schema to the dev-database and runs the "schema" test blocks
+defined in the given test files (.test.hcl). Each test starts on a database that contains only the desired schema
+and executes its steps in order. The "exec" step executes SQL statements and optionally compares their output,
+"assert" expects a query to return true, "expect" compares the rows returned by a query to the given "rows" list,
+"catch" expects a statement to fail and "log" prints a message.
+The results are reported in the format of 'atlas migrate lint', and the "--format" flag accepts the same
+values (e.g., "junit" or "sarif").
+
+If no paths are given, the test files are read from the "test.schema.src" attribute of the selected environment,
+or from the current directory.`,
+			Example: `  atlas schema test --url "file://schema.hcl" --dev-url "docker://postgres/15/dev" schema.test.hcl
+  atlas schema test --env dev
+  atlas schema test --env dev --run "users.*" tests/`,
+			PreRunE: func(cmd *cobra.Command, _ []string) error {
+				return schemaFlagsFromConfig(cmd)
+			},
+			RunE: RunE(func(cmd *cobra.Command, args []string) error {
+				env, err := selectEnv(cmd)
+				if err != nil {
+					return err
+				}
+				return schemaTestRun(cmd, args, flags, env)
+			}),
+		}
+	)
+	cmd.Flags().SortFlags = false
+	addFlagURLs(cmd.Flags(), &flags.urls)
+	addFlagDevURL(cmd.Flags(), &flags.devURL)
+	addFlagRun(cmd.Flags(), &flags.run)
+	addFlagFormat(cmd.Flags(), &flags.logFormat)
+	cobra.CheckErr(cmd.MarkFlagRequired(flagURL))
+	cobra.CheckErr(cmd.MarkFlagRequired(flagDevURL))
+	return cmd
+}
+
+func schemaTestRun(cmd *cobra.Command, args []string, flags schemaTestFlags, env *Env) error {
+	var (
+		err  error
+		run  *regexp.Regexp
+		vars Vars
+		ctx  = cmd.Context()
+	)
+	if flags.run != "" {
+		if run, err = regexp.Compile(flags.run); err != nil {
+			return fmt.Errorf("parse run pattern: %w", err)
+		}
+	}
+	if env.Test != nil {
+		vars = env.Test.Schema.Vars
+		if len(args) == 0 {
+			args = env.Test.Schema.Src
+		}
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	tests, err := testBlocks(args, testTypeSchema, run, testVars(vars))
+	if err != nil {
+		return err
+	}
+	dev, err := sqlclient.Open(ctx, flags.devURL)
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
+	to, err := stateReader(ctx, env, &stateReaderConfig{
+		urls:   flags.urls,
+		dev:    dev,
+		client: dev,
+		vars:   env.Vars(),
+	})
+	if err != nil {
+		return err
+	}
+	defer to.Close()
+	// The desired state is read once, as reading SQL
+	// schemas requires a clean dev-database.
+	realm, err := to.ReadState(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		report  = cmdlog.NewTestReport(dev, nil)
+		desired = &cmdext.StateReadCloser{StateReader: migrate.Realm(realm), Schema: to.Schema, HCL: to.HCL}
+		current = &cmdext.StateReadCloser{StateReader: migrate.RealmConn(dev, nil)}
+	)
+	if s := dev.URL.Schema; s != "" {
+		current.StateReader, current.Schema = migrate.SchemaConn(dev, s, nil), s
+	}
+	runner := &testRunner{
+		dev:    dev,
+		report: report,
+		// Each test starts on a database that contains only the desired schema.
+		setup: func(ctx context.Context) error {
+			d, err := computeDiff(ctx, dev, current, desired, diffOptions(cmd, env)...)
+			if err != nil {
+				return err
+			}
+			return dev.ApplyChanges(ctx, d.changes, planOptions(dev)...)
+		},
+	}
+	if err := runner.Run(ctx, tests); err != nil {
+		return err
+	}
+	return logSchemaTest(cmd, flags.logFormat, report)
+}
+
+// logSchemaTest writes the results of 'schema test' in the format of
+// the lint report. Each test is reported as a step of the summary.
+func logSchemaTest(cmd *cobra.Command, format string, r *cmdlog.TestReport) error {
+	f, err := lintFormat(format)
+	if err != nil {
+		return err
+	}
+	sum := &migratelint.SummaryReport{Start: r.Start, End: r.End}
+	sum.Env.Driver, sum.Env.URL = r.Driver, r.URL
+	for _, t := range r.Tests {
+		var (
+			rep  = sqlcheck.Report{Text: "test passed"}
+			step = &migratelint.StepReport{Name: "test " + t.Name, Result: &migratelint.FileReport{Name: t.File}}
+		)
+		for _, l := range t.Logs {
+			rep.Diagnostics = append(rep.Diagnostics, sqlcheck.Diagnostic{Text: l})
+		}
+		switch {
+		case t.Skipped:
+			rep.Text = "test skipped"
+		case t.Error != nil:
+			rep.Text, step.Error = "test failed", t.Error.Error()
+			rep.Diagnostics = append(rep.Diagnostics, sqlcheck.Diagnostic{Text: step.Error})
+		}
+		step.Result.Reports = append(step.Result.Reports, rep)
+		sum.Steps = append(sum.Steps, step)
+	}
+	if err := f.Execute(cmd.OutOrStdout(), sum); err != nil {
+		return fmt.Errorf("execute log template: %w", err)
+	}
+	if n := r.Failed(); n > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d of %d tests failed", n, len(r.Tests))
+	}
+	return nil
+}
+
 // selectEnv returns the Env from the current project file based on the selected
 // argument. If selected is "", or no project file exists in the current directory
 // a zero-value Env is returned.
@@ -434,6 +723,18 @@ func setSchemaEnvFlags(cmd *cobra.Command, env *Env) error {
 		} else if err := maySetFlag(cmd, flagURL, env.URL); err != nil {
 			return err
 		}
+	case "lint":
+		// Give the "src" precedence over the "url" argument.
+		if len(srcs) > 0 {
+			if err := maySetFlag(cmd, flagURL, strings.Join(srcs, ",")); err != nil {
+				return err
+			}
+		} else if err := maySetFlag(cmd, flagURL, env.URL); err != nil {
+			return err
+		}
+		if err := maySetFlag(cmd, flagFormat, env.Format.Schema.Lint); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -487,6 +788,7 @@ func computeDiff(ctx context.Context, differ *sqlclient.Client, from, to *cmdext
 const (
 	answerApply = "Apply"
 	answerAbort = "Abort"
+	answerSkip  = "Skip"
 )
 
 // cmdPrompt returns a promptui.Select that uses the given command's input and output.
@@ -1023,6 +1023,177 @@ table "users" {
 	require.Equal(t, "-- Create \"users\" table\nCREATE TABLE `users` (`id` int NOT NULL);\n", s)
 }
 
+func TestSchema_Test(t *testing.T) {
+	p := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(p, "schema.hcl"), []byte(`schema "main" {}
+table "users" {
+  schema = schema.main
+  column "id" {
+    type = int
+  }
+  column "email" {
+    type = text
+  }
+  index "users_email" {
+    unique  = true
+    columns = [column.email]
+  }
+}`), 0644))
+	// Test files can be stored in the schema directory.
+	require.NoError(t, os.WriteFile(filepath.Join(p, "schema.test.hcl"), []byte(`
+test "schema" "unique" {
+  exec {
+    sql = "INSERT INTO users (id, email) VALUES (1, 'a@example.com')"
+  }
+  exec {
+    sql    = "SELECT count(*) FROM users"
+    output = "1"
+  }
+  catch {
+    sql   = "INSERT INTO users (id, email) VALUES (2, 'a@example.com')"
+    error = "UNIQUE constraint failed"
+  }
+}
+
+test "schema" "clean" {
+  exec {
+    sql    = "SELECT count(*) FROM users"
+    output = "1"
+  }
+}
+
+test "migrate" "ignored" {}
+`), 0644))
+	s, err := runCmd(
+		schemaTestCmd(),
+		"--url", "file://"+p,
+		"--dev-url", "sqlite://test?mode=memory",
+		p,
+	)
+	require.EqualError(t, err, "1 of 2 tests failed", s)
+	require.Contains(t, s, "-- test unique\n    -- test passed:")
+	require.Contains(t, s, "-- test clean\n    -- test failed:")
+	require.Contains(t, s, filepath.Join(p, "schema.test.hcl")+":17:3:")
+	require.Contains(t, s, `expected: "1"`)
+
+	// Failed tests are reported in the lint formats.
+	s, err = runCmd(
+		schemaTestCmd(),
+		"--url", "file://"+p,
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "junit",
+		p,
+	)
+	require.EqualError(t, err, "1 of 2 tests failed", s)
+	require.Contains(t, s, "<testsuites")
+	require.Contains(t, s, `expected: &#34;1&#34;`)
+
+	s, err = runCmd(
+		schemaTestCmd(),
+		"--url", "file://"+filepath.Join(p, "schema.hcl"),
+		"--dev-url", "sqlite://test?mode=memory",
+		"--run", "unique",
+		"--format", "{{ range .Steps }}{{ .Name }}:{{ .Error }}{{ end }}",
+		p,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "test unique:", s)
+}
+
+func TestSchema_Lint(t *testing.T) {
+	p := t.TempDir()
+	path := filepath.Join(p, "schema.hcl")
+	require.NoError(t, os.WriteFile(path, []byte(`schema "main" {}
+table "users" {
+  schema = schema.main
+  column "id" {
+    type = int
+  }
+  column "email" {
+    type = text
+    null = true
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "users_email" {
+    unique  = true
+    columns = [column.email]
+  }
+}
+table "posts" {
+  schema = schema.main
+  column "id" {
+    type = int
+  }
+  column "author_id" {
+    type = int
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "posts_id" {
+    columns = [column.id]
+  }
+  foreign_key "posts_author" {
+    columns     = [column.author_id]
+    ref_columns = [table.users.column.id]
+  }
+}
+table "logs" {
+  schema = schema.main
+  column "text" {
+    type = text
+  }
+}`), 0644))
+	s, err := runCmd(
+		schemaLintCmd(),
+		"--url", "file://"+path,
+		"--dev-url", "sqlite://test?mode=memory",
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, "schema design issues detected")
+	require.Contains(t, s, path+`:14:3: Unique index "users_email" on table "users" allows duplicate NULL values in nullable column "email" (SD104)`)
+	require.Contains(t, s, path+`:30:3: Index "posts_id" on table "posts" is redundant as it is covered by the primary key of table "posts" (SD103)`)
+	require.Contains(t, s, path+`:33:3: Foreign key "posts_author" on table "posts" has no supporting index on column "author_id" (SD102)`)
+	require.Contains(t, s, path+`:38:1: Table "logs" has no primary key (SD101)`)
+
+	s, err = runCmd(
+		schemaLintCmd(),
+		"--url", "file://"+path,
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "{{ range .Steps }}{{ range .Diagnostics }}{{ .Code }} {{ end }}{{ end }}",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "SD104 SD103 SD102 SD101 ", s)
+
+	// Named formats encode the findings with the positions of the schema elements.
+	s, err = runCmd(
+		schemaLintCmd(),
+		"--url", "file://"+path,
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "github",
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, "::warning file="+path+",line=38,title=SD101::Table \"logs\" has no primary key")
+	s, err = runCmd(
+		schemaLintCmd(),
+		"--url", "file://"+path,
+		"--dev-url", "sqlite://test?mode=memory",
+		"--format", "junit",
+	)
+	require.NoError(t, err)
+	require.Contains(t, s, `<testsuite name="`+path+`" tests="1" failures="1" errors="0">`)
+
+	// Schemas without issues.
+	s, err = runCmd(
+		schemaLintCmd(),
+		"--url", "sqlite://test?mode=memory",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "No issues found\n", s)
+}
+
 func TestFmt(t *testing.T) {
 	for _, tt := range []struct {
 		name          string
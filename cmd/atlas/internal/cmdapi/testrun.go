@@ -0,0 +1,395 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package cmdapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ariga.io/atlas/cmd/atlas/internal/cmdext"
+	"ariga.io/atlas/cmd/atlas/internal/cmdlog"
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqlclient"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Test types, used as the first label of the test blocks.
+const (
+	testTypeMigrate = "migrate"
+	testTypeSchema  = "schema"
+)
+
+type (
+	// testFile describes the structure of a test file (.test.hcl).
+	testFile struct {
+		Tests []*testBlock `spec:"test"`
+		schemahcl.DefaultExtension
+	}
+
+	// testBlock describes a single test block. For example:
+	//
+	//	test "migrate" "name" {
+	//	  migrate {
+	//	    to = "20240101000000"
+	//	  }
+	//	  exec {
+	//	    sql = "INSERT INTO users (name) VALUES ('a8m')"
+	//	  }
+	//	  assert {
+	//	    sql = "SELECT count(*) = 1 FROM users"
+	//	  }
+	//	  expect {
+	//	    sql  = "SELECT id, name FROM users"
+	//	    rows = ["1, a8m"]
+	//	  }
+	//	}
+	//
+	// The steps of the test are the children blocks of the
+	// resource and are executed in the order they were defined.
+	testBlock struct {
+		Type  string     `spec:",qualifier"`
+		Name  string     `spec:",name"`
+		Skip  bool       `spec:"skip"`
+		Range *hcl.Range `spec:",range"`
+		schemahcl.DefaultExtension
+	}
+
+	// testRunner executes test blocks on the dev-database.
+	testRunner struct {
+		dev    *sqlclient.Client
+		report *cmdlog.TestReport
+		// setup is called after a snapshot of the dev-database
+		// was taken and before the steps of a test are executed.
+		setup func(context.Context) error
+		// migrate executes the "migrate" steps. Test types that
+		// do not support this step leave this field empty.
+		migrate func(context.Context, *schemahcl.Resource) error
+	}
+)
+
+// steps returns the steps of the test in the order they were defined. Note,
+// the remaining children of the resource are grouped by their types.
+func (t *testBlock) steps() []*schemahcl.Resource {
+	steps := slices.Clone(t.Remain().Children)
+	slices.SortStableFunc(steps, func(a, b *schemahcl.Resource) int {
+		return a.Range().Start.Byte - b.Range().Start.Byte
+	})
+	return steps
+}
+
+// testFiles returns the test files in the given paths. Directories are
+// searched (non-recursively) for files with the test file extension.
+func testFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		p = strings.TrimPrefix(p, "file://")
+		fi, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(p, "*"+cmdext.FileTypeTest))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// testBlocks parses the given test files and returns the test blocks of the
+// given type. If run is not nil, only tests with matching names are returned.
+func testBlocks(paths []string, typ string, run *regexp.Regexp, vars map[string]cty.Value) ([]*testBlock, error) {
+	files, err := testFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	var blocks []*testBlock
+	for _, name := range files {
+		var f testFile
+		// Files are evaluated separately, as each file
+		// may define its own variables and locals.
+		if err := schemahcl.New(schemahcl.WithPos()).EvalFiles([]string{name}, &f, vars); err != nil {
+			return nil, fmt.Errorf("parse test file %q: %w", name, err)
+		}
+		for _, t := range f.Tests {
+			if t.Type == typ && (run == nil || run.MatchString(t.Name)) {
+				blocks = append(blocks, t)
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// testVars returns the input variables of the test files. Variables
+// passed from the CLI override the ones defined in the project file.
+func testVars(vars Vars) Vars {
+	vs := vars.Copy()
+	for k, v := range GlobalFlags.Vars {
+		vs[k] = v
+	}
+	return vs
+}
+
+// Run executes the given test blocks and records their results in the report.
+func (r *testRunner) Run(ctx context.Context, tests []*testBlock) error {
+	for _, t := range tests {
+		res, err := r.runTest(ctx, t)
+		r.report.Tests = append(r.report.Tests, res)
+		if err != nil {
+			return err
+		}
+	}
+	r.report.End = time.Now()
+	return nil
+}
+
+// runTest executes a single test block. The returned error is reserved for errors
+// that prevent the runner from continuing, e.g., the dev-database cannot be restored.
+func (r *testRunner) runTest(ctx context.Context, t *testBlock) (*cmdlog.TestResult, error) {
+	res := &cmdlog.TestResult{Name: t.Name, Start: time.Now()}
+	if t.Range != nil {
+		res.File = t.Range.Filename
+	}
+	if t.Skip {
+		res.Skipped = true
+		res.End = res.Start
+		return res, nil
+	}
+	restore, err := r.dev.Driver.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("taking database snapshot: %w", err)
+	}
+	res.Error = r.runSteps(ctx, t, res)
+	res.End = time.Now()
+	if err := restore(ctx); err != nil {
+		return res, fmt.Errorf("restoring database snapshot: %w", err)
+	}
+	return res, nil
+}
+
+// runSteps executes the steps of the test block in order and stops on the
+// first failure. The returned error holds the position of the failed step.
+func (r *testRunner) runSteps(ctx context.Context, t *testBlock, res *cmdlog.TestResult) *cmdlog.TestError {
+	if r.setup != nil {
+		if err := r.setup(ctx); err != nil {
+			return testError(t.Range, err)
+		}
+	}
+	for _, s := range t.steps() {
+		if err := r.runStep(ctx, s, res); err != nil {
+			return testError(s.Range(), err)
+		}
+	}
+	return nil
+}
+
+func testError(r *hcl.Range, err error) *cmdlog.TestError {
+	e := &cmdlog.TestError{Text: err.Error()}
+	if r != nil {
+		e.Pos = schemahcl.RangeAsPos(r)
+	}
+	return e
+}
+
+func (r *testRunner) runStep(ctx context.Context, s *schemahcl.Resource, res *cmdlog.TestResult) error {
+	switch s.Type {
+	case "migrate":
+		if r.migrate == nil {
+			return errors.New(`"migrate" steps are supported only in migrate tests`)
+		}
+		return r.migrate(ctx, s)
+	case "exec":
+		stmt, err := stepAttr(s, "sql", true)
+		if err != nil {
+			return err
+		}
+		expect, err := stepAttr(s, "output", false)
+		if err != nil {
+			return err
+		}
+		out, err := r.exec(ctx, *stmt, expect != nil)
+		if err != nil {
+			return err
+		}
+		if expect != nil && strings.TrimSpace(*expect) != out {
+			return fmt.Errorf("unexpected output:\nexpected: %q\nactual: %q", strings.TrimSpace(*expect), out)
+		}
+	case "assert":
+		stmt, err := stepAttr(s, "sql", true)
+		if err != nil {
+			return err
+		}
+		out, err := r.exec(ctx, *stmt, true)
+		if err != nil {
+			return err
+		}
+		if ok, _ := strconv.ParseBool(out); !ok {
+			msg, err := stepAttr(s, "error_message", false)
+			switch {
+			case err != nil:
+				return err
+			case msg != nil:
+				return errors.New(*msg)
+			}
+			return fmt.Errorf("assertion failed: %q returned %q", *stmt, out)
+		}
+	case "expect":
+		stmt, err := stepAttr(s, "sql", true)
+		if err != nil {
+			return err
+		}
+		a, ok := s.Attr("rows")
+		if !ok {
+			return fmt.Errorf(`missing "rows" attribute in %q step`, s.Type)
+		}
+		rows, err := a.Strings()
+		if err != nil {
+			return fmt.Errorf(`attribute "rows" in %q step: %w`, s.Type, err)
+		}
+		out, err := r.exec(ctx, *stmt, true)
+		if err != nil {
+			return err
+		}
+		var got []string
+		if out != "" {
+			got = strings.Split(out, "\n")
+		}
+		if !slices.Equal(rows, got) {
+			msg, err := stepAttr(s, "error_message", false)
+			switch {
+			case err != nil:
+				return err
+			case msg != nil:
+				return errors.New(*msg)
+			}
+			return fmt.Errorf("unexpected result set of %q:\nexpected: %q\nactual: %q", *stmt, rows, got)
+		}
+	case "catch":
+		stmt, err := stepAttr(s, "sql", true)
+		if err != nil {
+			return err
+		}
+		match, err := stepAttr(s, "error", false)
+		if err != nil {
+			return err
+		}
+		switch _, err := r.exec(ctx, *stmt, false); {
+		case err == nil:
+			return fmt.Errorf("expected statement %q to fail", *stmt)
+		case match != nil:
+			rx, err1 := regexp.Compile(*match)
+			if err1 != nil {
+				return fmt.Errorf("invalid error pattern %q: %w", *match, err1)
+			}
+			if !rx.MatchString(err.Error()) {
+				return fmt.Errorf("expected error matching %q, got: %v", *match, err)
+			}
+		}
+	case "log":
+		msg, err := stepAttr(s, "message", true)
+		if err != nil {
+			return err
+		}
+		res.Logs = append(res.Logs, *msg)
+	default:
+		return fmt.Errorf("unknown test step %q", s.Type)
+	}
+	return nil
+}
+
+// exec executes the given statements on the dev-database. If query is true,
+// the last statement is queried, and its result is returned as text, where
+// columns are separated by commas and rows by newlines.
+func (r *testRunner) exec(ctx context.Context, stmt string, query bool) (string, error) {
+	stmts, err := migrate.FileStmts(r.dev.Driver, migrate.NewLocalFile("test.sql", []byte(stmt)))
+	if err != nil {
+		return "", err
+	}
+	if len(stmts) == 0 {
+		return "", errors.New("no statements to execute")
+	}
+	var last string
+	if query {
+		stmts, last = stmts[:len(stmts)-1], stmts[len(stmts)-1]
+	}
+	for _, s := range stmts {
+		if _, err := r.dev.ExecContext(ctx, s); err != nil {
+			return "", err
+		}
+	}
+	if !query {
+		return "", nil
+	}
+	rows, err := r.dev.QueryContext(ctx, last)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	return formatRows(rows)
+}
+
+// formatRows formats the given rows as text. Columns are
+// separated by commas and rows are separated by newlines.
+func formatRows(rows *sql.Rows) (string, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	for rows.Next() {
+		vs, ptr := make([]any, len(columns)), make([]any, len(columns))
+		for i := range vs {
+			ptr[i] = &vs[i]
+		}
+		if err := rows.Scan(ptr...); err != nil {
+			return "", err
+		}
+		cols := make([]string, len(vs))
+		for i, v := range vs {
+			switch v := v.(type) {
+			case nil:
+				cols[i] = "NULL"
+			case []byte:
+				cols[i] = string(v)
+			default:
+				cols[i] = fmt.Sprint(v)
+			}
+		}
+		lines = append(lines, strings.Join(cols, ", "))
+	}
+	return strings.Join(lines, "\n"), rows.Err()
+}
+
+// stepAttr returns the string value of the given step attribute.
+func stepAttr(s *schemahcl.Resource, name string, required bool) (*string, error) {
+	a, ok := s.Attr(name)
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("missing %q attribute in %q step", name, s.Type)
+		}
+		return nil, nil
+	}
+	v, err := a.String()
+	if err != nil {
+		return nil, fmt.Errorf("attribute %q in %q step: %w", name, s.Type, err)
+	}
+	return &v, nil
+}
@@ -141,6 +141,53 @@ table "t1" {
 	_, exists := r.Schemas[0].Tables[0].Column("name")
 	require.False(t, exists, "column 'name' should be excluded")
 
+	// Read schema file with include patterns.
+	sr, err = StateReaderHCL(ctx, &StateReaderConfig{
+		Dev:     dev,
+		URLs:    []*url.URL{u},
+		Include: []string{"t1.id"},
+	})
+	require.NoError(t, err)
+	r, err = sr.ReadState(ctx)
+	require.NoError(t, err)
+	require.Len(t, r.Schemas[0].Tables, 1)
+	require.Len(t, r.Schemas[0].Tables[0].Columns, 1)
+	require.Equal(t, "id", r.Schemas[0].Tables[0].Columns[0].Name)
+
+	// Include and exclude patterns, where exclude takes precedence.
+	sr, err = StateReaderHCL(ctx, &StateReaderConfig{
+		Dev:     dev,
+		URLs:    []*url.URL{u},
+		Include: []string{"t1"},
+		Exclude: []string{"t1.id"},
+	})
+	require.NoError(t, err)
+	r, err = sr.ReadState(ctx)
+	require.NoError(t, err)
+	require.Len(t, r.Schemas[0].Tables[0].Columns, 1)
+	require.Equal(t, "name", r.Schemas[0].Tables[0].Columns[0].Name)
+
+	// Test files can be stored in schema directories, as their
+	// blocks (e.g., "test") do not affect the schema definition.
+	require.NoError(t, os.WriteFile(p+"/schema.test.hcl", []byte(`
+test "schema" "t1" {
+  exec {
+    sql = "SELECT * FROM t1"
+  }
+}`), 0644))
+	d, err := url.Parse("file://" + p)
+	require.NoError(t, err)
+	sr, err = StateReaderHCL(ctx, &StateReaderConfig{
+		Dev:  dev,
+		URLs: []*url.URL{d},
+	})
+	require.NoError(t, err)
+	r, err = sr.ReadState(ctx)
+	require.NoError(t, err)
+	require.Len(t, r.Schemas, 1)
+	require.Len(t, r.Schemas[0].Tables, 1)
+	require.NoError(t, os.Remove(p+"/schema.test.hcl"))
+
 	// Mimic multi-schema file.
 	// Write an empty schema file into the directory.
 	require.NoError(t, os.WriteFile(p+"/schema.hcl", []byte(`
@@ -26,6 +26,7 @@ import (
 	"ariga.io/atlas/cmd/atlas/internal/migrate/ent/revision"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlclient"
 
 	"github.com/fatih/color"
@@ -711,6 +712,441 @@ func (f *AppliedFile) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// MigrateDownTemplate holds the default template of the 'migrate down' command.
+var MigrateDownTemplate = template.Must(template.
+	New("report").
+	Funcs(ApplyTemplateFuncs).
+	Parse(`{{- if not .Planned -}}
+{{- println "No migration files to revert" }}
+{{- else -}}
+{{- println .Header }}
+{{- range $i, $f := .Reverted }}
+	{{- println }}
+	{{- println " " (yellow "--") "reverting version" (cyan $f.Version) }}
+	{{- range $f.Applied }}
+		{{- println "   " (cyan "->") (indent_ln . 7) }}
+	{{- end }}
+	{{- with .Error }}
+		{{- println "   " (redBgWhiteFg .Text) }}
+	{{- else }}
+		{{- printf "  %s ok (%s)\n" (yellow "--") (yellow (.End.Sub .Start).String) }}
+	{{- end }}
+{{- else }}
+	{{- println }}
+{{- end }}
+{{- with .Error }}
+	{{- println "   " (redBgWhiteFg .) }}
+{{- end }}
+{{- println }}
+{{- println " " (cyan "-------------------------") }}
+{{- println " " (.Summary "  ") }}
+{{- end -}}
+`))
+
+type (
+	// MigrateDown contains a summary of a migration down attempt on a database.
+	MigrateDown struct {
+		ctx context.Context
+		Env
+		Planned  Files           `json:"Planned,omitempty"`  // Planned migration files
+		Reverted []*RevertedFile `json:"Reverted,omitempty"` // Reverted files
+		Current  string          `json:"Current,omitempty"`  // Current migration version
+		Target   string          `json:"Target,omitempty"`   // Target migration version
+		Total    int             `json:"Total,omitempty"`    // Total number of migrations to revert
+		Start    time.Time
+		End      time.Time
+		// Error is set even then, if it was not caused by a statement in a migration file,
+		// but by Atlas, e.g. when committing or rolling back a transaction.
+		Error string `json:"Error,omitempty"`
+	}
+
+	// RevertedFile is part of a MigrateDown containing information about a reverted file in a downgrade attempt.
+	RevertedFile struct {
+		migrate.File
+		Start   time.Time
+		End     time.Time
+		Skipped int        // Amount of skipped SQL statements in a partially applied file.
+		Applied []string   // SQL statements applied with success
+		Scope   string     // Scope of the revert. e.g., statement, versions, etc.
+		Error   *StmtError // Statement error, if any.
+	}
+)
+
+// RevertScopeVersion indicates a migration file was reverted as a whole.
+const RevertScopeVersion = "version"
+
+// NewMigrateDown returns a MigrateDown.
+func NewMigrateDown(ctx context.Context, client *sqlclient.Client, dirURL *url.URL) *MigrateDown {
+	return &MigrateDown{
+		ctx:   ctx,
+		Env:   NewEnv(client, dirURL),
+		Start: time.Now(),
+	}
+}
+
+// Log implements migrate.Logger. Note, the down logger records only the execution of the
+// reverting statements, as the planned files are set by the caller before execution.
+func (d *MigrateDown) Log(e migrate.LogEntry) {
+	switch e := e.(type) {
+	case migrate.LogFile:
+		if l := len(d.Reverted); l > 0 && d.Reverted[l-1].End.IsZero() {
+			d.Reverted[l-1].End = time.Now()
+		}
+		d.Reverted = append(d.Reverted, &RevertedFile{
+			File:    e.File,
+			Start:   time.Now(),
+			Skipped: e.Skip,
+			Scope:   RevertScopeVersion,
+		})
+	case migrate.LogStmt:
+		f := d.Reverted[len(d.Reverted)-1]
+		f.Applied = append(f.Applied, e.SQL)
+	case migrate.LogError:
+		if l := len(d.Reverted); l > 0 {
+			f := d.Reverted[l-1]
+			f.End = time.Now()
+			d.End = f.End
+			f.Error = &StmtError{Stmt: e.SQL, Text: e.Error.Error()}
+		} else {
+			d.End = time.Now()
+			d.Error = e.Error.Error()
+		}
+	case migrate.LogDone:
+		n := time.Now()
+		if l := len(d.Reverted); l > 0 && d.Reverted[l-1].End.IsZero() {
+			d.Reverted[l-1].End = n
+		}
+		d.End = n
+	}
+}
+
+// Header returns a header of the migration log.
+func (d *MigrateDown) Header() string {
+	if len(d.Planned) == 0 {
+		return "No migration files to revert"
+	}
+	var b strings.Builder
+	b.WriteString("Migrating down from version ")
+	b.WriteString(ColorCyan(d.Current))
+	if d.Target != "" {
+		b.WriteString(" to version ")
+		b.WriteString(ColorCyan(d.Target))
+	}
+	b.WriteString(" (")
+	b.WriteString(strconv.Itoa(len(d.Planned)))
+	b.WriteString(" migrations in total):")
+	return b.String()
+}
+
+// Summary returns a footer of the migration log.
+func (d *MigrateDown) Summary(ident string) string {
+	var (
+		passedS, failedS int
+		passedF, failedF int
+		lines            = make([]string, 0, 3)
+	)
+	for _, f := range d.Reverted {
+		passedS += len(f.Applied)
+		if f.Error != nil {
+			failedF++
+			if f.Error.Stmt != "" {
+				passedS--
+				failedS++
+			}
+		} else {
+			passedF++
+		}
+	}
+	lines = append(lines, d.End.Sub(d.Start).String())
+	switch {
+	case passedF > 0 && failedF > 0:
+		lines = append(lines, fmt.Sprintf("%d migration%s reverted, %d with errors", passedF, plural(passedF), failedF))
+	case passedF > 0:
+		lines = append(lines, fmt.Sprintf("%d migration%s reverted", passedF, plural(passedF)))
+	case failedF > 0:
+		lines = append(lines, fmt.Sprintf("%d migration%s with errors", failedF, plural(failedF)))
+	}
+	switch {
+	case passedS > 0 && failedS > 0:
+		lines = append(lines, fmt.Sprintf("%d sql statement%s ok, %d with errors", passedS, plural(passedS), failedS))
+	case passedS > 0:
+		lines = append(lines, fmt.Sprintf("%d sql statement%s", passedS, plural(passedS)))
+	case failedS > 0:
+		lines = append(lines, fmt.Sprintf("%d sql statement%s with errors", failedS, plural(failedS)))
+	}
+	var b strings.Builder
+	for i, l := range lines {
+		b.WriteString(ColorYellow("--"))
+		b.WriteByte(' ')
+		b.WriteString(l)
+		if i < len(lines)-1 {
+			b.WriteByte('\n')
+			b.WriteString(ident)
+		}
+	}
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *MigrateDown) MarshalJSON() ([]byte, error) {
+	type Alias MigrateDown
+	var v struct {
+		*Alias
+		Message string `json:"Message,omitempty"`
+	}
+	v.Alias = (*Alias)(d)
+	switch {
+	case d.Error != "":
+	case len(v.Planned) == 0:
+		v.Message = "No migration files to revert"
+	case v.Target == "":
+		v.Message = fmt.Sprintf("Migrated down from version %s (%d migrations in total)", v.Current, len(v.Planned))
+	default:
+		v.Message = fmt.Sprintf("Migrated down to version %s from %s (%d migrations in total)", v.Target, v.Current, len(v.Planned))
+	}
+	return json.Marshal(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (f *RevertedFile) MarshalJSON() ([]byte, error) {
+	type local struct {
+		Name        string     `json:"Name,omitempty"`
+		Version     string     `json:"Version,omitempty"`
+		Description string     `json:"Description,omitempty"`
+		Start       time.Time  `json:"Start,omitempty"`
+		End         time.Time  `json:"End,omitempty"`
+		Skipped     int        `json:"Skipped,omitempty"`
+		Stmts       []string   `json:"Applied,omitempty"`
+		Scope       string     `json:"Scope,omitempty"`
+		Error       *StmtError `json:"Error,omitempty"`
+	}
+	return json.Marshal(local{
+		Name:        f.Name(),
+		Version:     f.Version(),
+		Description: f.Desc(),
+		Start:       f.Start,
+		End:         f.End,
+		Skipped:     f.Skipped,
+		Stmts:       f.Applied,
+		Scope:       f.Scope,
+		Error:       f.Error,
+	})
+}
+
+// TestTemplate holds the default template of the 'migrate test' command.
+var TestTemplate = template.Must(template.
+	New("test").
+	Funcs(ApplyTemplateFuncs).
+	Parse(`{{- if not .Tests -}}
+{{- println "No tests to run" }}
+{{- else -}}
+{{- range $t := .Tests }}
+	{{- if $t.Skipped }}
+		{{- println (yellow "  --") "skipping test" (cyan $t.Name) }}
+		{{- println }}
+		{{- continue }}
+	{{- end }}
+	{{- println (yellow "  --") "running test" (cyan $t.Name) }}
+	{{- range $t.Logs }}
+		{{- println "   " (cyan "->") (indent_ln . 7) }}
+	{{- end }}
+	{{- with $t.Error }}
+		{{- if .Pos }}
+			{{- printf "    %s %s:\n" (red "--") .Pos }}
+		{{- else }}
+			{{- printf "    %s failed:\n" (red "--") }}
+		{{- end }}
+		{{- println "      " (indent_ln .Text 7) }}
+	{{- else }}
+		{{- printf "    %s ok (%s)\n" (yellow "--") (yellow ($t.End.Sub $t.Start).String) }}
+	{{- end }}
+	{{- println }}
+{{- end }}
+{{- println " " (cyan "-------------------------") }}
+{{- println " " (.Summary "  ") }}
+{{- end -}}
+`))
+
+type (
+	// TestReport contains a summary of a 'migrate test' or 'schema test' run.
+	TestReport struct {
+		Env
+		Tests []*TestResult `json:"Tests,omitempty"` // Executed tests
+		Start time.Time
+		End   time.Time
+	}
+
+	// TestResult is part of a TestReport containing the result of a single test block.
+	TestResult struct {
+		Name    string     `json:"Name"`              // Test name
+		File    string     `json:"File,omitempty"`    // File that defines the test
+		Start   time.Time  `json:"Start"`             // Start time
+		End     time.Time  `json:"End"`               // End time
+		Skipped bool       `json:"Skipped,omitempty"` // Skipped tests
+		Logs    []string   `json:"Logs,omitempty"`    // Messages logged by the test
+		Error   *TestError `json:"Error,omitempty"`   // Failure, if any
+	}
+
+	// TestError describes a failed step in a test block.
+	TestError struct {
+		Pos  *schema.Pos `json:"Pos,omitempty"` // Position of the failed step
+		Text string      `json:"Text"`          // Error message
+	}
+)
+
+// NewTestReport returns a TestReport.
+func NewTestReport(client *sqlclient.Client, dirURL *url.URL) *TestReport {
+	return &TestReport{
+		Env:   NewEnv(client, dirURL),
+		Start: time.Now(),
+	}
+}
+
+// Failed returns the number of failed tests.
+func (r *TestReport) Failed() (n int) {
+	for _, t := range r.Tests {
+		if t.Error != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Summary returns a footer of the test report.
+func (r *TestReport) Summary(ident string) string {
+	var passed, failed, skipped int
+	for _, t := range r.Tests {
+		switch {
+		case t.Skipped:
+			skipped++
+		case t.Error != nil:
+			failed++
+		default:
+			passed++
+		}
+	}
+	lines := []string{r.End.Sub(r.Start).String()}
+	if passed > 0 {
+		lines = append(lines, fmt.Sprintf("%d test%s passed", passed, plural(passed)))
+	}
+	if failed > 0 {
+		lines = append(lines, fmt.Sprintf("%d test%s failed", failed, plural(failed)))
+	}
+	if skipped > 0 {
+		lines = append(lines, fmt.Sprintf("%d test%s skipped", skipped, plural(skipped)))
+	}
+	var b strings.Builder
+	for i, l := range lines {
+		b.WriteString(ColorYellow("--"))
+		b.WriteByte(' ')
+		b.WriteString(l)
+		if i < len(lines)-1 {
+			b.WriteByte('\n')
+			b.WriteString(ident)
+		}
+	}
+	return b.String()
+}
+
+// Error implements the error interface.
+func (e *TestError) Error() string {
+	if e.Pos == nil {
+		return e.Text
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Text)
+}
+
+// SchemaLintTemplate holds the default template of the 'schema lint' command.
+var SchemaLintTemplate = template.Must(template.
+	New("lint").
+	Funcs(ApplyTemplateFuncs).
+	Parse(`{{- if not .Steps -}}
+{{- println "No issues found" }}
+{{- else -}}
+{{- range $s := .Steps }}
+	{{- println (yellow "  --") $s.Text }}
+	{{- range $d := $s.Diagnostics }}
+		{{- if $d.ObjectPos }}
+			{{- printf "    %s %s: %s" (red "--") $d.ObjectPos $d.Text }}
+		{{- else }}
+			{{- printf "    %s %s" (red "--") $d.Text }}
+		{{- end }}
+		{{- with $d.Code }}{{ printf " (%s)" . }}{{ end }}
+		{{- println }}
+	{{- end }}
+{{- end }}
+{{- end -}}
+`))
+
+// SchemaLint contains a summary of a 'schema lint' execution.
+type SchemaLint struct {
+	Env
+	Steps []sqlcheck.Report `json:"Steps,omitempty"` // Reports of the realm analyzers
+	// Errors of the realm analyzers. Analyzers that are configured
+	// to fail on findings return their report text as an error.
+	Errors []string `json:"-"`
+}
+
+// NewSchemaLint returns a SchemaLint.
+func NewSchemaLint(client *sqlclient.Client) *SchemaLint {
+	return &SchemaLint{Env: NewEnv(client, nil)}
+}
+
+// WriteReport implements sqlcheck.ReportWriter.
+func (r *SchemaLint) WriteReport(rr sqlcheck.Report) {
+	r.Steps = append(r.Steps, rr)
+}
+
+// Findings returns the findings of the report, located by the positions of the schema
+// objects they point to. It is used by the SARIF, JUnit, GitHub and GitLab output formats.
+func (r *SchemaLint) Findings() sqlcheck.Findings {
+	var fs sqlcheck.Findings
+	for _, s := range r.Steps {
+		for _, d := range s.Diagnostics {
+			fs.AddObject(d.ObjectPos, sqlcheck.Finding{
+				Analyzer: s.Text,
+				Name:     s.Analyzer,
+				Code:     d.Code,
+				Text:     d.Text,
+				Error:    slices.Contains(r.Errors, s.Text),
+			})
+		}
+	}
+	return fs
+}
+
+// MarshalJSON implements json.Marshaler. The diagnostics are reported
+// with the positions of the schema objects they point to, and reports
+// of analyzers that failed are marked as errors.
+func (r SchemaLint) MarshalJSON() ([]byte, error) {
+	type (
+		diagnostic struct {
+			Pos  *schema.Pos `json:"Pos,omitempty"`
+			Text string      `json:"Text"`
+			Code string      `json:"Code,omitempty"`
+		}
+		report struct {
+			Text        string       `json:"Text"`
+			Desc        string       `json:"Desc,omitempty"`
+			Error       bool         `json:"Error,omitempty"`
+			Diagnostics []diagnostic `json:"Diagnostics,omitempty"`
+		}
+	)
+	var v struct {
+		Env
+		Steps []report `json:"Steps,omitempty"`
+	}
+	v.Env = r.Env
+	for _, s := range r.Steps {
+		rr := report{Text: s.Text, Desc: s.Desc, Error: slices.Contains(r.Errors, s.Text)}
+		for _, d := range s.Diagnostics {
+			rr.Diagnostics = append(rr.Diagnostics, diagnostic{Pos: d.ObjectPos, Text: d.Text, Code: d.Code})
+		}
+		v.Steps = append(v.Steps, rr)
+	}
+	return json.Marshal(v)
+}
+
 // SchemaPlanTemplate holds the default template of the 'schema apply --dry-run' command.
 var SchemaPlanTemplate = template.Must(template.
 	New("plan").
@@ -21,6 +21,7 @@ import (
 	"ariga.io/atlas/cmd/atlas/internal/cmdlog"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlclient"
 	_ "ariga.io/atlas/sql/sqlite"
 
@@ -112,6 +113,40 @@ func TestSchemaInspect_MarshalJSON(t *testing.T) {
 }`, string(ident))
 }
 
+func TestSchemaLint_MarshalJSON(t *testing.T) {
+	pos := &schema.Pos{Filename: "schema.hcl"}
+	pos.Start.Line = 3
+	report := &cmdlog.SchemaLint{
+		Env: cmdlog.Env{Driver: "mysql"},
+		Steps: []sqlcheck.Report{
+			{Text: "schema design issues detected", Diagnostics: []sqlcheck.Diagnostic{{Code: "SD101", ObjectPos: pos, Text: `Table "t" has no primary key`}}},
+			{Text: "naming convention violations detected", Diagnostics: []sqlcheck.Diagnostic{{Text: "Schema violates the naming policy"}}},
+		},
+		Errors: []string{"schema design issues detected"},
+	}
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Driver":"mysql","Steps":[{"Text":"schema design issues detected","Error":true,"Diagnostics":[{"Pos":{"Filename":"schema.hcl","Start":{"Line":3,"Column":0,"Byte":0},"End":{"Line":0,"Column":0,"Byte":0}},"Text":"Table \"t\" has no primary key","Code":"SD101"}]},{"Text":"naming convention violations detected","Diagnostics":[{"Text":"Schema violates the naming policy"}]}]}`, string(b))
+}
+
+func TestSchemaLint_Findings(t *testing.T) {
+	pos := &schema.Pos{Filename: "schema.hcl"}
+	pos.Start.Line = 3
+	report := &cmdlog.SchemaLint{
+		Steps: []sqlcheck.Report{
+			{Text: "schema design issues detected", Analyzer: "design", Diagnostics: []sqlcheck.Diagnostic{{Code: "SD101", ObjectPos: pos, Text: `Table "t" has no primary key`}}},
+			{Text: "naming convention violations detected", Diagnostics: []sqlcheck.Diagnostic{{Text: "Schema violates the naming policy"}}},
+		},
+		Errors: []string{"schema design issues detected"},
+	}
+	fs := report.Findings()
+	require.Equal(t, []string{"schema.hcl", "."}, fs.Files)
+	require.Equal(t, []sqlcheck.Finding{
+		{Path: "schema.hcl", Line: 3, Analyzer: "schema design issues detected", Name: "design", Code: "SD101", Text: `Table "t" has no primary key`, Error: true},
+		{Path: ".", Analyzer: "naming convention violations detected", Text: "Schema violates the naming policy"},
+	}, fs.List)
+}
+
 func TestSchemaInspect_MarshalSQL(t *testing.T) {
 	client, err := sqlclient.Open(context.Background(), "sqlite://ci?mode=memory&_fk=1")
 	require.NoError(t, err)
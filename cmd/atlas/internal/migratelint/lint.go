@@ -447,13 +447,22 @@ type Runner struct {
 	// ReportWriter writes the summary report.
 	ReportWriter ReportWriter
 
+	// Fix reports if the text edit of the given suggested fix should be applied
+	// to the migration file. If nil, suggested fixes are reported but not applied.
+	// An error aborts the fix run, before any of its selected fixes are applied.
+	Fix func(*FileReport, sqlcheck.SuggestedFix) (bool, error)
+
 	// summary report. reset on each run.
 	sum *SummaryReport
 }
 
 // Run executes migration linting.
 func (r *Runner) Run(ctx context.Context) error {
-	switch err := r.summary(ctx); err.(type) {
+	err := r.summary(ctx)
+	if err == nil && r.Fix != nil {
+		err = r.fix(ctx)
+	}
+	switch err.(type) {
 	case nil:
 		if err := r.ReportWriter.WriteReport(r.sum); err != nil {
 			return err
@@ -489,11 +498,17 @@ const (
 	StepDetectChanges  = "Detect New Migration Files"
 	StepLoadChanges    = "Replay Migration Files"
 	StepAnalyzeFile    = "Analyze %s"
+	StepApplyFixes     = "Apply Suggested Fixes"
 )
 
 func (r *Runner) summary(ctx context.Context) error {
 	r.sum = NewSummaryReport(r.Dev, r.Dir)
 	defer func() { r.sum.End = time.Now() }()
+	for _, az := range r.Analyzers {
+		if az, ok := az.(sqlcheck.NamedAnalyzer); ok {
+			r.sum.Analyzers = append(r.sum.Analyzers, az.Name())
+		}
+	}
 
 	// Integrity check.
 	switch err := migrate.Validate(r.Dir); {
@@ -600,6 +615,158 @@ func (r *Runner) analyze(ctx context.Context, files []*sqlcheck.File) error {
 	return nil
 }
 
+// maxFixRounds bounds the number of times the fixes are applied and re-analyzed.
+const maxFixRounds = 5
+
+// fix applies the selected suggested fixes to the migration files, re-hashes the
+// sum file and re-runs the analysis to confirm the fixes resolved the reports. Since
+// applying fixes may expose new reports (e.g., an index that is now created concurrently
+// within a transaction), this is repeated until no more fixes are applied.
+func (r *Runner) fix(ctx context.Context) error {
+	var (
+		applied int
+		files   = make(map[string]bool)
+		skipped = make(map[string]bool)
+	)
+	for i := 0; ; i++ {
+		type change struct {
+			f     *FileReport
+			fixes []sqlcheck.SuggestedFix
+		}
+		var changes []change
+		// Fixes are selected for all files before any of them is changed,
+		// to allow aborting the selection without leaving partial changes.
+		for _, f := range r.sum.Files {
+			if f.File == nil {
+				continue
+			}
+			var fixes []sqlcheck.SuggestedFix
+			for _, x := range f.SuggestedFixes() {
+				if x.TextEdit == nil {
+					continue
+				}
+				// Fixes that were not selected are not suggested again.
+				k := f.Name + "\x00" + x.Message + "\x00" + x.TextEdit.NewText
+				if skipped[k] {
+					continue
+				}
+				ok, err := r.Fix(f, x)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					skipped[k] = true
+					continue
+				}
+				fixes = append(fixes, x)
+			}
+			if len(fixes) > 0 {
+				changes = append(changes, change{f: f, fixes: fixes})
+			}
+		}
+		var round int
+		for _, c := range changes {
+			b, n := applyEdits(c.f.File.Bytes(), nonOverlapping(c.fixes))
+			if n == 0 {
+				continue
+			}
+			if err := r.Dir.WriteFile(c.f.Name, b); err != nil {
+				return fmt.Errorf("writing fixes to file %q: %w", c.f.Name, err)
+			}
+			round += n
+			files[c.f.Name] = true
+		}
+		if round == 0 {
+			break
+		}
+		applied += round
+		// Files were changed, and the sum file (if exists) must be updated.
+		if _, err := fs.Stat(r.Dir, migrate.HashFileName); err == nil {
+			sum, err := r.Dir.Checksum()
+			if err != nil {
+				return err
+			}
+			if err := migrate.WriteSumFile(r.Dir, sum); err != nil {
+				return err
+			}
+		}
+		if err := r.summary(ctx); err != nil {
+			return err
+		}
+		if i == maxFixRounds-1 {
+			return r.sum.StepError(StepApplyFixes, fmt.Sprintf("Suggested fixes were not resolved after %d rounds", maxFixRounds), &FileError{
+				File: changes[len(changes)-1].f.Name,
+				Err:  fmt.Errorf("applying suggested fixes did not converge after %d rounds", maxFixRounds),
+			})
+		}
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	r.sum.StepResult(StepApplyFixes, fmt.Sprintf("Applied %d suggested fix%s to %d migration file%s", applied, plural(applied, "es"), len(files), plural(len(files), "s")), nil)
+	return nil
+}
+
+// nonOverlapping returns the text edits of the given fixes sorted by their
+// lines. Overlapping edits are resolved deterministically: the edit that starts
+// first is applied, and ties are broken by the order the fixes were reported.
+func nonOverlapping(fixes []sqlcheck.SuggestedFix) []*sqlcheck.TextEdit {
+	all := make([]*sqlcheck.TextEdit, 0, len(fixes))
+	for _, x := range fixes {
+		if e := x.TextEdit; e.Line > 0 && e.End >= e.Line {
+			all = append(all, e)
+		}
+	}
+	slices.SortStableFunc(all, func(e1, e2 *sqlcheck.TextEdit) int { return e1.Line - e2.Line })
+	edits := make([]*sqlcheck.TextEdit, 0, len(all))
+	for _, e := range all {
+		// Edits that overlap with a previous edit (including
+		// identical edits reported twice) are skipped.
+		if n := len(edits); n == 0 || e.Line > edits[n-1].End {
+			edits = append(edits, e)
+		}
+	}
+	return edits
+}
+
+// applyEdits applies the given sorted and non-overlapping edits to the file content,
+// and returns the number of edits that were applied. Edits that exceed the number of
+// lines in the file are skipped.
+func applyEdits(b []byte, edits []*sqlcheck.TextEdit) ([]byte, int) {
+	var (
+		n     int
+		buf   strings.Builder
+		next  = 1
+		lines = strings.SplitAfter(string(b), "\n")
+	)
+	for _, e := range edits {
+		if e.End > len(lines) {
+			continue
+		}
+		n++
+		for ; next < e.Line; next++ {
+			buf.WriteString(lines[next-1])
+		}
+		buf.WriteString(e.NewText)
+		if strings.HasSuffix(lines[e.End-1], "\n") && !strings.HasSuffix(e.NewText, "\n") {
+			buf.WriteByte('\n')
+		}
+		next = e.End + 1
+	}
+	for ; next <= len(lines); next++ {
+		buf.WriteString(lines[next-1])
+	}
+	return []byte(buf.String()), n
+}
+
+// plural returns the given suffix if n is not 1.
+func plural(n int, suffix string) string {
+	if n != 1 {
+		return suffix
+	}
+	return ""
+}
+
 var (
 	// TemplateFuncs are global functions available in templates.
 	TemplateFuncs = template.FuncMap{
@@ -618,6 +785,10 @@ var (
 			}
 			return string(b), err
 		},
+		"sarif":     formatFunc(sqlcheck.WriteSARIF),
+		"junit":     formatFunc(sqlcheck.WriteJUnit),
+		"github":    formatFunc(sqlcheck.WriteGitHub),
+		"gitlab":    formatFunc(sqlcheck.WriteGitLab),
 		"sub":       func(i, j int) int { return i - j },
 		"add":       func(i, j int) int { return i + j },
 		"repeat":    strings.Repeat,
@@ -773,8 +944,29 @@ var (
 	JSONTemplate = template.Must(template.New("json").
 		Funcs(TemplateFuncs).
 		Parse("{{ json . }}"))
+
+	// Formats holds the built-in output formats of the lint
+	// report, that can be selected by their name.
+	Formats = map[string]*template.Template{
+		"sarif":  template.Must(template.New("sarif").Funcs(TemplateFuncs).Parse("{{ sarif . }}")),
+		"junit":  template.Must(template.New("junit").Funcs(TemplateFuncs).Parse("{{ junit . }}")),
+		"github": template.Must(template.New("github").Funcs(TemplateFuncs).Parse("{{ github . }}")),
+		"gitlab": template.Must(template.New("gitlab").Funcs(TemplateFuncs).Parse("{{ gitlab . }}")),
+	}
 )
 
+// formatFunc returns a template function that encodes the findings of a
+// report (e.g., a lint summary or a schema lint report) using the given writer.
+func formatFunc(write func(io.Writer, sqlcheck.Findings) error) func(interface{ Findings() sqlcheck.Findings }) (string, error) {
+	return func(r interface{ Findings() sqlcheck.Findings }) (string, error) {
+		var b strings.Builder
+		if err := write(&b, r.Findings()); err != nil {
+			return "", err
+		}
+		return b.String(), nil
+	}
+}
+
 type (
 	// A SummaryReport contains a summary of the analysis of all files.
 	// It is used as an input to templates to report the CI results.
@@ -800,6 +992,9 @@ type (
 		// Files reports. Non-empty in case there are findings.
 		Files []*FileReport `json:"Files,omitempty"`
 
+		// Names of the analyzers that ran on the files.
+		Analyzers []string `json:"Analyzers,omitempty"`
+
 		// Logging only info.
 		Start      time.Time `json:"-"` // Start time of the analysis.
 		End        time.Time `json:"-"` // End time of the analysis.
@@ -988,13 +1183,32 @@ func (r *SummaryReport) NonFileReports() (rs []*StepReport) {
 	return rs
 }
 
+// Findings returns the findings of the summary, with the diagnostics resolved to
+// file lines. It is used by the SARIF, JUnit, GitHub and GitLab output formats.
+func (r *SummaryReport) Findings() sqlcheck.Findings {
+	var (
+		files   = make([]sqlcheck.LintFile, 0, len(r.Files))
+		reports []sqlcheck.Report
+	)
+	for _, f := range r.Files {
+		files = append(files, sqlcheck.LintFile{Path: filepath.Join(r.Env.Dir, f.Name), Reports: f.Reports, Error: f.Error, Line: f.Line})
+	}
+	for _, s := range r.NonFileReports() {
+		reports = append(reports, s.Result.Reports...)
+	}
+	return sqlcheck.LintFindings(r.Env.Dir, r.Analyzers, files, reports)
+}
+
 // NewFileReport returns a new FileReport.
 func NewFileReport(f *sqlcheck.File) *FileReport {
 	return &FileReport{Name: f.Name(), Text: string(f.Bytes()), Start: time.Now(), File: f}
 }
 
-// Line returns the line number from a position.
+// Line returns the line number from a position, or 0 if the position is out of range.
 func (f *FileReport) Line(pos int) int {
+	if pos < 0 || pos > len(f.Text) {
+		return 0
+	}
 	return strings.Count(f.Text[:pos], "\n") + 1
 }
 
@@ -1087,7 +1301,11 @@ func (s *skipRules) reporterFor(rw sqlcheck.ReportWriter, az sqlcheck.Analyzer)
 			}
 		}
 		if s.skipped = len(ds) == 0; !s.skipped {
-			rw.WriteReport(sqlcheck.Report{Text: r.Text, Diagnostics: ds})
+			rep := sqlcheck.Report{Text: r.Text, Diagnostics: ds}
+			if ok {
+				rep.Analyzer = az.Name()
+			}
+			rw.WriteReport(rep)
 		}
 	})
 }
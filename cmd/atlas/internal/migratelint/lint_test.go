@@ -6,15 +6,20 @@ package migratelint_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"ariga.io/atlas/cmd/atlas/internal/migratelint"
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqlcheck"
+	"ariga.io/atlas/sql/sqlclient"
 	_ "ariga.io/atlas/sql/sqlite"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -130,3 +135,204 @@ func TestLatestChanges(t *testing.T) {
 	require.Equal(t, files[:1], base)
 	require.Equal(t, files[1:], feat)
 }
+
+func TestRunner_Fix(t *testing.T) {
+	ctx := context.Background()
+	dev, err := sqlclient.Open(ctx, "sqlite://ci?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer dev.Close()
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1.sql", []byte("CREATE TABLE t1 (id int);\n")))
+	require.NoError(t, dir.WriteFile("2.sql", []byte("CREATE TABLE t2 (id int);\n-- comment\nCREATE TABLE t3 (id int);\n")))
+	sum, err := dir.Checksum()
+	require.NoError(t, err)
+	require.NoError(t, migrate.WriteSumFile(dir, sum))
+
+	var (
+		prompted []string
+		w        = &reportWriter{}
+		r        = &migratelint.Runner{
+			Dev:            dev,
+			Dir:            dir,
+			ChangeDetector: migratelint.LatestChanges(dir, 1),
+			ReportWriter:   w,
+			Analyzers: []sqlcheck.Analyzer{
+				sqlcheck.AnalyzerFunc(func(_ context.Context, p *sqlcheck.Pass) error {
+					var ds []sqlcheck.Diagnostic
+					for _, c := range p.File.Changes {
+						if strings.Contains(c.Stmt.Text, "IF NOT EXISTS") {
+							continue
+						}
+						d := sqlcheck.Diagnostic{Pos: c.Stmt.Pos, Text: "missing IF NOT EXISTS"}
+						d.SuggestFix("Add IF NOT EXISTS", p.File.StmtTextEdit(c.Stmt, strings.Replace(c.Stmt.Text, "CREATE TABLE", "CREATE TABLE IF NOT EXISTS", 1)))
+						// Overlaps with the fix above and must be ignored.
+						d.SuggestFix("Remove statement", p.File.StmtTextEdit(c.Stmt, ""))
+						ds = append(ds, d)
+					}
+					if len(ds) > 0 {
+						p.Reporter.WriteReport(sqlcheck.Report{Text: "Tables", Diagnostics: ds})
+					}
+					return nil
+				}),
+			},
+		}
+	)
+	// Reporting only.
+	require.NoError(t, r.Run(ctx))
+	require.Len(t, w.reports, 1)
+	require.Equal(t, 2, w.reports[0].DiagnosticsCount())
+	b, err := os.ReadFile(filepath.Join(dir.Path(), "2.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE t2 (id int);\n-- comment\nCREATE TABLE t3 (id int);\n", string(b))
+
+	// Aborting the selection leaves the files unchanged.
+	r.Fix = func(*migratelint.FileReport, sqlcheck.SuggestedFix) (bool, error) { return false, errors.New("^C") }
+	require.EqualError(t, r.Run(ctx), "^C")
+	b, err = os.ReadFile(filepath.Join(dir.Path(), "2.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE t2 (id int);\n-- comment\nCREATE TABLE t3 (id int);\n", string(b))
+
+	// Apply only the fixes that were selected. Skipped fixes
+	// are not suggested again after the analysis is re-run.
+	r.Fix = func(f *migratelint.FileReport, x sqlcheck.SuggestedFix) (bool, error) {
+		prompted = append(prompted, fmt.Sprintf("%s:%d:%s", f.Name, x.TextEdit.Line, x.Message))
+		return x.TextEdit.Line == 1, nil
+	}
+	require.NoError(t, r.Run(ctx))
+	require.Equal(t, []string{"2.sql:1:Add IF NOT EXISTS", "2.sql:1:Remove statement", "2.sql:3:Add IF NOT EXISTS", "2.sql:3:Remove statement"}, prompted)
+	b, err = os.ReadFile(filepath.Join(dir.Path(), "2.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS t2 (id int);\n-- comment\nCREATE TABLE t3 (id int);\n", string(b))
+	// Analysis was re-run on the fixed file.
+	require.Len(t, w.reports, 2)
+	require.Equal(t, 1, w.reports[1].DiagnosticsCount())
+	step := w.reports[1].Steps[len(w.reports[1].Steps)-1]
+	require.Equal(t, migratelint.StepApplyFixes, step.Name)
+	require.Equal(t, "Applied 1 suggested fix to 1 migration file", step.Text)
+	// Sum file was updated.
+	require.NoError(t, migrate.Validate(dir))
+
+	// Apply all fixes.
+	r.Fix = func(*migratelint.FileReport, sqlcheck.SuggestedFix) (bool, error) { return true, nil }
+	require.NoError(t, r.Run(ctx))
+	b, err = os.ReadFile(filepath.Join(dir.Path(), "2.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS t2 (id int);\n-- comment\nCREATE TABLE IF NOT EXISTS t3 (id int);\n", string(b))
+	require.Len(t, w.reports, 3)
+	require.Zero(t, w.reports[2].DiagnosticsCount())
+	require.NoError(t, migrate.Validate(dir))
+	b, err = os.ReadFile(filepath.Join(dir.Path(), "1.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE t1 (id int);\n", string(b), "files that were not analyzed are not changed")
+}
+
+func TestRunner_FixOutOfRange(t *testing.T) {
+	ctx := context.Background()
+	dev, err := sqlclient.Open(ctx, "sqlite://ci?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer dev.Close()
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1.sql", []byte("CREATE TABLE t1 (id int);\n")))
+	var (
+		w = &reportWriter{}
+		r = &migratelint.Runner{
+			Dev:            dev,
+			Dir:            dir,
+			ChangeDetector: migratelint.LatestChanges(dir, 1),
+			ReportWriter:   w,
+			Fix:            func(*migratelint.FileReport, sqlcheck.SuggestedFix) (bool, error) { return true, nil },
+			Analyzers: []sqlcheck.Analyzer{
+				sqlcheck.AnalyzerFunc(func(_ context.Context, p *sqlcheck.Pass) error {
+					if strings.Contains(p.File.Changes[0].Stmt.Text, "IF NOT EXISTS") {
+						return nil
+					}
+					d := sqlcheck.Diagnostic{Text: "missing IF NOT EXISTS"}
+					d.SuggestFix("Add IF NOT EXISTS", &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "CREATE TABLE IF NOT EXISTS t1 (id int);"})
+					// Exceeds the number of lines in the file and must be skipped.
+					d.SuggestFix("Remove line", &sqlcheck.TextEdit{Line: 10, End: 10})
+					p.Reporter.WriteReport(sqlcheck.Report{Text: "Tables", Diagnostics: []sqlcheck.Diagnostic{d}})
+					return nil
+				}),
+			},
+		}
+	)
+	require.NoError(t, r.Run(ctx))
+	b, err := os.ReadFile(filepath.Join(dir.Path(), "1.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE IF NOT EXISTS t1 (id int);\n", string(b))
+	step := w.reports[0].Steps[len(w.reports[0].Steps)-1]
+	require.Equal(t, migratelint.StepApplyFixes, step.Name)
+	require.Equal(t, "Applied 1 suggested fix to 1 migration file", step.Text)
+}
+
+func TestRunner_FixRounds(t *testing.T) {
+	ctx := context.Background()
+	dev, err := sqlclient.Open(ctx, "sqlite://ci?mode=memory&_fk=1")
+	require.NoError(t, err)
+	defer dev.Close()
+	dir, err := migrate.NewLocalDir(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, dir.WriteFile("1.sql", []byte("CREATE TABLE t1 (id int);\n")))
+	var (
+		converge = true
+		w        = &reportWriter{}
+		r        = &migratelint.Runner{
+			Dev:            dev,
+			Dir:            dir,
+			ChangeDetector: migratelint.LatestChanges(dir, 1),
+			ReportWriter:   w,
+			Fix:            func(*migratelint.FileReport, sqlcheck.SuggestedFix) (bool, error) { return true, nil },
+			Analyzers: []sqlcheck.Analyzer{
+				sqlcheck.AnalyzerFunc(func(_ context.Context, p *sqlcheck.Pass) error {
+					var (
+						d    sqlcheck.Diagnostic
+						b    = string(p.File.Bytes())
+						s, _ = strings.CutSuffix(b, "\n")
+					)
+					switch {
+					case !strings.Contains(b, "IF NOT EXISTS"):
+						d = sqlcheck.Diagnostic{Text: "missing IF NOT EXISTS"}
+						d.SuggestFix("Add IF NOT EXISTS", &sqlcheck.TextEdit{Line: 1, End: 1, NewText: strings.Replace(s, "CREATE TABLE", "CREATE TABLE IF NOT EXISTS", 1)})
+					// Reported only after the first fix was applied.
+					case !strings.HasPrefix(b, "-- checked"):
+						d = sqlcheck.Diagnostic{Text: "missing header"}
+						d.SuggestFix("Add header", &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "-- checked\n" + s})
+					case !converge:
+						d = sqlcheck.Diagnostic{Text: "never fixed"}
+						d.SuggestFix("Add comment", &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "-- checked"})
+					default:
+						return nil
+					}
+					p.Reporter.WriteReport(sqlcheck.Report{Text: "Tables", Diagnostics: []sqlcheck.Diagnostic{d}})
+					return nil
+				}),
+			},
+		}
+	)
+	require.NoError(t, r.Run(ctx))
+	b, err := os.ReadFile(filepath.Join(dir.Path(), "1.sql"))
+	require.NoError(t, err)
+	require.Equal(t, "-- checked\nCREATE TABLE IF NOT EXISTS t1 (id int);\n", string(b))
+	require.Zero(t, w.reports[0].DiagnosticsCount())
+	step := w.reports[0].Steps[len(w.reports[0].Steps)-1]
+	require.Equal(t, migratelint.StepApplyFixes, step.Name)
+	require.Equal(t, "Applied 2 suggested fixes to 1 migration file", step.Text)
+
+	// Fixes that do not resolve their reports are applied a bounded number of times.
+	converge = false
+	require.Error(t, r.Run(ctx))
+	step = w.reports[1].Steps[len(w.reports[1].Steps)-1]
+	require.Equal(t, migratelint.StepApplyFixes, step.Name)
+	require.Equal(t, "Suggested fixes were not resolved after 5 rounds", step.Text)
+}
+
+type reportWriter struct {
+	reports []*migratelint.SummaryReport
+}
+
+func (w *reportWriter) WriteReport(r *migratelint.SummaryReport) error {
+	w.reports = append(w.reports, r)
+	return nil
+}
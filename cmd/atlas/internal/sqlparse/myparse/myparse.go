@@ -5,8 +5,7 @@
 package myparse
 
 import (
-	"errors"
-
+	"ariga.io/atlas/cmd/atlas/internal/sqlparse/parseutil"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 )
@@ -14,17 +13,134 @@ import (
 // Parser for fixing linting changes.
 type FileParser struct{}
 
+// lexer for MySQL statements.
+var lexer = &parseutil.Lexer{
+	IdentQuote:      '`',
+	HashComment:     true,
+	BackslashEscape: true,
+}
+
 // FixChange fixes the changes according to the given statement.
-func (*FileParser) FixChange(migrate.Driver, string, schema.Changes) (schema.Changes, error) {
-	return nil, errors.New("unimplemented")
+func (*FileParser) FixChange(_ migrate.Driver, stmt string, changes schema.Changes) (schema.Changes, error) {
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	tokens, err := lexer.Tokens(stmt)
+	if err != nil {
+		return nil, err
+	}
+	s := parseutil.NewTokenScanner(tokens)
+	switch {
+	case s.Keywords("ALTER", "TABLE"), s.Keywords("ALTER", "IGNORE", "TABLE"):
+		name, ok := s.Name()
+		if !ok {
+			return changes, nil
+		}
+		var columns, indexes []*parseutil.Rename
+		for _, spec := range s.Until() {
+			switch r, kind := alterSpec(spec); kind {
+			case "TABLE":
+				r.From = parseutil.Last(name)
+				changes = parseutil.RenameTable(changes, r)
+			case "COLUMN":
+				columns = append(columns, r)
+			case "INDEX":
+				indexes = append(indexes, r)
+			}
+		}
+		if len(columns)+len(indexes) == 0 {
+			return changes, nil
+		}
+		modify := modifyT(changes)
+		if modify == nil {
+			return changes, nil
+		}
+		for _, r := range columns {
+			parseutil.RenameColumn(modify, r)
+		}
+		for _, r := range indexes {
+			parseutil.RenameIndex(modify, r)
+		}
+	case s.Keywords("RENAME", "TABLE"):
+		for _, pair := range s.Until() {
+			ps := parseutil.NewTokenScanner(pair)
+			from, ok1 := ps.Name()
+			ps.Keywords("TO")
+			to, ok2 := ps.Name()
+			if ok1 && ok2 {
+				changes = parseutil.RenameTable(changes, &parseutil.Rename{From: parseutil.Last(from), To: parseutil.Last(to)})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// alterSpec parses a single ALTER TABLE specification and returns the rename
+// it describes and its target: "TABLE", "COLUMN", "INDEX" or "" for others.
+func alterSpec(spec []parseutil.Token) (*parseutil.Rename, string) {
+	s := parseutil.NewTokenScanner(spec)
+	switch {
+	case s.Keywords("RENAME", "COLUMN"):
+		from, ok1 := s.Name()
+		s.Keywords("TO")
+		to, ok2 := s.Name()
+		if ok1 && ok2 {
+			return &parseutil.Rename{From: parseutil.Last(from), To: parseutil.Last(to)}, "COLUMN"
+		}
+	case s.Keywords("RENAME", "INDEX"), s.Keywords("RENAME", "KEY"):
+		from, ok1 := s.Name()
+		s.Keywords("TO")
+		to, ok2 := s.Name()
+		if ok1 && ok2 {
+			return &parseutil.Rename{From: parseutil.Last(from), To: parseutil.Last(to)}, "INDEX"
+		}
+	case s.Keywords("RENAME"):
+		if !s.Keywords("TO") {
+			s.Keywords("AS")
+		}
+		if to, ok := s.Name(); ok {
+			return &parseutil.Rename{To: parseutil.Last(to)}, "TABLE"
+		}
+	// CHANGE [COLUMN] old new definition.
+	case s.Keywords("CHANGE"):
+		s.Keywords("COLUMN")
+		from, ok1 := s.Name()
+		to, ok2 := s.Name()
+		if ok1 && ok2 && parseutil.Last(from) != parseutil.Last(to) {
+			return &parseutil.Rename{From: parseutil.Last(from), To: parseutil.Last(to)}, "COLUMN"
+		}
+	}
+	return nil, ""
+}
+
+// modifyT returns the first ModifyTable change, if exists.
+func modifyT(changes schema.Changes) *schema.ModifyTable {
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyTable); ok {
+			return m
+		}
+	}
+	return nil
 }
 
 // ColumnFilledBefore checks if the column was filled with values before the given position in the file.
-func (*FileParser) ColumnFilledBefore([]*migrate.Stmt, *schema.Table, *schema.Column, int) (bool, error) {
-	return false, errors.New("unimplemented")
+func (*FileParser) ColumnFilledBefore(stmts []*migrate.Stmt, t *schema.Table, c *schema.Column, pos int) (bool, error) {
+	return parseutil.MatchStmtBefore(stmts, pos, func(s *migrate.Stmt) (bool, error) {
+		u, err := parseutil.ParseUpdate(lexer, s.Text)
+		if err != nil || u == nil {
+			return false, err
+		}
+		return u.FillsColumn(t, c), nil
+	})
 }
 
 // CreateViewAfter checks if a view was created after the position with the given name to a table.
-func (*FileParser) CreateViewAfter([]*migrate.Stmt, string, string, int) (bool, error) {
-	return false, errors.New("unimplemented")
+func (*FileParser) CreateViewAfter(stmts []*migrate.Stmt, old, new string, pos int) (bool, error) {
+	return parseutil.MatchStmtAfter(stmts, pos, func(s *migrate.Stmt) (bool, error) {
+		v, err := parseutil.ParseCreateView(lexer, s.Text)
+		if err != nil || v == nil {
+			return false, err
+		}
+		return parseutil.Last(v.Name) == old && parseutil.Last(v.Table) == new, nil
+	})
 }
@@ -0,0 +1,145 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package myparse_test
+
+import (
+	"testing"
+
+	"ariga.io/atlas/cmd/atlas/internal/sqlparse/myparse"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileParser_ColumnFilledBefore(t *testing.T) {
+	var (
+		p   myparse.FileParser
+		tbl = schema.NewTable("users").SetSchema(schema.New("test"))
+		c   = schema.NewColumn("name")
+	)
+	tbl.AddColumns(c)
+	for _, tt := range []struct {
+		stmt  string
+		pos   int
+		wantT bool
+	}{
+		{stmt: "UPDATE users SET name = 'a8m'", pos: 100, wantT: true},
+		{stmt: "UPDATE `users` SET `name` = 'a8m' WHERE `name` IS NULL;", pos: 100, wantT: true},
+		{stmt: "UPDATE LOW_PRIORITY IGNORE `test`.`users` SET id = id + 1, `name` = 'a\\'8m' ORDER BY id", pos: 100, wantT: true},
+		{stmt: "UPDATE users u SET u.name = 'a8m' WHERE u.name IS NULL", pos: 100, wantT: true},
+		{stmt: "UPDATE users SET name = 'a8m'", pos: 0},
+		{stmt: "UPDATE users SET name = NULL", pos: 100},
+		{stmt: "UPDATE users SET name = DEFAULT", pos: 100},
+		{stmt: "UPDATE users SET name = 'a8m' WHERE id = 1", pos: 100},
+		{stmt: "UPDATE users SET name = 'a8m' LIMIT 10", pos: 100},
+		{stmt: "UPDATE users, pets SET users.name = pets.name", pos: 100},
+		{stmt: "UPDATE users JOIN pets ON users.id = pets.owner_id SET users.name = pets.name", pos: 100},
+		{stmt: "UPDATE other.users SET name = 'a8m'", pos: 100},
+		{stmt: "UPDATE users SET id = 1", pos: 100},
+	} {
+		t.Run(tt.stmt, func(t *testing.T) {
+			stmts := []*migrate.Stmt{{Pos: 1, Text: tt.stmt}}
+			got, err := p.ColumnFilledBefore(stmts, tbl, c, tt.pos)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantT, got)
+		})
+	}
+}
+
+func TestFileParser_CreateViewAfter(t *testing.T) {
+	var p myparse.FileParser
+	for _, tt := range []struct {
+		stmt  string
+		wantT bool
+	}{
+		{stmt: "CREATE VIEW users AS SELECT * FROM Users", wantT: true},
+		{stmt: "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `users` (`id`) AS SELECT `id` FROM `test`.`Users` WITH CHECK OPTION", wantT: true},
+		{stmt: "CREATE VIEW users AS SELECT * FROM Users JOIN pets", wantT: false},
+		{stmt: "CREATE VIEW users AS SELECT * FROM pets", wantT: false},
+		{stmt: "CREATE VIEW pets AS SELECT * FROM Users", wantT: false},
+	} {
+		t.Run(tt.stmt, func(t *testing.T) {
+			stmts := []*migrate.Stmt{
+				{Pos: 0, Text: "ALTER TABLE users RENAME TO Users"},
+				{Pos: 1, Text: tt.stmt},
+			}
+			got, err := p.CreateViewAfter(stmts, "users", "Users", 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantT, got)
+		})
+	}
+}
+
+func TestFileParser_FixChange(t *testing.T) {
+	var p myparse.FileParser
+	changes, err := p.FixChange(
+		nil,
+		"ALTER TABLE `t` RENAME COLUMN `c1` TO `c2`, CHANGE c3 c4 int NOT NULL, RENAME INDEX i1 TO i2",
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.DropColumn{C: schema.NewColumn("c1")},
+					&schema.AddColumn{C: schema.NewColumn("c2")},
+					&schema.DropColumn{C: schema.NewColumn("c3")},
+					&schema.AddColumn{C: schema.NewColumn("c4")},
+					&schema.DropIndex{I: schema.NewIndex("i1")},
+					&schema.AddIndex{I: schema.NewIndex("i2")},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.RenameColumn{From: schema.NewColumn("c1"), To: schema.NewColumn("c2")},
+					&schema.RenameColumn{From: schema.NewColumn("c3"), To: schema.NewColumn("c4")},
+					&schema.RenameIndex{From: schema.NewIndex("i1"), To: schema.NewIndex("i2")},
+				},
+			},
+		},
+		changes,
+	)
+
+	changes, err = p.FixChange(
+		nil,
+		"RENAME TABLE t1 TO t2, t3 TO t4",
+		schema.Changes{
+			&schema.DropTable{T: schema.NewTable("t1")},
+			&schema.DropTable{T: schema.NewTable("t3")},
+			&schema.AddTable{T: schema.NewTable("t2")},
+			&schema.AddTable{T: schema.NewTable("t4")},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.RenameTable{From: schema.NewTable("t1"), To: schema.NewTable("t2")},
+			&schema.RenameTable{From: schema.NewTable("t3"), To: schema.NewTable("t4")},
+		},
+		changes,
+	)
+
+	changes, err = p.FixChange(
+		nil,
+		"ALTER TABLE t1 RENAME AS t2",
+		schema.Changes{
+			&schema.DropTable{T: schema.NewTable("t1")},
+			&schema.AddTable{T: schema.NewTable("t2")},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.RenameTable{From: schema.NewTable("t1"), To: schema.NewTable("t2")},
+		},
+		changes,
+	)
+}
@@ -0,0 +1,312 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package parseutil
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenType identifies the type of lexical tokens.
+type TokenType int
+
+// List of token types.
+const (
+	TokenEOF    TokenType = iota // end of input
+	TokenIdent                   // unquoted identifiers and keywords
+	TokenQuoted                  // quoted identifiers
+	TokenString                  // string literals
+	TokenNumber                  // numeric literals
+	TokenPunct                   // punctuation and operators
+)
+
+// Token is a lexical token of an SQL statement.
+type Token struct {
+	Type TokenType
+	// Text holds the token text. For quoted identifiers and strings,
+	// it holds the unquoted value. For unquoted identifiers, the text
+	// is folded according to the Lexer configuration.
+	Text string
+}
+
+// Is reports if the token is the given keyword. Keywords are matched
+// case-insensitively, and quoted identifiers are never keywords.
+func (t Token) Is(kw string) bool {
+	return t.Type == TokenIdent && strings.EqualFold(t.Text, kw)
+}
+
+// IsName reports if the token can be used as an object name.
+func (t Token) IsName() bool {
+	return t.Type == TokenIdent || t.Type == TokenQuoted
+}
+
+// IsPunct reports if the token is the given punctuation.
+func (t Token) IsPunct(p string) bool {
+	return t.Type == TokenPunct && t.Text == p
+}
+
+// A Lexer splits a single SQL statement into tokens.
+type Lexer struct {
+	// IdentQuote is the character used for quoting identifiers.
+	// For example, '"' in PostgreSQL and '`' in MySQL.
+	IdentQuote rune
+	// HashComment enables MySQL-style comments that start with '#'.
+	HashComment bool
+	// DollarQuote enables PostgreSQL dollar-quoted strings.
+	DollarQuote bool
+	// BackslashEscape enables escaping characters in string literals
+	// using backslashes, as done by default in MySQL.
+	BackslashEscape bool
+	// Fold is applied on unquoted identifiers, if set.
+	// For example, PostgreSQL folds them to lower case.
+	Fold func(string) string
+}
+
+// Tokens returns the tokens of the given statement.
+func (l *Lexer) Tokens(s string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(s[i:], "--"), l.HashComment && r == '#':
+			j := strings.IndexByte(s[i:], '\n')
+			if j == -1 {
+				return tokens, nil
+			}
+			i += j + 1
+		case strings.HasPrefix(s[i:], "/*"):
+			j := strings.Index(s[i+2:], "*/")
+			if j == -1 {
+				return nil, fmt.Errorf("unterminated comment at position %d", i)
+			}
+			i += j + 4
+		case r == l.IdentQuote:
+			v, n, err := unquote(s[i:], byte(r), false)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{Type: TokenQuoted, Text: v})
+			i += n
+		case r == '\'' || r == '"':
+			v, n, err := unquote(s[i:], byte(r), l.BackslashEscape)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, Token{Type: TokenString, Text: v})
+			i += n
+		case l.DollarQuote && r == '$' && dollarTag(s[i:]) != "":
+			tag := dollarTag(s[i:])
+			j := strings.Index(s[i+len(tag):], tag)
+			if j == -1 {
+				return nil, fmt.Errorf("unterminated dollar-quoted string at position %d", i)
+			}
+			tokens = append(tokens, Token{Type: TokenString, Text: s[i+len(tag) : i+len(tag)+j]})
+			i += 2*len(tag) + j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(s) && (isDigit(s[j]) || s[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, Token{Type: TokenNumber, Text: s[i:j]})
+			i = j
+		case isIdentStart(r):
+			j := i + size
+			for j < len(s) {
+				r, size := utf8.DecodeRuneInString(s[j:])
+				if !isIdentStart(r) && !unicode.IsDigit(r) && r != '$' {
+					break
+				}
+				j += size
+			}
+			v := s[i:j]
+			if l.Fold != nil {
+				v = l.Fold(v)
+			}
+			tokens = append(tokens, Token{Type: TokenIdent, Text: v})
+			i = j
+		default:
+			tokens = append(tokens, Token{Type: TokenPunct, Text: s[i : i+size]})
+			i += size
+		}
+	}
+	return tokens, nil
+}
+
+// unquote returns the unquoted value of the quoted text at the beginning
+// of s, and the number of bytes it spans. Doubled quotes are unescaped, and
+// backslash-escaped characters are skipped (but kept as-is) if esc is true.
+func unquote(s string, q byte, esc bool) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch {
+		case esc && s[i] == '\\' && i+1 < len(s):
+			b.WriteByte(s[i])
+			b.WriteByte(s[i+1])
+			i++
+		case s[i] != q:
+			b.WriteByte(s[i])
+		case i+1 < len(s) && s[i+1] == q:
+			b.WriteByte(q)
+			i++
+		default:
+			return b.String(), i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted text: %s", s)
+}
+
+// dollarTag returns the dollar-quote tag at the beginning of s, if exists.
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch r := rune(s[i]); {
+		case r == '$':
+			return s[:i+1]
+		case !isIdentStart(r) && !(i > 1 && unicode.IsDigit(r)):
+			return ""
+		}
+	}
+	return ""
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// A TokenScanner provides helper methods for walking over statement tokens.
+type TokenScanner struct {
+	tokens []Token
+	pos    int
+}
+
+// NewTokenScanner returns a new TokenScanner for the given tokens.
+func NewTokenScanner(tokens []Token) *TokenScanner {
+	return &TokenScanner{tokens: tokens}
+}
+
+// Done reports if all tokens were consumed. A trailing
+// semicolon is not considered as part of the statement.
+func (s *TokenScanner) Done() bool {
+	return s.pos >= len(s.tokens) || s.pos == len(s.tokens)-1 && s.tokens[s.pos].IsPunct(";")
+}
+
+// Peek returns the next token without consuming it.
+func (s *TokenScanner) Peek() Token {
+	if s.Done() {
+		return Token{Type: TokenEOF}
+	}
+	return s.tokens[s.pos]
+}
+
+// Next consumes and returns the next token.
+func (s *TokenScanner) Next() Token {
+	t := s.Peek()
+	if t.Type != TokenEOF {
+		s.pos++
+	}
+	return t
+}
+
+// Keywords consumes the given sequence of keywords, if matched.
+func (s *TokenScanner) Keywords(kws ...string) bool {
+	for i, kw := range kws {
+		if s.pos+i >= len(s.tokens) || !s.tokens[s.pos+i].Is(kw) {
+			return false
+		}
+	}
+	s.pos += len(kws)
+	return true
+}
+
+// Punct consumes the given punctuation, if matched.
+func (s *TokenScanner) Punct(p string) bool {
+	if s.Peek().IsPunct(p) {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+// Name consumes a (possibly qualified) object name, and returns its parts.
+func (s *TokenScanner) Name() ([]string, bool) {
+	if !s.Peek().IsName() {
+		return nil, false
+	}
+	parts := []string{s.Next().Text}
+	for s.Peek().IsPunct(".") && s.pos+1 < len(s.tokens) && s.tokens[s.pos+1].IsName() {
+		parts = append(parts, s.tokens[s.pos+1].Text)
+		s.pos += 2
+	}
+	return parts, true
+}
+
+// Skip consumes the next token. In case the token opens
+// parentheses, the whole parenthesized group is consumed.
+func (s *TokenScanner) Skip() {
+	if !s.Punct("(") {
+		s.Next()
+		return
+	}
+	for depth := 1; depth > 0 && !s.Done(); {
+		switch t := s.Next(); {
+		case t.IsPunct("("):
+			depth++
+		case t.IsPunct(")"):
+			depth--
+		}
+	}
+}
+
+// Until consumes tokens until one of the given keywords is reached in the
+// top level of the statement (outside parentheses), and returns them split
+// by top-level commas. The matched keyword is not consumed.
+func (s *TokenScanner) Until(kws ...string) [][]Token {
+	var (
+		list [][]Token
+		curr []Token
+	)
+	for !s.Done() {
+		t := s.Peek()
+		if t.Type == TokenIdent && matchAny(t, kws) {
+			break
+		}
+		if t.IsPunct(",") {
+			list, curr = append(list, curr), nil
+			s.Next()
+			continue
+		}
+		start := s.pos
+		s.Skip()
+		curr = append(curr, s.tokens[start:s.pos]...)
+	}
+	if len(curr) > 0 {
+		list = append(list, curr)
+	}
+	return list
+}
+
+func matchAny(t Token, kws []string) bool {
+	for _, kw := range kws {
+		if t.Is(kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// Last returns the last part of a qualified name.
+func Last(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[len(parts)-1]
+}
@@ -0,0 +1,202 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package parseutil
+
+import (
+	"slices"
+
+	"ariga.io/atlas/sql/schema"
+)
+
+// UpdateStmt describes a single-table UPDATE statement.
+type UpdateStmt struct {
+	Table []string  // Qualified table name.
+	Alias string    // Optional table alias.
+	Set   [][]Token // Assignment list.
+	Where []Token   // WHERE clause, if exists.
+	// Joined indicates the statement updates rows using other
+	// tables, e.g., UPDATE ... FROM in PostgreSQL.
+	Joined bool
+	// Limited indicates the number of updated rows is limited.
+	Limited bool
+}
+
+// ParseUpdate parses the given statement as an UPDATE statement. A nil
+// statement is returned in case the statement is not a single-table UPDATE.
+func ParseUpdate(l *Lexer, stmt string) (*UpdateStmt, error) {
+	tokens, err := l.Tokens(stmt)
+	if err != nil {
+		return nil, err
+	}
+	s := NewTokenScanner(tokens)
+	if !s.Keywords("UPDATE") {
+		return nil, nil
+	}
+	// MySQL modifiers and PostgreSQL ONLY.
+	for _, m := range []string{"LOW_PRIORITY", "IGNORE", "ONLY"} {
+		s.Keywords(m)
+	}
+	u := &UpdateStmt{}
+	if u.Table, _ = s.Name(); u.Table == nil {
+		return nil, nil
+	}
+	s.Punct("*")
+	if s.Keywords("AS") || !s.Peek().Is("SET") && s.Peek().IsName() {
+		u.Alias = s.Next().Text
+	}
+	// Multi-table updates (e.g., MySQL joins) are not supported.
+	if !s.Keywords("SET") {
+		return nil, nil
+	}
+	u.Set = s.Until("FROM", "WHERE", "RETURNING", "ORDER", "LIMIT")
+	for !s.Done() {
+		switch {
+		case s.Keywords("FROM"):
+			u.Joined = true
+			s.Until("WHERE", "RETURNING")
+		case s.Keywords("WHERE"):
+			for _, e := range s.Until("RETURNING", "ORDER", "LIMIT") {
+				u.Where = append(u.Where, e...)
+			}
+		case s.Keywords("LIMIT"):
+			u.Limited = true
+			s.Until()
+		default:
+			s.Skip()
+		}
+	}
+	return u, nil
+}
+
+// Updates reports if the statement updates the given table.
+func (u *UpdateStmt) Updates(t *schema.Table) bool {
+	switch n := len(u.Table); {
+	case Last(u.Table) != t.Name:
+		return false
+	case n > 1 && t.Schema != nil && t.Schema.Name != "":
+		return u.Table[n-2] == t.Schema.Name
+	default:
+		return true
+	}
+}
+
+// FillsColumn reports if the statement sets a value for the given column in all
+// rows of the table, or in all rows where the column is NULL. Statements with
+// other filters are ignored, as we cannot determine if all NULL values were filled.
+func (u *UpdateStmt) FillsColumn(t *schema.Table, c *schema.Column) bool {
+	if !u.Updates(t) || u.Joined || u.Limited {
+		return false
+	}
+	if len(u.Where) > 0 && !u.isNull(u.Where, c.Name) {
+		return false
+	}
+	return slices.ContainsFunc(u.Set, func(a []Token) bool {
+		return u.assigns(a, c.Name)
+	})
+}
+
+// assigns reports if the assignment sets a (non-NULL) value to the column.
+// Both forms are supported: "c = v" and "(c1, c2) = (v1, v2)".
+func (u *UpdateStmt) assigns(a []Token, name string) bool {
+	i := slices.IndexFunc(a, func(t Token) bool {
+		return t.IsPunct("=")
+	})
+	if i < 1 || i == len(a)-1 {
+		return false
+	}
+	lhs, rhs := a[:i], a[i+1:]
+	if len(rhs) == 1 && (rhs[0].Is("NULL") || rhs[0].Is("DEFAULT")) {
+		return false
+	}
+	if lhs[0].IsPunct("(") {
+		return slices.ContainsFunc(lhs, func(t Token) bool {
+			return t.IsName() && t.Text == name
+		})
+	}
+	return u.column(lhs, name)
+}
+
+// isNull reports if the expression is in the form of "c IS NULL".
+func (u *UpdateStmt) isNull(expr []Token, name string) bool {
+	n := len(expr)
+	return n >= 3 && expr[n-2].Is("IS") && expr[n-1].Is("NULL") && u.column(expr[:n-2], name)
+}
+
+// column reports if the tokens reference the given column,
+// optionally qualified with the table name or its alias.
+func (u *UpdateStmt) column(tokens []Token, name string) bool {
+	s := NewTokenScanner(tokens)
+	parts, ok := s.Name()
+	if !ok || !s.Done() || Last(parts) != name {
+		return false
+	}
+	if len(parts) > 1 {
+		q := parts[len(parts)-2]
+		return q == Last(u.Table) || q == u.Alias
+	}
+	return true
+}
+
+// CreateViewStmt describes a CREATE VIEW statement that selects from a single table.
+type CreateViewStmt struct {
+	Name  []string // Qualified view name.
+	Table []string // Qualified name of the selected table.
+}
+
+// ParseCreateView parses the given statement as a CREATE VIEW statement. A nil
+// statement is returned in case the statement is not a CREATE VIEW statement,
+// or the view is not defined on a single table.
+func ParseCreateView(l *Lexer, stmt string) (*CreateViewStmt, error) {
+	tokens, err := l.Tokens(stmt)
+	if err != nil {
+		return nil, err
+	}
+	s := NewTokenScanner(tokens)
+	if !s.Keywords("CREATE") {
+		return nil, nil
+	}
+	// Skip modifiers, such as OR REPLACE, ALGORITHM, DEFINER and TEMPORARY.
+	for !s.Done() && !s.Peek().Is("VIEW") && !s.Peek().Is("AS") {
+		s.Skip()
+	}
+	if !s.Keywords("VIEW") {
+		return nil, nil
+	}
+	v := &CreateViewStmt{}
+	s.Keywords("IF", "NOT", "EXISTS")
+	if v.Name, _ = s.Name(); v.Name == nil {
+		return nil, nil
+	}
+	// Skip the optional column list and view options.
+	s.Until("AS")
+	if !s.Keywords("AS") || !s.Keywords("SELECT") {
+		return nil, nil
+	}
+	s.Until("FROM")
+	if !s.Keywords("FROM") {
+		return nil, nil
+	}
+	s.Keywords("ONLY")
+	if v.Table, _ = s.Name(); v.Table == nil {
+		return nil, nil
+	}
+	// Optional alias.
+	if s.Keywords("AS") || s.Peek().Type == TokenQuoted || s.Peek().Type == TokenIdent && !isClause(s.Peek()) {
+		s.Next()
+	}
+	if !s.Done() && !isClause(s.Peek()) || isJoin(s.Peek()) {
+		return nil, nil
+	}
+	return v, nil
+}
+
+// isClause reports if the token starts a SELECT clause (or a join) that may follow the FROM clause.
+func isClause(t Token) bool {
+	return isJoin(t) || matchAny(t, []string{"WHERE", "GROUP", "HAVING", "WINDOW", "ORDER", "LIMIT", "OFFSET", "UNION", "WITH", "FOR"})
+}
+
+func isJoin(t Token) bool {
+	return t.IsPunct(",") || matchAny(t, []string{"JOIN", "INNER", "LEFT", "RIGHT", "FULL", "CROSS", "NATURAL", "STRAIGHT_JOIN"})
+}
@@ -5,22 +5,165 @@
 package pgparse
 
 import (
-	"errors"
+	"fmt"
+	"strings"
 
+	"ariga.io/atlas/cmd/atlas/internal/sqlparse/parseutil"
 	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
 	"ariga.io/atlas/sql/schema"
 )
 
+// Parser for fixing linting changes.
 type Parser struct{}
 
-func (*Parser) ColumnFilledBefore([]*migrate.Stmt, *schema.Table, *schema.Column, int) (bool, error) {
-	return false, errors.New("unimplemented")
+// lexer for PostgreSQL statements. Unquoted identifiers are folded to lower case.
+var lexer = &parseutil.Lexer{
+	IdentQuote:  '"',
+	DollarQuote: true,
+	Fold:        strings.ToLower,
 }
 
-func (*Parser) CreateViewAfter([]*migrate.Stmt, string, string, int) (bool, error) {
-	return false, errors.New("unimplemented")
+// ColumnFilledBefore checks if the column was filled with values before the given position in the file.
+func (*Parser) ColumnFilledBefore(stmts []*migrate.Stmt, t *schema.Table, c *schema.Column, pos int) (bool, error) {
+	return parseutil.MatchStmtBefore(stmts, pos, func(s *migrate.Stmt) (bool, error) {
+		u, err := parseutil.ParseUpdate(lexer, s.Text)
+		if err != nil || u == nil {
+			return false, err
+		}
+		return u.FillsColumn(t, c), nil
+	})
 }
 
-func (*Parser) FixChange(_ migrate.Driver, _ string, changes schema.Changes) (schema.Changes, error) {
-	return changes, nil // Unimplemented.
+// CreateViewAfter checks if a view was created after the position with the given name to a table.
+func (*Parser) CreateViewAfter(stmts []*migrate.Stmt, old, new string, pos int) (bool, error) {
+	return parseutil.MatchStmtAfter(stmts, pos, func(s *migrate.Stmt) (bool, error) {
+		v, err := parseutil.ParseCreateView(lexer, s.Text)
+		if err != nil || v == nil {
+			return false, err
+		}
+		return parseutil.Last(v.Name) == old && parseutil.Last(v.Table) == new, nil
+	})
+}
+
+// FixChange fixes the changes according to the given statement.
+func (*Parser) FixChange(_ migrate.Driver, stmt string, changes schema.Changes) (schema.Changes, error) {
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	tokens, err := lexer.Tokens(stmt)
+	if err != nil {
+		return nil, err
+	}
+	s := parseutil.NewTokenScanner(tokens)
+	switch {
+	case s.Keywords("ALTER", "TABLE"):
+		s.Keywords("IF", "EXISTS")
+		s.Keywords("ONLY")
+		name, ok := s.Name()
+		if !ok {
+			return changes, nil
+		}
+		s.Punct("*")
+		if !s.Keywords("RENAME") {
+			return changes, nil
+		}
+		switch r, kind := rename(s); kind {
+		case "TABLE":
+			r.From = parseutil.Last(name)
+			changes = parseutil.RenameTable(changes, r)
+		case "COLUMN":
+			modify, err := expectModify(changes)
+			if err != nil {
+				return nil, err
+			}
+			parseutil.RenameColumn(modify, r)
+		}
+	case s.Keywords("ALTER", "INDEX"):
+		s.Keywords("IF", "EXISTS")
+		name, ok := s.Name()
+		if !ok || !s.Keywords("RENAME", "TO") {
+			return changes, nil
+		}
+		to, ok := s.Name()
+		if !ok {
+			return changes, nil
+		}
+		modify, err := expectModify(changes)
+		if err != nil {
+			return nil, err
+		}
+		parseutil.RenameIndex(modify, &parseutil.Rename{From: parseutil.Last(name), To: parseutil.Last(to)})
+	case s.Keywords("CREATE"):
+		s.Keywords("UNIQUE")
+		if !s.Keywords("INDEX", "CONCURRENTLY") {
+			return changes, nil
+		}
+		modify, err := expectModify(changes)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range modify.Changes {
+			if add, ok := c.(*schema.AddIndex); ok {
+				add.Extra = appendConcurrently(add.Extra)
+			}
+		}
+	case s.Keywords("DROP", "INDEX", "CONCURRENTLY"):
+		modify, err := expectModify(changes)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range modify.Changes {
+			if drop, ok := c.(*schema.DropIndex); ok {
+				drop.Extra = appendConcurrently(drop.Extra)
+			}
+		}
+	}
+	return changes, nil
+}
+
+// rename parses the RENAME clause of an ALTER TABLE statement, and
+// returns its target: "TABLE", "COLUMN" or "" in case of other objects.
+func rename(s *parseutil.TokenScanner) (*parseutil.Rename, string) {
+	switch {
+	case s.Keywords("TO"):
+		to, ok := s.Name()
+		if !ok {
+			return nil, ""
+		}
+		return &parseutil.Rename{To: parseutil.Last(to)}, "TABLE"
+	// Constraints are not handled.
+	case s.Keywords("CONSTRAINT"):
+		return nil, ""
+	default:
+		s.Keywords("COLUMN")
+		from, ok1 := s.Name()
+		s.Keywords("TO")
+		to, ok2 := s.Name()
+		if !ok1 || !ok2 {
+			return nil, ""
+		}
+		return &parseutil.Rename{From: parseutil.Last(from), To: parseutil.Last(to)}, "COLUMN"
+	}
+}
+
+// expectModify returns the first and only ModifyTable change.
+func expectModify(changes schema.Changes) (*schema.ModifyTable, error) {
+	if len(changes) != 1 {
+		return nil, fmt.Errorf("unexpected number of changes: %d", len(changes))
+	}
+	modify, ok := changes[0].(*schema.ModifyTable)
+	if !ok {
+		return nil, fmt.Errorf("expected modify-table change for alter-table statement, but got: %T", changes[0])
+	}
+	return modify, nil
+}
+
+func appendConcurrently(extra []schema.Clause) []schema.Clause {
+	for _, c := range extra {
+		if _, ok := c.(*postgres.Concurrently); ok {
+			return extra
+		}
+	}
+	return append(extra, &postgres.Concurrently{})
 }
@@ -0,0 +1,189 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package pgparse_test
+
+import (
+	"testing"
+
+	"ariga.io/atlas/cmd/atlas/internal/sqlparse/pgparse"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ColumnFilledBefore(t *testing.T) {
+	var (
+		p   pgparse.Parser
+		tbl = schema.NewTable("users").SetSchema(schema.New("public"))
+		c   = schema.NewColumn("name")
+	)
+	tbl.AddColumns(c)
+	for i, tt := range []struct {
+		stmt  string
+		pos   int
+		wantT bool
+		wantE bool
+	}{
+		{stmt: `UPDATE users SET name = 'a8m'`, pos: 100, wantT: true},
+		{stmt: `UPDATE "users" SET "name" = 'a8m' WHERE "name" IS NULL;`, pos: 100, wantT: true},
+		{stmt: `update public.users u set u.name = 'a8m' where u.name is null`, pos: 100, wantT: true},
+		{stmt: `UPDATE ONLY users SET (id, name) = (1, 'a8m')`, pos: 100, wantT: true},
+		{stmt: `UPDATE users SET name = 'a8m'`, pos: 0},
+		{stmt: `UPDATE users SET name = NULL`, pos: 100},
+		{stmt: `UPDATE users SET name = DEFAULT`, pos: 100},
+		{stmt: `UPDATE users SET name = 'a8m' WHERE id = 1`, pos: 100},
+		{stmt: `UPDATE users SET name = 'a8m' WHERE id = 1 AND name IS NULL`, pos: 100},
+		{stmt: `UPDATE users SET name = p.name FROM pets p WHERE p.owner_id = users.id`, pos: 100},
+		{stmt: `UPDATE other.users SET name = 'a8m'`, pos: 100},
+		{stmt: `UPDATE "Users" SET name = 'a8m'`, pos: 100},
+		{stmt: `UPDATE pets SET name = 'a8m'`, pos: 100},
+		{stmt: `UPDATE users SET id = 1`, pos: 100},
+		{stmt: `UPDATE users SET name = 'a8m`, pos: 100, wantE: true},
+	} {
+		t.Run(tt.stmt, func(t *testing.T) {
+			stmts := []*migrate.Stmt{{Pos: i, Text: tt.stmt}}
+			got, err := p.ColumnFilledBefore(stmts, tbl, c, tt.pos)
+			require.Equal(t, tt.wantE, err != nil, err)
+			require.Equal(t, tt.wantT, got)
+		})
+	}
+}
+
+func TestParser_CreateViewAfter(t *testing.T) {
+	var p pgparse.Parser
+	for _, tt := range []struct {
+		stmt  string
+		wantT bool
+	}{
+		{stmt: `CREATE VIEW users AS SELECT * FROM "Users"`, wantT: true},
+		{stmt: `CREATE OR REPLACE VIEW public.users (id, name) AS SELECT id, name FROM public."Users" WHERE id > 0`, wantT: true},
+		{stmt: `CREATE VIEW users AS SELECT * FROM "Users" u;`, wantT: true},
+		{stmt: `CREATE VIEW users AS SELECT * FROM "Users" JOIN pets ON true`},
+		{stmt: `CREATE VIEW users AS SELECT * FROM "Users", pets`},
+		{stmt: `CREATE VIEW users AS SELECT * FROM pets`},
+		{stmt: `CREATE VIEW other AS SELECT * FROM "Users"`},
+		{stmt: `CREATE TABLE users (id int)`},
+	} {
+		t.Run(tt.stmt, func(t *testing.T) {
+			stmts := []*migrate.Stmt{
+				{Pos: 0, Text: `ALTER TABLE users RENAME TO "Users"`},
+				{Pos: 1, Text: tt.stmt},
+			}
+			got, err := p.CreateViewAfter(stmts, "users", "Users", 0)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantT, got)
+			got, err = p.CreateViewAfter(stmts, "users", "Users", 1)
+			require.NoError(t, err)
+			require.False(t, got)
+		})
+	}
+}
+
+func TestParser_FixChange(t *testing.T) {
+	var p pgparse.Parser
+	changes, err := p.FixChange(nil, `ALTER TABLE t RENAME COLUMN c1 TO c2`, nil)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = p.FixChange(
+		nil,
+		`ALTER TABLE t RENAME COLUMN c1 TO c2`,
+		schema.Changes{&schema.AddTable{}},
+	)
+	require.Error(t, err)
+
+	changes, err = p.FixChange(
+		nil,
+		`ALTER TABLE "t" RENAME "c1" TO "c2"`,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.DropColumn{C: schema.NewColumn("c1")},
+					&schema.AddColumn{C: schema.NewColumn("c2")},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.RenameColumn{From: schema.NewColumn("c1"), To: schema.NewColumn("c2")},
+				},
+			},
+		},
+		changes,
+	)
+
+	changes, err = p.FixChange(
+		nil,
+		`ALTER INDEX IF EXISTS public.i1 RENAME TO i2`,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.DropIndex{I: schema.NewIndex("i1")},
+					&schema.AddIndex{I: schema.NewIndex("i2")},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.RenameIndex{From: schema.NewIndex("i1"), To: schema.NewIndex("i2")},
+				},
+			},
+		},
+		changes,
+	)
+
+	changes, err = p.FixChange(
+		nil,
+		`ALTER TABLE t1 RENAME TO t2`,
+		schema.Changes{
+			&schema.DropTable{T: schema.NewTable("t1")},
+			&schema.AddTable{T: schema.NewTable("t2")},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.RenameTable{From: schema.NewTable("t1"), To: schema.NewTable("t2")},
+		},
+		changes,
+	)
+
+	changes, err = p.FixChange(
+		nil,
+		`CREATE INDEX CONCURRENTLY i1 ON t1 (c1)`,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.AddIndex{I: schema.NewIndex("i1")},
+				},
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		schema.Changes{
+			&schema.ModifyTable{
+				Changes: schema.Changes{
+					&schema.AddIndex{I: schema.NewIndex("i1"), Extra: []schema.Clause{&postgres.Concurrently{}}},
+				},
+			},
+		},
+		changes,
+	)
+}
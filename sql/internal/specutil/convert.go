@@ -34,19 +34,39 @@ type (
 	IndexSpecFunc          func(*schema.Index) (*sqlspec.Index, error)
 	ForeignKeySpecFunc     func(*schema.ForeignKey) (*sqlspec.ForeignKey, error)
 	CheckSpecFunc          func(*schema.Check) *sqlspec.Check
+	ConvertViewFunc        func(*sqlspec.View, *schema.Schema) (*schema.View, error)
+	ConvertViewColumnFunc  func(*sqlspec.Column, *schema.View) (*schema.Column, error)
+	ViewSpecFunc           func(*schema.View) (*sqlspec.View, error)
+	ViewColumnSpecFunc     func(*schema.Column, *schema.View) (*sqlspec.Column, error)
+	ConvertFuncFunc        func(*sqlspec.Func, *schema.Schema) (*schema.Func, error)
+	ConvertProcFunc        func(*sqlspec.Proc, *schema.Schema) (*schema.Proc, error)
+	FuncSpecFunc           func(*schema.Func) (*sqlspec.Func, error)
+	ProcSpecFunc           func(*schema.Proc) (*sqlspec.Proc, error)
+	ConvertTriggerFunc     func(*sqlspec.Trigger, *schema.Realm) (*schema.Trigger, error)
+	TriggerSpecFunc        func(*schema.Trigger) (*sqlspec.Trigger, error)
 )
 
 type (
 	// ScanDoc represents a scanned HCL document.
 	ScanDoc struct {
-		Schemas []*sqlspec.Schema
-		Tables  []*sqlspec.Table
+		Schemas      []*sqlspec.Schema
+		Tables       []*sqlspec.Table
+		Views        []*sqlspec.View
+		Materialized []*sqlspec.View
+		Funcs        []*sqlspec.Func
+		Procs        []*sqlspec.Proc
+		Triggers     []*sqlspec.Trigger
 	}
 
 	// ScanFuncs represents a set of scan functions
 	// used to convert the HCL document to the Realm.
 	ScanFuncs struct {
-		Table ConvertTableFunc
+		Table        ConvertTableFunc
+		View         ConvertViewFunc
+		Materialized ConvertViewFunc
+		Func         ConvertFuncFunc
+		Proc         ConvertProcFunc
+		Trigger      ConvertTriggerFunc
 		// Objects add themselves to the realm.
 		Objects func(*schema.Realm) error
 		// Optional function to extend the foreign keys.
@@ -56,7 +76,12 @@ type (
 	// SchemaFuncs represents a set of spec functions
 	// used to convert the Schema object to an HCL document.
 	SchemaFuncs struct {
-		Table TableSpecFunc
+		Table        TableSpecFunc
+		View         ViewSpecFunc
+		Materialized ViewSpecFunc
+		Func         FuncSpecFunc
+		Proc         ProcSpecFunc
+		Trigger      TriggerSpecFunc
 	}
 	// RefNamer is an interface for objects that can
 	// return their reference.
@@ -80,10 +105,14 @@ type (
 )
 
 const (
-	typeTable  = "table"
-	typeColumn = "column"
-	typeIndex  = "index"
-	typeSchema = "schema"
+	typeTable        = "table"
+	typeView         = "view"
+	typeMaterialized = "materialized"
+	typeColumn       = "column"
+	typeIndex        = "index"
+	typeSchema       = "schema"
+	typeRole         = "role"
+	typeUser         = "user"
 )
 
 // typeName returns the type name of the given object.
@@ -103,6 +132,11 @@ func typeName(o schema.Object) string {
 			return typeTable
 		}
 		return attrOr(typeTable, o.Attrs)
+	case *schema.View:
+		if o == nil {
+			return typeView
+		}
+		return attrOr(typeView, o.Attrs)
 	default:
 		if ts, ok := o.(SpecTyper); ok && ts != nil && ts.SpecType() != "" {
 			return ts.SpecType()
@@ -154,6 +188,85 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 			deps[t] = refs
 		}
 	}
+	for _, vs := range []struct {
+		typ   string
+		specs []*sqlspec.View
+		conv  ConvertViewFunc
+	}{
+		{typ: typeView, specs: doc.Views, conv: funcs.View},
+		{typ: typeMaterialized, specs: doc.Materialized, conv: funcs.Materialized},
+	} {
+		for _, sv := range vs.specs {
+			if vs.conv == nil {
+				return fmt.Errorf("%s views are not supported by this driver: %q", vs.typ, sv.Name)
+			}
+			name, err := SchemaName(sv.Schema)
+			if err != nil {
+				return fmt.Errorf("cannot extract schema name for %s %q: %w", vs.typ, sv.Name, err)
+			}
+			s, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("schema %q not found for %s %q", name, vs.typ, sv.Name)
+			}
+			v, err := vs.conv(sv, s)
+			if err != nil {
+				return fmt.Errorf("cannot convert %s %q: %w", vs.typ, sv.Name, err)
+			}
+			if tn := typeName(v); tn != typeView {
+				aliases[tn] = typeView
+			}
+			s.AddViews(v)
+			if d, ok := sv.Attr("depends_on"); ok {
+				refs, err := d.Refs()
+				if err != nil {
+					return fmt.Errorf("expect list of references for attribute %s.%s.depends_on: %w", vs.typ, sv.Name, err)
+				}
+				deps[v] = refs
+			}
+		}
+	}
+	for _, sf := range doc.Funcs {
+		if funcs.Func == nil {
+			return fmt.Errorf("functions are not supported by this driver: %q", sf.Name)
+		}
+		s, err := specSchema(byName, sf.Schema, "function", sf.Name)
+		if err != nil {
+			return err
+		}
+		f, err := funcs.Func(sf, s)
+		if err != nil {
+			return fmt.Errorf("cannot convert function %q: %w", sf.Name, err)
+		}
+		s.AddObjects(f)
+		if d, ok := sf.Attr("depends_on"); ok {
+			refs, err := d.Refs()
+			if err != nil {
+				return fmt.Errorf("expect list of references for attribute function.%s.depends_on: %w", sf.Name, err)
+			}
+			deps[f] = refs
+		}
+	}
+	for _, sp := range doc.Procs {
+		if funcs.Proc == nil {
+			return fmt.Errorf("procedures are not supported by this driver: %q", sp.Name)
+		}
+		s, err := specSchema(byName, sp.Schema, "procedure", sp.Name)
+		if err != nil {
+			return err
+		}
+		p, err := funcs.Proc(sp, s)
+		if err != nil {
+			return fmt.Errorf("cannot convert procedure %q: %w", sp.Name, err)
+		}
+		s.AddObjects(p)
+		if d, ok := sp.Attr("depends_on"); ok {
+			refs, err := d.Refs()
+			if err != nil {
+				return fmt.Errorf("expect list of references for attribute procedure.%s.depends_on: %w", sp.Name, err)
+			}
+			deps[p] = refs
+		}
+	}
 	// Link the foreign keys.
 	for t, fks := range fks {
 		if err := linkForeignKeys(funcs, t, fks); err != nil {
@@ -165,11 +278,27 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 			return err
 		}
 	}
+	// Triggers are converted after all tables,
+	// views and functions were added to the realm.
+	for _, st := range doc.Triggers {
+		if funcs.Trigger == nil {
+			return fmt.Errorf("triggers are not supported by this driver: %q", st.Name)
+		}
+		if _, err := funcs.Trigger(st, r); err != nil {
+			return fmt.Errorf("cannot convert trigger %q: %w", st.Name, err)
+		}
+	}
 	for o, refs := range deps {
 		var err error
 		switch o := o.(type) {
 		case *schema.Table:
 			err = fromDependsOn(fmt.Sprintf("%s.%s", typeName(o), o.Name), o, o.Schema, refs, aliases)
+		case *schema.View:
+			err = fromDependsOn(fmt.Sprintf("%s.%s", typeName(o), o.Name), o, o.Schema, refs, aliases)
+		case *schema.Func:
+			err = fromDependsOn(fmt.Sprintf("%s.%s", typeName(o), o.Name), o, o.Schema, refs, aliases)
+		case *schema.Proc:
+			err = fromDependsOn(fmt.Sprintf("%s.%s", typeName(o), o.Name), o, o.Schema, refs, aliases)
 		}
 		if err != nil {
 			return err
@@ -178,6 +307,19 @@ func Scan(r *schema.Realm, doc *ScanDoc, funcs *ScanFuncs) error {
 	return nil
 }
 
+// specSchema returns the schema of the given top-level object spec.
+func specSchema(byName map[string]*schema.Schema, ref *schemahcl.Ref, typ, name string) (*schema.Schema, error) {
+	sn, err := SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract schema name for %s %q: %w", typ, name, err)
+	}
+	s, ok := byName[sn]
+	if !ok {
+		return nil, fmt.Errorf("schema %q not found for %s %q", sn, typ, name)
+	}
+	return s, nil
+}
+
 // Table converts a sqlspec.Table to a schema.Table. Table conversion is done without converting
 // ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
 // at this point. Instead, the linking is done by the Schema function.
@@ -226,6 +368,265 @@ func Table(spec *sqlspec.Table, parent *schema.Schema, convertColumn ConvertTabl
 	return t, nil
 }
 
+// View converts a sqlspec.View to a schema.View. The view definition is
+// read from the "as" attribute, and its dependencies are linked by Scan.
+func View(spec *sqlspec.View, parent *schema.Schema, convertColumn ConvertViewColumnFunc) (*schema.View, error) {
+	as, ok := spec.Attr("as")
+	if !ok {
+		return nil, fmt.Errorf("missing 'as' definition for view %q", spec.Name)
+	}
+	def, err := as.String()
+	if err != nil {
+		return nil, fmt.Errorf("expect string definition for attribute view.%s.as: %w", spec.Name, err)
+	}
+	v := schema.NewView(spec.Name, def).SetSchema(parent)
+	schemahcl.AppendPos(&v.Attrs, spec.Range)
+	for _, cs := range spec.Columns {
+		c, err := convertColumn(cs, v)
+		if err != nil {
+			return nil, err
+		}
+		schemahcl.AppendPos(&c.Attrs, cs.Range)
+		v.AddColumns(c)
+	}
+	if a, ok := spec.Attr("check_option"); ok {
+		opt, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("expect string value for attribute view.%s.check_option: %w", spec.Name, err)
+		}
+		v.SetCheckOption(opt)
+	}
+	if err := convertCommentFromSpec(spec, &v.Attrs); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Func converts a sqlspec.Func to a schema.Func. The function body is read
+// from the "as" attribute, and its return type from the "return" attribute.
+// Driver-specific attributes, such as the language, are set by the caller.
+func Func(spec *sqlspec.Func, parent *schema.Schema, conv ConvertTypeFunc) (*schema.Func, error) {
+	body, err := bodyAttr(spec, "function", spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	f := schema.NewFunc(spec.Name, body).SetSchema(parent)
+	schemahcl.AppendPos(&f.Attrs, spec.Range)
+	args, err := funcArgs(spec.Args, conv)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", spec.Name, err)
+	}
+	f.AddArgs(args...)
+	if r, ok := spec.Attr("return"); ok {
+		t, err := r.Type()
+		if err != nil {
+			return nil, fmt.Errorf("expect type for attribute function.%s.return: %w", spec.Name, err)
+		}
+		if f.Ret, err = conv(&sqlspec.Column{Type: t}); err != nil {
+			return nil, err
+		}
+	}
+	if err := convertCommentFromSpec(spec, &f.Attrs); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Proc converts a sqlspec.Proc to a schema.Proc. The procedure body is
+// read from the "as" attribute. Driver-specific attributes, such as the
+// language, are set by the caller.
+func Proc(spec *sqlspec.Proc, parent *schema.Schema, conv ConvertTypeFunc) (*schema.Proc, error) {
+	body, err := bodyAttr(spec, "procedure", spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	p := schema.NewProc(spec.Name, body).SetSchema(parent)
+	schemahcl.AppendPos(&p.Attrs, spec.Range)
+	args, err := funcArgs(spec.Args, conv)
+	if err != nil {
+		return nil, fmt.Errorf("procedure %q: %w", spec.Name, err)
+	}
+	p.AddArgs(args...)
+	if err := convertCommentFromSpec(spec, &p.Attrs); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Trigger converts a sqlspec.Trigger to a schema.Trigger, and attaches it to the
+// table or the view it is defined on. Driver-specific bodies (e.g., functions
+// execution) can be set by the caller in case the "as" attribute is missing.
+func Trigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	if spec.On == nil {
+		return nil, errors.New(`missing "on" attribute`)
+	}
+	p, err := spec.On.Path()
+	if err != nil {
+		return nil, err
+	}
+	if len(p) == 0 || len(r.Schemas) == 0 {
+		return nil, fmt.Errorf("unexpected trigger reference: %q", spec.On.V)
+	}
+	q, n, err := RefName(spec.On, p[0].T)
+	if err != nil {
+		return nil, err
+	}
+	t := schema.NewTrigger(spec.Name)
+	var tv interface {
+		Column(string) (*schema.Column, bool)
+	}
+	switch p[0].T {
+	case typeTable:
+		tb, err := findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.Table, bool) {
+			return s.Table(name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		tb.AddTriggers(t)
+		tv = tb
+	case typeView:
+		v, err := findT(r.Schemas[0], q, n, func(s *schema.Schema, name string) (*schema.View, bool) {
+			return s.View(name)
+		})
+		if err != nil {
+			return nil, err
+		}
+		v.AddTriggers(t)
+		tv = v
+	default:
+		return nil, fmt.Errorf("unexpected trigger reference type: %q", p[0].T)
+	}
+	for _, at := range []schema.TriggerTime{schema.TriggerTimeBefore, schema.TriggerTimeAfter, schema.TriggerTimeInstead} {
+		e, ok := spec.Remain().Resource(strings.ToLower(Var(string(at))))
+		if !ok {
+			continue
+		}
+		if t.ActionTime != "" {
+			return nil, fmt.Errorf("multiple action times were defined: %s and %s", t.ActionTime, at)
+		}
+		t.ActionTime = at
+		for _, ev := range []schema.TriggerEvent{schema.TriggerEventInsert, schema.TriggerEventUpdate, schema.TriggerEventDelete, schema.TriggerEventTruncate} {
+			if a, ok := e.Attr(strings.ToLower(ev.Name)); ok {
+				b, err := a.Bool()
+				if err != nil {
+					return nil, err
+				}
+				if b {
+					t.AddEvents(ev)
+				}
+			}
+		}
+		if a, ok := e.Attr("update_of"); ok {
+			refs, err := a.Refs()
+			if err != nil {
+				return nil, err
+			}
+			columns := make([]*schema.Column, 0, len(refs))
+			for _, ref := range refs {
+				c, err := ColumnByRef(tv, ref)
+				if err != nil {
+					return nil, err
+				}
+				columns = append(columns, c)
+			}
+			t.AddEvents(schema.TriggerEventUpdateOf(columns...))
+		}
+	}
+	if t.ActionTime == "" {
+		return nil, errors.New("missing action time block: before, after or instead_of")
+	}
+	if len(t.Events) == 0 {
+		return nil, errors.New("missing trigger events")
+	}
+	if a, ok := spec.Attr("for"); ok {
+		f, err := a.String()
+		if err != nil {
+			return nil, err
+		}
+		t.For = schema.TriggerFor(strings.ToUpper(FromVar(f)))
+	}
+	if a, ok := spec.Attr("when"); ok {
+		w, err := a.String()
+		if err != nil {
+			return nil, err
+		}
+		t.SetWhen(w)
+	}
+	if a, ok := spec.Attr("as"); ok {
+		if t.Body, err = a.String(); err != nil {
+			return nil, err
+		}
+	}
+	if err := convertCommentFromSpec(spec, &t.Attrs); err != nil {
+		return nil, err
+	}
+	schemahcl.AppendPos(&t.Attrs, spec.Range)
+	return t, nil
+}
+
+// FuncByRef returns the function referenced by ref. In case the reference is not
+// qualified, the function is searched in all schemas of the connected realm.
+func FuncByRef(ns *schema.Schema, ref *schemahcl.Ref) (*schema.Func, error) {
+	q, n, err := RefName(ref, "function")
+	if err != nil {
+		return nil, err
+	}
+	return findT(ns, q, n, func(s *schema.Schema, name string) (*schema.Func, bool) {
+		return s.Func(name)
+	})
+}
+
+// TableByRef returns the table referenced by ref. In case the reference is not
+// qualified, the table is searched in all schemas of the connected realm.
+func TableByRef(ns *schema.Schema, ref *schemahcl.Ref) (*schema.Table, error) {
+	q, n, err := TableName(ref)
+	if err != nil {
+		return nil, err
+	}
+	return findT(ns, q, n, func(s *schema.Schema, name string) (*schema.Table, bool) {
+		return s.Table(name)
+	})
+}
+
+// bodyAttr returns the body definition of a function or a procedure.
+func bodyAttr(spec Attrer, typ, name string) (string, error) {
+	as, ok := spec.Attr("as")
+	if !ok {
+		return "", fmt.Errorf("missing 'as' definition for %s %q", typ, name)
+	}
+	body, err := as.String()
+	if err != nil {
+		return "", fmt.Errorf("expect string definition for attribute %s.%s.as: %w", typ, name, err)
+	}
+	return body, nil
+}
+
+// funcArgs converts the arguments specs of a function or a procedure.
+func funcArgs(specs []*sqlspec.FuncArg, conv ConvertTypeFunc) ([]*schema.FuncArg, error) {
+	args := make([]*schema.FuncArg, 0, len(specs))
+	for _, as := range specs {
+		t, err := conv(&sqlspec.Column{Type: as.Type, DefaultExtension: as.DefaultExtension})
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", as.Name, err)
+		}
+		a := &schema.FuncArg{Name: as.Name, Type: t}
+		if a.Default, err = columnDefault(as.Remain()); err != nil {
+			return nil, fmt.Errorf("argument %q: %w", as.Name, err)
+		}
+		if m, ok := as.Attr("mode"); ok {
+			v, err := m.String()
+			if err != nil {
+				return nil, fmt.Errorf("expect string value for argument %q mode: %w", as.Name, err)
+			}
+			a.Mode = schema.FuncArgMode(strings.ToUpper(v))
+		}
+		schemahcl.AppendPos(&a.Attrs, as.Range)
+		args = append(args, a)
+	}
+	return args, nil
+}
+
 // Column converts a sqlspec.Column into a schema.Column.
 func Column(spec *sqlspec.Column, conv ConvertTypeFunc) (*schema.Column, error) {
 	out := &schema.Column{
@@ -458,7 +859,7 @@ func linkForeignKeys(funcs *ScanFuncs, tbl *schema.Table, fks []*sqlspec.Foreign
 	return nil
 }
 
-// FromSchema converts a schema.Schema into sqlspec.Schema and []sqlspec.Table.
+// FromSchema converts a schema.Schema into sqlspec.Schema, []sqlspec.Table and []sqlspec.View.
 func FromSchema(s *schema.Schema, funcs *SchemaFuncs) (*SchemaSpec, error) {
 	spec := &SchemaSpec{
 		Schema: &sqlspec.Schema{
@@ -476,10 +877,88 @@ func FromSchema(s *schema.Schema, funcs *SchemaFuncs) (*SchemaSpec, error) {
 		}
 		spec.Tables = append(spec.Tables, table)
 	}
+	for _, v := range s.Views {
+		var (
+			view  *sqlspec.View
+			err   error
+			specs = &spec.Views
+		)
+		switch {
+		case v.Materialized() && funcs.Materialized == nil:
+			return nil, fmt.Errorf("materialized views are not supported by this driver: schema %q", s.Name)
+		case v.Materialized():
+			view, err = funcs.Materialized(v)
+			specs = &spec.Materialized
+		case funcs.View == nil:
+			return nil, fmt.Errorf("views are not supported by this driver: schema %q", s.Name)
+		default:
+			view, err = funcs.View(v)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if s.Name != "" {
+			view.Schema = SchemaRef(s.Name)
+		}
+		*specs = append(*specs, view)
+	}
+	for _, o := range s.Objects {
+		switch o := o.(type) {
+		case *schema.Func:
+			if funcs.Func == nil {
+				return nil, fmt.Errorf("functions are not supported by this driver: schema %q", s.Name)
+			}
+			f, err := funcs.Func(o)
+			if err != nil {
+				return nil, err
+			}
+			if s.Name != "" {
+				f.Schema = SchemaRef(s.Name)
+			}
+			spec.Funcs = append(spec.Funcs, f)
+		case *schema.Proc:
+			if funcs.Proc == nil {
+				return nil, fmt.Errorf("procedures are not supported by this driver: schema %q", s.Name)
+			}
+			p, err := funcs.Proc(o)
+			if err != nil {
+				return nil, err
+			}
+			if s.Name != "" {
+				p.Schema = SchemaRef(s.Name)
+			}
+			spec.Procs = append(spec.Procs, p)
+		}
+	}
+	if err := fromTriggers(s, funcs, spec); err != nil {
+		return nil, err
+	}
 	convertCommentFromSchema(s.Attrs, &spec.Schema.Extra.Attrs)
 	return spec, nil
 }
 
+// fromTriggers converts the triggers defined on the schema tables and views to specs.
+func fromTriggers(s *schema.Schema, funcs *SchemaFuncs, spec *SchemaSpec) error {
+	var triggers []*schema.Trigger
+	for _, t := range s.Tables {
+		triggers = append(triggers, t.Triggers...)
+	}
+	for _, v := range s.Views {
+		triggers = append(triggers, v.Triggers...)
+	}
+	if len(triggers) > 0 && funcs.Trigger == nil {
+		return fmt.Errorf("triggers are not supported by this driver: schema %q", s.Name)
+	}
+	for _, t := range triggers {
+		tr, err := funcs.Trigger(t)
+		if err != nil {
+			return err
+		}
+		spec.Triggers = append(spec.Triggers, tr)
+	}
+	return nil
+}
+
 // FromTable converts a schema.Table to a sqlspec.Table.
 func FromTable(t *schema.Table, colFn TableColumnSpecFunc, pkFn PrimaryKeySpecFunc, idxFn IndexSpecFunc,
 	fkFn ForeignKeySpecFunc, ckFn CheckSpecFunc) (*sqlspec.Table, error) {
@@ -527,6 +1006,182 @@ func FromTable(t *schema.Table, colFn TableColumnSpecFunc, pkFn PrimaryKeySpecFu
 	return spec, nil
 }
 
+// FromView converts a schema.View to a sqlspec.View.
+func FromView(v *schema.View, colFn ViewColumnSpecFunc) (*sqlspec.View, error) {
+	spec := &sqlspec.View{
+		Name: v.Name,
+	}
+	for _, c := range v.Columns {
+		col, err := colFn(c, v)
+		if err != nil {
+			return nil, err
+		}
+		spec.Columns = append(spec.Columns, col)
+	}
+	// Embedding a resource push its attributes to the end,
+	// as the definition is expected to come after the columns.
+	embed := &schemahcl.Resource{
+		Attrs: []*schemahcl.Attr{
+			defAttr("as", v.Def),
+		},
+	}
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		embed.Attrs = append(embed.Attrs, VarAttr("check_option", strings.ToUpper(c.V)))
+	}
+	convertCommentFromSchema(v.Attrs, &embed.Attrs)
+	var realm *schema.Realm
+	if v.Schema != nil {
+		realm = v.Schema.Realm
+	}
+	if deps, ok := dependsOn(realm, v.Deps); ok {
+		embed.Attrs = append(embed.Attrs, deps)
+	}
+	spec.Extra.Children = append(spec.Extra.Children, embed)
+	return spec, nil
+}
+
+// FromFunc converts a schema.Func to a sqlspec.Func. The given driver-specific
+// attributes (e.g., language) are added after the return type and before the
+// function body.
+func FromFunc(f *schema.Func, typeSpec ColumnTypeSpecFunc, attrs ...*schemahcl.Attr) (*sqlspec.Func, error) {
+	args, err := fromFuncArgs(f.Args, typeSpec)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", f.Name, err)
+	}
+	spec := &sqlspec.Func{
+		Name: f.Name,
+		Args: args,
+	}
+	embed := &schemahcl.Resource{}
+	if f.Ret != nil {
+		rt, err := typeSpec(f.Ret)
+		if err != nil {
+			return nil, fmt.Errorf("function %q: %w", f.Name, err)
+		}
+		embed.Attrs = append(embed.Attrs, TypeAttr("return", rt.Type))
+	}
+	embed.Attrs = append(embed.Attrs, attrs...)
+	embed.Attrs = append(embed.Attrs, defAttr("as", f.Body))
+	convertCommentFromSchema(f.Attrs, &embed.Attrs)
+	var realm *schema.Realm
+	if f.Schema != nil {
+		realm = f.Schema.Realm
+	}
+	if deps, ok := dependsOn(realm, f.Deps); ok {
+		embed.Attrs = append(embed.Attrs, deps)
+	}
+	spec.Extra.Children = append(spec.Extra.Children, embed)
+	return spec, nil
+}
+
+// FromProc converts a schema.Proc to a sqlspec.Proc. The given driver-specific
+// attributes (e.g., language) are added before the procedure body.
+func FromProc(p *schema.Proc, typeSpec ColumnTypeSpecFunc, attrs ...*schemahcl.Attr) (*sqlspec.Proc, error) {
+	args, err := fromFuncArgs(p.Args, typeSpec)
+	if err != nil {
+		return nil, fmt.Errorf("procedure %q: %w", p.Name, err)
+	}
+	spec := &sqlspec.Proc{
+		Name: p.Name,
+		Args: args,
+	}
+	embed := &schemahcl.Resource{Attrs: attrs}
+	embed.Attrs = append(embed.Attrs, defAttr("as", p.Body))
+	convertCommentFromSchema(p.Attrs, &embed.Attrs)
+	var realm *schema.Realm
+	if p.Schema != nil {
+		realm = p.Schema.Realm
+	}
+	if deps, ok := dependsOn(realm, p.Deps); ok {
+		embed.Attrs = append(embed.Attrs, deps)
+	}
+	spec.Extra.Children = append(spec.Extra.Children, embed)
+	return spec, nil
+}
+
+// FromTrigger converts a schema.Trigger to a sqlspec.Trigger. In case the given
+// driver-specific body is not nil (e.g., functions execution), it is used instead
+// of the "as" attribute.
+func FromTrigger(t *schema.Trigger, body *schemahcl.Resource) (*sqlspec.Trigger, error) {
+	spec := &sqlspec.Trigger{Name: t.Name}
+	switch {
+	case t.Table != nil:
+		spec.On = TableSpecRef(t.Table)
+	case t.View != nil:
+		spec.On = viewSpecRef(t.View)
+	default:
+		return nil, fmt.Errorf("trigger %q: missing table or view", t.Name)
+	}
+	on, err := spec.On.Path()
+	if err != nil {
+		return nil, fmt.Errorf("trigger %q: %w", t.Name, err)
+	}
+	if t.ActionTime == "" {
+		return nil, fmt.Errorf("trigger %q: missing action time", t.Name)
+	}
+	events := &schemahcl.Resource{Type: strings.ToLower(Var(string(t.ActionTime)))}
+	for _, e := range t.Events {
+		switch name := strings.ToUpper(e.Name); name {
+		case schema.TriggerEventInsert.Name, schema.TriggerEventUpdate.Name, schema.TriggerEventDelete.Name, schema.TriggerEventTruncate.Name:
+			events.SetAttr(schemahcl.BoolAttr(strings.ToLower(name), true))
+		case "UPDATE OF":
+			refs := make([]*schemahcl.Ref, 0, len(e.Columns))
+			for _, c := range e.Columns {
+				refs = append(refs, schemahcl.BuildRef(append(on[:len(on):len(on)], schemahcl.PathIndex{T: typeColumn, V: []string{c.Name}})))
+			}
+			events.SetAttr(schemahcl.RefsAttr("update_of", refs...))
+		default:
+			return nil, fmt.Errorf("trigger %q: unexpected event %q", t.Name, e.Name)
+		}
+	}
+	embed := &schemahcl.Resource{Children: []*schemahcl.Resource{events}}
+	if t.For != "" {
+		embed.Attrs = append(embed.Attrs, VarAttr("for", strings.ToUpper(Var(string(t.For)))))
+	}
+	var w schema.TriggerWhen
+	if sqlx.Has(t.Attrs, &w) && w.X != "" {
+		embed.Attrs = append(embed.Attrs, schemahcl.StringAttr("when", w.X))
+	}
+	if body != nil {
+		embed.Children = append(embed.Children, body)
+	} else {
+		embed.Attrs = append(embed.Attrs, defAttr("as", t.Body))
+	}
+	convertCommentFromSchema(t.Attrs, &embed.Attrs)
+	spec.Extra.Children = append(spec.Extra.Children, embed)
+	return spec, nil
+}
+
+// fromFuncArgs converts the arguments of a function or a procedure to specs.
+func fromFuncArgs(args []*schema.FuncArg, typeSpec ColumnTypeSpecFunc) ([]*sqlspec.FuncArg, error) {
+	specs := make([]*sqlspec.FuncArg, 0, len(args))
+	for _, a := range args {
+		ct, err := typeSpec(a.Type)
+		if err != nil {
+			return nil, fmt.Errorf("argument %q: %w", a.Name, err)
+		}
+		spec := &sqlspec.FuncArg{Name: a.Name, Type: ct.Type}
+		if a.Mode != "" && a.Mode != schema.FuncArgModeIn {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, VarAttr("mode", string(a.Mode)))
+		}
+		if a.Default != nil {
+			v, err := ColumnDefault(&schema.Column{Default: a.Default, Type: &schema.ColumnType{Type: a.Type}})
+			if err != nil {
+				return nil, fmt.Errorf("argument %q: %w", a.Name, err)
+			}
+			spec.Extra.Attrs = append(spec.Extra.Attrs, &schemahcl.Attr{K: "default", V: v})
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// defAttr returns an attribute for the given body definition (e.g., view
+// definition). Multi-line definitions are formatted as indented heredoc.
+func defAttr(k, def string) *schemahcl.Attr {
+	return schemahcl.StringAttr(k, sqlspec.MightHeredoc(def))
+}
+
 // dependsOn returns the depends_on attribute for the given objects.
 func dependsOn(realm *schema.Realm, objects []schema.Object) (*schemahcl.Attr, bool) {
 	var (
@@ -539,6 +1194,9 @@ func dependsOn(realm *schema.Realm, objects []schema.Object) (*schemahcl.Attr, b
 			for _, t := range s.Tables {
 				n2s[name(t, t.Name)] = append(n2s[name(t, t.Name)], s)
 			}
+			for _, v := range s.Views {
+				n2s[name(v, v.Name)] = append(n2s[name(v, v.Name)], s)
+			}
 		}
 	}
 	var (
@@ -551,6 +1209,12 @@ func dependsOn(realm *schema.Realm, objects []schema.Object) (*schemahcl.Attr, b
 		switch d := o.(type) {
 		case *schema.Table:
 			n, s = d.Name, d.Schema.Name
+		case *schema.View:
+			n, s = d.Name, d.Schema.Name
+		case *schema.Func:
+			n, s = d.Name, d.Schema.Name
+		case *schema.Proc:
+			n, s = d.Name, d.Schema.Name
 		case RefNamer:
 			// If the object is a reference, add it to the depends_on list.
 			deps = append(deps, d.Ref())
@@ -597,6 +1261,13 @@ func fromDependsOn[T interface{ AddDeps(...schema.Object) T }](loc string, t T,
 				}
 				return nil, false
 			})
+		case tn == typeView, aliases[tn] == typeView:
+			o, err = findT(ns, q, n, func(s *schema.Schema, name string) (*schema.View, bool) {
+				if v, ok := s.View(name); ok && typeName(v) == tn {
+					return v, true
+				}
+				return nil, false
+			})
 		default:
 			if o, err = findT(ns, q, n, func(s *schema.Schema, name string) (schema.Object, bool) {
 				return s.Object(func(o schema.Object) bool {
@@ -1055,6 +1726,13 @@ var ReferenceVars = []string{
 	Var(string(schema.SetDefault)),
 }
 
+// ViewCheckOptions holds the HCL variables
+// for the views' 'WITH CHECK OPTION' clause.
+var ViewCheckOptions = []string{
+	schema.ViewCheckOptionLocal,
+	schema.ViewCheckOptionCascaded,
+}
+
 // Var formats a string as variable to make it HCL compatible.
 // The result is simple, replace each space with underscore.
 func Var(s string) string { return strings.ReplaceAll(s, " ", "_") }
@@ -1070,3 +1748,269 @@ func oneOfPrefix(s string, ps ...string) bool {
 	}
 	return false
 }
+
+// RoleFuncs holds the driver-specific functions for converting role
+// and user options (e.g., superuser or connection limit) from and to
+// their specs. All functions are optional.
+type RoleFuncs struct {
+	Role     func(*sqlspec.Role, *schema.Role) error
+	User     func(*sqlspec.User, *schema.User) error
+	RoleSpec func(*schema.Role, *sqlspec.Role) error
+	UserSpec func(*schema.User, *sqlspec.User) error
+}
+
+// Roles converts the role, user and permission specs into realm-level objects
+// and adds them to the realm. Permissions are linked to the schema resources,
+// and therefore, this function should be called after the realm was scanned.
+func Roles(r *schema.Realm, roles []*sqlspec.Role, users []*sqlspec.User, perms []*sqlspec.Permission, fns *RoleFuncs) error {
+	if fns == nil {
+		fns = &RoleFuncs{}
+	}
+	type member struct {
+		typ, name string
+		refs      []*schemahcl.Ref
+		set       func([]*schema.Role)
+	}
+	var members []member
+	for _, spec := range roles {
+		if _, ok := r.Role(spec.Name); ok {
+			return fmt.Errorf("duplicate role %q", spec.Name)
+		}
+		role := &schema.Role{Name: spec.Name}
+		if fns.Role != nil {
+			if err := fns.Role(spec, role); err != nil {
+				return fmt.Errorf("role %q: %w", spec.Name, err)
+			}
+		}
+		schemahcl.AppendPos(&role.Attrs, spec.Range)
+		members = append(members, member{typ: typeRole, name: role.Name, refs: spec.MemberOf, set: func(of []*schema.Role) { role.MemberOf = of }})
+		r.AddObjects(role)
+	}
+	for _, spec := range users {
+		if _, ok := r.User(spec.Name, spec.Host); ok {
+			return fmt.Errorf("duplicate user %q", spec.Name)
+		}
+		// Passwords are rejected on parse, as they would otherwise
+		// be written to planned statements and migration files.
+		if _, ok := spec.Extra.Attr("password"); ok {
+			return fmt.Errorf("user %q: passwords are not supported and should be set separately", spec.Name)
+		}
+		u := &schema.User{Name: spec.Name, Host: spec.Host}
+		if fns.User != nil {
+			if err := fns.User(spec, u); err != nil {
+				return fmt.Errorf("user %q: %w", spec.Name, err)
+			}
+		}
+		schemahcl.AppendPos(&u.Attrs, spec.Range)
+		members = append(members, member{typ: typeUser, name: u.Name, refs: spec.MemberOf, set: func(of []*schema.Role) { u.MemberOf = of }})
+		r.AddObjects(u)
+	}
+	// Link role memberships after all roles were created,
+	// as the spec order does not reflect their dependencies.
+	for _, m := range members {
+		if len(m.refs) == 0 {
+			continue
+		}
+		of := make([]*schema.Role, 0, len(m.refs))
+		for _, ref := range m.refs {
+			role, err := RoleByRef(r, ref)
+			if err != nil {
+				return fmt.Errorf("%s %q: attribute \"member_of\": %w", m.typ, m.name, err)
+			}
+			of = append(of, role)
+		}
+		m.set(of)
+	}
+	for _, spec := range perms {
+		p, err := permission(r, spec)
+		if err != nil {
+			return fmt.Errorf("permission %q: %w", spec.Name, err)
+		}
+		r.AddObjects(p)
+	}
+	return nil
+}
+
+// permission converts a permission spec into a schema.Permission.
+func permission(r *schema.Realm, spec *sqlspec.Permission) (*schema.Permission, error) {
+	if spec.To == nil {
+		return nil, errors.New("missing \"to\" attribute")
+	}
+	if spec.For == nil {
+		return nil, errors.New("missing \"for\" attribute")
+	}
+	p := &schema.Permission{Grantable: spec.Grantable}
+	path, err := spec.To.Path()
+	if err != nil {
+		return nil, err
+	}
+	switch name := spec.To.V; {
+	case len(path) == 1 && path[0].T == typeRole && len(path[0].V) == 1:
+		if p.To, err = RoleByRef(r, spec.To); err != nil {
+			return nil, err
+		}
+	case len(path) == 1 && path[0].T == typeUser && len(path[0].V) == 1:
+		u, ok := r.Object(func(o schema.Object) bool {
+			u, ok := o.(*schema.User)
+			return ok && u.Name == path[0].V[0]
+		})
+		if !ok {
+			return nil, fmt.Errorf("user %q was not found in realm", path[0].V[0])
+		}
+		p.To = u
+	default:
+		return nil, fmt.Errorf("unexpected grantee reference %q, expect a role or a user", name)
+	}
+	if path, err = spec.For.Path(); err != nil {
+		return nil, err
+	}
+	switch {
+	case len(path) == 1 && path[0].T == typeSchema:
+		n, err := SchemaName(spec.For)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := r.Schema(n)
+		if !ok {
+			return nil, fmt.Errorf("schema %q was not found in realm", n)
+		}
+		p.Schema = s
+	case len(path) == 1 && path[0].T == typeTable:
+		if len(r.Schemas) == 0 {
+			return nil, fmt.Errorf("table %q was not found in realm", spec.For.V)
+		}
+		t, err := TableByRef(r.Schemas[0], spec.For)
+		if err != nil {
+			return nil, err
+		}
+		p.Schema, p.Table = t.Schema, t
+		for _, ref := range spec.Columns {
+			c, err := ColumnByRef(t, ref)
+			if err != nil {
+				return nil, err
+			}
+			p.Columns = append(p.Columns, c)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected resource reference %q, expect a schema or a table", spec.For.V)
+	}
+	if len(spec.Columns) > 0 && p.Table == nil {
+		return nil, errors.New("columns can be set only on table permissions")
+	}
+	a, ok := spec.Attr("privileges")
+	if !ok {
+		return nil, errors.New("missing \"privileges\" attribute")
+	}
+	privs, err := a.Strings()
+	if err != nil {
+		return nil, fmt.Errorf("attribute \"privileges\": %w", err)
+	}
+	if len(privs) == 0 {
+		return nil, errors.New("attribute \"privileges\" cannot be empty")
+	}
+	for _, pv := range privs {
+		p.Privileges = append(p.Privileges, strings.ToUpper(FromVar(pv)))
+	}
+	schemahcl.AppendPos(&p.Attrs, spec.Range)
+	return p, nil
+}
+
+// FromRoles converts the realm-level roles, users and permissions into their specs.
+func FromRoles(r *schema.Realm, fns *RoleFuncs) (roles []*sqlspec.Role, users []*sqlspec.User, perms []*sqlspec.Permission, err error) {
+	if fns == nil {
+		fns = &RoleFuncs{}
+	}
+	for _, o := range r.Objects {
+		switch o := o.(type) {
+		case *schema.Role:
+			spec := &sqlspec.Role{Name: o.Name, MemberOf: roleRefs(o.MemberOf)}
+			if fns.RoleSpec != nil {
+				if err := fns.RoleSpec(o, spec); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			roles = append(roles, spec)
+		case *schema.User:
+			spec := &sqlspec.User{Name: o.Name, Host: o.Host, MemberOf: roleRefs(o.MemberOf)}
+			if fns.UserSpec != nil {
+				if err := fns.UserSpec(o, spec); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			users = append(users, spec)
+		case *schema.Permission:
+			spec, err := permissionSpec(o)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			perms = append(perms, spec)
+		}
+	}
+	return roles, users, perms, nil
+}
+
+// permissionSpec converts a schema.Permission into its spec.
+func permissionSpec(p *schema.Permission) (*sqlspec.Permission, error) {
+	nt, ok := p.To.(schema.SpecTypeNamer)
+	if !ok {
+		return nil, fmt.Errorf("unexpected permission grantee %T", p.To)
+	}
+	if p.Schema == nil {
+		return nil, fmt.Errorf("missing schema for permission of %s %q", nt.SpecType(), nt.SpecName())
+	}
+	var (
+		name  = []string{nt.SpecName(), p.Schema.Name}
+		privs = make([]*schemahcl.Ref, len(p.Privileges))
+		spec  = &sqlspec.Permission{
+			To:        schemahcl.BuildRef([]schemahcl.PathIndex{{T: nt.SpecType(), V: []string{nt.SpecName()}}}),
+			For:       SchemaRef(p.Schema.Name),
+			Grantable: p.Grantable,
+		}
+	)
+	if p.Table != nil {
+		name = append(name, p.Table.Name)
+		spec.For = TableSpecRef(p.Table)
+		for _, c := range p.Columns {
+			name = append(name, c.Name)
+			if path, err := spec.For.Path(); err == nil && len(path) == 1 && len(path[0].V) == 2 {
+				spec.Columns = append(spec.Columns, QualifiedExternalColRef(c.Name, p.Table.Name, p.Schema.Name))
+			} else {
+				spec.Columns = append(spec.Columns, ExternalColumnRef(c.Name, p.Table.Name))
+			}
+		}
+	}
+	for i, pv := range p.Privileges {
+		privs[i] = &schemahcl.Ref{V: Var(pv)}
+	}
+	spec.Name = strings.Join(name, "_")
+	spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.RefsAttr("privileges", privs...))
+	return spec, nil
+}
+
+// RoleByRef returns the role referenced by ref from the realm.
+func RoleByRef(r *schema.Realm, ref *schemahcl.Ref) (*schema.Role, error) {
+	vs, err := ref.ByType(typeRole)
+	if err != nil {
+		return nil, err
+	}
+	if len(vs) != 1 {
+		return nil, fmt.Errorf("expected 1 role ref, got %d", len(vs))
+	}
+	role, ok := r.Role(vs[0])
+	if !ok {
+		return nil, fmt.Errorf("role %q was not found in realm", vs[0])
+	}
+	return role, nil
+}
+
+// roleRefs returns the references to the given roles.
+func roleRefs(roles []*schema.Role) []*schemahcl.Ref {
+	refs := make([]*schemahcl.Ref, 0, len(roles))
+	for _, r := range roles {
+		refs = append(refs, schemahcl.BuildRef([]schemahcl.PathIndex{{T: typeRole, V: []string{r.Name}}}))
+	}
+	if len(refs) == 0 {
+		return nil
+	}
+	return refs
+}
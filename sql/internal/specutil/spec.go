@@ -20,18 +20,33 @@ type (
 	// SchemaSpec is returned by driver convert functions to
 	// marshal a *schema.Schema into top-level spec objects.
 	SchemaSpec struct {
-		Schema *sqlspec.Schema
-		Tables []*sqlspec.Table
+		Schema       *sqlspec.Schema
+		Tables       []*sqlspec.Table
+		Views        []*sqlspec.View
+		Materialized []*sqlspec.View
+		Funcs        []*sqlspec.Func
+		Procs        []*sqlspec.Proc
+		Triggers     []*sqlspec.Trigger
 	}
 	// RealmFuncs represents the functions that used
 	// to convert the schema.Realm into HCL spec document.
 	RealmFuncs struct {
 		Schema func(*schema.Schema) (*SchemaSpec, error)
+		// Roles is set by drivers that support
+		// roles, users and permissions.
+		Roles *RoleFuncs
 	}
 	// Doc represents the common HCL spec document.
 	Doc struct {
-		Tables  []*sqlspec.Table  `spec:"table"`
-		Schemas []*sqlspec.Schema `spec:"schema"`
+		Tables      []*sqlspec.Table      `spec:"table"`
+		Views       []*sqlspec.View       `spec:"view"`
+		Funcs       []*sqlspec.Func       `spec:"function"`
+		Procs       []*sqlspec.Proc       `spec:"procedure"`
+		Triggers    []*sqlspec.Trigger    `spec:"trigger"`
+		Roles       []*sqlspec.Role       `spec:"role"`
+		Users       []*sqlspec.User       `spec:"user"`
+		Permissions []*sqlspec.Permission `spec:"permission"`
+		Schemas     []*sqlspec.Schema     `spec:"schema"`
 	}
 )
 
@@ -46,6 +61,10 @@ func Marshal(v any, marshaler schemahcl.Marshaler, funcs RealmFuncs) ([]byte, er
 			return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 		}
 		d.Tables = spec.Tables
+		d.Views = spec.Views
+		d.Funcs = spec.Funcs
+		d.Procs = spec.Procs
+		d.Triggers = spec.Triggers
 		d.Schemas = []*sqlspec.Schema{spec.Schema}
 	case *schema.Realm:
 		for _, s := range s.Schemas {
@@ -54,14 +73,33 @@ func Marshal(v any, marshaler schemahcl.Marshaler, funcs RealmFuncs) ([]byte, er
 				return nil, fmt.Errorf("specutil: failed converting schema to spec: %w", err)
 			}
 			d.Tables = append(d.Tables, spec.Tables...)
+			d.Views = append(d.Views, spec.Views...)
+			d.Funcs = append(d.Funcs, spec.Funcs...)
+			d.Procs = append(d.Procs, spec.Procs...)
+			d.Triggers = append(d.Triggers, spec.Triggers...)
 			d.Schemas = append(d.Schemas, spec.Schema)
 		}
 		if err := QualifyObjects(d.Tables); err != nil {
 			return nil, err
 		}
+		if err := QualifyObjects(d.Views); err != nil {
+			return nil, err
+		}
+		if err := QualifyObjects(d.Funcs); err != nil {
+			return nil, err
+		}
+		if err := QualifyObjects(d.Procs); err != nil {
+			return nil, err
+		}
 		if err := QualifyReferences(d.Tables, s); err != nil {
 			return nil, err
 		}
+		if funcs.Roles != nil {
+			var err error
+			if d.Roles, d.Users, d.Permissions, err = FromRoles(s, funcs.Roles); err != nil {
+				return nil, err
+			}
+		}
 	default:
 		return nil, fmt.Errorf("specutil: failed marshaling spec. %T is not supported", v)
 	}
@@ -202,6 +240,21 @@ func TableSpecRef(t *schema.Table) *schemahcl.Ref {
 	return schemahcl.BuildRef([]schemahcl.PathIndex{idx})
 }
 
+// viewSpecRef returns a reference to the view in the spec. In case there is more than
+// one view with the same name, the reference will be qualified with the schema name.
+func viewSpecRef(v *schema.View) *schemahcl.Ref {
+	typ, name := typeView, v.Name
+	idx := schemahcl.PathIndex{T: typ, V: []string{name}}
+	if s := v.Schema; s != nil && s.Realm != nil && len(s.Realm.Schemas) > 1 && slices.ContainsFunc(s.Realm.Schemas, func(s1 *schema.Schema) bool {
+		return s1 != s && slices.ContainsFunc(s1.Views, func(v1 *schema.View) bool {
+			return v1.Name == v.Name
+		})
+	}) {
+		idx.V = append([]string{s.Name}, idx.V...)
+	}
+	return schemahcl.BuildRef([]schemahcl.PathIndex{idx})
+}
+
 // HCLBytesFunc returns a helper that evaluates an HCL document from a byte slice instead
 // of from an hclparse.Parser instance.
 func HCLBytesFunc(ev schemahcl.Evaluator) func(b []byte, v any, inp map[string]cty.Value) error {
@@ -49,6 +49,13 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 	)
 	name2pos := make(key2pos)
 	for _, o := range r.Objects {
+		// Roles, users and permissions are not created on the dev database, as
+		// some databases share them across databases and restoring a snapshot
+		// does not drop them. They are copied as-is to the normalized realm.
+		switch o.(type) {
+		case *schema.Role, *schema.User, *schema.Permission:
+			continue
+		}
 		changes = append(changes, &schema.AddObject{
 			O: o,
 			Extra: []schema.Clause{
@@ -78,6 +85,11 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 			name2pos.putObject(o, k)
 			changes = append(changes, &schema.AddObject{O: o})
 		}
+		for _, v := range s.Views {
+			changes = append(changes, &schema.AddView{V: v})
+			name2pos.put(v.Attrs, k, keyV, v.Name)
+		}
+		changes = append(changes, schemaTriggers(s)...)
 	}
 	if err := d.Driver.ApplyChanges(ctx, changes); err != nil {
 		return nil, err
@@ -85,12 +97,46 @@ func (d *DevDriver) NormalizeRealm(ctx context.Context, r *schema.Realm) (nr *sc
 	if nr, err = d.Driver.InspectRealm(ctx, opts); err != nil {
 		return nil, err
 	}
+	if err := copyRoles(r, nr); err != nil {
+		return nil, err
+	}
 	if len(name2pos) > 0 {
 		name2pos.patchRealm(nr)
 	}
 	return nr, nil
 }
 
+// copyRoles copies the roles, users and permissions from the given realm to the
+// normalized one, and links the permissions to the normalized schema resources.
+func copyRoles(from, to *schema.Realm) error {
+	for _, o := range from.Objects {
+		switch o := o.(type) {
+		case *schema.Role, *schema.User:
+			to.AddObjects(o)
+		case *schema.Permission:
+			p := *o
+			if p.Schema, _ = to.Schema(o.Schema.Name); p.Schema == nil {
+				return fmt.Errorf("sql/sqlx: schema %q of permission was not found in normalized realm", o.Schema.Name)
+			}
+			if o.Table != nil {
+				if p.Table, _ = p.Schema.Table(o.Table.Name); p.Table == nil {
+					return fmt.Errorf("sql/sqlx: table %q of permission was not found in normalized realm", o.Table.Name)
+				}
+				p.Columns = nil
+				for _, c := range o.Columns {
+					c1, ok := p.Table.Column(c.Name)
+					if !ok {
+						return fmt.Errorf("sql/sqlx: column %q of permission was not found in table %q", c.Name, o.Table.Name)
+					}
+					p.Columns = append(p.Columns, c1)
+				}
+			}
+			to.AddObjects(&p)
+		}
+	}
+	return nil
+}
+
 // NormalizeSchema returns the normal representation of the given database. See NormalizeRealm for more info.
 func (d *DevDriver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*schema.Schema, error) {
 	restore, err := d.Driver.Snapshot(ctx)
@@ -145,6 +191,14 @@ func (d *DevDriver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*sch
 		name2pos.putObject(o, k)
 		changes = append(changes, &schema.AddObject{O: o})
 	}
+	for _, v := range s.Views {
+		if v.Schema != s {
+			v.Schema = s
+		}
+		changes = append(changes, &schema.AddView{V: v})
+		name2pos.put(v.Attrs, k, keyV, v.Name)
+	}
+	changes = append(changes, schemaTriggers(s)...)
 	if err := d.Driver.ApplyChanges(ctx, changes, func(opts *migrate.PlanOptions) {
 		noQualifier := ""
 		opts.SchemaQualifier = &noQualifier
@@ -170,6 +224,7 @@ func (d *DevDriver) NormalizeSchema(ctx context.Context, s *schema.Schema) (*sch
 const (
 	keyS = "schema"
 	keyT = "table"
+	keyV = "view"
 	keyC = "column"
 	keyI = "index"
 	keyP = "pk"
@@ -267,6 +322,9 @@ func (k key2pos) patchSchema(s *schema.Schema) {
 			k.patch(ck, tk, keyK, ck.Name)
 		}
 	}
+	for _, v := range s.Views {
+		k.patch(v, ks, keyV, v.Name)
+	}
 }
 
 func (k key2pos) patch(ps schema.PosSetter, typename ...string) (string, bool) {
@@ -281,3 +339,16 @@ func (k key2pos) patch(ps schema.PosSetter, typename ...string) (string, bool) {
 func poskey(typename ...string) string {
 	return strings.Join(typename, ".")
 }
+
+// schemaTriggers returns the changes for creating the triggers
+// of the tables and views in the given schema.
+func schemaTriggers(s *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, t := range s.Tables {
+		changes = append(changes, addTriggers(t.Triggers)...)
+	}
+	for _, v := range s.Views {
+		changes = append(changes, addTriggers(v.Triggers)...)
+	}
+	return changes
+}
@@ -101,6 +101,13 @@ type (
 		AnnotateChanges([]schema.Change, *schema.DiffOptions) ([]schema.Change, error)
 	}
 
+	// ViewAttrChanger is an optional interface allows DiffDriver to report
+	// if the driver-specific attributes of a view were changed. For example,
+	// the MySQL SQL SECURITY characteristic, or PostgreSQL view options.
+	ViewAttrChanger interface {
+		ViewAttrChanged(from, to *schema.View) bool
+	}
+
 	// ChangeSupporter wraps the single SupportChange method.
 	ChangeSupporter interface {
 		// SupportChange can be implemented to tell the Differ if they support
@@ -152,6 +159,15 @@ func (d *Diff) RealmDiff(from, to *schema.Realm, options ...schema.DiffOption) (
 		for _, t := range s1.Tables {
 			changes = opts.AddOrSkip(changes, addTableChange(t)...)
 		}
+		for _, v := range s1.Views {
+			changes = opts.AddOrSkip(changes, &schema.AddView{V: v})
+		}
+		for _, t := range s1.Tables {
+			changes = opts.AddOrSkip(changes, addTriggers(t.Triggers)...)
+		}
+		for _, v := range s1.Views {
+			changes = opts.AddOrSkip(changes, addTriggers(v.Triggers)...)
+		}
 	}
 	return d.mayAnnotate(changes, opts)
 }
@@ -211,10 +227,175 @@ func (d *Diff) schemaDiff(from, to *schema.Schema, opts *schema.DiffOptions) ([]
 			return nil, err
 		}
 	}
-
+	// Drop or modify views.
+	for _, v1 := range from.Views {
+		v2, ok := to.View(v1.Name)
+		if !ok {
+			changes = opts.AddOrSkip(changes, &schema.DropView{V: v1})
+			continue
+		}
+		if change := d.viewDiff(v1, v2); change != nil {
+			changes = opts.AddOrSkip(changes, change)
+		}
+	}
+	// Add views.
+	for _, v1 := range to.Views {
+		if _, ok := from.View(v1.Name); !ok {
+			changes = opts.AddOrSkip(changes, &schema.AddView{V: v1})
+		}
+	}
+	// Add, drop or modify triggers. Triggers of dropped
+	// tables and views are dropped along with them.
+	for _, t1 := range to.Tables {
+		switch t2, err := d.findTable(from, t1); {
+		case schema.IsNotExistError(err):
+			changes = opts.AddOrSkip(changes, addTriggers(t1.Triggers)...)
+		case err != nil:
+			return nil, err
+		default:
+			changes = opts.AddOrSkip(changes, d.triggersDiff(t2.Triggers, t1.Triggers)...)
+		}
+	}
+	for _, v1 := range to.Views {
+		if v2, ok := from.View(v1.Name); ok {
+			changes = opts.AddOrSkip(changes, d.triggersDiff(v2.Triggers, v1.Triggers)...)
+		} else {
+			changes = opts.AddOrSkip(changes, addTriggers(v1.Triggers)...)
+		}
+	}
 	return changes, nil
 }
 
+// triggersDiff returns the changes for migrating the triggers of
+// a table (or a view) from one state to the other. Triggers are
+// matched by their names.
+func (d *Diff) triggersDiff(from, to []*schema.Trigger) []schema.Change {
+	var changes []schema.Change
+	for _, t1 := range from {
+		i := slices.IndexFunc(to, func(t2 *schema.Trigger) bool { return t1.Name == t2.Name })
+		if i == -1 {
+			changes = append(changes, &schema.DropTrigger{T: t1})
+			continue
+		}
+		if change := d.triggerDiff(t1, to[i]); change != nil {
+			changes = append(changes, change)
+		}
+	}
+	for _, t1 := range to {
+		if !slices.ContainsFunc(from, func(t2 *schema.Trigger) bool { return t1.Name == t2.Name }) {
+			changes = append(changes, &schema.AddTrigger{T: t1})
+		}
+	}
+	return changes
+}
+
+// triggerDiff returns the change for migrating a trigger from one
+// state to the other, or nil if the triggers are identical.
+func (*Diff) triggerDiff(from, to *schema.Trigger) *schema.ModifyTrigger {
+	m := &schema.ModifyTrigger{From: from, To: to}
+	if change := CommentDiff(from.Attrs, to.Attrs); change != nil {
+		m.Changes = append(m.Changes, change)
+	}
+	if len(m.Changes) > 0 || TriggerDefChanged(m) {
+		return m
+	}
+	return nil
+}
+
+// TriggerDefChanged reports if the definition of the trigger was changed, and
+// the trigger needs to be replaced. A false value indicates the modification
+// contains only changes that are extra to the trigger definition.
+func TriggerDefChanged(m *schema.ModifyTrigger) bool {
+	from, to := m.From, m.To
+	if from.ActionTime != to.ActionTime || triggerFor(from) != triggerFor(to) || BodyDefChanged(from.Body, to.Body) {
+		return true
+	}
+	if len(from.Events) != len(to.Events) {
+		return true
+	}
+	for i := range from.Events {
+		e1, e2 := from.Events[i], to.Events[i]
+		if !strings.EqualFold(e1.Name, e2.Name) || len(e1.Columns) != len(e2.Columns) {
+			return true
+		}
+		for j := range e1.Columns {
+			if e1.Columns[j].Name != e2.Columns[j].Name {
+				return true
+			}
+		}
+	}
+	var w1, w2 schema.TriggerWhen
+	return Has(from.Attrs, &w1) != Has(to.Attrs, &w2) || BodyDefChanged(w1.X, w2.X)
+}
+
+// triggerFor returns the FOR EACH spec of the trigger. Triggers
+// are defined FOR EACH STATEMENT, unless specified otherwise.
+func triggerFor(t *schema.Trigger) schema.TriggerFor {
+	if t.For == "" {
+		return schema.TriggerForStmt
+	}
+	return t.For
+}
+
+// addTriggers returns the creation changes of the given triggers.
+func addTriggers(ts []*schema.Trigger) []schema.Change {
+	changes := make([]schema.Change, 0, len(ts))
+	for _, t := range ts {
+		changes = append(changes, &schema.AddTrigger{T: t})
+	}
+	return changes
+}
+
+// viewDiff returns the change for migrating a view from one state to the other,
+// or nil if the views are identical. Definitions are compared after trimming the
+// indentation and terminators, as the inspected definition is normalized by the
+// database and the desired one is expected to be normalized using a dev-database.
+func (d *Diff) viewDiff(from, to *schema.View) *schema.ModifyView {
+	m := &schema.ModifyView{From: from, To: to}
+	if change := CommentDiff(from.Attrs, to.Attrs); change != nil {
+		m.Changes = append(m.Changes, change)
+	}
+	m.Changes = append(m.Changes, d.viewIndexDiff(from, to)...)
+	if len(m.Changes) > 0 || ViewDefChanged(m) {
+		return m
+	}
+	if vc, ok := d.DiffDriver.(ViewAttrChanger); ok && vc.ViewAttrChanged(from, to) {
+		return m
+	}
+	return nil
+}
+
+// viewIndexDiff returns the changes for migrating the indexes of a materialized
+// view from one state to the other. Modified indexes are reported as ModifyIndex.
+func (d *Diff) viewIndexDiff(from, to *schema.View) []schema.Change {
+	var changes []schema.Change
+	for _, idx1 := range from.Indexes {
+		idx2, ok := to.Index(idx1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropIndex{I: idx1})
+			continue
+		}
+		if change := d.indexChange(idx1, idx2); change != schema.NoChange {
+			changes = append(changes, &schema.ModifyIndex{From: idx1, To: idx2, Change: change})
+		}
+	}
+	for _, idx := range to.Indexes {
+		if _, ok := from.Index(idx.Name); !ok {
+			changes = append(changes, &schema.AddIndex{I: idx})
+		}
+	}
+	return changes
+}
+
+// ViewDefChanged reports if the definition of the view was changed, and
+// the view needs to be replaced. A false value indicates the modification
+// contains only changes that are extra to the view definition.
+func ViewDefChanged(m *schema.ModifyView) bool {
+	var c1, c2 schema.ViewCheckOption
+	return BodyDefChanged(m.From.Def, m.To.Def) || m.From.Materialized() != m.To.Materialized() ||
+		Has(m.From.Attrs, &c1) != Has(m.To.Attrs, &c2) || !strings.EqualFold(c1.V, c2.V)
+}
+
 // TableDiff implements the schema.TableDiffer interface and returns a list of
 // changes that need to be applied in order to move from one state to the other.
 func (d *Diff) TableDiff(from, to *schema.Table, options ...schema.DiffOption) ([]schema.Change, error) {
@@ -822,3 +1003,127 @@ func BodyDefChanged(from, to string) bool {
 	return noident(from) != noident(to)
 }
 
+
+// RoleDiffFuncs holds the driver-specific functions used by RolesDiff.
+// All functions are optional.
+type RoleDiffFuncs struct {
+	// Changed reports if the driver-specific options
+	// of a role or a user (e.g., superuser) were changed.
+	Changed func(from, to schema.Object) bool
+	// Privileges returns the normalized privileges of a permission.
+	// For example, expanding ALL to the privileges it grants.
+	Privileges func(*schema.Permission) []string
+}
+
+// RolesDiff returns the changes for migrating the realm-level roles, users
+// and permissions from one state to the other.
+func RolesDiff(from, to *schema.Realm, fns *RoleDiffFuncs) []schema.Change {
+	if fns == nil {
+		fns = &RoleDiffFuncs{}
+	}
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		switch o1.(type) {
+		case *schema.Role, *schema.User, *schema.Permission:
+		default:
+			continue
+		}
+		o2, ok := to.Object(func(o2 schema.Object) bool { return SameRoleObject(o1, o2) })
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropObject{O: o1})
+		case roleObjectChanged(o1, o2, fns):
+			changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		switch o2.(type) {
+		case *schema.Role, *schema.User, *schema.Permission:
+		default:
+			continue
+		}
+		if _, ok := from.Object(func(o1 schema.Object) bool { return SameRoleObject(o1, o2) }); !ok {
+			changes = append(changes, &schema.AddObject{O: o2})
+		}
+	}
+	return changes
+}
+
+// SameRoleObject reports if the two objects represent the same role, user or permission.
+// Permissions are identified by their grantee, their resource and their grant option,
+// as the same privilege can be granted once with the grant option and once without.
+func SameRoleObject(o1, o2 schema.Object) bool {
+	switch o1 := o1.(type) {
+	case *schema.Role, *schema.User:
+		return SameGrantee(o1, o2)
+	case *schema.Permission:
+		p2, ok := o2.(*schema.Permission)
+		return ok && o1.Grantable == p2.Grantable && SameGrantee(o1.To, p2.To) && SameSchema(o1.Schema, p2.Schema) &&
+			SameTable(o1.Table, p2.Table) && slices.Equal(columnNames(o1.Columns), columnNames(p2.Columns))
+	}
+	return false
+}
+
+// SameGrantee reports if the two objects represent the same role or user.
+func SameGrantee(o1, o2 schema.Object) bool {
+	switch o1 := o1.(type) {
+	case *schema.Role:
+		r2, ok := o2.(*schema.Role)
+		return ok && o1.Name == r2.Name
+	case *schema.User:
+		u2, ok := o2.(*schema.User)
+		return ok && o1.Name == u2.Name && o1.Host == u2.Host
+	}
+	return false
+}
+
+// Privileges returns the sorted and deduplicated privileges of the permission.
+func Privileges(p *schema.Permission) []string {
+	privs := make([]string, len(p.Privileges))
+	for i, pv := range p.Privileges {
+		privs[i] = strings.ToUpper(pv)
+	}
+	slices.Sort(privs)
+	return slices.Compact(privs)
+}
+
+// RoleNames returns the sorted names of the given roles.
+func RoleNames(roles []*schema.Role) []string {
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = r.Name
+	}
+	slices.Sort(names)
+	return names
+}
+
+// roleObjectChanged reports if the role, the user or the permission was changed.
+func roleObjectChanged(from, to schema.Object, fns *RoleDiffFuncs) bool {
+	switch from := from.(type) {
+	case *schema.Role:
+		if !slices.Equal(RoleNames(from.MemberOf), RoleNames(to.(*schema.Role).MemberOf)) {
+			return true
+		}
+	case *schema.User:
+		if !slices.Equal(RoleNames(from.MemberOf), RoleNames(to.(*schema.User).MemberOf)) {
+			return true
+		}
+	case *schema.Permission:
+		privs := Privileges
+		if fns.Privileges != nil {
+			privs = fns.Privileges
+		}
+		return !slices.Equal(privs(from), privs(to.(*schema.Permission)))
+	}
+	return fns.Changed != nil && fns.Changed(from, to)
+}
+
+// columnNames returns the sorted names of the given columns.
+func columnNames(columns []*schema.Column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	slices.Sort(names)
+	return names
+}
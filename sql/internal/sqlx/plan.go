@@ -337,6 +337,11 @@ func CheckChangesScope(opts migrate.PlanOptions, changes []schema.Change) error
 			t = c.T
 		case *schema.DropTable:
 			t = c.T
+		case *schema.AddView, *schema.DropView, *schema.ModifyView:
+			if s := viewSchema(c); s != nil && s.Name != "" {
+				names[s.Name] = struct{}{}
+			}
+			continue
 		default:
 			continue
 		}
@@ -361,6 +366,19 @@ func CheckChangesScope(opts migrate.PlanOptions, changes []schema.Change) error
 	return nil
 }
 
+// viewSchema returns the schema of the view in the given change.
+func viewSchema(c schema.Change) *schema.Schema {
+	switch c := c.(type) {
+	case *schema.AddView:
+		return c.V.Schema
+	case *schema.DropView:
+		return c.V.Schema
+	case *schema.ModifyView:
+		return c.To.Schema
+	}
+	return nil
+}
+
 // byKeys sorts a map by keys.
 func byKeys[T any](m map[string]T) []struct {
 	K string
@@ -498,6 +516,8 @@ func depOfDrop(o schema.Object, c schema.Change) bool {
 	switch c := c.(type) {
 	case *schema.DropTable:
 		deps = c.T.Deps
+	case *schema.DropView:
+		deps = c.V.Deps
 	}
 	return slices.Contains(deps, o)
 }
@@ -516,6 +536,11 @@ func depOfAdd(refs []schema.Object, c schema.Change) bool {
 			t, ok := o.(*schema.Table)
 			return ok && SameTable(c.T, t)
 		})
+	case *schema.AddView:
+		return slices.ContainsFunc(refs, func(o schema.Object) bool {
+			v, ok := o.(*schema.View)
+			return ok && SameView(c.V, v)
+		})
 	case *schema.AddObject:
 		o = c.O
 	default:
@@ -549,6 +574,14 @@ func SameTable(t1, t2 *schema.Table) bool {
 	return t1.Name == t2.Name && SameSchema(t1.Schema, t2.Schema)
 }
 
+// SameView reports if the two objects represent the same view.
+func SameView(v1, v2 *schema.View) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == v2
+	}
+	return v1.Name == v2.Name && SameSchema(v1.Schema, v2.Schema)
+}
+
 // SameSchema reports if the given schemas are the same.
 // Objects can be different as they might reside in two
 // different states (current and desired).
@@ -129,6 +129,90 @@ func TestConsistentOrder(t *testing.T) {
 	}
 }
 
+func TestSortChanges_Funcs(t *testing.T) {
+	f := schema.NewFunc("f", "SELECT 1")
+	t1 := schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int").SetDefault(&schema.RawExpr{X: "f()"}))
+	t2 := schema.NewTable("t2").AddColumns(schema.NewIntColumn("id", "int")).AddChecks(schema.NewCheck().SetExpr("id > f ()"))
+	v1 := schema.NewView("v1", "SELECT myf() AS id")
+	planned := SortChanges([]schema.Change{
+		&schema.AddTable{T: t1},
+		&schema.AddTable{T: t2},
+		&schema.AddView{V: v1},
+		&schema.AddObject{O: f},
+	}, nil)
+	require.Equal(t, []schema.Change{
+		&schema.AddObject{O: f},
+		&schema.AddTable{T: t1},
+		&schema.AddTable{T: t2},
+		&schema.AddView{V: v1},
+	}, planned)
+	require.False(t, dependsOn(&schema.AddView{V: v1}, &schema.AddObject{O: f}, SortOptions{}), "myf does not call f")
+
+	// Functions are dropped after the tables that use them.
+	planned = SortChanges([]schema.Change{
+		&schema.DropObject{O: f},
+		&schema.DropTable{T: t1},
+	}, nil)
+	require.Equal(t, &schema.DropTable{T: t1}, planned[0])
+}
+
+func TestSortChanges_Triggers(t *testing.T) {
+	f := schema.NewFunc("audit", "BEGIN RETURN NEW; END")
+	t1 := schema.NewTable("t1").AddColumns(schema.NewIntColumn("id", "int"))
+	tr := schema.NewTrigger("tr").
+		SetActionTime(schema.TriggerTimeAfter).
+		AddEvents(schema.TriggerEventInsert).
+		SetBody("EXECUTE FUNCTION audit()").
+		AddDeps(f)
+	t1.AddTriggers(tr)
+	planned := SortChanges([]schema.Change{
+		&schema.AddTrigger{T: tr},
+		&schema.AddTable{T: t1},
+		&schema.AddObject{O: f},
+	}, nil)
+	require.Equal(t, &schema.AddTrigger{T: tr}, planned[2])
+
+	// Triggers are dropped before the functions they execute.
+	planned = SortChanges([]schema.Change{
+		&schema.DropObject{O: f},
+		&schema.DropTrigger{T: tr},
+	}, nil)
+	require.Equal(t, []schema.Change{
+		&schema.DropTrigger{T: tr},
+		&schema.DropObject{O: f},
+	}, planned)
+
+	// Triggers are recreated after the old version was dropped.
+	planned = SortChanges([]schema.Change{
+		&schema.AddTrigger{T: tr},
+		&schema.DropTrigger{T: tr},
+	}, nil)
+	require.Equal(t, &schema.DropTrigger{T: tr}, planned[0])
+}
+
+func TestTriggerDefChanged(t *testing.T) {
+	users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+	newT := func() *schema.Trigger {
+		return schema.NewTrigger("t").
+			SetTable(users).
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("EXECUTE FUNCTION f()")
+	}
+	require.False(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT()}))
+	require.False(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().SetBody("EXECUTE FUNCTION f();")}))
+	require.False(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().SetComment("c")}))
+	require.True(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().SetActionTime(schema.TriggerTimeAfter)}))
+	require.True(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().SetFor(schema.TriggerForStmt)}))
+	require.True(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().AddEvents(schema.TriggerEventDelete)}))
+	require.True(t, TriggerDefChanged(&schema.ModifyTrigger{From: newT(), To: newT().SetWhen("NEW.id > 0")}))
+	require.True(t, TriggerDefChanged(&schema.ModifyTrigger{
+		From: newT().AddEvents(schema.TriggerEventUpdateOf(users.Columns[0])),
+		To:   newT().AddEvents(schema.TriggerEventUpdateOf(users.Columns[1])),
+	}))
+}
+
 func TestCheckChangesScope(t *testing.T) {
 	err := CheckChangesScope(migrate.PlanOptions{}, []schema.Change{
 		&schema.AddSchema{},
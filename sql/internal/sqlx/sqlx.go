@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -306,6 +307,24 @@ func (b *Builder) Table(t *schema.Table) *Builder {
 	return b.mayQualify(t.Schema, t.Name)
 }
 
+// View writes the view identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) View(v *schema.View) *Builder {
+	return b.mayQualify(v.Schema, v.Name)
+}
+
+// Func writes the function identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Func(f *schema.Func) *Builder {
+	return b.mayQualify(f.Schema, f.Name)
+}
+
+// Proc writes the procedure identifier to the builder, prefixed
+// with the schema name if exists.
+func (b *Builder) Proc(p *schema.Proc) *Builder {
+	return b.mayQualify(p.Schema, p.Name)
+}
+
 // RefTable writes the referenced/parent table identifier to the builder.
 // Unlike the Table method, RefTable prefix the table with its schema qualifier
 // if the "Schema" is set to nil, or if the schema is set to empty (schema-scope),
@@ -601,6 +620,39 @@ func DefaultValue(c *schema.Column) (string, bool) {
 	}
 }
 
+// SplitExprs splits a comma-separated list of expressions (e.g., the default
+// values of function arguments) into its elements. Commas that appear inside
+// quotes (including backticks), parentheses or brackets are ignored.
+func SplitExprs(s string) []string {
+	var (
+		xs    []string
+		depth int
+		quote byte
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case c == ',' && depth == 0:
+			xs = append(xs, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if x := strings.TrimSpace(s[start:]); x != "" || len(xs) > 0 {
+		xs = append(xs, x)
+	}
+	return xs
+}
+
 // MayWrap ensures the given string is wrapped with parentheses.
 // Used by the different drivers to turn strings valid expressions.
 func MayWrap(s string) string {
@@ -693,9 +745,317 @@ func (*Diff) fixRenames(changes schema.Changes) schema.Changes {
 	return changes // unimplemented.
 }
 
+// roleDependsOn reports if the given change depends on the other change, where one
+// of them is a creation, modification or deletion of a role, a user or a permission.
+// Roles and users are created before the permissions granted to them, and dropped
+// after these permissions were revoked. Also, privileges are granted after their
+// resources were created, and revoked before they are dropped.
+func roleDependsOn(c1, c2 schema.Change) bool {
+	switch c1 := c1.(type) {
+	case *schema.AddObject:
+		return roleObjectDependsOn(c1.O, c2)
+	case *schema.ModifyObject:
+		return roleObjectDependsOn(c1.To, c2)
+	case *schema.DropObject:
+		switch c1.O.(type) {
+		case *schema.Role, *schema.User:
+			return revokeFrom(c2, func(p *schema.Permission) bool { return SameGrantee(p.To, c1.O) })
+		}
+	case *schema.DropTable:
+		return revokeFrom(c2, func(p *schema.Permission) bool { return SameTable(p.Table, c1.T) })
+	case *schema.ModifyTable:
+		// Column privileges are revoked before their columns are dropped.
+		return revokeFrom(c2, func(p *schema.Permission) bool {
+			return SameTable(p.Table, c1.T) && slices.ContainsFunc(c1.Changes, func(c schema.Change) bool {
+				d, ok := c.(*schema.DropColumn)
+				return ok && slices.ContainsFunc(p.Columns, func(pc *schema.Column) bool { return pc.Name == d.C.Name })
+			})
+		})
+	case *schema.DropSchema:
+		return revokeFrom(c2, func(p *schema.Permission) bool { return SameSchema(p.Schema, c1.S) })
+	}
+	return false
+}
+
+// roleObjectDependsOn reports if the creation or the modification of the given
+// role, user or permission depends on the other change.
+func roleObjectDependsOn(o schema.Object, c schema.Change) bool {
+	var memberOf []*schema.Role
+	switch o := o.(type) {
+	case *schema.Role:
+		memberOf = o.MemberOf
+	case *schema.User:
+		memberOf = o.MemberOf
+	case *schema.Permission:
+		switch c := c.(type) {
+		case *schema.AddObject:
+			return SameGrantee(o.To, c.O)
+		case *schema.ModifyObject:
+			return SameGrantee(o.To, c.To)
+		case *schema.AddSchema:
+			return SameSchema(o.Schema, c.S)
+		case *schema.AddTable:
+			return o.Table != nil && SameTable(o.Table, c.T)
+		case *schema.ModifyTable:
+			return o.Table != nil && SameTable(o.Table, c.T)
+		}
+		return false
+	default:
+		return false
+	}
+	add, ok := c.(*schema.AddObject)
+	return ok && slices.ContainsFunc(memberOf, func(r *schema.Role) bool {
+		return SameGrantee(r, add.O)
+	})
+}
+
+// revokeFrom reports if the given change is a revocation of a permission that matches f.
+func revokeFrom(c schema.Change, f func(*schema.Permission) bool) bool {
+	d, ok := c.(*schema.DropObject)
+	if !ok {
+		return false
+	}
+	p, ok := d.O.(*schema.Permission)
+	return ok && f(p)
+}
+
+// funcDependsOn reports if the given change depends on the other change,
+// where one of them is a creation, modification or deletion of a function
+// or a procedure. Functions are created before the views, defaults and checks
+// that call them, and dropped after them.
+func funcDependsOn(c1, c2 schema.Change) bool {
+	switch c1 := c1.(type) {
+	case *schema.AddObject:
+		var deps []schema.Object
+		switch o := c1.O.(type) {
+		case *schema.Func:
+			deps = o.Deps
+			if slices.ContainsFunc(append(argTypes(o.Args), o.Ret), func(t schema.Type) bool {
+				return addType(c2, t)
+			}) {
+				return true
+			}
+		case *schema.Proc:
+			deps = o.Deps
+			if slices.ContainsFunc(argTypes(o.Args), func(t schema.Type) bool {
+				return addType(c2, t)
+			}) {
+				return true
+			}
+		default:
+			return false
+		}
+		if s, ok := c2.(*schema.AddSchema); ok {
+			return SameSchema(objectSchema(c1.O), s.S)
+		}
+		return depOfAdd(deps, c2)
+	case *schema.DropObject:
+		f, ok := c1.O.(*schema.Func)
+		if !ok {
+			return false
+		}
+		switch c2 := c2.(type) {
+		case *schema.DropTable:
+			return tableCalls(c2.T, f)
+		case *schema.ModifyTable:
+			return slices.ContainsFunc(c2.Changes, func(c schema.Change) bool {
+				switch c := c.(type) {
+				case *schema.DropColumn:
+					return columnCalls(c.C, f)
+				case *schema.ModifyColumn:
+					return columnCalls(c.From, f)
+				case *schema.DropCheck:
+					return callsFunc(c.C.Expr, f)
+				case *schema.ModifyCheck:
+					return callsFunc(c.From.Expr, f)
+				}
+				return false
+			})
+		case *schema.DropView:
+			return callsFunc(c2.V.Def, f)
+		case *schema.ModifyView:
+			return callsFunc(c2.From.Def, f)
+		case *schema.DropTrigger:
+			return triggerCalls(c2.T, f)
+		case *schema.ModifyTrigger:
+			return triggerCalls(c2.From, f)
+		}
+	case *schema.AddTable:
+		if f, ok := changedFunc(c2); ok {
+			return tableCalls(c1.T, f)
+		}
+	case *schema.ModifyTable:
+		if f, ok := changedFunc(c2); ok {
+			return slices.ContainsFunc(c1.Changes, func(c schema.Change) bool {
+				switch c := c.(type) {
+				case *schema.AddColumn:
+					return columnCalls(c.C, f)
+				case *schema.ModifyColumn:
+					return columnCalls(c.To, f)
+				case *schema.AddCheck:
+					return callsFunc(c.C.Expr, f)
+				case *schema.ModifyCheck:
+					return callsFunc(c.To.Expr, f)
+				case *schema.AddIndex:
+					return indexCalls(c.I, f)
+				}
+				return false
+			})
+		}
+	case *schema.AddView:
+		if f, ok := changedFunc(c2); ok {
+			return callsFunc(c1.V.Def, f)
+		}
+	case *schema.ModifyView:
+		if f, ok := changedFunc(c2); ok {
+			return callsFunc(c1.To.Def, f)
+		}
+	case *schema.AddTrigger:
+		if f, ok := changedFunc(c2); ok {
+			return triggerCalls(c1.T, f)
+		}
+	case *schema.ModifyTrigger:
+		if f, ok := changedFunc(c2); ok {
+			return triggerCalls(c1.To, f)
+		}
+	}
+	return false
+}
+
+// triggerCalls reports if the trigger executes (or calls) the given function.
+func triggerCalls(t *schema.Trigger, f *schema.Func) bool {
+	if slices.Contains(t.Deps, schema.Object(f)) {
+		return true
+	}
+	var w schema.TriggerWhen
+	return callsFunc(t.Body, f) || Has(t.Attrs, &w) && callsFunc(w.X, f)
+}
+
+// changedFunc returns the function that is created or modified by the given change.
+func changedFunc(c schema.Change) (*schema.Func, bool) {
+	switch c := c.(type) {
+	case *schema.AddObject:
+		f, ok := c.O.(*schema.Func)
+		return f, ok
+	case *schema.ModifyObject:
+		f, ok := c.To.(*schema.Func)
+		return f, ok
+	}
+	return nil, false
+}
+
+// addType reports if the given change creates the type t.
+func addType(c schema.Change, t schema.Type) bool {
+	add, ok := c.(*schema.AddObject)
+	if !ok || t == nil {
+		return false
+	}
+	t1, ok := add.O.(schema.Type)
+	return ok && schema.IsType(t, t1)
+}
+
+// argTypes returns the types of the given arguments.
+func argTypes(args []*schema.FuncArg) []schema.Type {
+	ts := make([]schema.Type, 0, len(args))
+	for _, a := range args {
+		ts = append(ts, a.Type)
+	}
+	return ts
+}
+
+// objectSchema returns the schema of the given function or procedure.
+func objectSchema(o schema.Object) *schema.Schema {
+	switch o := o.(type) {
+	case *schema.Func:
+		return o.Schema
+	case *schema.Proc:
+		return o.Schema
+	}
+	return nil
+}
+
+// tableCalls reports if one of the table expressions calls the given function.
+func tableCalls(t *schema.Table, f *schema.Func) bool {
+	return slices.ContainsFunc(t.Columns, func(c *schema.Column) bool {
+		return columnCalls(c, f)
+	}) || slices.ContainsFunc(t.Checks(), func(c *schema.Check) bool {
+		return callsFunc(c.Expr, f)
+	}) || slices.ContainsFunc(t.Indexes, func(idx *schema.Index) bool {
+		return indexCalls(idx, f)
+	})
+}
+
+// columnCalls reports if the column default or generated expression calls the given function.
+func columnCalls(c *schema.Column, f *schema.Func) bool {
+	if x, ok := schema.UnderlyingExpr(c.Default).(*schema.RawExpr); ok && callsFunc(x.X, f) {
+		return true
+	}
+	var x schema.GeneratedExpr
+	return Has(c.Attrs, &x) && callsFunc(x.Expr, f)
+}
+
+// indexCalls reports if one of the index expressions calls the given function.
+func indexCalls(idx *schema.Index, f *schema.Func) bool {
+	return slices.ContainsFunc(idx.Parts, func(p *schema.IndexPart) bool {
+		x, ok := p.X.(*schema.RawExpr)
+		return ok && callsFunc(x.X, f)
+	})
+}
+
+// callsFunc reports if the given expression (or definition) calls the given function.
+// Note, the check is textual, as expressions and definitions are not parsed by Atlas.
+func callsFunc(x string, f *schema.Func) bool {
+	if x == "" || f == nil || !strings.Contains(strings.ToLower(x), strings.ToLower(f.Name)) {
+		return false
+	}
+	return regexp.MustCompile("(?i)(?:^|[^\\w$])" + regexp.QuoteMeta(f.Name) + "[\"`]?\\s*\\(").MatchString(x)
+}
+
+// triggerOn reports if the given change creates or modifies the table
+// or the view the trigger is defined on, or one of its dependencies.
+func triggerOn(t *schema.Trigger, c schema.Change) bool {
+	switch c := c.(type) {
+	case *schema.AddSchema:
+		return SameSchema(triggerSchema(t), c.S)
+	case *schema.AddTable:
+		return t.Table != nil && SameTable(t.Table, c.T)
+	case *schema.ModifyTable:
+		return t.Table != nil && SameTable(t.Table, c.T)
+	case *schema.AddView:
+		return t.View != nil && SameView(t.View, c.V)
+	case *schema.ModifyView:
+		return t.View != nil && SameView(t.View, c.To)
+	}
+	return depOfAdd(t.Deps, c)
+}
+
+// triggerSchema returns the schema of the table or the view the trigger is defined on.
+func triggerSchema(t *schema.Trigger) *schema.Schema {
+	switch {
+	case t.Table != nil:
+		return t.Table.Schema
+	case t.View != nil:
+		return t.View.Schema
+	}
+	return nil
+}
+
+// tableOf reports if the given change creates or modifies a table in the
+// given schema. Views with unknown dependencies (e.g., SQLite views, or HCL
+// views without the "depends_on" attribute) are planned after these tables.
+func tableOf(c schema.Change, s *schema.Schema) bool {
+	switch c := c.(type) {
+	case *schema.AddTable:
+		return SameSchema(c.T.Schema, s)
+	case *schema.ModifyTable:
+		return SameSchema(c.T.Schema, s)
+	}
+	return false
+}
+
 // dependsOn reports if the given change depends on the other change.
 func dependsOn(c1, c2 schema.Change, _ SortOptions) bool {
-	if dependOnOf(c1, c2) {
+	if dependOnOf(c1, c2) || funcDependsOn(c1, c2) || roleDependsOn(c1, c2) {
 		return true
 	}
 	switch c1 := c1.(type) {
@@ -711,6 +1071,8 @@ func dependsOn(c1, c2 schema.Change, _ SortOptions) bool {
 				fk, ok := c.(*schema.DropForeignKey)
 				return ok && SameSchema(c1.S, fk.F.RefTable.Schema)
 			})
+		case *schema.DropView:
+			return SameSchema(c1.S, c2.V.Schema)
 		}
 	case *schema.AddTable:
 		switch c2 := c2.(type) {
@@ -768,7 +1130,39 @@ func dependsOn(c1, c2 schema.Change, _ SortOptions) bool {
 				return true
 			}
 		}
+		if d, ok := c2.(*schema.DropView); ok && len(d.V.Deps) == 0 && SameSchema(c1.T.Schema, d.V.Schema) {
+			return true
+		}
 		return depOfDrop(c1.T, c2)
+	case *schema.AddView:
+		switch c2 := c2.(type) {
+		case *schema.AddSchema:
+			return c1.V.Schema != nil && c1.V.Schema.Name == c2.S.Name
+		case *schema.DropView:
+			// View recreation.
+			return SameView(c1.V, c2.V)
+		case *schema.DropTable:
+			// Table was replaced by a view.
+			return c1.V.Name == c2.T.Name && SameSchema(c1.V.Schema, c2.T.Schema)
+		}
+		return depOfAdd(c1.V.Deps, c2) || len(c1.V.Deps) == 0 && tableOf(c2, c1.V.Schema)
+	case *schema.ModifyView:
+		return depOfAdd(c1.To.Deps, c2) || len(c1.To.Deps) == 0 && tableOf(c2, c1.To.Schema)
+	case *schema.AddTrigger:
+		switch c2 := c2.(type) {
+		case *schema.DropTrigger:
+			// Trigger recreation.
+			return c1.T.Name == c2.T.Name
+		default:
+			return triggerOn(c1.T, c2)
+		}
+	case *schema.ModifyTrigger:
+		return triggerOn(c1.To, c2)
+	case *schema.DropView:
+		// Views must be dropped after all views that rely on them.
+		if d, ok := c2.(*schema.DropView); ok {
+			return depOfDrop(c1.V, d)
+		}
 	case *schema.ModifyTable:
 		switch c2 := c2.(type) {
 		case *schema.AddTable:
@@ -790,6 +1184,16 @@ func dependsOn(c1, c2 schema.Change, _ SortOptions) bool {
 			}) {
 				return true
 			}
+		case *schema.DropTrigger:
+			// Triggers are dropped before their tables are modified,
+			// as they might rely on columns that are being dropped.
+			return c2.T.Table != nil && SameTable(c2.T.Table, c1.T)
+		case *schema.DropView:
+			// Views that rely on the table are dropped before it is modified.
+			return slices.ContainsFunc(c2.V.Deps, func(o schema.Object) bool {
+				t, ok := o.(*schema.Table)
+				return ok && SameTable(t, c1.T)
+			})
 		case *schema.ModifyTable:
 			if c1.T != c2.T {
 				addC := make(map[*schema.Column]bool)
@@ -155,6 +155,25 @@ func TestMayWrap(t *testing.T) {
 	}
 }
 
+func TestSplitExprs(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect []string
+	}{
+		{"", nil},
+		{"1", []string{"1"}},
+		{"1, 'a'::text", []string{"1", "'a'::text"}},
+		{"'a,b', f(1, 2), ARRAY[1, 2]", []string{"'a,b'", "f(1, 2)", "ARRAY[1, 2]"}},
+		{`"a,b", 'it''s, ok'`, []string{`"a,b"`, `'it''s, ok'`}},
+		{"`a,b` int, `c` int", []string{"`a,b` int", "`c` int"}},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			require.Equal(t, tt.expect, SplitExprs(tt.input))
+		})
+	}
+}
+
 func TestExprLastIndex(t *testing.T) {
 	tests := []struct {
 		input   string
@@ -8,9 +8,11 @@ import (
 	"encoding/hex"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 
 	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/schema"
@@ -64,14 +66,128 @@ func (d *diff) SchemaAttrDiff(from, to *schema.Schema) []schema.Change {
 
 // RealmObjectDiff returns a changeset for migrating realm (database) objects
 // from one state to the other. For example, adding extensions or users.
-func (*diff) RealmObjectDiff(_, _ *schema.Realm) ([]schema.Change, error) {
-	return nil, nil
+func (*diff) RealmObjectDiff(from, to *schema.Realm) ([]schema.Change, error) {
+	return sqlx.RolesDiff(from, to, &sqlx.RoleDiffFuncs{Privileges: permissionPrivileges}), nil
 }
 
 // SchemaObjectDiff returns a changeset for migrating schema objects from
 // one state to the other.
-func (*diff) SchemaObjectDiff(_, _ *schema.Schema, _ *schema.DiffOptions) ([]schema.Change, error) {
-	return nil, nil
+func (*diff) SchemaObjectDiff(from, to *schema.Schema, _ *schema.DiffOptions) ([]schema.Change, error) {
+	var changes []schema.Change
+	// Drop or modify functions and procedures.
+	for _, o1 := range from.Objects {
+		k1, ok := routineKey(o1)
+		if !ok {
+			continue // Unsupported object type.
+		}
+		o2, ok := to.Object(func(o schema.Object) bool {
+			k2, ok := routineKey(o)
+			return ok && k1 == k2
+		})
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: o1})
+			continue
+		}
+		changed, err := routineChanged(o1, o2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+		}
+	}
+	// Add new functions and procedures.
+	for _, o1 := range to.Objects {
+		k1, ok := routineKey(o1)
+		if !ok {
+			continue // Unsupported object type.
+		}
+		if _, ok := from.Object(func(o schema.Object) bool {
+			k2, ok := routineKey(o)
+			return ok && k1 == k2
+		}); !ok {
+			changes = append(changes, &schema.AddObject{O: o1})
+		}
+	}
+	return changes, nil
+}
+
+// routineKey returns the identifier of a function or a procedure. Unlike PostgreSQL,
+// MySQL does not support overloading, and routines are identified by their names.
+func routineKey(o schema.Object) (string, bool) {
+	switch o := o.(type) {
+	case *schema.Func:
+		return "function/" + o.Name, true
+	case *schema.Proc:
+		return "procedure/" + o.Name, true
+	}
+	return "", false
+}
+
+// routineChanged reports if the function or procedure o1 was changed to o2.
+func routineChanged(o1, o2 schema.Object) (bool, error) {
+	var (
+		args1, args2   []*schema.FuncArg
+		attrs1, attrs2 []schema.Attr
+		body1, body2   string
+	)
+	switch f1 := o1.(type) {
+	case *schema.Func:
+		f2 := o2.(*schema.Func)
+		if f1.Ret == nil || f2.Ret == nil {
+			if f1.Ret != f2.Ret {
+				return true, nil
+			}
+		} else if changed, err := funcTypeChanged(f1.Ret, f2.Ret); changed || err != nil {
+			return changed, err
+		}
+		if deterministic(f1.Attrs) != deterministic(f2.Attrs) {
+			return true, nil
+		}
+		args1, args2, attrs1, attrs2, body1, body2 = f1.Args, f2.Args, f1.Attrs, f2.Attrs, f1.Body, f2.Body
+	case *schema.Proc:
+		p2 := o2.(*schema.Proc)
+		args1, args2, attrs1, attrs2, body1, body2 = f1.Args, p2.Args, f1.Attrs, p2.Attrs, f1.Body, p2.Body
+	}
+	if len(args1) != len(args2) {
+		return true, nil
+	}
+	for i := range args1 {
+		if args1[i].Name != args2[i].Name || argMode(args1[i]) != argMode(args2[i]) {
+			return true, nil
+		}
+		if changed, err := funcTypeChanged(args1[i].Type, args2[i].Type); changed || err != nil {
+			return changed, err
+		}
+	}
+	return sqlx.BodyDefChanged(body1, body2) || sqlx.CommentChange(attrs1, attrs2) != schema.NoChange, nil
+}
+
+// funcTypeChanged reports if the argument or the return type of a routine was changed.
+func funcTypeChanged(t1, t2 schema.Type) (bool, error) {
+	f1, err := FormatType(t1)
+	if err != nil {
+		return false, err
+	}
+	f2, err := FormatType(t2)
+	if err != nil {
+		return false, err
+	}
+	return f1 != f2, nil
+}
+
+// argMode returns the mode of the argument, defaults to IN.
+func argMode(a *schema.FuncArg) schema.FuncArgMode {
+	if a.Mode == "" {
+		return schema.FuncArgModeIn
+	}
+	return schema.FuncArgMode(strings.ToUpper(string(a.Mode)))
+}
+
+// deterministic reports if the function was defined as DETERMINISTIC.
+func deterministic(attrs []schema.Attr) bool {
+	v := schema.FuncVolatility{}
+	return sqlx.Has(attrs, &v) && strings.EqualFold(v.V, Deterministic)
 }
 
 // TableAttrDiff returns a changeset for migrating table attributes from one state to the other.
@@ -95,6 +211,7 @@ func (d *diff) TableAttrDiff(from, to *schema.Table, opts *schema.DiffOptions) (
 	if change := d.systemVerChange(from.Attrs, to.Attrs); change != noChange {
 		changes = append(changes, change)
 	}
+	changes = append(changes, partitionChanges(from, to)...)
 	if !d.SupportsCheck() && sqlx.Has(to.Attrs, &schema.Check{}) {
 		return nil, fmt.Errorf("version %q does not support CHECK constraints", d.V)
 	}
@@ -465,6 +582,171 @@ func (*diff) autoIncChange(from, to []schema.Attr) schema.Change {
 	return noChange
 }
 
+// partitionChanges returns the changes for migrating the partitioning of a table.
+// Partitions of RANGE and LIST tables are added, dropped or reorganized in place,
+// while other changes require repartitioning the table.
+func partitionChanges(from, to *schema.Table) []schema.Change {
+	fromP, toP := tablePartition(from), tablePartition(to)
+	switch {
+	case fromP == nil && toP == nil:
+		return nil
+	case fromP == nil:
+		return []schema.Change{&schema.AddAttr{A: toP}}
+	case toP == nil:
+		return []schema.Change{&schema.DropAttr{A: fromP}}
+	case partitionKey(fromP) != partitionKey(toP):
+		return []schema.Change{&schema.ModifyAttr{From: fromP, To: toP}}
+	case fromP.hashed():
+		if !slices.Equal(hashNames(fromP), hashNames(toP)) {
+			return []schema.Change{&schema.ModifyAttr{From: fromP, To: toP}}
+		}
+		return nil
+	}
+	// Common partitions must keep their order, as partitions
+	// cannot be reordered without repartitioning the table.
+	var common [2][]string
+	for i, p := range []*Partition{fromP, toP} {
+		other := []*Partition{toP, fromP}[i]
+		for _, d := range p.Defs {
+			if other.def(d.Name) != nil {
+				common[i] = append(common[i], d.Name)
+			}
+		}
+	}
+	if !slices.Equal(common[0], common[1]) {
+		return []schema.Change{&schema.ModifyAttr{From: fromP, To: toP}}
+	}
+	var (
+		changes   []schema.Change
+		add, drop []*PartitionDef
+		// New RANGE partitions that precede an existing partition.
+		pending []*PartitionDef
+		ranged  = strings.HasPrefix(strings.ToUpper(toP.T), PartitionTypeRange)
+	)
+	for _, d := range fromP.Defs {
+		if toP.def(d.Name) == nil {
+			drop = append(drop, d)
+		}
+	}
+	if len(drop) > 0 {
+		changes = append(changes, &DropPartition{Defs: drop})
+	}
+	for _, d2 := range toP.Defs {
+		switch d1 := fromP.def(d2.Name); {
+		case d1 == nil && ranged:
+			pending = append(pending, d2)
+		case d1 == nil:
+			add = append(add, d2)
+		// RANGE partitions can be added only after the last partition.
+		// Hence, new partitions in between are created by splitting the
+		// partition that follows them.
+		case len(pending) > 0:
+			changes = append(changes, &ReorganizePartition{From: []*PartitionDef{d1}, To: append(pending, d2)})
+			pending = nil
+		case normalizeValues(d1.Values) != normalizeValues(d2.Values):
+			changes = append(changes, &ReorganizePartition{From: []*PartitionDef{d1}, To: []*PartitionDef{d2}})
+		}
+	}
+	if add = append(add, pending...); len(add) > 0 {
+		changes = append(changes, &AddPartition{Defs: add})
+	}
+	return changes
+}
+
+// tablePartition returns the Partition attribute of the table, if exists.
+func tablePartition(t *schema.Table) *Partition {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*Partition); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// def returns the partition definition with the given name, if exists.
+func (p *Partition) def(name string) *PartitionDef {
+	for _, d := range p.Defs {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
+}
+
+// hashed reports if the partitioning is of type HASH or KEY.
+func (p *Partition) hashed() bool {
+	t := strings.ToUpper(p.T)
+	return strings.HasSuffix(t, PartitionTypeHash) || strings.HasSuffix(t, PartitionTypeKey)
+}
+
+// partitionKey returns the partitioning type and key in a normalized form for comparison.
+func partitionKey(p *Partition) string {
+	parts := make([]string, len(p.Parts))
+	for i, k := range p.Parts {
+		switch {
+		case k.C != nil:
+			parts[i] = strings.ToLower(k.C.Name)
+		case k.X != nil:
+			if x, ok := k.X.(*schema.RawExpr); ok {
+				parts[i] = strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(x.X, "`", "")), ""))
+			}
+		}
+	}
+	return strings.Join(strings.Fields(strings.ToUpper(p.T)), " ") + "(" + strings.Join(parts, ",") + ")"
+}
+
+// hashNames returns the names of the HASH or KEY partitions.
+func hashNames(p *Partition) []string {
+	if len(p.Defs) > 0 {
+		return partitionNames(p.Defs)
+	}
+	return defaultHashNames(max(p.Count, 1))
+}
+
+// defaultHashNames returns the names given by the database
+// to the partitions created using the PARTITIONS clause.
+func defaultHashNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = "p" + strconv.Itoa(i)
+	}
+	return names
+}
+
+// partitionNames returns the names of the given partitions.
+func partitionNames(defs []*PartitionDef) []string {
+	names := make([]string, len(defs))
+	for i, d := range defs {
+		names[i] = d.Name
+	}
+	return names
+}
+
+// normalizeValues returns the VALUES clause of a partition in a normalized form for
+// comparison. Whitespace is removed and keywords are uppercased, except in literals.
+func normalizeValues(v string) string {
+	var (
+		b     strings.Builder
+		quote rune
+	)
+	for _, r := range v {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case unicode.IsSpace(r):
+			continue
+		default:
+			r = unicode.ToUpper(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
 // indexType returns the index type from its attribute.
 // The default type is BTREE if no type was specified.
 func indexType(attr []schema.Attr) *IndexType {
@@ -733,3 +1015,48 @@ func (d *diff) defaultCharset(attrs *[]schema.Attr) error {
 	return nil
 }
 
+
+// permissionPrivileges returns the sorted privileges of the permission,
+// with ALL expanded to the privileges it grants on the resource.
+func permissionPrivileges(p *schema.Permission) []string {
+	var privs []string
+	for _, pv := range sqlx.Privileges(p) {
+		if pv == PrivilegeAll || pv == "ALL PRIVILEGES" {
+			privs = append(privs, allPrivileges(p)...)
+		} else {
+			privs = append(privs, pv)
+		}
+	}
+	slices.Sort(privs)
+	return slices.Compact(privs)
+}
+
+// allPrivileges returns the sorted privileges ALL grants on the permission resource.
+// Note, the GRANT OPTION is not included, as it is represented by the Grantable field.
+func allPrivileges(p *schema.Permission) []string {
+	switch {
+	case len(p.Columns) > 0:
+		return []string{PrivilegeInsert, PrivilegeReferences, PrivilegeSelect, PrivilegeUpdate}
+	case p.Table != nil:
+		return []string{
+			PrivilegeAlter, PrivilegeCreate, PrivilegeCreateView, PrivilegeDelete, PrivilegeDrop, PrivilegeIndex,
+			PrivilegeInsert, PrivilegeReferences, PrivilegeSelect, PrivilegeShowView, PrivilegeTrigger, PrivilegeUpdate,
+		}
+	default:
+		return []string{
+			PrivilegeAlter, PrivilegeAlterRoutine, PrivilegeCreate, PrivilegeCreateRoutine, PrivilegeCreateTempTables,
+			PrivilegeCreateView, PrivilegeDelete, PrivilegeDrop, PrivilegeEvent, PrivilegeExecute, PrivilegeIndex,
+			PrivilegeInsert, PrivilegeLockTables, PrivilegeReferences, PrivilegeSelect, PrivilegeShowView,
+			PrivilegeTrigger, PrivilegeUpdate,
+		}
+	}
+}
+
+// privilegesOrAll returns the sorted privileges of the permission,
+// or ALL if all privileges were granted on its resource.
+func privilegesOrAll(p *schema.Permission) []string {
+	if privs := sqlx.Privileges(p); !slices.Equal(privs, allPrivileges(p)) {
+		return privs
+	}
+	return []string{PrivilegeAll}
+}
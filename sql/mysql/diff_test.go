@@ -596,6 +596,164 @@ func TestDiff_TableDiff(t *testing.T) {
 				},
 			}
 		}(),
+		func() testcase {
+			var (
+				c    = schema.NewIntColumn("created", "int")
+				from = schema.NewTable("logs").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeRange,
+					Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created`)"}}},
+					Defs: []*PartitionDef{
+						{Name: "p2020", Values: "LESS THAN (2021)"},
+						{Name: "p2021", Values: "LESS THAN (2022)"},
+						{Name: "p2022", Values: "LESS THAN (2023)"},
+					},
+				})
+				to = schema.NewTable("logs").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     "range",
+					Parts: []*PartitionPart{{X: &schema.RawExpr{X: "YEAR(created)"}}},
+					Defs: []*PartitionDef{
+						{Name: "p2019", Values: "LESS THAN (2020)"},
+						{Name: "p2020", Values: "less than(2021)"},
+						{Name: "p2022", Values: "LESS THAN (2024)"},
+						{Name: "p2024", Values: "LESS THAN (2025)"},
+					},
+				})
+			)
+			return testcase{
+				name: "range partitions",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&DropPartition{Defs: []*PartitionDef{{Name: "p2021", Values: "LESS THAN (2022)"}}},
+					&ReorganizePartition{
+						From: []*PartitionDef{{Name: "p2020", Values: "LESS THAN (2021)"}},
+						To:   []*PartitionDef{{Name: "p2019", Values: "LESS THAN (2020)"}, {Name: "p2020", Values: "less than(2021)"}},
+					},
+					&ReorganizePartition{
+						From: []*PartitionDef{{Name: "p2022", Values: "LESS THAN (2023)"}},
+						To:   []*PartitionDef{{Name: "p2022", Values: "LESS THAN (2024)"}},
+					},
+					&AddPartition{Defs: []*PartitionDef{{Name: "p2024", Values: "LESS THAN (2025)"}}},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = schema.NewIntColumn("region", "int")
+				from = schema.NewTable("events").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeList,
+					Parts: []*PartitionPart{{C: c}},
+					Defs:  []*PartitionDef{{Name: "eu", Values: "IN (1,2)"}, {Name: "us", Values: "IN (3)"}},
+				})
+				to = schema.NewTable("events").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeList,
+					Parts: []*PartitionPart{{X: &schema.RawExpr{X: "`region`"}}},
+					Defs:  []*PartitionDef{{Name: "eu", Values: "IN (1, 2)"}, {Name: "asia", Values: "IN (4)"}, {Name: "us", Values: "IN (3)"}},
+				})
+			)
+			return testcase{
+				name: "list partitions",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&AddPartition{Defs: []*PartitionDef{{Name: "asia", Values: "IN (4)"}}},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = schema.NewIntColumn("region", "int")
+				from = schema.NewTable("events").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeList,
+					Parts: []*PartitionPart{{C: c}},
+					Defs:  []*PartitionDef{{Name: "eu", Values: "IN (1,2)"}, {Name: "us", Values: "IN (3)"}},
+				})
+				to = schema.NewTable("events").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeList,
+					Parts: []*PartitionPart{{C: c}},
+					Defs:  []*PartitionDef{{Name: "us", Values: "IN (3)"}, {Name: "eu", Values: "IN (1,2)"}},
+				})
+			)
+			return testcase{
+				name: "reordered partitions",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: tablePartition(from), To: tablePartition(to)},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = schema.NewIntColumn("id", "int")
+				from = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeHash,
+					Parts: []*PartitionPart{{C: c}},
+					Count: 2,
+				})
+				to = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeHash,
+					Parts: []*PartitionPart{{C: c}},
+					Defs:  []*PartitionDef{{Name: "p0"}, {Name: "p1"}},
+				})
+			)
+			return testcase{
+				name: "no hash partition changes",
+				from: from,
+				to:   to,
+			}
+		}(),
+		func() testcase {
+			var (
+				c    = schema.NewIntColumn("id", "int")
+				from = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeHash,
+					Parts: []*PartitionPart{{C: c}},
+					Count: 2,
+				})
+				to = schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(&Partition{
+					T:     PartitionTypeHash,
+					Parts: []*PartitionPart{{C: c}},
+					Count: 4,
+				})
+			)
+			return testcase{
+				name: "hash partitions count",
+				from: from,
+				to:   to,
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: tablePartition(from), To: tablePartition(to)},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c = schema.NewIntColumn("id", "int")
+				p = &Partition{T: PartitionTypeKey, Count: 4}
+			)
+			return testcase{
+				name: "add partitioning",
+				from: schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c),
+				to:   schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(p),
+				wantChanges: []schema.Change{
+					&schema.AddAttr{A: p},
+				},
+			}
+		}(),
+		func() testcase {
+			var (
+				c = schema.NewIntColumn("id", "int")
+				p = &Partition{T: PartitionTypeKey, Count: 4}
+			)
+			return testcase{
+				name: "remove partitioning",
+				from: schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c).AddAttrs(p),
+				to:   schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(c),
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: p},
+				},
+			}
+		}(),
 	}
 	for _, tt := range tests {
 		db, m, err := sqlmock.New()
@@ -675,6 +833,25 @@ func TestDiff_SchemaDiff(t *testing.T) {
 		&schema.DropTable{T: from.Tables[1]},
 		&schema.AddTable{T: to.Tables[1]},
 	}, changes)
+
+	intT := &schema.IntegerType{T: TypeInt}
+	from = schema.New("public").AddObjects(
+		schema.NewFunc("f1", "RETURN a").AddArgs(&schema.FuncArg{Name: "a", Type: intT}).SetReturn(intT),
+		schema.NewFunc("f2", "RETURN 1").SetReturn(intT),
+		schema.NewProc("p1", "BEGIN END"),
+	)
+	to = schema.New("public").AddObjects(
+		schema.NewFunc("f1", " RETURN a ").AddArgs(&schema.FuncArg{Name: "a", Type: intT}).SetReturn(intT),
+		schema.NewFunc("f2", "RETURN 1").SetReturn(intT).SetVolatility(Deterministic),
+		schema.NewFunc("p1", "RETURN 1").SetReturn(intT),
+	)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
 }
 
 func TestDiff_LowerCaseMode(t *testing.T) {
@@ -790,6 +967,28 @@ func TestDiff_RealmDiff(t *testing.T) {
 		&schema.AddSchema{S: to.Schemas[1]},
 		&schema.AddTable{T: to.Schemas[1].Tables[0]},
 	}, changes)
+
+	// Users are compared by their names and hosts, and ALL
+	// is equal to the privileges it grants on the resource.
+	fromU, toU := &schema.User{Name: "app", Host: "%"}, &schema.User{Name: "app", Host: "%"}
+	from = schema.NewRealm(schema.New("public"))
+	from.AddObjects(
+		fromU,
+		&schema.User{Name: "app", Host: "localhost"},
+		&schema.Permission{To: fromU, Schema: from.Schemas[0], Privileges: allPrivileges(&schema.Permission{})},
+	)
+	to = schema.NewRealm(schema.New("public"))
+	to.AddObjects(
+		toU,
+		&schema.Permission{To: toU, Schema: to.Schemas[0], Privileges: []string{PrivilegeAll}},
+		&schema.Role{Name: "reader"},
+	)
+	changes, err = drv.RealmDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.DropObject{O: from.Objects[1]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
 }
 
 func TestDefaultDiff(t *testing.T) {
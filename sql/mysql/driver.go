@@ -470,6 +470,39 @@ const (
 	EngineCSV    = "CSV"
 	EngineNDB    = "NDB" // NDBCLUSTER
 
+	PartitionTypeRange        = "RANGE"
+	PartitionTypeRangeColumns = "RANGE COLUMNS"
+	PartitionTypeList         = "LIST"
+	PartitionTypeListColumns  = "LIST COLUMNS"
+	PartitionTypeHash         = "HASH"
+	PartitionTypeLinearHash   = "LINEAR HASH"
+	PartitionTypeKey          = "KEY"
+	PartitionTypeLinearKey    = "LINEAR KEY"
+
+	PrivilegeAll              = "ALL"
+	PrivilegeAlter            = "ALTER"
+	PrivilegeAlterRoutine     = "ALTER ROUTINE"
+	PrivilegeCreate           = "CREATE"
+	PrivilegeCreateRoutine    = "CREATE ROUTINE"
+	PrivilegeCreateTempTables = "CREATE TEMPORARY TABLES"
+	PrivilegeCreateView       = "CREATE VIEW"
+	PrivilegeDelete           = "DELETE"
+	PrivilegeDrop             = "DROP"
+	PrivilegeEvent            = "EVENT"
+	PrivilegeExecute          = "EXECUTE"
+	PrivilegeIndex            = "INDEX"
+	PrivilegeInsert           = "INSERT"
+	PrivilegeLockTables       = "LOCK TABLES"
+	PrivilegeReferences       = "REFERENCES"
+	PrivilegeSelect           = "SELECT"
+	PrivilegeShowView         = "SHOW VIEW"
+	PrivilegeTrigger          = "TRIGGER"
+	PrivilegeUpdate           = "UPDATE"
+
+	// Deterministic is the volatility of functions that
+	// always produce the same result for the same input.
+	Deterministic = "DETERMINISTIC"
+
 	currentTS     = "current_timestamp"
 	defaultGen    = "default_generated"
 	autoIncrement = "auto_increment"
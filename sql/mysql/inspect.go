@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -42,6 +43,29 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 			}
 			sqlx.LinkSchemaTables(schemas)
 		}
+		if mode.Is(schema.InspectViews) {
+			if err := i.inspectViews(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectFuncs) {
+			if err := i.inspectFuncs(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectTriggers) {
+			if err := i.inspectTriggers(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if mode.Is(schema.InspectRoles) && i.SupportsRoles() {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if r, err = schema.IncludeRealm(r, opts.Include); err != nil {
+		return nil, err
 	}
 	return schema.ExcludeRealm(r, opts.Exclude)
 }
@@ -72,7 +96,26 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		}
 		sqlx.LinkSchemaTables(schemas)
 	}
-	return schema.ExcludeSchema(r.Schemas[0], opts.Exclude)
+	if mode.Is(schema.InspectViews) {
+		if err := i.inspectViews(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	s, err := schema.IncludeSchema(r.Schemas[0], opts.Include)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ExcludeSchema(s, opts.Exclude)
 }
 
 func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
@@ -95,6 +138,9 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 		if err := i.checks(ctx, s); err != nil {
 			return err
 		}
+		if err := i.partitions(ctx, s); err != nil {
+			return err
+		}
 		if err := i.showCreate(ctx, s); err != nil {
 			return err
 		}
@@ -102,6 +148,472 @@ func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *sche
 	return nil
 }
 
+// inspectViews queries the views of the given realm schemas, their columns and dependencies.
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	if err := i.views(ctx, r, opts); err != nil {
+		return err
+	}
+	for _, s := range r.Schemas {
+		if len(s.Views) == 0 {
+			continue
+		}
+		if err := i.viewColumns(ctx, s); err != nil {
+			return err
+		}
+	}
+	return i.viewDeps(ctx, r)
+}
+
+// views queries and appends the views of the given realm schemas.
+func (i *inspect) views(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	var (
+		args  []any
+		query = fmt.Sprintf(viewsQuery, nArgs(len(r.Schemas)))
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	if opts != nil && len(opts.Tables) > 0 {
+		for _, t := range opts.Tables {
+			args = append(args, t)
+		}
+		query = fmt.Sprintf(viewsQueryArgs, nArgs(len(r.Schemas)), nArgs(len(opts.Tables)))
+	}
+	rows, err := i.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, name, def, checkOpt sql.NullString
+		if err := rows.Scan(&vSchema, &name, &def, &checkOpt); err != nil {
+			return fmt.Errorf("scan view information: %w", err)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewView(name.String, def.String)
+		s.AddViews(v)
+		if sqlx.ValidString(checkOpt) && checkOpt.String != "NONE" {
+			v.SetCheckOption(checkOpt.String)
+		}
+	}
+	return rows.Err()
+}
+
+// viewColumns queries and appends the columns of the schema views.
+func (i *inspect) viewColumns(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		args = append(args, v.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(columnsQuery, nArgs(len(s.Views))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: query schema %q view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := i.addColumn(s, rows); err != nil {
+			return fmt.Errorf("mysql: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+// viewDeps links the inspected views to the tables and views they depend on. If the
+// VIEW_TABLE_USAGE table is not supported by the server, the dependencies are extracted
+// from the (fully-qualified) view definitions. Objects outside the realm are ignored.
+func (i *inspect) viewDeps(ctx context.Context, r *schema.Realm) error {
+	var args []any
+	for _, s := range r.Schemas {
+		if len(s.Views) > 0 {
+			args = append(args, s.Name)
+		}
+	}
+	usage := i.SupportsViewUsage()
+	if len(args) > 0 && usage {
+		rows, err := i.QueryContext(ctx, fmt.Sprintf(viewDepsQuery, nArgs(len(args))), args...)
+		if err != nil {
+			return fmt.Errorf("mysql: querying view dependencies: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var vSchema, vName, dSchema, dName string
+			if err := rows.Scan(&vSchema, &vName, &dSchema, &dName); err != nil {
+				return fmt.Errorf("scan view dependency: %w", err)
+			}
+			if vs, ok := r.Schema(vSchema); ok {
+				if v, ok := vs.View(vName); ok {
+					addViewDep(r, v, dSchema, dName)
+				}
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	for _, s := range r.Schemas {
+		for _, v := range s.Views {
+			if !usage {
+				for _, ds := range r.Schemas {
+					for _, t := range ds.Tables {
+						if strings.Contains(v.Def, qualified(ds.Name, t.Name)) {
+							addViewDep(r, v, ds.Name, t.Name)
+						}
+					}
+					for _, dv := range ds.Views {
+						if strings.Contains(v.Def, qualified(ds.Name, dv.Name)) {
+							addViewDep(r, v, ds.Name, dv.Name)
+						}
+					}
+				}
+			}
+			// Definitions are returned qualified with the schema name. Remove the
+			// qualifier of the view schema to make the definition schema-agnostic.
+			v.Def = strings.ReplaceAll(v.Def, "`"+s.Name+"`.", "")
+		}
+	}
+	return nil
+}
+
+// addViewDep adds the table or view with the given schema and name as a dependency of the view.
+func addViewDep(r *schema.Realm, v *schema.View, s, name string) {
+	ds, ok := r.Schema(s)
+	if !ok {
+		return
+	}
+	if t, ok := ds.Table(name); ok {
+		v.AddDeps(t)
+	} else if dv, ok := ds.View(name); ok && dv != v {
+		v.AddDeps(dv)
+	}
+}
+
+// qualified returns the qualified and quoted name of a schema object.
+func qualified(s, name string) string {
+	return "`" + s + "`.`" + name + "`"
+}
+
+// inspectFuncs queries the functions and procedures of the given realm schemas and their arguments.
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm) error {
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, len(r.Schemas))
+	for j, s := range r.Schemas {
+		args[j] = s.Name
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(funcsQuery, nArgs(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying functions: %w", err)
+	}
+	if err := i.addFuncs(r, rows); err != nil {
+		return err
+	}
+	if rows, err = i.QueryContext(ctx, fmt.Sprintf(funcArgsQuery, nArgs(len(args))), args...); err != nil {
+		return fmt.Errorf("mysql: querying function arguments: %w", err)
+	}
+	return i.addFuncArgs(r, rows)
+}
+
+// addFuncs scans the rows of the functions query and appends them to their schemas.
+func (i *inspect) addFuncs(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, name, kind, body, det string
+			ret, comment              sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &kind, &ret, &body, &det, &comment); err != nil {
+			return fmt.Errorf("mysql: scan function information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q was not found in realm", ns)
+		}
+		var o schema.Object
+		switch kind {
+		case "PROCEDURE":
+			p := schema.NewProc(name, body).SetSchema(s)
+			if sqlx.ValidString(comment) {
+				p.SetComment(comment.String)
+			}
+			o = p
+		default:
+			f := schema.NewFunc(name, body).SetSchema(s)
+			if sqlx.ValidString(ret) {
+				t, err := ParseType(ret.String)
+				if err != nil {
+					return fmt.Errorf("mysql: parse return type of function %q: %w", name, err)
+				}
+				f.SetReturn(t)
+			}
+			if det == "YES" {
+				f.SetVolatility(Deterministic)
+			}
+			if sqlx.ValidString(comment) {
+				f.SetComment(comment.String)
+			}
+			o = f
+		}
+		s.AddObjects(o)
+	}
+	return rows.Err()
+}
+
+// addFuncArgs scans the rows of the function arguments query and appends them to their routines.
+func (i *inspect) addFuncArgs(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, name, kind, typ string
+			argName, mode       sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &kind, &argName, &mode, &typ); err != nil {
+			return fmt.Errorf("mysql: scan function argument: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			continue
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			return fmt.Errorf("mysql: parse type of argument %q: %w", argName.String, err)
+		}
+		a := &schema.FuncArg{Name: argName.String, Type: t}
+		switch kind {
+		case "PROCEDURE":
+			if p, ok := s.Proc(name); ok {
+				if mode.String != "" && mode.String != string(schema.FuncArgModeIn) {
+					a.Mode = schema.FuncArgMode(mode.String)
+				}
+				p.AddArgs(a)
+			}
+		default:
+			if f, ok := s.Func(name); ok {
+				f.AddArgs(a)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// inspectTriggers queries the triggers of the given realm schemas and attaches them to their tables.
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm) error {
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, len(r.Schemas))
+	for j, s := range r.Schemas {
+		args[j] = s.Name
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(triggersQuery, nArgs(len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying triggers: %w", err)
+	}
+	return i.addTriggers(r, rows)
+}
+
+// addTriggers scans the rows of the triggers query and appends them to their tables.
+func (i *inspect) addTriggers(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var ns, table, name, timing, event, orient, body string
+		if err := rows.Scan(&ns, &table, &name, &timing, &event, &orient, &body); err != nil {
+			return fmt.Errorf("mysql: scan trigger information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("mysql: schema %q for trigger %q was not found in inspection", ns, name)
+		}
+		// Triggers of excluded (or not inspected) tables are skipped.
+		t, ok := s.Table(table)
+		if !ok {
+			continue
+		}
+		t.AddTriggers(
+			schema.NewTrigger(name).
+				SetActionTime(schema.TriggerTime(strings.ToUpper(timing))).
+				AddEvents(schema.TriggerEvent{Name: strings.ToUpper(event)}).
+				SetFor(schema.TriggerFor(strings.ToUpper(orient))).
+				SetBody(body),
+		)
+	}
+	return rows.Err()
+}
+
+// inspectRoles queries and appends the roles, the users and
+// the privileges granted on the inspected schemas to the realm.
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	rows, err := i.QueryContext(ctx, usersQuery)
+	if err != nil {
+		return fmt.Errorf("mysql: querying users: %w", err)
+	}
+	if err := i.addUsers(r, rows); err != nil {
+		return err
+	}
+	if rows, err = i.QueryContext(ctx, roleEdgesQuery); err != nil {
+		return fmt.Errorf("mysql: querying role edges: %w", err)
+	}
+	if err := i.addRoleEdges(r, rows); err != nil {
+		return err
+	}
+	if len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas)*3)
+	for range 3 {
+		for _, s := range r.Schemas {
+			args = append(args, s.Name)
+		}
+	}
+	rows, err = i.QueryContext(ctx, strings.ReplaceAll(privilegesQuery, "%s", nArgs(len(r.Schemas))), args...)
+	if err != nil {
+		return fmt.Errorf("mysql: querying privileges: %w", err)
+	}
+	return i.addPermissions(r, rows)
+}
+
+// addUsers scans the rows returned by the usersQuery. Roles in MySQL are
+// locked accounts without a password, that were created for any host.
+func (i *inspect) addUsers(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name, host string
+			isRole     bool
+		)
+		if err := rows.Scan(&name, &host, &isRole); err != nil {
+			return fmt.Errorf("mysql: scanning user: %w", err)
+		}
+		if isRole {
+			r.AddObjects(&schema.Role{Name: name})
+		} else {
+			r.AddObjects(&schema.User{Name: name, Host: host})
+		}
+	}
+	return rows.Err()
+}
+
+// addRoleEdges scans the rows returned by the roleEdgesQuery.
+func (i *inspect) addRoleEdges(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var name, member, host string
+		if err := rows.Scan(&name, &member, &host); err != nil {
+			return fmt.Errorf("mysql: scanning role edge: %w", err)
+		}
+		// Grants of accounts that are not roles are not supported.
+		role, ok := r.Role(name)
+		if !ok {
+			continue
+		}
+		if m, ok := granteeByName(r, member, host); ok {
+			switch m := m.(type) {
+			case *schema.Role:
+				m.MemberOf = append(m.MemberOf, role)
+			case *schema.User:
+				m.MemberOf = append(m.MemberOf, role)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// addPermissions scans the rows returned by the privilegesQuery. Column privileges
+// that were granted with the same privileges are grouped into a single permission.
+func (i *inspect) addPermissions(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	var perms []*schema.Permission
+	for rows.Next() {
+		var (
+			ns, grantee, priv string
+			tname, cname      sql.NullString
+			grantable         bool
+		)
+		if err := rows.Scan(&ns, &tname, &cname, &grantee, &priv, &grantable); err != nil {
+			return fmt.Errorf("mysql: scanning privilege: %w", err)
+		}
+		name, host, ok := parseGrantee(grantee)
+		if !ok {
+			continue
+		}
+		p := &schema.Permission{Grantable: grantable}
+		if p.To, ok = granteeByName(r, name, host); !ok {
+			continue
+		}
+		if p.Schema, ok = r.Schema(ns); !ok {
+			continue
+		}
+		if sqlx.ValidString(tname) {
+			if p.Table, ok = p.Schema.Table(tname.String); !ok {
+				continue
+			}
+			if sqlx.ValidString(cname) {
+				c, ok := p.Table.Column(cname.String)
+				if !ok {
+					continue
+				}
+				p.Columns = []*schema.Column{c}
+			}
+		}
+		// Rows are ordered by their resource and grantee.
+		if n := len(perms); n > 0 && sqlx.SameRoleObject(perms[n-1], p) {
+			perms[n-1].Privileges = append(perms[n-1].Privileges, priv)
+		} else {
+			p.Privileges = []string{priv}
+			perms = append(perms, p)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := 0; i < len(perms); i++ {
+		p := perms[i]
+		if len(p.Columns) == 0 {
+			continue
+		}
+		// Group column permissions of the same table and grantee.
+		for j := i + 1; j < len(perms); j++ {
+			p1 := perms[j]
+			if len(p1.Columns) > 0 && p1.Table == p.Table && p1.To == p.To && p1.Grantable == p.Grantable && slices.Equal(p1.Privileges, p.Privileges) {
+				p.Columns = append(p.Columns, p1.Columns...)
+				perms = slices.Delete(perms, j, j+1)
+				j--
+			}
+		}
+	}
+	for _, p := range perms {
+		p.Privileges = privilegesOrAll(p)
+		r.AddObjects(p)
+	}
+	return nil
+}
+
+// granteeByName returns the role or the user with the given account name from the realm.
+func granteeByName(r *schema.Realm, name, host string) (schema.Object, bool) {
+	if host == "%" {
+		if role, ok := r.Role(name); ok {
+			return role, true
+		}
+	}
+	if u, ok := r.User(name, host); ok {
+		return u, true
+	}
+	return nil, false
+}
+
+// parseGrantee parses the name and the host of a grantee in
+// the information schema format. For example, 'user'@'host'.
+func parseGrantee(s string) (name, host string, ok bool) {
+	idx := strings.LastIndex(s, "'@'")
+	if idx == -1 || len(s) < 5 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return "", "", false
+	}
+	return strings.ReplaceAll(s[1:idx], "''", "'"), strings.ReplaceAll(s[idx+3:len(s)-1], "''", "'"), true
+}
+
 // schemas returns the list of the schemas in the database.
 func (i *inspect) schemas(ctx context.Context, opts *schema.InspectRealmOption) ([]*schema.Schema, error) {
 	var (
@@ -246,8 +758,11 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) error {
 		return err
 	}
 	t, ok := s.Table(table.String)
+	var v *schema.View
 	if !ok {
-		return fmt.Errorf("table %q was not found in schema", table.String)
+		if v, ok = s.View(table.String); !ok {
+			return fmt.Errorf("table or view %q was not found in schema", table.String)
+		}
 	}
 	c := &schema.Column{
 		Name: name.String,
@@ -258,14 +773,14 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) error {
 	}
 	ct, err := ParseType(c.Type.Raw)
 	if err != nil {
-		return fmt.Errorf("parse %q.%q type %q: %w", t.Name, c.Name, c.Type.Raw, err)
+		return fmt.Errorf("parse %q.%q type %q: %w", table.String, c.Name, c.Type.Raw, err)
 	}
 	c.Type.Type = ct
 	attr, err := parseExtra(extra.String)
 	if err != nil {
 		return err
 	}
-	if attr.autoinc {
+	if attr.autoinc && t != nil {
 		a := &AutoIncrement{}
 		if !sqlx.Has(t.Attrs, a) {
 			// A table can have only one AUTO_INCREMENT column. If it was returned as NULL
@@ -300,6 +815,10 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) error {
 	if sqlx.ValidString(collation) {
 		c.SetCollation(collation.String)
 	}
+	if v != nil {
+		v.AddColumns(c)
+		return nil
+	}
 	t.AddColumns(c)
 	return nil
 }
@@ -450,6 +969,112 @@ func (i *inspect) checks(ctx context.Context, s *schema.Schema) error {
 	return rows.Err()
 }
 
+// partitions queries and appends the partitioning of the partitioned tables in
+// the given schema. Note, sub-partitions are not supported, and only the
+// top-level partitions of the table are inspected.
+func (i *inspect) partitions(ctx context.Context, s *schema.Schema) error {
+	if !slices.ContainsFunc(s.Tables, partitioned) {
+		return nil
+	}
+	rows, err := i.querySchema(ctx, partitionsQuery, s)
+	if err != nil {
+		return fmt.Errorf("mysql: querying %q partitions: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table, name, method, expr, desc sql.NullString
+		if err := rows.Scan(&table, &name, &method, &expr, &desc); err != nil {
+			return fmt.Errorf("mysql: scan partitions: %w", err)
+		}
+		t, ok := s.Table(table.String)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema", table.String)
+		}
+		p := tablePartition(t)
+		if p == nil {
+			p = &Partition{T: method.String, Parts: partitionParts(t, expr.String)}
+			t.AddAttrs(p)
+		}
+		p.Defs = append(p.Defs, &PartitionDef{
+			Name:   name.String,
+			Values: partitionValues(method.String, desc.String),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, t := range s.Tables {
+		if p := tablePartition(t); p != nil && p.hashed() && slices.Equal(partitionNames(p.Defs), defaultHashNames(len(p.Defs))) {
+			// Partitions that were created using the PARTITIONS
+			// clause are named implicitly by the database.
+			p.Count, p.Defs = len(p.Defs), nil
+		}
+		// The "partitioned" option is set by the database, and
+		// is described by the Partition attribute of the table.
+		if o := (&CreateOptions{}); sqlx.Has(t.Attrs, o) && partitioned(t) {
+			t.Attrs = slices.DeleteFunc(t.Attrs, func(a schema.Attr) bool {
+				_, ok := a.(*CreateOptions)
+				return ok
+			})
+			if v := slices.DeleteFunc(strings.Fields(o.V), func(s string) bool { return strings.EqualFold(s, "partitioned") }); len(v) > 0 {
+				t.AddAttrs(&CreateOptions{V: strings.Join(v, " ")})
+			}
+		}
+	}
+	return nil
+}
+
+// partitioned reports if the table was marked as partitioned by the database.
+func partitioned(t *schema.Table) bool {
+	o := &CreateOptions{}
+	return sqlx.Has(t.Attrs, o) && slices.ContainsFunc(strings.Fields(o.V), func(s string) bool {
+		return strings.EqualFold(s, "partitioned")
+	})
+}
+
+// reKeyColumns matches a list of quoted column names. e.g., `a`,`b`.
+var reKeyColumns = regexp.MustCompile("^`[^`]+`(?:,\\s*`[^`]+`)*$")
+
+// partitionParts returns the parts of the partitioning key from the
+// PARTITION_EXPRESSION column. The value holds a list of columns for
+// the KEY and COLUMNS partitioning, and an expression otherwise.
+func partitionParts(t *schema.Table, expr string) []*PartitionPart {
+	if expr = strings.TrimSpace(expr); expr == "" {
+		return nil
+	}
+	if reKeyColumns.MatchString(expr) {
+		var parts []*PartitionPart
+		for _, n := range strings.Split(expr, ",") {
+			c, ok := t.Column(strings.Trim(strings.TrimSpace(n), "`"))
+			if !ok {
+				parts = nil
+				break
+			}
+			parts = append(parts, &PartitionPart{C: c})
+		}
+		if len(parts) > 0 {
+			return parts
+		}
+	}
+	return []*PartitionPart{{X: &schema.RawExpr{X: expr}}}
+}
+
+// partitionValues returns the VALUES clause of a partition definition from
+// its PARTITION_DESCRIPTION. e.g., "LESS THAN (10)" or "IN (1,2)".
+func partitionValues(method, desc string) string {
+	switch method = strings.ToUpper(method); {
+	case desc == "":
+		return ""
+	case method == PartitionTypeRange && strings.EqualFold(desc, "MAXVALUE"):
+		return "LESS THAN MAXVALUE"
+	case strings.HasPrefix(method, PartitionTypeRange):
+		return "LESS THAN (" + desc + ")"
+	case strings.HasPrefix(method, PartitionTypeList):
+		return "IN (" + desc + ")"
+	}
+	return ""
+}
+
 // supportsCheck reports if the connected database supports
 // the CHECK clause, and return the querying for getting them.
 func (i *inspect) supportsCheck() (string, bool) {
@@ -681,6 +1306,33 @@ const (
 	indexesExprQuery      = "SELECT `TABLE_NAME`, `INDEX_NAME`, `COLUMN_NAME`, `NON_UNIQUE`, `SEQ_IN_INDEX`, `INDEX_TYPE`, UPPER(`COLLATION`) = 'D' AS `DESC`, `INDEX_COMMENT`, `SUB_PART`, `EXPRESSION` FROM `INFORMATION_SCHEMA`.`STATISTICS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` IN (%s) ORDER BY `index_name`, `seq_in_index`"
 	indexesNoCommentQuery = "SELECT `TABLE_NAME`, `INDEX_NAME`, `COLUMN_NAME`, `NON_UNIQUE`, `SEQ_IN_INDEX`, `INDEX_TYPE`, UPPER(`COLLATION`) = 'D' AS `DESC`, NULL AS `INDEX_COMMENT`, `SUB_PART`, NULL AS `EXPRESSION` FROM `INFORMATION_SCHEMA`.`STATISTICS` WHERE `TABLE_SCHEMA` = ? AND `TABLE_NAME` IN (%s) ORDER BY `index_name`, `seq_in_index`"
 
+	// Query to list schema views.
+	viewsQuery = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `VIEW_DEFINITION`, `CHECK_OPTION` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` IN (%s) ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list schema views by their names.
+	viewsQueryArgs = "SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `VIEW_DEFINITION`, `CHECK_OPTION` FROM `INFORMATION_SCHEMA`.`VIEWS` WHERE `TABLE_SCHEMA` IN (%s) AND `TABLE_NAME` IN (%s) ORDER BY `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list the tables and views that views depend on.
+	viewDepsQuery = "SELECT `VIEW_SCHEMA`, `VIEW_NAME`, `TABLE_SCHEMA`, `TABLE_NAME` FROM `INFORMATION_SCHEMA`.`VIEW_TABLE_USAGE` WHERE `VIEW_SCHEMA` IN (%s) ORDER BY `VIEW_SCHEMA`, `VIEW_NAME`, `TABLE_SCHEMA`, `TABLE_NAME`"
+
+	// Query to list the triggers of the given schemas.
+	triggersQuery = "SELECT `EVENT_OBJECT_SCHEMA`, `EVENT_OBJECT_TABLE`, `TRIGGER_NAME`, `ACTION_TIMING`, `EVENT_MANIPULATION`, `ACTION_ORIENTATION`, `ACTION_STATEMENT` FROM `INFORMATION_SCHEMA`.`TRIGGERS` WHERE `EVENT_OBJECT_SCHEMA` IN (%s) ORDER BY `EVENT_OBJECT_SCHEMA`, `EVENT_OBJECT_TABLE`, `ACTION_ORDER`, `TRIGGER_NAME`"
+
+	// Query to list the users and the roles. System accounts are ignored.
+	usersQuery = "SELECT `User`, `Host`, `account_locked` = 'Y' AND `authentication_string` = '' AND `Host` = '%' AS `is_role` FROM `mysql`.`user` WHERE `User` NOT IN ('', 'root') AND `User` NOT LIKE 'mysql.%' ORDER BY `User`, `Host`"
+
+	// Query to list the roles granted to roles and users.
+	roleEdgesQuery = "SELECT `FROM_USER`, `TO_USER`, `TO_HOST` FROM `mysql`.`role_edges` WHERE `FROM_HOST` = '%' ORDER BY `TO_USER`, `TO_HOST`, `FROM_USER`"
+
+	// Query to list the privileges granted on the given schemas, and their tables and columns.
+	privilegesQuery = "SELECT `TABLE_SCHEMA`, NULL AS `TABLE_NAME`, NULL AS `COLUMN_NAME`, `GRANTEE`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` = 'YES' FROM `INFORMATION_SCHEMA`.`SCHEMA_PRIVILEGES` WHERE `TABLE_SCHEMA` IN (%s) UNION ALL SELECT `TABLE_SCHEMA`, `TABLE_NAME`, NULL, `GRANTEE`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` = 'YES' FROM `INFORMATION_SCHEMA`.`TABLE_PRIVILEGES` WHERE `TABLE_SCHEMA` IN (%s) UNION ALL SELECT `TABLE_SCHEMA`, `TABLE_NAME`, `COLUMN_NAME`, `GRANTEE`, `PRIVILEGE_TYPE`, `IS_GRANTABLE` = 'YES' FROM `INFORMATION_SCHEMA`.`COLUMN_PRIVILEGES` WHERE `TABLE_SCHEMA` IN (%s) ORDER BY 1, 2, 3, 4, 6, 5"
+
+	// Query to list the functions and procedures of the given schemas.
+	funcsQuery = "SELECT `ROUTINE_SCHEMA`, `ROUTINE_NAME`, `ROUTINE_TYPE`, IF(`ROUTINE_TYPE` = 'FUNCTION', `DTD_IDENTIFIER`, NULL) AS `DTD_IDENTIFIER`, `ROUTINE_DEFINITION`, `IS_DETERMINISTIC`, `ROUTINE_COMMENT` FROM `INFORMATION_SCHEMA`.`ROUTINES` WHERE `ROUTINE_SCHEMA` IN (%s) AND `ROUTINE_TYPE` IN ('FUNCTION', 'PROCEDURE') ORDER BY `ROUTINE_SCHEMA`, `ROUTINE_NAME`"
+
+	// Query to list the arguments of the functions and procedures of the given schemas.
+	funcArgsQuery = "SELECT `SPECIFIC_SCHEMA`, `SPECIFIC_NAME`, `ROUTINE_TYPE`, `PARAMETER_NAME`, `PARAMETER_MODE`, `DTD_IDENTIFIER` FROM `INFORMATION_SCHEMA`.`PARAMETERS` WHERE `SPECIFIC_SCHEMA` IN (%s) AND `ORDINAL_POSITION` > 0 ORDER BY `SPECIFIC_SCHEMA`, `SPECIFIC_NAME`, `ORDINAL_POSITION`"
+
 	tablesQuery = `
 SELECT
 	t1.TABLE_SCHEMA,
@@ -763,6 +1415,24 @@ WHERE
 	AND TABLE_NAME IN (%s)
 ORDER BY
 	TABLE_NAME, CONSTRAINT_NAME
+`
+	// Query to list the top-level partitions of partitioned tables.
+	partitionsQuery = `
+SELECT
+	TABLE_NAME,
+	PARTITION_NAME,
+	PARTITION_METHOD,
+	PARTITION_EXPRESSION,
+	PARTITION_DESCRIPTION
+FROM
+	INFORMATION_SCHEMA.PARTITIONS
+WHERE
+	TABLE_SCHEMA = ?
+	AND TABLE_NAME IN (%s)
+	AND PARTITION_NAME IS NOT NULL
+	AND (SUBPARTITION_ORDINAL_POSITION IS NULL OR SUBPARTITION_ORDINAL_POSITION = 1)
+ORDER BY
+	TABLE_NAME, PARTITION_ORDINAL_POSITION
 `
 	// Query to list table foreign keys.
 	fksQuery = `
@@ -868,6 +1538,39 @@ type (
 		P string // Name of the parser plugin. e.g., ngram or mecab.
 	}
 
+	// Partition describes the partitioning of a table.
+	// i.e., the PARTITION BY clause of the table.
+	Partition struct {
+		schema.Attr
+		// T defines the type of the partitioning. Can be one of: RANGE,
+		// LIST, HASH, KEY, RANGE COLUMNS, LIST COLUMNS, LINEAR HASH or
+		// LINEAR KEY.
+		T string
+		// Parts of the partitioning key. An empty
+		// KEY partitioning uses the primary key.
+		Parts []*PartitionPart
+		// Count holds the number of HASH and KEY partitions
+		// that are not defined explicitly (PARTITIONS clause).
+		Count int
+		// Defs holds the partition definitions.
+		Defs []*PartitionDef
+	}
+
+	// A PartitionPart represents a part of the partitioning
+	// key that can be either an expression or a column.
+	PartitionPart struct {
+		X schema.Expr
+		C *schema.Column
+	}
+
+	// A PartitionDef describes a single partition of a partitioned table.
+	PartitionDef struct {
+		Name string
+		// Values holds the VALUES clause of RANGE and LIST partitions.
+		// For example: "LESS THAN (10)", "LESS THAN MAXVALUE" or "IN (1, 2)".
+		Values string
+	}
+
 	// BitType represents the type bit.
 	BitType struct {
 		schema.Type
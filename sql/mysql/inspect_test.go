@@ -9,6 +9,7 @@ package mysql
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"ariga.io/atlas/sql/internal/sqltest"
@@ -768,7 +769,7 @@ func TestDriver_InspectTable(t *testing.T) {
 			drv, err := Open(db)
 			require.NoError(t, err)
 			s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
-				Mode: ^schema.InspectViews,
+				Mode: ^(schema.InspectViews | schema.InspectFuncs | schema.InspectTriggers),
 			})
 			require.NoError(t, err)
 			require.NotNil(t, s)
@@ -906,7 +907,7 @@ func TestDriver_InspectSchema(t *testing.T) {
 			drv, err := Open(db)
 			require.NoError(t, err)
 			tables, err := drv.InspectSchema(context.Background(), tt.schema, &schema.InspectOptions{
-				Mode: ^schema.InspectViews,
+				Mode: ^(schema.InspectViews | schema.InspectFuncs | schema.InspectTriggers),
 			})
 			tt.expect(require.New(t), tables, err)
 		})
@@ -930,7 +931,7 @@ func TestDriver_Realm(t *testing.T) {
 	drv, err := Open(db)
 	require.NoError(t, err)
 	realm, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
-		Mode: ^schema.InspectViews,
+		Mode: ^(schema.InspectViews | schema.InspectFuncs | schema.InspectTriggers | schema.InspectRoles),
 	})
 	require.NoError(t, err)
 	require.EqualValues(t, func() *schema.Realm {
@@ -972,7 +973,7 @@ func TestDriver_Realm(t *testing.T) {
 		WithArgs("test", "public").
 		WillReturnRows(sqlmock.NewRows([]string{"schema", "table", "charset", "collate", "inc", "comment", "options"}))
 	realm, err = drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
-		Mode:    ^schema.InspectViews,
+		Mode:    ^(schema.InspectViews | schema.InspectFuncs | schema.InspectTriggers | schema.InspectRoles),
 		Schemas: []string{"test", "public"},
 	})
 	require.NoError(t, err)
@@ -1053,6 +1054,336 @@ func TestInspectMode_InspectRealm(t *testing.T) {
 	}(), realm)
 }
 
+func TestInspect_Roles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.31")
+	mk.ExpectQuery(sqltest.Escape(schemasQuery)).
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_0900_ai_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(usersQuery)).
+		WillReturnRows(sqltest.Rows(`
++--------+-----------+---------+
+| User   | Host      | is_role |
++--------+-----------+---------+
+| app    | localhost | 0       |
+| ops    | %         | 0       |
+| reader | %         | 1       |
++--------+-----------+---------+
+`))
+	mk.ExpectQuery(sqltest.Escape(roleEdgesQuery)).
+		WillReturnRows(sqltest.Rows(`
++-----------+---------+-----------+
+| FROM_USER | TO_USER | TO_HOST   |
++-----------+---------+-----------+
+| reader    | app     | localhost |
+| ops       | app     | localhost |
++-----------+---------+-----------+
+`))
+	mk.ExpectQuery(sqltest.Escape(strings.ReplaceAll(privilegesQuery, "%s", "?"))).
+		WithArgs("test", "test", "test").
+		WillReturnRows(sqltest.Rows(`
++--------------+------------+-------------+---------------------+----------------+--------------+
+| TABLE_SCHEMA | TABLE_NAME | COLUMN_NAME | GRANTEE             | PRIVILEGE_TYPE | IS_GRANTABLE |
++--------------+------------+-------------+---------------------+----------------+--------------+
+| test         | NULL       | NULL        | 'ops'@'%'           | SELECT         | 1            |
+| test         | NULL       | NULL        | 'reader'@'%'        | SELECT         | 0            |
+| test         | NULL       | NULL        | 'reader'@'%'        | SHOW VIEW      | 0            |
+| test         | users      | NULL        | 'app'@'localhost'   | UPDATE         | 0            |
+| test         | NULL       | NULL        | 'unknown'@'%'       | SELECT         | 0            |
++--------------+------------+-------------+---------------------+----------------+--------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	r, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
+		Mode:    schema.InspectSchemas | schema.InspectRoles,
+		Exclude: []string{"ops[type=user]"},
+	})
+	require.NoError(t, err)
+	reader, ok := r.Role("reader")
+	require.True(t, ok)
+	app, ok := r.User("app", "localhost")
+	require.True(t, ok)
+	// Grants of accounts that are not roles, and privileges of
+	// excluded grantees or tables that were not inspected are skipped.
+	require.Equal(t, []*schema.Role{reader}, app.MemberOf)
+	require.Equal(t, []schema.Object{
+		app, reader,
+		&schema.Permission{To: reader, Schema: r.Schemas[0], Privileges: []string{PrivilegeSelect, PrivilegeShowView}},
+	}, r.Objects)
+}
+
+func TestInspect_Views(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_0900_ai_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewsQuery, "?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++--------------+------------+---------------------------------------------------------------+--------------+
+| TABLE_SCHEMA | TABLE_NAME | VIEW_DEFINITION                                               | CHECK_OPTION |
++--------------+------------+---------------------------------------------------------------+--------------+
+| test         | v1         | select 1 AS ` + "`a`" + `                                              | NONE         |
+| test         | v2         | select ` + "`test`.`v1`.`a` AS `a` from `test`.`v1`" + `                 | CASCADED     |
++--------------+------------+---------------------------------------------------------------+--------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "?, ?"))).
+		WithArgs("test", "v1", "v2").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| v1         | a           | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| v2         | a           | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewDepsQuery, "?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+-----------+--------------+------------+
+| VIEW_SCHEMA | VIEW_NAME | TABLE_SCHEMA | TABLE_NAME |
++-------------+-----------+--------------+------------+
+| test        | v2        | test         | v1         |
++-------------+-----------+--------------+------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "test", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectViews,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 2)
+	v1, v2 := s.Views[0], s.Views[1]
+	require.Equal(t, "select 1 AS `a`", v1.Def)
+	require.Empty(t, v1.Attrs)
+	require.Equal(t, "select `v1`.`a` AS `a` from `v1`", v2.Def)
+	require.Equal(t, []schema.Attr{&schema.ViewCheckOption{V: schema.ViewCheckOptionCascaded}}, v2.Attrs)
+	require.Len(t, v2.Columns, 1)
+	require.Equal(t, []schema.Object{v1}, v2.Deps)
+	require.Equal(t, []schema.Object{v2}, v1.Refs)
+}
+
+func TestInspect_Partitions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_0900_ai_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(queryTable).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++--------------+------------+--------------------+--------------------+----------------+---------------+-----------------------------------+--------+----------------+------------+
+| TABLE_SCHEMA | TABLE_NAME | CHARACTER_SET_NAME | TABLE_COLLATION    | AUTO_INCREMENT | TABLE_COMMENT | CREATE_OPTIONS                    | ENGINE | DEFAULT_ENGINE | TABLE_TYPE |
++--------------+------------+--------------------+--------------------+----------------+---------------+-----------------------------------+--------+----------------+------------+
+| test         | events     | NULL               | NULL               | NULL           |               | partitioned                       | NULL   | NULL           | BASE TABLE |
+| test         | logs       | NULL               | NULL               | NULL           |               | COMPRESSION="ZLIB" partitioned    | NULL   | NULL           | BASE TABLE |
+| test         | users      | NULL               | NULL               | NULL           |               | partitioned                       | NULL   | NULL           | BASE TABLE |
++--------------+------------+--------------------+--------------------+----------------+---------------+-----------------------------------+--------+----------------+------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsExprQuery, "?, ?, ?"))).
+		WithArgs("test", "events", "logs", "users").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| TABLE_NAME | COLUMN_NAME | COLUMN_TYPE | COLUMN_COMMENT | IS_NULLABLE | COLUMN_KEY | COLUMN_DEFAULT | EXTRA | CHARACTER_SET_NAME | COLLATION_NAME | GENERATION_EXPRESSION |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| events     | region      | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| logs       | created     | date        |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
+| users      | id          | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesExprQuery, "?, ?, ?"))).
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "non_unique", "key_part", "expression"}))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "?, ?, ?"))).
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "CONSTRAINT_NAME", "TABLE_NAME", "COLUMN_NAME", "REFERENCED_TABLE_NAME", "REFERENCED_COLUMN_NAME", "REFERENCED_TABLE_SCHEMA", "UPDATE_RULE", "DELETE_RULE"}))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(partitionsQuery, "?, ?, ?"))).
+		WithArgs("test", "events", "logs", "users").
+		WillReturnRows(sqltest.Rows(`
++------------+----------------+------------------+----------------------+-----------------------+
+| TABLE_NAME | PARTITION_NAME | PARTITION_METHOD | PARTITION_EXPRESSION | PARTITION_DESCRIPTION |
++------------+----------------+------------------+----------------------+-----------------------+
+| events     | eu             | LIST COLUMNS     | ` + "`region`" + `             | 1,2                   |
+| events     | us             | LIST COLUMNS     | ` + "`region`" + `             | 3                     |
+| logs       | p2020          | RANGE            | ` + "year(`created`)" + `      | 2021                  |
+| logs       | pmax           | RANGE            | ` + "year(`created`)" + `      | MAXVALUE              |
+| users      | p0             | HASH             | ` + "`id`" + `                 | NULL                  |
+| users      | p1             | HASH             | ` + "`id`" + `                 | NULL                  |
++------------+----------------+------------------+----------------------+-----------------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "test", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTables,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Tables, 3)
+	events, logs, users := s.Tables[0], s.Tables[1], s.Tables[2]
+	require.Equal(t, []schema.Attr{
+		&Partition{
+			T:     PartitionTypeListColumns,
+			Parts: []*PartitionPart{{C: events.Columns[0]}},
+			Defs: []*PartitionDef{
+				{Name: "eu", Values: "IN (1,2)"},
+				{Name: "us", Values: "IN (3)"},
+			},
+		},
+	}, events.Attrs)
+	require.Equal(t, []schema.Attr{
+		&Partition{
+			T:     PartitionTypeRange,
+			Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created`)"}}},
+			Defs: []*PartitionDef{
+				{Name: "p2020", Values: "LESS THAN (2021)"},
+				{Name: "pmax", Values: "LESS THAN MAXVALUE"},
+			},
+		},
+		&CreateOptions{V: `COMPRESSION="ZLIB"`},
+	}, logs.Attrs)
+	require.Equal(t, []schema.Attr{
+		&Partition{
+			T:     PartitionTypeHash,
+			Parts: []*PartitionPart{{C: users.Columns[0]}},
+			Count: 2,
+		},
+	}, users.Attrs)
+}
+
+func TestInspect_Funcs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_0900_ai_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(funcsQuery, "?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++----------------+--------------+--------------+----------------+-------------------------+------------------+-----------------+
+| ROUTINE_SCHEMA | ROUTINE_NAME | ROUTINE_TYPE | DTD_IDENTIFIER | ROUTINE_DEFINITION      | IS_DETERMINISTIC | ROUTINE_COMMENT |
++----------------+--------------+--------------+----------------+-------------------------+------------------+-----------------+
+| test           | add          | FUNCTION     | int            | RETURN a + b            | YES              | sum             |
+| test           | reset        | PROCEDURE    | NULL           | BEGIN SET n = 0; END    | NO               |                 |
++----------------+--------------+--------------+----------------+-------------------------+------------------+-----------------+
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(funcArgsQuery, "?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-----------------+---------------+--------------+----------------+----------------+----------------+
+| SPECIFIC_SCHEMA | SPECIFIC_NAME | ROUTINE_TYPE | PARAMETER_NAME | PARAMETER_MODE | DTD_IDENTIFIER |
++-----------------+---------------+--------------+----------------+----------------+----------------+
+| test            | add           | FUNCTION     | a              | NULL           | int            |
+| test            | add           | FUNCTION     | b              | NULL           | int            |
+| test            | reset         | PROCEDURE    | n              | INOUT          | int            |
++-----------------+---------------+--------------+----------------+----------------+----------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "test", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectFuncs,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 2)
+	add, ok := s.Func("add")
+	require.True(t, ok)
+	require.Equal(t, "RETURN a + b", add.Body)
+	require.Equal(t, &schema.IntegerType{T: TypeInt}, add.Ret)
+	require.Equal(t, []schema.Attr{&schema.FuncVolatility{V: Deterministic}, &schema.Comment{Text: "sum"}}, add.Attrs)
+	require.Len(t, add.Args, 2)
+	require.Equal(t, "b", add.Args[1].Name)
+	reset, ok := s.Proc("reset")
+	require.True(t, ok)
+	require.Empty(t, reset.Attrs)
+	require.Len(t, reset.Args, 1)
+	require.Equal(t, schema.FuncArgModeInOut, reset.Args[0].Mode)
+}
+
+func TestInspect_Triggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("8.0.13")
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= ?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++-------------+----------------------------+------------------------+
+| SCHEMA_NAME | DEFAULT_CHARACTER_SET_NAME | DEFAULT_COLLATION_NAME |
++-------------+----------------------------+------------------------+
+| test        | utf8mb4                    | utf8mb4_0900_ai_ci     |
++-------------+----------------------------+------------------------+
+`))
+	mk.tables("test", "users")
+	mk.ExpectQuery(queryColumns).
+		WithArgs("test", "users").
+		WillReturnRows(sqltest.Rows(`
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| table_name | column_name | column_type | column_comment | is_nullable | column_key | column_default | extra | character_set_name | collation_name | generation_expression |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+| users      | id          | int         |                | NO          |            | NULL           |       | NULL               | NULL           | NULL                  |
++------------+-------------+-------------+----------------+-------------+------------+----------------+-------+--------------------+----------------+-----------------------+
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(triggersQuery, "?"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
++---------------------+--------------------+--------------+---------------+--------------------+--------------------+------------------------------+
+| EVENT_OBJECT_SCHEMA | EVENT_OBJECT_TABLE | TRIGGER_NAME | ACTION_TIMING | EVENT_MANIPULATION | ACTION_ORIENTATION | ACTION_STATEMENT             |
++---------------------+--------------------+--------------+---------------+--------------------+--------------------+------------------------------+
+| test                | users              | users_ins    | BEFORE        | INSERT             | ROW                | SET NEW.id = NEW.id + 1      |
+| test                | users              | users_del    | AFTER         | DELETE             | ROW                | BEGIN DELETE FROM t; END     |
+| test                | logs               | logs_ins     | AFTER         | INSERT             | ROW                | SET @n = 1                   |
++---------------------+--------------------+--------------+---------------+--------------------+--------------------+------------------------------+
+`))
+	drv, err := Open(db)
+	require.NoError(t, err)
+	s, err := drv.InspectSchema(context.Background(), "test", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTables | schema.InspectTriggers,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, users.Triggers, 2)
+	ins, del := users.Triggers[0], users.Triggers[1]
+	require.Equal(t, users, ins.Table)
+	require.Equal(t, schema.TriggerTimeBefore, ins.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert}, ins.Events)
+	require.Equal(t, schema.TriggerForRow, ins.For)
+	require.Equal(t, "SET NEW.id = NEW.id + 1", ins.Body)
+	require.Equal(t, schema.TriggerTimeAfter, del.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventDelete}, del.Events)
+	require.Equal(t, "BEGIN DELETE FROM t; END", del.Body)
+}
+
 type mock struct {
 	sqlmock.Sqlmock
 }
@@ -94,6 +94,12 @@ func (v V) SupportsViewUsage() bool {
 	return !v.Maria() && v.GTE("8.0.13")
 }
 
+// SupportsRoles reports if the version supports roles and
+// the mysql.role_edges table for inspecting their grants.
+func (v V) SupportsRoles() bool {
+	return !v.Maria() && !v.TiDB() && v.GTE("8.0.0")
+}
+
 // CharsetToCollate returns the mapping from charset to its default collation.
 func (v V) CharsetToCollate(conn schema.ExecQuerier) (map[string]string, error) {
 	name := "is/charset2collate"
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -95,6 +96,28 @@ func (s *state) plan(changes []schema.Change) error {
 			err = s.modifyTable(c)
 		case *schema.RenameTable:
 			s.renameTable(c)
+		case *schema.AddView:
+			s.addView(c)
+		case *schema.DropView:
+			s.dropView(c)
+		case *schema.ModifyView:
+			s.modifyView(c)
+		case *schema.RenameView:
+			s.renameView(c)
+		case *schema.AddObject:
+			err = s.addObject(c)
+		case *schema.DropObject:
+			err = s.dropObject(c)
+		case *schema.ModifyObject:
+			err = s.modifyObject(c)
+		case *schema.AddTrigger:
+			err = s.addTrigger(c)
+		case *schema.DropTrigger:
+			err = s.dropTrigger(c)
+		case *schema.ModifyTrigger:
+			err = s.modifyTrigger(c)
+		case *schema.RenameTrigger:
+			err = s.renameTrigger(c)
 		default:
 			err = fmt.Errorf("unsupported change %T", c)
 		}
@@ -261,6 +284,12 @@ func (s *state) addTable(add *schema.AddTable) error {
 		return fmt.Errorf("create table %q: %s", add.T.Name, strings.Join(errs, ", "))
 	}
 	s.tableAttrs(b, add, add.T.Attrs...)
+	// The partitioning clause must follow the table options.
+	if p := tablePartition(add.T); p != nil {
+		if err := partitionBy(b, p); err != nil {
+			return fmt.Errorf("create table %q: %w", add.T.Name, err)
+		}
+	}
 	s.append(&migrate.Change{
 		Cmd:     b.String(),
 		Source:  add,
@@ -294,12 +323,38 @@ func (s *state) dropTable(drop *schema.DropTable) error {
 // modifyTable builds and appends the migration changes for
 // bringing the table into its modified state.
 func (s *state) modifyTable(modify *schema.ModifyTable) error {
-	var changes [2][]schema.Change
+	var (
+		changes [2][]schema.Change
+		// Partitioning changes cannot be combined with other changes,
+		// and each is executed in a separate ALTER TABLE statement.
+		// Partitioning is removed first, and changed or extended last.
+		remove, parts []schema.Change
+	)
 	if len(modify.T.Columns) == 0 {
 		return fmt.Errorf("table %q has no columns; drop the table instead", modify.T.Name)
 	}
 	for _, change := range skipAutoChanges(modify.Changes) {
 		switch change := change.(type) {
+		case *AddPartition, *DropPartition, *ReorganizePartition:
+			parts = append(parts, change)
+		case *schema.DropAttr:
+			if _, ok := change.A.(*Partition); ok {
+				remove = append(remove, change)
+			} else {
+				changes[1] = append(changes[1], change)
+			}
+		case *schema.AddAttr:
+			if _, ok := change.A.(*Partition); ok {
+				parts = append(parts, change)
+			} else {
+				changes[1] = append(changes[1], change)
+			}
+		case *schema.ModifyAttr:
+			if _, ok := change.To.(*Partition); ok {
+				parts = append(parts, change)
+			} else {
+				changes[1] = append(changes[1], change)
+			}
 		// Foreign-key modification is translated into 2 steps.
 		// Dropping the current foreign key and creating a new one.
 		case *schema.ModifyForeignKey:
@@ -332,6 +387,11 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			changes[1] = append(changes[1], change)
 		}
 	}
+	for _, c := range remove {
+		if err := s.alterTable(modify.T, []schema.Change{c}); err != nil {
+			return err
+		}
+	}
 	for i := range changes {
 		if len(changes[i]) > 0 {
 			if err := s.alterTable(modify.T, changes[i]); err != nil {
@@ -339,9 +399,43 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			}
 		}
 	}
+	for _, c := range parts {
+		if err := s.alterTable(modify.T, []schema.Change{c}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+type (
+	// AddPartition describes a change that adds partitions to a
+	// partitioned table. i.e., ALTER TABLE ... ADD PARTITION.
+	AddPartition struct {
+		schema.Change
+		Defs []*PartitionDef
+	}
+
+	// DropPartition describes a change that drops partitions, and the data stored
+	// in them, from a partitioned table. i.e., ALTER TABLE ... DROP PARTITION.
+	DropPartition struct {
+		schema.Change
+		Defs []*PartitionDef
+	}
+
+	// ReorganizePartition describes a change that splits or merges partitions of a
+	// partitioned table, and preserves their data. For example, changing the values
+	// of a partition. i.e., ALTER TABLE ... REORGANIZE PARTITION ... INTO.
+	ReorganizePartition struct {
+		schema.Change
+		From, To []*PartitionDef
+	}
+)
+
+// DroppedPartitions returns the names of the dropped partitions.
+func (d *DropPartition) DroppedPartitions() []string {
+	return partitionNames(d.Defs)
+}
+
 // alterTable modifies the given table by executing on it a list of
 // changes in one SQL statement.
 func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
@@ -428,15 +522,51 @@ func (s *state) alterTable(t *schema.Table, changes []schema.Change) error {
 				b.P("DROP FOREIGN KEY").Ident(change.F.Symbol)
 				reverse = append(reverse, &schema.AddForeignKey{F: change.F})
 			case *schema.AddAttr:
-				s.tableAttrs(b, change, change.A)
+				if p, ok := change.A.(*Partition); ok {
+					if err := partitionBy(b, p); err != nil {
+						return err
+					}
+					reverse = append(reverse, &schema.DropAttr{A: p})
+				} else {
+					s.tableAttrs(b, change, change.A)
+				}
 			case *schema.DropAttr:
-				s.tableAttrs(b, change, change.A)
+				if p, ok := change.A.(*Partition); ok {
+					b.P("REMOVE PARTITIONING")
+					reverse = append(reverse, &schema.AddAttr{A: p})
+				} else {
+					s.tableAttrs(b, change, change.A)
+				}
 			case *schema.ModifyAttr:
-				s.tableAttrs(b, change, change.To)
+				if p, ok := change.To.(*Partition); ok {
+					if err := partitionBy(b, p); err != nil {
+						return err
+					}
+				} else {
+					s.tableAttrs(b, change, change.To)
+				}
 				reverse = append(reverse, &schema.ModifyAttr{
 					From: change.To,
 					To:   change.From,
 				})
+			case *AddPartition:
+				b.P("ADD PARTITION").Wrap(func(b *sqlx.Builder) {
+					partitionDefs(b, change.Defs)
+				})
+				reverse = append(reverse, &DropPartition{Defs: change.Defs})
+			case *DropPartition:
+				b.P("DROP PARTITION").MapComma(change.Defs, func(i int, b *sqlx.Builder) {
+					b.Ident(change.Defs[i].Name)
+				})
+				reverse = append(reverse, &AddPartition{Defs: change.Defs})
+			case *ReorganizePartition:
+				b.P("REORGANIZE PARTITION").MapComma(change.From, func(i int, b *sqlx.Builder) {
+					b.Ident(change.From[i].Name)
+				})
+				b.P("INTO").Wrap(func(b *sqlx.Builder) {
+					partitionDefs(b, change.To)
+				})
+				reverse = append(reverse, &ReorganizePartition{From: change.To, To: change.From})
 			case *schema.AddCheck:
 				s.check(b.P("ADD"), change.C)
 				// Reverse operation is supported if
@@ -511,6 +641,505 @@ func (s *state) renameTable(c *schema.RenameTable) {
 	})
 }
 
+// addView builds and appends the migrate.Change for creating a view.
+func (s *state) addView(add *schema.AddView) {
+	s.append(&migrate.Change{
+		Cmd:     s.createView(add.V, sqlx.Has(add.Extra, &schema.OrReplace{})),
+		Source:  add,
+		Reverse: s.Build("DROP VIEW").View(add.V).String(),
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+	})
+}
+
+// dropView builds and appends the migrate.Change for dropping a view.
+func (s *state) dropView(drop *schema.DropView) {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.View(drop.V).String(),
+		Source:  drop,
+		Reverse: s.createView(drop.V, false),
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+	})
+}
+
+// modifyView builds and appends the migrate.Change for replacing the view
+// definition. Note, MySQL does not support comments on views.
+func (s *state) modifyView(modify *schema.ModifyView) {
+	if !sqlx.ViewDefChanged(modify) {
+		return
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.createView(modify.To, true),
+		Source:  modify,
+		Reverse: s.createView(modify.From, true),
+		Comment: fmt.Sprintf("modify %q view", modify.To.Name),
+	})
+}
+
+func (s *state) renameView(c *schema.RenameView) {
+	s.append(&migrate.Change{
+		Source:  c,
+		Comment: fmt.Sprintf("rename a view from %q to %q", c.From.Name, c.To.Name),
+		Cmd:     s.Build("RENAME TABLE").View(c.From).P("TO").View(c.To).String(),
+		Reverse: s.Build("RENAME TABLE").View(c.To).P("TO").View(c.From).String(),
+	})
+}
+
+// createView returns the 'CREATE [OR REPLACE] VIEW' statement of the given view.
+func (s *state) createView(v *schema.View, replace bool) string {
+	b := s.Build("CREATE")
+	if replace {
+		b.P("OR REPLACE")
+	}
+	b.P("VIEW").View(v).P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";"))
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		b.P("WITH", strings.ToUpper(c.V), "CHECK OPTION")
+	}
+	return b.String()
+}
+
+// addObject builds and appends the migrate.Change for creating a function, a procedure,
+// a role or a user, or for granting the privileges of a permission.
+func (s *state) addObject(add *schema.AddObject) error {
+	switch o := add.O.(type) {
+	case *schema.Role, *schema.User:
+		s.addAccount(add, accountOf(o))
+		return nil
+	case *schema.Permission:
+		return s.addPermission(add, o)
+	}
+	create, err := s.createRoutine(add.O)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Reverse: s.dropRoutine(add.O, false),
+		Comment: fmt.Sprintf("create %s", routineComment(add.O)),
+	})
+	return nil
+}
+
+// dropObject builds and appends the migrate.Change for dropping a function, a procedure,
+// a role or a user, or for revoking the privileges of a permission.
+func (s *state) dropObject(drop *schema.DropObject) error {
+	switch o := drop.O.(type) {
+	case *schema.Role, *schema.User:
+		s.dropAccount(drop, accountOf(o))
+		return nil
+	case *schema.Permission:
+		return s.dropPermission(drop, o)
+	}
+	create, err := s.createRoutine(drop.O)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.dropRoutine(drop.O, sqlx.Has(drop.Extra, &schema.IfExists{})),
+		Source:  drop,
+		Reverse: create,
+		Comment: fmt.Sprintf("drop %s", routineComment(drop.O)),
+	})
+	return nil
+}
+
+// modifyObject builds and appends the migrate.Changes for modifying a function or a procedure.
+// MySQL does not support replacing the definition of existing routines, and therefore, the
+// routine is dropped and recreated. Roles, users and permissions are modified using grants.
+func (s *state) modifyObject(modify *schema.ModifyObject) error {
+	switch from := modify.From.(type) {
+	case *schema.Role, *schema.User:
+		s.modifyAccount(modify, accountOf(from), accountOf(modify.To))
+		return nil
+	case *schema.Permission:
+		return s.modifyPermission(modify, from, modify.To.(*schema.Permission))
+	}
+	if err := s.dropObject(&schema.DropObject{O: modify.From}); err != nil {
+		return err
+	}
+	return s.addObject(&schema.AddObject{O: modify.To})
+}
+
+// account is a common representation of roles and users. In MySQL, roles
+// are accounts that are created for any host, and cannot be logged into.
+type account struct {
+	kind     string // role or user.
+	name     string
+	host     string
+	memberOf []*schema.Role
+}
+
+// accountOf returns the account representation of the given role or user.
+func accountOf(o schema.Object) *account {
+	switch o := o.(type) {
+	case *schema.Role:
+		return &account{kind: "role", name: o.Name, memberOf: o.MemberOf}
+	case *schema.User:
+		return &account{kind: "user", name: o.Name, host: o.Host, memberOf: o.MemberOf}
+	}
+	return nil
+}
+
+// String returns the account name in the 'name'@'host' format.
+func (a *account) String() string {
+	if a.host == "" || a.kind == "role" {
+		return quoteString(a.name)
+	}
+	return quoteString(a.name) + "@" + quoteString(a.host)
+}
+
+// addAccount builds and appends the migrate.Changes for creating a role
+// or a user, and granting it the roles it is a member of.
+func (s *state) addAccount(add *schema.AddObject, a *account) {
+	s.append(&migrate.Change{
+		Cmd:     s.Build("CREATE", strings.ToUpper(a.kind), a.String()).String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %s %q", a.kind, a.name),
+		Reverse: s.Build("DROP", strings.ToUpper(a.kind), a.String()).String(),
+	})
+	if len(a.memberOf) > 0 {
+		s.append(s.grantRoles(add, a, sqlx.RoleNames(a.memberOf)))
+	}
+}
+
+// dropAccount builds and appends the migrate.Change for dropping a role or a user. The
+// change is irreversible, as the password, the roles and the privileges of the account
+// are lost once it is dropped.
+func (s *state) dropAccount(drop *schema.DropObject, a *account) {
+	b := s.Build("DROP", strings.ToUpper(a.kind))
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(a.String()).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %s %q", a.kind, a.name),
+	})
+}
+
+// modifyAccount builds and appends the migrate.Changes for
+// migrating the roles a role or a user is a member of.
+func (s *state) modifyAccount(modify *schema.ModifyObject, from, to *account) {
+	fromR, toR := sqlx.RoleNames(from.memberOf), sqlx.RoleNames(to.memberOf)
+	if added := slices.DeleteFunc(slices.Clone(toR), func(n string) bool { return slices.Contains(fromR, n) }); len(added) > 0 {
+		s.append(s.grantRoles(modify, to, added))
+	}
+	if removed := slices.DeleteFunc(slices.Clone(fromR), func(n string) bool { return slices.Contains(toR, n) }); len(removed) > 0 {
+		c := s.grantRoles(modify, to, removed)
+		c.Cmd, c.Reverse = c.Reverse.(string), c.Cmd
+		c.Comment = fmt.Sprintf("revoke roles from %s %q", to.kind, to.name)
+		s.append(c)
+	}
+}
+
+// grantRoles returns the change for granting the given roles to the role or the user.
+func (s *state) grantRoles(src schema.Change, a *account, roles []string) *migrate.Change {
+	names := func(b *sqlx.Builder) *sqlx.Builder {
+		return b.MapComma(roles, func(i int, b *sqlx.Builder) { b.P(quoteString(roles[i])) })
+	}
+	return &migrate.Change{
+		Cmd:     names(s.Build("GRANT")).P("TO", a.String()).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("grant roles to %s %q", a.kind, a.name),
+		Reverse: names(s.Build("REVOKE")).P("FROM", a.String()).String(),
+	}
+}
+
+// addPermission builds and appends the migrate.Change for granting the privileges of a permission.
+func (s *state) addPermission(src schema.Change, p *schema.Permission) error {
+	privs := sqlx.Privileges(p)
+	grant, err := s.grant(p, privs)
+	if err != nil {
+		return err
+	}
+	revoke, err := s.revoke(p, privs)
+	if err != nil {
+		return err
+	}
+	a := accountOf(p.To)
+	s.append(&migrate.Change{
+		Cmd:     grant,
+		Source:  src,
+		Comment: fmt.Sprintf("grant privileges on %s to %s %q", permissionResource(p), a.kind, a.name),
+		Reverse: revoke,
+	})
+	return nil
+}
+
+// dropPermission builds and appends the migrate.Change for revoking the privileges of a permission.
+func (s *state) dropPermission(src schema.Change, p *schema.Permission) error {
+	privs := sqlx.Privileges(p)
+	grant, err := s.grant(p, privs)
+	if err != nil {
+		return err
+	}
+	revoke, err := s.revoke(p, privs)
+	if err != nil {
+		return err
+	}
+	a := accountOf(p.To)
+	s.append(&migrate.Change{
+		Cmd:     revoke,
+		Source:  src,
+		Comment: fmt.Sprintf("revoke privileges on %s from %s %q", permissionResource(p), a.kind, a.name),
+		Reverse: grant,
+	})
+	return nil
+}
+
+// modifyPermission builds and appends the migrate.Changes for granting
+// the added privileges of a permission and revoking the removed ones.
+func (s *state) modifyPermission(modify *schema.ModifyObject, from, to *schema.Permission) error {
+	fromP, toP := permissionPrivileges(from), permissionPrivileges(to)
+	if added := slices.DeleteFunc(slices.Clone(toP), func(p string) bool { return slices.Contains(fromP, p) }); len(added) > 0 {
+		if err := s.addPermission(modify, &schema.Permission{To: to.To, Schema: to.Schema, Table: to.Table, Columns: to.Columns, Privileges: added, Grantable: to.Grantable}); err != nil {
+			return err
+		}
+	}
+	if removed := slices.DeleteFunc(slices.Clone(fromP), func(p string) bool { return slices.Contains(toP, p) }); len(removed) > 0 {
+		if err := s.dropPermission(modify, &schema.Permission{To: to.To, Schema: to.Schema, Table: to.Table, Columns: to.Columns, Privileges: removed, Grantable: to.Grantable}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grant returns the 'GRANT' statement of the given privileges of a permission.
+func (s *state) grant(p *schema.Permission, privs []string) (string, error) {
+	b, err := s.privileges(s.Build("GRANT"), p, privs)
+	if err != nil {
+		return "", err
+	}
+	b.P("TO", accountOf(p.To).String())
+	if p.Grantable {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), nil
+}
+
+// revoke returns the 'REVOKE' statement of the given privileges of a permission.
+func (s *state) revoke(p *schema.Permission, privs []string) (string, error) {
+	b, err := s.privileges(s.Build("REVOKE"), p, privs)
+	if err != nil {
+		return "", err
+	}
+	return b.P("FROM", accountOf(p.To).String()).String(), nil
+}
+
+// privileges writes the privileges of a permission and the resource they are granted on.
+func (s *state) privileges(b *sqlx.Builder, p *schema.Permission, privs []string) (*sqlx.Builder, error) {
+	if accountOf(p.To) == nil {
+		return nil, fmt.Errorf("unexpected permission grantee: %T", p.To)
+	}
+	b.MapComma(privs, func(i int, b *sqlx.Builder) {
+		b.P(privs[i])
+		if len(p.Columns) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(p.Columns, func(i int, b *sqlx.Builder) { b.Ident(p.Columns[i].Name) })
+			})
+		}
+	})
+	switch {
+	case p.Table != nil:
+		b.P("ON").Table(p.Table)
+	case p.Schema != nil:
+		b.P("ON", fmt.Sprintf("%c%s%c.*", b.QuoteOpening, p.Schema.Name, b.QuoteClosing))
+	default:
+		return nil, errors.New("missing resource for permission")
+	}
+	return b, nil
+}
+
+// permissionResource returns a description of the permission resource.
+func permissionResource(p *schema.Permission) string {
+	switch {
+	case p.Table != nil && len(p.Columns) > 0:
+		return fmt.Sprintf("columns of table %q", p.Table.Name)
+	case p.Table != nil:
+		return fmt.Sprintf("table %q", p.Table.Name)
+	default:
+		return fmt.Sprintf("schema %q", p.Schema.Name)
+	}
+}
+
+// quoteString returns the given string as a single-quoted SQL string literal.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// createRoutine returns the 'CREATE FUNCTION|PROCEDURE' statement of the given object.
+func (s *state) createRoutine(o schema.Object) (string, error) {
+	var (
+		args  []*schema.FuncArg
+		attrs []schema.Attr
+		body  string
+		b     = s.Build("CREATE")
+	)
+	switch o := o.(type) {
+	case *schema.Func:
+		b.P("FUNCTION").SchemaResource(o.Schema, o.Name)
+		args, attrs, body = o.Args, o.Attrs, o.Body
+	case *schema.Proc:
+		b.P("PROCEDURE").SchemaResource(o.Schema, o.Name)
+		args, attrs, body = o.Args, o.Attrs, o.Body
+	default:
+		return "", fmt.Errorf("unsupported object type %T", o)
+	}
+	_, isProc := o.(*schema.Proc)
+	err := b.WrapErr(func(b *sqlx.Builder) error {
+		return b.MapCommaErr(args, func(i int, b *sqlx.Builder) error {
+			typ, err := FormatType(args[i].Type)
+			if err != nil {
+				return fmt.Errorf("format type for argument %q: %w", args[i].Name, err)
+			}
+			// Only procedures support argument modes.
+			if isProc {
+				b.P(string(argMode(args[i])))
+			}
+			b.Ident(args[i].Name).P(typ)
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if f, ok := o.(*schema.Func); ok {
+		if f.Ret == nil {
+			return "", fmt.Errorf("missing return type for function %q", f.Name)
+		}
+		typ, err := FormatType(f.Ret)
+		if err != nil {
+			return "", fmt.Errorf("format return type of function %q: %w", f.Name, err)
+		}
+		b.P("RETURNS", typ)
+		if deterministic(f.Attrs) {
+			b.P(Deterministic)
+		}
+	}
+	if c := (schema.Comment{}); sqlx.Has(attrs, &c) && c.Text != "" {
+		b.P("COMMENT", quote(c.Text))
+	}
+	return b.P(strings.TrimSuffix(strings.TrimSpace(body), ";")).String(), nil
+}
+
+// dropRoutine returns the 'DROP FUNCTION|PROCEDURE' statement of the given object.
+func (s *state) dropRoutine(o schema.Object, ifExists bool) string {
+	b := s.Build("DROP")
+	switch o.(type) {
+	case *schema.Func:
+		b.P("FUNCTION")
+	case *schema.Proc:
+		b.P("PROCEDURE")
+	}
+	if ifExists {
+		b.P("IF EXISTS")
+	}
+	switch o := o.(type) {
+	case *schema.Func:
+		b.SchemaResource(o.Schema, o.Name)
+	case *schema.Proc:
+		b.SchemaResource(o.Schema, o.Name)
+	}
+	return b.String()
+}
+
+// routineComment returns the description of a function or a procedure used in change comments.
+func routineComment(o schema.Object) string {
+	switch o := o.(type) {
+	case *schema.Func:
+		return fmt.Sprintf("%q function", o.Name)
+	case *schema.Proc:
+		return fmt.Sprintf("%q procedure", o.Name)
+	}
+	return fmt.Sprintf("%T object", o)
+}
+
+// addTrigger builds and appends the migrate.Change for creating a trigger.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	create, err := s.createTrigger(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create trigger %q", add.T.Name),
+		Reverse: s.dropTriggerCmd(add.T, false),
+	})
+	return nil
+}
+
+// dropTrigger builds and appends the migrate.Change for dropping a trigger.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	create, err := s.createTrigger(drop.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.dropTriggerCmd(drop.T, sqlx.Has(drop.Extra, &schema.IfExists{})),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop trigger %q", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyTrigger builds and appends the migrate.Changes for modifying a trigger.
+// MySQL does not support altering or replacing existing triggers, and therefore,
+// the trigger is dropped and recreated.
+func (s *state) modifyTrigger(modify *schema.ModifyTrigger) error {
+	if err := s.dropTrigger(&schema.DropTrigger{T: modify.From}); err != nil {
+		return err
+	}
+	return s.addTrigger(&schema.AddTrigger{T: modify.To})
+}
+
+// renameTrigger builds and appends the migrate.Changes for renaming a trigger.
+// MySQL does not support renaming triggers, and therefore, the trigger is
+// dropped and recreated with its new name.
+func (s *state) renameTrigger(c *schema.RenameTrigger) error {
+	return s.modifyTrigger(&schema.ModifyTrigger{From: c.From, To: c.To})
+}
+
+// createTrigger returns the 'CREATE TRIGGER' statement of the given trigger.
+func (s *state) createTrigger(t *schema.Trigger) (string, error) {
+	switch {
+	case t.Table == nil:
+		return "", fmt.Errorf("missing table for trigger %q", t.Name)
+	case t.ActionTime == "" || t.Body == "":
+		return "", fmt.Errorf("incomplete trigger definition: %q", t.Name)
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("trigger %q must be defined with exactly one event, got %d", t.Name, len(t.Events))
+	case t.ActionTime == schema.TriggerTimeInstead:
+		return "", fmt.Errorf("INSTEAD OF triggers are not supported by MySQL: %q", t.Name)
+	case t.For == schema.TriggerForStmt:
+		return "", fmt.Errorf("statement-level triggers are not supported by MySQL: %q", t.Name)
+	}
+	return s.Build("CREATE TRIGGER").
+		SchemaResource(t.Table.Schema, t.Name).
+		P(string(t.ActionTime), t.Events[0].Name, "ON").
+		Table(t.Table).
+		P("FOR EACH ROW", strings.TrimSuffix(strings.TrimSpace(t.Body), ";")).
+		String(), nil
+}
+
+// dropTriggerCmd returns the 'DROP TRIGGER' statement of the given trigger.
+func (s *state) dropTriggerCmd(t *schema.Trigger, ifExists bool) string {
+	b := s.Build("DROP TRIGGER")
+	if ifExists {
+		b.P("IF EXISTS")
+	}
+	var ns *schema.Schema
+	if t.Table != nil {
+		ns = t.Table.Schema
+	}
+	return b.SchemaResource(ns, t.Name).String()
+}
+
 func (s *state) column(b *sqlx.Builder, t *schema.Table, c *schema.Column) error {
 	typ, err := FormatType(c.Type.Type)
 	if err != nil {
@@ -781,6 +1410,51 @@ func skipAutoChanges(changes []schema.Change) []schema.Change {
 	return planned
 }
 
+// partitionBy writes the PARTITION BY clause of the given partitioning to the builder.
+func partitionBy(b *sqlx.Builder, p *Partition) error {
+	switch t := strings.ToUpper(p.T); t {
+	case PartitionTypeRange, PartitionTypeRangeColumns, PartitionTypeList, PartitionTypeListColumns,
+		PartitionTypeHash, PartitionTypeLinearHash, PartitionTypeKey, PartitionTypeLinearKey:
+		b.P("PARTITION BY", t)
+	default:
+		return fmt.Errorf("unknown partition type: %q", p.T)
+	}
+	// KEY partitioning without columns uses the primary key.
+	if len(p.Parts) == 0 && !strings.HasSuffix(strings.ToUpper(p.T), PartitionTypeKey) {
+		return errors.New("missing parts for partition key")
+	}
+	b.Wrap(func(b *sqlx.Builder) {
+		b.MapComma(p.Parts, func(i int, b *sqlx.Builder) {
+			switch k := p.Parts[i]; {
+			case k.C != nil:
+				b.Ident(k.C.Name)
+			case k.X != nil:
+				b.P(k.X.(*schema.RawExpr).X)
+			}
+		})
+	})
+	if p.Count > 0 {
+		b.P("PARTITIONS", strconv.Itoa(p.Count))
+	}
+	if len(p.Defs) > 0 {
+		b.WriteByte(' ')
+		b.Wrap(func(b *sqlx.Builder) {
+			partitionDefs(b, p.Defs)
+		})
+	}
+	return nil
+}
+
+// partitionDefs writes the given partition definitions to the builder.
+func partitionDefs(b *sqlx.Builder, defs []*PartitionDef) {
+	b.MapComma(defs, func(i int, b *sqlx.Builder) {
+		b.P("PARTITION").Ident(defs[i].Name)
+		if defs[i].Values != "" {
+			b.P("VALUES", defs[i].Values)
+		}
+	})
+}
+
 // checks writes the CHECK constraint to the builder.
 func (s *state) check(b *sqlx.Builder, c *schema.Check) {
 	if c.Name != "" {
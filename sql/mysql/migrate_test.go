@@ -203,13 +203,14 @@ func TestMigrate_DetachCycles(t *testing.T) {
 }
 
 func TestPlanChanges(t *testing.T) {
-	tests := []struct {
+	type testCase struct {
 		version  string
 		changes  []schema.Change
 		options  []migrate.PlanOption
 		wantPlan *migrate.Plan
 		wantErr  bool
-	}{
+	}
+	tests := []testCase{
 		{
 			changes: []schema.Change{
 				&schema.AddTable{T: schema.NewTable("users")},
@@ -1146,6 +1147,328 @@ func TestPlanChanges(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		// Views are dropped before the tables they depend on.
+		func() testCase {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+			return testCase{
+				changes: []schema.Change{
+					&schema.DropTable{T: users},
+					&schema.DropView{V: schema.NewView("v1", "select `users`.`id` AS `id` from `users`").AddDeps(users)},
+					&schema.AddView{V: schema.NewView("v2", "select 1 AS `a`").SetCheckOption(schema.ViewCheckOptionLocal)},
+					&schema.ModifyView{From: schema.NewView("v3", "select 1 AS `a`"), To: schema.NewView("v3", "select 2 AS `a`")},
+					&schema.RenameView{From: schema.NewView("v4", ""), To: schema.NewView("v5", "")},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: false,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "DROP VIEW `v1`",
+							Reverse: "CREATE VIEW `v1` AS select `users`.`id` AS `id` from `users`",
+						},
+						{
+							Cmd:     "CREATE VIEW `v2` AS select 1 AS `a` WITH LOCAL CHECK OPTION",
+							Reverse: "DROP VIEW `v2`",
+						},
+						{
+							Cmd:     "CREATE OR REPLACE VIEW `v3` AS select 2 AS `a`",
+							Reverse: "CREATE OR REPLACE VIEW `v3` AS select 1 AS `a`",
+						},
+						{
+							Cmd:     "RENAME TABLE `v4` TO `v5`",
+							Reverse: "RENAME TABLE `v5` TO `v4`",
+						},
+						{
+							Cmd:     "DROP TABLE `users`",
+							Reverse: "CREATE TABLE `users` (`id` int NOT NULL)",
+						},
+					},
+				},
+			}
+		}(),
+		// Functions are created before the tables that call them, and modified by drop and create.
+		func() testCase {
+			f := schema.NewFunc("next_id", "RETURN 1").
+				SetReturn(&schema.IntegerType{T: TypeInt}).
+				SetVolatility(Deterministic)
+			users := schema.NewTable("users").
+				AddColumns(schema.NewIntColumn("id", TypeInt).SetDefault(&schema.RawExpr{X: "(next_id())"}))
+			p := schema.NewProc("reset", "BEGIN SET n = 0; END").
+				AddArgs(&schema.FuncArg{Name: "n", Type: &schema.IntegerType{T: TypeInt}, Mode: schema.FuncArgModeInOut}).
+				SetComment("reset counter")
+			p2 := schema.NewProc("reset", "BEGIN SET n = 1; END").AddArgs(p.Args...)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: f},
+					&schema.ModifyObject{From: p, To: p2},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "CREATE FUNCTION `next_id` () RETURNS int DETERMINISTIC RETURN 1",
+							Reverse: "DROP FUNCTION `next_id`",
+						},
+						{
+							Cmd:     "CREATE TABLE `users` (`id` int NOT NULL DEFAULT (next_id()))",
+							Reverse: "DROP TABLE `users`",
+						},
+						{
+							Cmd:     "DROP PROCEDURE `reset`",
+							Reverse: "CREATE PROCEDURE `reset` (INOUT `n` int) COMMENT \"reset counter\" BEGIN SET n = 0; END",
+						},
+						{
+							Cmd:     "CREATE PROCEDURE `reset` (INOUT `n` int) BEGIN SET n = 1; END",
+							Reverse: "DROP PROCEDURE `reset`",
+						},
+					},
+				},
+			}
+		}(),
+		func() testCase {
+			users := schema.NewTable("users").
+				AddColumns(schema.NewIntColumn("id", TypeInt))
+			logs := schema.NewTable("logs").
+				AddColumns(schema.NewIntColumn("id", TypeInt))
+			t1 := schema.NewTrigger("users_ins").
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventInsert).
+				SetFor(schema.TriggerForRow).
+				SetBody("SET NEW.id = NEW.id + 1;")
+			users.AddTriggers(t1)
+			t2 := schema.NewTrigger("logs_del").
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventDelete).
+				SetFor(schema.TriggerForRow).
+				SetBody("SET @n = 1")
+			logs.AddTriggers(t2)
+			t3 := schema.NewTrigger("logs_del").
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventDelete).
+				SetFor(schema.TriggerForRow).
+				SetBody("SET @n = 2")
+			logs.AddTriggers(t3)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTrigger{T: t1},
+					&schema.AddTable{T: users},
+					&schema.ModifyTrigger{From: t2, To: t3},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "CREATE TABLE `users` (`id` int NOT NULL)",
+							Reverse: "DROP TABLE `users`",
+						},
+						{
+							Cmd:     "CREATE TRIGGER `users_ins` BEFORE INSERT ON `users` FOR EACH ROW SET NEW.id = NEW.id + 1",
+							Reverse: "DROP TRIGGER `users_ins`",
+						},
+						{
+							Cmd:     "DROP TRIGGER `logs_del`",
+							Reverse: "CREATE TRIGGER `logs_del` AFTER DELETE ON `logs` FOR EACH ROW SET @n = 1",
+						},
+						{
+							Cmd:     "CREATE TRIGGER `logs_del` AFTER DELETE ON `logs` FOR EACH ROW SET @n = 2",
+							Reverse: "DROP TRIGGER `logs_del`",
+						},
+					},
+				},
+			}
+		}(),
+		{
+			changes: []schema.Change{
+				&schema.AddTrigger{
+					T: schema.NewTrigger("t").
+						SetTable(schema.NewTable("users")).
+						SetActionTime(schema.TriggerTimeBefore).
+						AddEvents(schema.TriggerEventInsert, schema.TriggerEventUpdate).
+						SetBody("SET @n = 1"),
+				},
+			},
+			// MySQL triggers are defined with exactly one event.
+			wantErr: true,
+		},
+		func() testCase {
+			c := schema.NewIntColumn("created", "int")
+			t := schema.NewTable("logs").AddColumns(c).AddAttrs(&Partition{
+				T:     PartitionTypeRange,
+				Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created`)"}}},
+				Defs: []*PartitionDef{
+					{Name: "p2020", Values: "LESS THAN (2021)"},
+					{Name: "pmax", Values: "LESS THAN MAXVALUE"},
+				},
+			})
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTable{T: t},
+					&schema.ModifyTable{
+						T: t,
+						Changes: []schema.Change{
+							&AddPartition{Defs: []*PartitionDef{{Name: "p2021", Values: "LESS THAN (2022)"}}},
+							&schema.AddColumn{C: schema.NewIntColumn("level", "int")},
+							&DropPartition{Defs: []*PartitionDef{{Name: "p2019"}, {Name: "p2018"}}},
+							&ReorganizePartition{
+								From: []*PartitionDef{{Name: "pmax", Values: "LESS THAN MAXVALUE"}},
+								To:   []*PartitionDef{{Name: "p2022", Values: "LESS THAN (2023)"}, {Name: "pmax", Values: "LESS THAN MAXVALUE"}},
+							},
+						},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "CREATE TABLE `logs` (`created` int NOT NULL) PARTITION BY RANGE (year(`created`)) (PARTITION `p2020` VALUES LESS THAN (2021), PARTITION `pmax` VALUES LESS THAN MAXVALUE)",
+							Reverse: "DROP TABLE `logs`",
+						},
+						{
+							Cmd:     "ALTER TABLE `logs` ADD COLUMN `level` int NOT NULL",
+							Reverse: "ALTER TABLE `logs` DROP COLUMN `level`",
+						},
+						{
+							Cmd:     "ALTER TABLE `logs` ADD PARTITION (PARTITION `p2021` VALUES LESS THAN (2022))",
+							Reverse: "ALTER TABLE `logs` DROP PARTITION `p2021`",
+						},
+						{
+							Cmd:     "ALTER TABLE `logs` DROP PARTITION `p2019`, `p2018`",
+							Reverse: "ALTER TABLE `logs` ADD PARTITION (PARTITION `p2019`, PARTITION `p2018`)",
+						},
+						{
+							Cmd:     "ALTER TABLE `logs` REORGANIZE PARTITION `pmax` INTO (PARTITION `p2022` VALUES LESS THAN (2023), PARTITION `pmax` VALUES LESS THAN MAXVALUE)",
+							Reverse: "ALTER TABLE `logs` REORGANIZE PARTITION `p2022`, `pmax` INTO (PARTITION `pmax` VALUES LESS THAN MAXVALUE)",
+						},
+					},
+				},
+			}
+		}(),
+		func() testCase {
+			var (
+				c  = schema.NewIntColumn("id", "int")
+				t  = schema.NewTable("users").AddColumns(c)
+				p1 = &Partition{T: PartitionTypeKey, Count: 4}
+				p2 = &Partition{T: PartitionTypeLinearHash, Parts: []*PartitionPart{{C: c}}, Defs: []*PartitionDef{{Name: "a"}, {Name: "b"}}}
+			)
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyTable{
+						T: t,
+						Changes: []schema.Change{
+							&schema.ModifyAttr{From: p1, To: p2},
+							&schema.AddColumn{C: schema.NewIntColumn("age", "int")},
+						},
+					},
+					&schema.ModifyTable{
+						T: t,
+						Changes: []schema.Change{
+							&schema.AddColumn{C: schema.NewIntColumn("name", "int")},
+							&schema.DropAttr{A: p2},
+						},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "ALTER TABLE `users` ADD COLUMN `age` int NOT NULL",
+							Reverse: "ALTER TABLE `users` DROP COLUMN `age`",
+						},
+						{
+							Cmd:     "ALTER TABLE `users` PARTITION BY LINEAR HASH (`id`) (PARTITION `a`, PARTITION `b`)",
+							Reverse: "ALTER TABLE `users` PARTITION BY KEY () PARTITIONS 4",
+						},
+						{
+							Cmd:     "ALTER TABLE `users` REMOVE PARTITIONING",
+							Reverse: "ALTER TABLE `users` PARTITION BY LINEAR HASH (`id`) (PARTITION `a`, PARTITION `b`)",
+						},
+						{
+							Cmd:     "ALTER TABLE `users` ADD COLUMN `name` int NOT NULL",
+							Reverse: "ALTER TABLE `users` DROP COLUMN `name`",
+						},
+					},
+				},
+			}
+		}(),
+		// Accounts are created before the privileges granted to them,
+		// and privileges are revoked before their accounts are dropped.
+		func() testCase {
+			test := schema.New("test")
+			users := schema.NewTable("users").SetSchema(test).
+				AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+			reader, ops := &schema.Role{Name: "reader"}, &schema.Role{Name: "ops"}
+			app := &schema.User{Name: "app", Host: "localhost", MemberOf: []*schema.Role{reader}}
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddObject{O: &schema.Permission{To: app, Schema: test, Table: users, Columns: users.Columns[1:], Privileges: []string{PrivilegeUpdate}}},
+					&schema.AddObject{O: app},
+					&schema.DropObject{O: ops},
+					&schema.DropObject{O: &schema.Permission{To: ops, Schema: test, Privileges: []string{PrivilegeAll}, Grantable: true}},
+					&schema.AddTable{T: users},
+					&schema.ModifyObject{
+						From: &schema.Permission{To: reader, Schema: test, Privileges: []string{PrivilegeSelect}},
+						To:   &schema.Permission{To: reader, Schema: test, Privileges: []string{PrivilegeShowView}},
+					},
+					&schema.ModifyObject{
+						From: &schema.User{Name: "admin", Host: "%", MemberOf: []*schema.Role{ops}},
+						To:   &schema.User{Name: "admin", Host: "%", MemberOf: []*schema.Role{reader}},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "CREATE USER 'app'@'localhost'",
+							Reverse: "DROP USER 'app'@'localhost'",
+						},
+						{
+							Cmd:     "GRANT 'reader' TO 'app'@'localhost'",
+							Reverse: "REVOKE 'reader' FROM 'app'@'localhost'",
+						},
+						{
+							Cmd:     "CREATE TABLE `test`.`users` (`id` int NOT NULL, `name` text NOT NULL)",
+							Reverse: "DROP TABLE `test`.`users`",
+						},
+						{
+							Cmd:     "GRANT UPDATE (`name`) ON `test`.`users` TO 'app'@'localhost'",
+							Reverse: "REVOKE UPDATE (`name`) ON `test`.`users` FROM 'app'@'localhost'",
+						},
+						{
+							Cmd:     "GRANT SHOW VIEW ON `test`.* TO 'reader'",
+							Reverse: "REVOKE SHOW VIEW ON `test`.* FROM 'reader'",
+						},
+						{
+							Cmd:     "REVOKE SELECT ON `test`.* FROM 'reader'",
+							Reverse: "GRANT SELECT ON `test`.* TO 'reader'",
+						},
+						{
+							Cmd:     "GRANT 'reader' TO 'admin'@'%'",
+							Reverse: "REVOKE 'reader' FROM 'admin'@'%'",
+						},
+						{
+							Cmd:     "REVOKE 'ops' FROM 'admin'@'%'",
+							Reverse: "GRANT 'ops' TO 'admin'@'%'",
+						},
+						{
+							Cmd:     "REVOKE ALL ON `test`.* FROM 'ops'",
+							Reverse: "GRANT ALL ON `test`.* TO 'ops' WITH GRANT OPTION",
+						},
+						{
+							Cmd: "DROP ROLE 'ops'",
+						},
+					},
+				},
+			}
+		}(),
+		{
+			changes: []schema.Change{
+				&schema.AddTable{
+					T: schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int")).AddAttrs(&Partition{T: "SYSTEM_TIME"}),
+				},
+			},
+			// Unknown partition type.
+			wantErr: true,
+		},
 	}
 	for i, tt := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -0,0 +1,465 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package mysqlcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/mysql/internal/mysqlversion"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+// AlgorithmAnalyzer classifies the changes of ALTER TABLE statements by the
+// online-DDL algorithm the server uses to execute them (INSTANT, INPLACE or
+// COPY), and reports changes that force the server to copy the table.
+type AlgorithmAnalyzer struct {
+	sqlcheck.Options
+
+	// Explicit indicates if statements that can run without copying the
+	// table should be reported in case they do not set the ALGORITHM clause.
+	// It is disabled by default, and can be enabled in the lint policy:
+	//
+	//	lint {
+	//	  online_ddl {
+	//	    explicit_algorithm = true
+	//	  }
+	//	}
+	Explicit bool `spec:"explicit_algorithm"`
+}
+
+// NewAlgorithmAnalyzer creates a new online-DDL algorithm Analyzer with the given options.
+func NewAlgorithmAnalyzer(r *schemahcl.Resource) (*AlgorithmAnalyzer, error) {
+	az := &AlgorithmAnalyzer{}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing online_ddl check options: %w", err)
+		}
+		if err := r.As(az); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing online_ddl check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+var (
+	// codeTableCopy is a MySQL specific code for reporting changes that copy the table.
+	codeTableCopy = sqlcheck.Code("MY103")
+	// codeAlgorithm is a MySQL specific code for suggesting explicit ALGORITHM clauses.
+	codeAlgorithm = sqlcheck.Code("MY104")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*AlgorithmAnalyzer) Name() string {
+	return "online_ddl"
+}
+
+// The online-DDL algorithms, ordered by their cost.
+type algorithm uint
+
+const (
+	algInstant algorithm = iota
+	algInplace
+	algCopy
+)
+
+// String implements fmt.Stringer.
+func (a algorithm) String() string {
+	switch a {
+	case algInstant:
+		return "INSTANT"
+	case algInplace:
+		return "INPLACE"
+	default:
+		return "COPY"
+	}
+}
+
+// alterOp describes the algorithm and the lock level used to execute a change.
+type alterOp struct {
+	alg algorithm
+	// Lock level that is permitted by the algorithm. Empty means NONE.
+	lock string
+	// Describes the change, for reporting (e.g., Changing the type of column "c").
+	desc string
+}
+
+var (
+	reAlterTable   = regexp.MustCompile(`(?i)^\s*ALTER\s+(?:ONLINE\s+)?(?:IGNORE\s+)?TABLE\b`)
+	reCreateIndex  = regexp.MustCompile(`(?i)^\s*CREATE\s+(?:(?:UNIQUE|FULLTEXT|SPATIAL)\s+)?INDEX\b`)
+	reAlgorithm    = regexp.MustCompile(`(?i)\bALGORITHM\s*=?\s*\w+`)
+	reFKChecksOff  = regexp.MustCompile(`(?i)\bSET\s+(?:SESSION\s+|@@SESSION\.|@@)?FOREIGN_KEY_CHECKS\s*=\s*(?:0|OFF)\b`)
+	reStmtTerminal = regexp.MustCompile(`\s*;?\s*$`)
+	reModifyColumn = regexp.MustCompile("(?is)\\b(MODIFY|CHANGE)\\s+(?:COLUMN\\s+)?(`(?:[^`]|``)+`|\\w+)(?:\\s+(`(?:[^`]|``)+`|\\w+))?")
+	rePosition     = regexp.MustCompile("(?is)\\s(?:FIRST|AFTER\\s+(?:`(?:[^`]|``)+`|\\w+))$")
+)
+
+// Analyze implements sqlcheck.Analyzer.
+func (a *AlgorithmAnalyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
+	if p.Dev == nil {
+		return nil
+	}
+	drv, ok := p.Dev.Driver.(*mysql.Driver)
+	if !ok {
+		return nil
+	}
+	v := mysqlversion.V(drv.Version())
+	classify := mysqlOp
+	switch {
+	case v.TiDB():
+		classify = tidbOp
+	case v.Maria():
+		classify = mariaOp
+	}
+	var (
+		diags       []sqlcheck.Diagnostic
+		fkChecksOff bool
+	)
+	for _, sc := range p.File.Changes {
+		if reFKChecksOff.MatchString(sc.Stmt.Text) {
+			fkChecksOff = true
+		}
+		isAlter, isIndex := reAlterTable.MatchString(sc.Stmt.Text), reCreateIndex.MatchString(sc.Stmt.Text)
+		if !isAlter && !isIndex {
+			continue
+		}
+		var ops []*alterOp
+		for _, c := range sc.Changes {
+			m, ok := c.(*schema.ModifyTable)
+			if !ok || p.File.TableSpan(m.T)&sqlcheck.SpanAdded != 0 {
+				continue
+			}
+			for _, mc := range m.Changes {
+				if op := classify(v, sc.Stmt, m, mc, fkChecksOff); op != nil {
+					ops = append(ops, op)
+				}
+			}
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		worst := slices.MaxFunc(ops, func(a, b *alterOp) int { return int(a.alg) - int(b.alg) })
+		switch {
+		case worst.alg == algCopy:
+			for _, op := range ops {
+				if op.alg != algCopy {
+					continue
+				}
+				d := sqlcheck.Diagnostic{
+					Code: codeTableCopy,
+					Pos:  sc.Stmt.Pos,
+					Text: fmt.Sprintf("%s requires a table copy (ALGORITHM=COPY) that blocks concurrent writes", op.desc),
+				}
+				if v.TiDB() {
+					d.Text = fmt.Sprintf("%s requires reorganizing the data of all rows in table", op.desc)
+				}
+				// Statements that set the ALGORITHM clause explicitly are not changed.
+				if isAlter && !v.TiDB() && !reAlgorithm.MatchString(sc.Stmt.Text) {
+					d.SuggestFix(
+						"Set the ALGORITHM and LOCK clauses explicitly to make the table copy visible in the migration",
+						p.File.StmtTextEdit(sc.Stmt, withAlgorithm(sc.Stmt.Text, isAlter, algCopy, "SHARED")),
+					)
+				}
+				diags = append(diags, d)
+			}
+		// TiDB accepts the ALGORITHM clause only for compatibility.
+		case a.Explicit && !reAlgorithm.MatchString(sc.Stmt.Text) && !v.TiDB():
+			lock := worst.lock
+			if worst.alg == algInplace {
+				for _, op := range ops {
+					if op.lock != "" {
+						lock = op.lock
+					}
+				}
+				if lock == "" {
+					lock = "NONE"
+				}
+			}
+			d := sqlcheck.Diagnostic{
+				Code: codeAlgorithm,
+				Pos:  sc.Stmt.Pos,
+				Text: fmt.Sprintf("Statement can be executed with ALGORITHM=%s, but the server may silently fall back to a slower algorithm", worst.alg),
+			}
+			d.SuggestFix(
+				fmt.Sprintf("Add ALGORITHM=%s to fail the statement instead of copying the table", worst.alg),
+				p.File.StmtTextEdit(sc.Stmt, withAlgorithm(sc.Stmt.Text, isAlter, worst.alg, lock)),
+			)
+			diags = append(diags, d)
+		}
+	}
+	if len(diags) > 0 {
+		const reportText = "online-DDL algorithm changes detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+// withAlgorithm returns the statement with the given ALGORITHM and LOCK clauses.
+func withAlgorithm(stmt string, alter bool, alg algorithm, lock string) string {
+	sep := " "
+	if alter {
+		sep = ", "
+	}
+	clause := "ALGORITHM=" + alg.String()
+	// The LOCK clause cannot be used with ALGORITHM=INSTANT.
+	if alg != algInstant && lock != "" {
+		clause += sep + "LOCK=" + lock
+	}
+	return reStmtTerminal.ReplaceAllString(stmt, "") + sep + clause + ";"
+}
+
+// mysqlOp returns the algorithm MySQL uses to execute the given change.
+// See: https://dev.mysql.com/doc/refman/8.0/en/innodb-online-ddl-operations.html
+func mysqlOp(v mysqlversion.V, s *migrate.Stmt, m *schema.ModifyTable, c schema.Change, fkChecksOff bool) *alterOp {
+	switch c := c.(type) {
+	case *schema.AddColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Adding column %q", c.C.Name)}
+		switch g := (&schema.GeneratedExpr{}); {
+		case sqlx.Has(c.C.Attrs, g) && storedExpr(g):
+			op.alg = algCopy
+			op.desc = fmt.Sprintf("Adding stored generated column %q", c.C.Name)
+		case sqlx.Has(c.C.Attrs, g):
+			op.alg = algInstant
+		case sqlx.Has(c.C.Attrs, &mysql.AutoIncrement{}):
+			op.lock = "SHARED"
+		case v.GTE("8.0.29"), v.GTE("8.0.12") && lastColumn(m.T, c.C):
+			op.alg = algInstant
+		}
+		return op
+	case *schema.DropColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Dropping column %q", c.C.Name)}
+		if v.GTE("8.0.29") && !storedColumn(c.C) {
+			op.alg = algInstant
+		}
+		return op
+	case *schema.RenameColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Renaming column %q", c.From.Name)}
+		if v.GTE("8.0.28") {
+			op.alg = algInstant
+		}
+		return op
+	case *schema.ModifyColumn:
+		op := &alterOp{alg: algInstant, desc: fmt.Sprintf("Modifying column %q", c.To.Name)}
+		switch {
+		case repositioned(s, c.To):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Reordering column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeType):
+			op.alg, op.desc = typeChangeOp(c.From.Type.Type, c.To.Type.Type), fmt.Sprintf("Changing the type of column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeCharset) || c.Change.Is(schema.ChangeCollate):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the character set of column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeGenerated) && storedColumn(c.To):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the stored generated column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeNull) || c.Change.Is(schema.ChangeGenerated):
+			op.alg = algInplace
+		}
+		return op
+	case *schema.AddIndex:
+		return indexOp(c.I)
+	case *schema.DropIndex, *schema.ModifyIndex:
+		return &alterOp{alg: algInplace}
+	case *schema.RenameIndex:
+		return &alterOp{alg: algInstant}
+	case *schema.AddPrimaryKey:
+		return &alterOp{alg: algInplace}
+	case *schema.ModifyPrimaryKey:
+		return &alterOp{alg: algInplace}
+	case *schema.DropPrimaryKey:
+		return &alterOp{alg: algCopy, desc: "Dropping the primary key"}
+	case *schema.AddForeignKey:
+		return fkOp(c.F, fkChecksOff)
+	case *schema.DropForeignKey:
+		return &alterOp{alg: algInplace}
+	case *schema.AddAttr, *schema.ModifyAttr:
+		return attrOp(c)
+	}
+	return nil
+}
+
+// mariaOp returns the algorithm MariaDB uses to execute the given change.
+// See: https://mariadb.com/kb/en/innodb-online-ddl-operations-with-the-instant-alter-algorithm/
+func mariaOp(v mysqlversion.V, s *migrate.Stmt, m *schema.ModifyTable, c schema.Change, fkChecksOff bool) *alterOp {
+	switch c := c.(type) {
+	case *schema.AddColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Adding column %q", c.C.Name)}
+		switch g := (&schema.GeneratedExpr{}); {
+		case sqlx.Has(c.C.Attrs, g) && storedExpr(g):
+			op.alg = algCopy
+			op.desc = fmt.Sprintf("Adding stored generated column %q", c.C.Name)
+		case sqlx.Has(c.C.Attrs, &mysql.AutoIncrement{}):
+			op.lock = "SHARED"
+		case v.GTE("10.4.0"), v.GTE("10.3.2") && lastColumn(m.T, c.C):
+			op.alg = algInstant
+		}
+		return op
+	case *schema.DropColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Dropping column %q", c.C.Name)}
+		if v.GTE("10.4.0") {
+			op.alg = algInstant
+		}
+		return op
+	case *schema.RenameColumn:
+		op := &alterOp{alg: algInplace, desc: fmt.Sprintf("Renaming column %q", c.From.Name)}
+		if v.GTE("10.3.0") {
+			op.alg = algInstant
+		}
+		return op
+	case *schema.ModifyColumn:
+		op := &alterOp{alg: algInstant, desc: fmt.Sprintf("Modifying column %q", c.To.Name)}
+		switch {
+		// Reordering columns is an instant operation since MariaDB 10.4.
+		case repositioned(s, c.To) && v.LT("10.4.0"):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Reordering column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeType):
+			op.alg, op.desc = typeChangeOp(c.From.Type.Type, c.To.Type.Type), fmt.Sprintf("Changing the type of column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeCharset) || c.Change.Is(schema.ChangeCollate):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the character set of column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeGenerated) && storedColumn(c.To):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the stored generated column %q", c.To.Name)
+		// Changing a column to NULL is an instant operation since MariaDB 10.4.
+		case c.Change.Is(schema.ChangeNull) && (!c.To.Type.Null || v.LT("10.4.0")), c.Change.Is(schema.ChangeGenerated):
+			op.alg = algInplace
+		}
+		return op
+	case *schema.AddIndex:
+		return indexOp(c.I)
+	case *schema.DropIndex, *schema.ModifyIndex:
+		return &alterOp{alg: algInplace}
+	case *schema.RenameIndex:
+		return &alterOp{alg: algInstant}
+	case *schema.AddPrimaryKey, *schema.ModifyPrimaryKey:
+		return &alterOp{alg: algInplace}
+	case *schema.DropPrimaryKey:
+		return &alterOp{alg: algCopy, desc: "Dropping the primary key"}
+	case *schema.AddForeignKey:
+		return fkOp(c.F, fkChecksOff)
+	case *schema.DropForeignKey:
+		return &alterOp{alg: algInstant}
+	case *schema.AddAttr, *schema.ModifyAttr:
+		return attrOp(c)
+	}
+	return nil
+}
+
+// typeChangeOp returns the algorithm used for changing the column type. Extending VARCHAR
+// columns without changing the number of length bytes, and appending values to ENUM or
+// SET columns without changing their storage size are executed without copying the table.
+func typeChangeOp(from, to schema.Type) algorithm {
+	switch from := from.(type) {
+	case *schema.StringType:
+		// The number of length bytes is computed based on the maximum byte length of
+		// the column. Since the character set is unknown at this stage, we assume the
+		// worst case of 4 bytes per character (utf8mb4).
+		if to, ok := to.(*schema.StringType); ok && from.T == mysql.TypeVarchar && to.T == mysql.TypeVarchar &&
+			to.Size >= from.Size && (from.Size*4 < 256) == (to.Size*4 < 256) {
+			return algInplace
+		}
+	case *schema.EnumType:
+		if to, ok := to.(*schema.EnumType); ok && appended(from.Values, to.Values) && (len(from.Values) < 256) == (len(to.Values) < 256) {
+			return algInstant
+		}
+	case *mysql.SetType:
+		if to, ok := to.(*mysql.SetType); ok && appended(from.Values, to.Values) && (len(from.Values)+7)/8 == (len(to.Values)+7)/8 {
+			return algInstant
+		}
+	}
+	return algCopy
+}
+
+// indexOp returns the algorithm used for creating the given index.
+func indexOp(idx *schema.Index) *alterOp {
+	op := &alterOp{alg: algInplace}
+	if t := (&mysql.IndexType{}); sqlx.Has(idx.Attrs, t) && (strings.EqualFold(t.T, mysql.IndexTypeFullText) || strings.EqualFold(t.T, mysql.IndexTypeSpatial)) {
+		op.lock = "SHARED"
+	}
+	return op
+}
+
+// fkOp returns the algorithm used for adding the given foreign key. The INPLACE
+// algorithm is supported only when the foreign_key_checks variable is disabled.
+func fkOp(fk *schema.ForeignKey, checksOff bool) *alterOp {
+	if checksOff {
+		return &alterOp{alg: algInplace}
+	}
+	return &alterOp{alg: algCopy, desc: fmt.Sprintf("Adding foreign key %q", fk.Symbol)}
+}
+
+// attrOp returns the algorithm used for changing the table attributes.
+func attrOp(c schema.Change) *alterOp {
+	var a schema.Attr
+	switch c := c.(type) {
+	case *schema.AddAttr:
+		a = c.A
+	case *schema.ModifyAttr:
+		a = c.To
+	}
+	switch a.(type) {
+	case *schema.Comment:
+		return &alterOp{alg: algInstant}
+	case *schema.Charset, *schema.Collation:
+		return &alterOp{alg: algCopy, desc: "Converting the table character set"}
+	case *mysql.Engine:
+		return &alterOp{alg: algCopy, desc: "Changing the table engine"}
+	default:
+		return &alterOp{alg: algInplace}
+	}
+}
+
+// lastColumn reports if the column is the last column in the table.
+func lastColumn(t *schema.Table, c *schema.Column) bool {
+	return len(t.Columns) > 0 && t.Columns[len(t.Columns)-1].Name == c.Name
+}
+
+// repositioned reports if the statement changes the column position (FIRST or AFTER).
+func repositioned(s *migrate.Stmt, c *schema.Column) bool {
+	for _, spec := range sqlx.SplitExprs(reStmtTerminal.ReplaceAllString(s.Text, "")) {
+		m := reModifyColumn.FindStringSubmatch(spec)
+		if m == nil || !rePosition.MatchString(spec) {
+			continue
+		}
+		// The new name of the column follows the old one in CHANGE specs.
+		name := m[2]
+		if strings.EqualFold(m[1], "CHANGE") {
+			name = m[3]
+		}
+		if strings.EqualFold(unquote(name), c.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// unquote returns the unquoted form of the given identifier.
+func unquote(name string) string {
+	if len(name) > 1 && name[0] == '`' && name[len(name)-1] == '`' {
+		return strings.ReplaceAll(name[1:len(name)-1], "``", "`")
+	}
+	return name
+}
+
+// appended reports if the "to" values were created by appending values to "from".
+func appended(from, to []string) bool {
+	return len(to) >= len(from) && slices.Equal(from, to[:len(from)])
+}
+
+func storedColumn(c *schema.Column) bool {
+	g := &schema.GeneratedExpr{}
+	return sqlx.Has(c.Attrs, g) && storedExpr(g)
+}
+
+func storedExpr(g *schema.GeneratedExpr) bool {
+	return strings.EqualFold(g.Type, "STORED") || strings.EqualFold(g.Type, "PERSISTENT")
+}
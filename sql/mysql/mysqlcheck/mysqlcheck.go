@@ -18,8 +18,11 @@ import (
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/dataloss"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
+	"ariga.io/atlas/sql/sqlcheck/naming"
 )
 
 var (
@@ -212,19 +215,49 @@ func analyzers(r *schemahcl.Resource) ([]sqlcheck.Analyzer, error) {
 	if err != nil {
 		return nil, err
 	}
+	al, err := NewAlgorithmAnalyzer(r)
+	if err != nil {
+		return nil, err
+	}
 	dd, err := datadepend.New(r, datadepend.Handler{
 		AddNotNull: addNotNull,
 	})
 	if err != nil {
 		return nil, err
 	}
+	dl, err := dataloss.New(r, dataloss.Handler{
+		Length: "CHAR_LENGTH",
+	})
+	if err != nil {
+		return nil, err
+	}
 	bc, err := incompatible.New(r)
 	if err != nil {
 		return nil, err
 	}
-	return []sqlcheck.Analyzer{ds, dd, cd, bc, sqlcheck.AnalyzerFunc(inlineRefs)}, nil
+	// See: https://dev.mysql.com/doc/refman/8.0/en/identifier-length.html
+	nm, err := naming.New(r, 64)
+	if err != nil {
+		return nil, err
+	}
+	return []sqlcheck.Analyzer{ds, al, dd, dl, cd, bc, nm, sqlcheck.AnalyzerFunc(inlineRefs)}, nil
+}
+
+func realmAnalyzers(r *schemahcl.Resource) ([]sqlcheck.RealmAnalyzer, error) {
+	sd, err := design.New(r, design.Handler{
+		// InnoDB creates an index for foreign keys without one.
+		ImplicitFKIndex: true,
+		BoundedPart: func(p *schema.IndexPart) bool {
+			return sqlx.Has(p.Attrs, &mysql.SubPart{})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []sqlcheck.RealmAnalyzer{sd}, nil
 }
 
 func init() {
 	sqlcheck.Register(mysql.DriverName, analyzers)
+	sqlcheck.RegisterRealm(mysql.DriverName, realmAnalyzers)
 }
@@ -6,12 +6,14 @@ package mysqlcheck_test
 
 import (
 	"context"
+	"slices"
 	"testing"
 
+	"ariga.io/atlas/schemahcl"
 	"ariga.io/atlas/sql/internal/sqltest"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/mysql"
-	_ "ariga.io/atlas/sql/mysql/mysqlcheck"
+	"ariga.io/atlas/sql/mysql/mysqlcheck"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlclient"
@@ -169,6 +171,98 @@ func TestDataDepend_MySQL_MightFail(t *testing.T) {
 	require.Equal(t, report.Diagnostics[2].Text, `Adding a non-nullable "point" column "d" will fail in case table "users" is not empty`)
 }
 
+func TestDataLoss_MySQL(t *testing.T) {
+	db, mk, err := sqlmock.New()
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape("SELECT @@version, @@collation_server, @@character_set_server, @@lower_case_table_names")).
+		WillReturnRows(sqltest.Rows(`
++-----------------+--------------------+------------------------+--------------------------+
+| @@version       | @@collation_server | @@character_set_server | @@lower_case_table_names |
++-----------------+--------------------+------------------------+--------------------------+
+| 8.0.19          | utf8_general_ci    | utf8                   | 0                        |
++-----------------+--------------------+------------------------+--------------------------+
+`))
+	drv, err := mysql.Open(db)
+	require.NoError(t, err)
+	expectCounts := func(name, id, status int) {
+		mk.ExpectQuery(sqltest.Escape("SELECT COUNT(*) FROM `test`.`users` WHERE CHAR_LENGTH(`name`) > 50")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(name))
+		mk.ExpectQuery(sqltest.Escape("SELECT COUNT(*) FROM `test`.`users` WHERE `id` < -2147483648 OR `id` > 2147483647")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(id))
+		mk.ExpectQuery(sqltest.Escape("SELECT COUNT(*) FROM `test`.`users` WHERE `status` IN ('deleted', 'it''s')")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(status))
+	}
+	expectCounts(3, 0, 1)
+	var (
+		reports []sqlcheck.Report
+		users   = schema.NewTable("users").SetSchema(schema.New("test"))
+		pass    = &sqlcheck.Pass{
+			Dev: &sqlclient.Client{Name: "mysql", Driver: drv},
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql"},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: &migrate.Stmt{
+							Text: "ALTER TABLE users",
+						},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T: users,
+								Changes: []schema.Change{
+									&schema.ModifyColumn{
+										From:   schema.NewStringColumn("name", mysql.TypeVarchar, schema.StringSize(255)),
+										To:     schema.NewStringColumn("name", mysql.TypeVarchar, schema.StringSize(50)),
+										Change: schema.ChangeType,
+									},
+									&schema.ModifyColumn{
+										From:   schema.NewIntColumn("id", mysql.TypeBigInt),
+										To:     schema.NewIntColumn("id", mysql.TypeInt),
+										Change: schema.ChangeType,
+									},
+									&schema.ModifyColumn{
+										From:   schema.NewEnumColumn("status", schema.EnumValues("active", "deleted", "it's")),
+										To:     schema.NewEnumColumn("status", schema.EnumValues("active")),
+										Change: schema.ChangeType,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				reports = append(reports, r)
+			}),
+		}
+	)
+	azs, err := sqlcheck.AnalyzerFor(mysql.DriverName, nil)
+	require.NoError(t, err)
+	require.NoError(t, sqlcheck.Analyzers(azs).Analyze(context.Background(), pass))
+	require.NoError(t, mk.ExpectationsWereMet())
+	idx := slices.IndexFunc(reports, func(r sqlcheck.Report) bool { return r.Text == "data loss changes detected" })
+	require.NotEqual(t, -1, idx)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "DL101", Text: `Shrinking column "name" of table "users" from varchar(255) to varchar(50) might truncate existing values. 3 existing rows in the dev database are affected`},
+		{Code: "DL102", Text: `Narrowing column "id" of table "users" from bigint to int might fail on out-of-range values. No existing rows in the dev database are affected`},
+		{Code: "DL104", Text: `Removing enum values "deleted", "it's" from column "status" of table "users" might fail on existing values. 1 existing row in the dev database is affected`},
+	}, reports[idx].Diagnostics)
+
+	// Changes that do not affect any rows in the dev database are still reported as errors,
+	// as the dev database is not expected to hold the data of the target database.
+	expectCounts(0, 0, 0)
+	azs, err = sqlcheck.AnalyzerFor(mysql.DriverName, &schemahcl.Resource{
+		Children: []*schemahcl.Resource{
+			{
+				Type:  "data_loss",
+				Attrs: []*schemahcl.Attr{schemahcl.BoolAttr("error", true)},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.EqualError(t, sqlcheck.Analyzers(azs).Analyze(context.Background(), pass), "data loss changes detected")
+	require.NoError(t, mk.ExpectationsWereMet())
+}
+
 func TestDataDepend_Maria_ImplicitUpdate(t *testing.T) {
 	var (
 		report *sqlcheck.Report
@@ -230,8 +324,255 @@ func TestDataDepend_Maria_ImplicitUpdate(t *testing.T) {
 
 }
 
+func TestAlgorithmAnalyzer(t *testing.T) {
+	const content = "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL AFTER `a`;\nALTER TABLE `users` MODIFY COLUMN `b` bigint NOT NULL;\nCREATE INDEX `idx` ON `users` (`a`);\n"
+	users := schema.NewTable("users").
+		SetSchema(schema.New("test")).
+		AddColumns(
+			schema.NewIntColumn("a", mysql.TypeInt),
+			schema.NewIntColumn("c", mysql.TypeInt),
+			schema.NewIntColumn("b", mysql.TypeBigInt),
+		)
+	stmts, err := migrate.Stmts(content)
+	require.NoError(t, err)
+	changes := []*sqlcheck.Change{
+		{
+			Stmt: stmts[0],
+			Changes: schema.Changes{
+				&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.AddColumn{C: users.Columns[1]}}},
+			},
+		},
+		{
+			Stmt: stmts[1],
+			Changes: schema.Changes{
+				&schema.ModifyTable{T: users, Changes: schema.Changes{
+					&schema.ModifyColumn{
+						From:   schema.NewIntColumn("b", mysql.TypeInt),
+						To:     users.Columns[2],
+						Change: schema.ChangeType,
+					},
+				}},
+			},
+		},
+		{
+			Stmt: stmts[2],
+			Changes: schema.Changes{
+				&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.AddIndex{I: schema.NewIndex("idx")}}},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		version  string
+		explicit bool
+		want     []sqlcheck.Diagnostic
+	}{
+		{
+			// Only table copies are reported by default.
+			version: "8.0.20",
+			want: []sqlcheck.Diagnostic{
+				{
+					Code: "MY103",
+					Pos:  stmts[1].Pos,
+					Text: `Changing the type of column "b" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`,
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Set the ALGORITHM and LOCK clauses explicitly to make the table copy visible in the migration",
+							TextEdit: &sqlcheck.TextEdit{Line: 2, End: 2, NewText: "ALTER TABLE `users` MODIFY COLUMN `b` bigint NOT NULL, ALGORITHM=COPY, LOCK=SHARED;"},
+						},
+					},
+				},
+			},
+		},
+		{
+			version:  "8.0.20",
+			explicit: true,
+			want: []sqlcheck.Diagnostic{
+				{
+					Code: "MY104",
+					Pos:  stmts[0].Pos,
+					Text: "Statement can be executed with ALGORITHM=INPLACE, but the server may silently fall back to a slower algorithm",
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Add ALGORITHM=INPLACE to fail the statement instead of copying the table",
+							TextEdit: &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL AFTER `a`, ALGORITHM=INPLACE, LOCK=NONE;"},
+						},
+					},
+				},
+				{
+					Code: "MY103",
+					Pos:  stmts[1].Pos,
+					Text: `Changing the type of column "b" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`,
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Set the ALGORITHM and LOCK clauses explicitly to make the table copy visible in the migration",
+							TextEdit: &sqlcheck.TextEdit{Line: 2, End: 2, NewText: "ALTER TABLE `users` MODIFY COLUMN `b` bigint NOT NULL, ALGORITHM=COPY, LOCK=SHARED;"},
+						},
+					},
+				},
+				{
+					Code: "MY104",
+					Pos:  stmts[2].Pos,
+					Text: "Statement can be executed with ALGORITHM=INPLACE, but the server may silently fall back to a slower algorithm",
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Add ALGORITHM=INPLACE to fail the statement instead of copying the table",
+							TextEdit: &sqlcheck.TextEdit{Line: 3, End: 3, NewText: "CREATE INDEX `idx` ON `users` (`a`) ALGORITHM=INPLACE LOCK=NONE;"},
+						},
+					},
+				},
+			},
+		},
+		{
+			version:  "10.4.7-MariaDB",
+			explicit: true,
+			want: []sqlcheck.Diagnostic{
+				{
+					Code: "MY104",
+					Pos:  stmts[0].Pos,
+					Text: "Statement can be executed with ALGORITHM=INSTANT, but the server may silently fall back to a slower algorithm",
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Add ALGORITHM=INSTANT to fail the statement instead of copying the table",
+							TextEdit: &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "ALTER TABLE `users` ADD COLUMN `c` int NOT NULL AFTER `a`, ALGORITHM=INSTANT;"},
+						},
+					},
+				},
+				{
+					Code: "MY103",
+					Pos:  stmts[1].Pos,
+					Text: `Changing the type of column "b" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`,
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Set the ALGORITHM and LOCK clauses explicitly to make the table copy visible in the migration",
+							TextEdit: &sqlcheck.TextEdit{Line: 2, End: 2, NewText: "ALTER TABLE `users` MODIFY COLUMN `b` bigint NOT NULL, ALGORITHM=COPY, LOCK=SHARED;"},
+						},
+					},
+				},
+				{
+					Code: "MY104",
+					Pos:  stmts[2].Pos,
+					Text: "Statement can be executed with ALGORITHM=INPLACE, but the server may silently fall back to a slower algorithm",
+					SuggestedFixes: []sqlcheck.SuggestedFix{
+						{
+							Message:  "Add ALGORITHM=INPLACE to fail the statement instead of copying the table",
+							TextEdit: &sqlcheck.TextEdit{Line: 3, End: 3, NewText: "CREATE INDEX `idx` ON `users` (`a`) ALGORITHM=INPLACE LOCK=NONE;"},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Widening integer columns does not reorganize the data in TiDB.
+			version:  "5.7.25-TiDB-v6.5.0",
+			explicit: true,
+		},
+	} {
+		t.Run(tt.version, func(t *testing.T) {
+			var (
+				report *sqlcheck.Report
+				pass   = &sqlcheck.Pass{
+					Dev: &sqlclient.Client{
+						Name:   "mysql",
+						Driver: devDriver(t, tt.version),
+					},
+					File: &sqlcheck.File{
+						File:    testFile{name: "1.sql", bytes: []byte(content)},
+						Changes: changes,
+					},
+					Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+						report = &r
+					}),
+				}
+			)
+			az, err := mysqlcheck.NewAlgorithmAnalyzer(&schemahcl.Resource{
+				Children: []*schemahcl.Resource{
+					{
+						Type:  "online_ddl",
+						Attrs: []*schemahcl.Attr{schemahcl.BoolAttr("explicit_algorithm", tt.explicit)},
+					},
+				},
+			})
+			require.NoError(t, err)
+			require.NoError(t, az.Analyze(context.Background(), pass))
+			if tt.want == nil {
+				require.Nil(t, report)
+				return
+			}
+			require.Equal(t, "online-DDL algorithm changes detected", report.Text)
+			require.Equal(t, tt.want, report.Diagnostics)
+		})
+	}
+}
+
+func TestAlgorithmAnalyzer_Reorder(t *testing.T) {
+	const content = "ALTER TABLE `users` MODIFY COLUMN `price` decimal(10,2) NOT NULL AFTER `a`;\nALTER TABLE `users` MODIFY COLUMN `valid` int NOT NULL AFTER `a`, MODIFY COLUMN `id` bigint NULL;\nALTER TABLE `users` CHANGE `n` `a,b` int NOT NULL FIRST, ALGORITHM=COPY;\n"
+	users := schema.NewTable("users").
+		SetSchema(schema.New("test")).
+		AddColumns(
+			schema.NewIntColumn("a", mysql.TypeInt),
+			schema.NewDecimalColumn("price", mysql.TypeDecimal, schema.DecimalPrecision(10), schema.DecimalScale(2)),
+			schema.NewIntColumn("valid", mysql.TypeInt),
+			schema.NewNullIntColumn("id", mysql.TypeBigInt),
+			schema.NewIntColumn("a,b", mysql.TypeInt),
+		)
+	stmts, err := migrate.Stmts(content)
+	require.NoError(t, err)
+	notNull := func(c *schema.Column) schema.Change {
+		return &schema.ModifyColumn{From: c, To: c, Change: schema.ChangeNull}
+	}
+	var (
+		report *sqlcheck.Report
+		pass   = &sqlcheck.Pass{
+			Dev: &sqlclient.Client{
+				Name:   "mysql",
+				Driver: devDriver(t, "8.0.20"),
+			},
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql", bytes: []byte(content)},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: stmts[0],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{notNull(users.Columns[1])}},
+						},
+					},
+					{
+						Stmt: stmts[1],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{notNull(users.Columns[2]), notNull(users.Columns[3])}},
+						},
+					},
+					{
+						Stmt: stmts[2],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{notNull(users.Columns[4])}},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := mysqlcheck.NewAlgorithmAnalyzer(nil)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Len(t, report.Diagnostics, 3)
+	// Commas in column types do not split the column specs.
+	require.Equal(t, `Reordering column "price" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`, report.Diagnostics[0].Text)
+	require.Len(t, report.Diagnostics[0].SuggestedFixes, 1)
+	// Column names are matched exactly ("id" is not repositioned).
+	require.Equal(t, `Reordering column "valid" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`, report.Diagnostics[1].Text)
+	// Commas in quoted names do not split the column specs, and statements
+	// that set the ALGORITHM clause explicitly are not changed.
+	require.Equal(t, `Reordering column "a,b" requires a table copy (ALGORITHM=COPY) that blocks concurrent writes`, report.Diagnostics[2].Text)
+	require.Empty(t, report.Diagnostics[2].SuggestedFixes)
+}
+
 type testFile struct {
-	name string
+	name  string
+	bytes []byte
 	migrate.File
 }
 
@@ -239,6 +580,10 @@ func (t testFile) Name() string {
 	return t.name
 }
 
+func (t testFile) Bytes() []byte {
+	return t.bytes
+}
+
 func devDriver(t *testing.T, version string) migrate.Driver {
 	db, mk, err := sqlmock.New()
 	require.NoError(t, err)
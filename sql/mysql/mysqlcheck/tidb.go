@@ -0,0 +1,72 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package mysqlcheck
+
+import (
+	"fmt"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/mysql"
+	"ariga.io/atlas/sql/mysql/internal/mysqlversion"
+	"ariga.io/atlas/sql/schema"
+)
+
+// tidbOp returns the algorithm TiDB uses to execute the given change. All DDL statements
+// in TiDB are online and do not block reads or writes. However, changes that cannot be
+// done by updating the table metadata require reorganizing the data of all rows, which
+// is reported as a COPY operation. The ALGORITHM clause is parsed by TiDB only for MySQL
+// compatibility. See: https://docs.pingcap.com/tidb/stable/ddl-introduction
+func tidbOp(_ mysqlversion.V, _ *migrate.Stmt, _ *schema.ModifyTable, c schema.Change, _ bool) *alterOp {
+	switch c := c.(type) {
+	case *schema.AddColumn, *schema.DropColumn, *schema.RenameColumn, *schema.RenameIndex, *schema.DropIndex, *schema.DropForeignKey:
+		return &alterOp{alg: algInstant}
+	case *schema.ModifyColumn:
+		op := &alterOp{alg: algInstant}
+		switch {
+		case c.Change.Is(schema.ChangeType) && !tidbLossless(c.From.Type.Type, c.To.Type.Type):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the type of column %q", c.To.Name)
+		case c.Change.Is(schema.ChangeCharset) || c.Change.Is(schema.ChangeCollate):
+			op.alg, op.desc = algCopy, fmt.Sprintf("Changing the character set of column %q", c.To.Name)
+		}
+		return op
+	case *schema.AddIndex, *schema.ModifyIndex, *schema.AddPrimaryKey, *schema.ModifyPrimaryKey, *schema.DropPrimaryKey, *schema.AddForeignKey:
+		// Adding indexes requires backfilling them, but
+		// does not rewrite the data of the table rows.
+		return &alterOp{alg: algInplace}
+	}
+	return nil
+}
+
+// tidbLossless reports if the type change can be done by TiDB by updating the
+// table metadata only. For example, extending the length of a VARCHAR column,
+// or changing an integer column to a larger integer type with the same sign.
+func tidbLossless(from, to schema.Type) bool {
+	switch from := from.(type) {
+	case *schema.IntegerType:
+		to, ok := to.(*schema.IntegerType)
+		return ok && from.Unsigned == to.Unsigned && intSize(from.T) > 0 && intSize(from.T) <= intSize(to.T)
+	case *schema.StringType:
+		to, ok := to.(*schema.StringType)
+		return ok && from.T == to.T && (from.T == mysql.TypeVarchar || from.T == mysql.TypeChar) && from.Size <= to.Size
+	}
+	return false
+}
+
+// intSize returns the storage size of the integer type in bytes.
+func intSize(t string) int {
+	switch t {
+	case mysql.TypeTinyInt:
+		return 1
+	case mysql.TypeSmallInt:
+		return 2
+	case mysql.TypeMediumInt:
+		return 3
+	case mysql.TypeInt:
+		return 4
+	case mysql.TypeBigInt:
+		return 8
+	}
+	return 0
+}
@@ -39,7 +39,7 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 			return err
 		}
 		if err := specutil.Scan(v,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Funcs: d.Funcs, Procs: d.Procs, Triggers: d.Triggers},
 			scanFuncs,
 		); err != nil {
 			return fmt.Errorf("mysql: failed converting to *schema.Realm: %w", err)
@@ -53,6 +53,9 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 				return err
 			}
 		}
+		if err := specutil.Roles(v, d.Roles, d.Users, d.Permissions, roleFuncs); err != nil {
+			return fmt.Errorf("mysql: failed converting roles: %w", err)
+		}
 	case *schema.Schema:
 		var d specutil.Doc
 		if err := c.State.EvalOptions(p, &d, opts); err != nil {
@@ -63,7 +66,7 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		}
 		r := &schema.Realm{}
 		if err := specutil.Scan(r,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Funcs: d.Funcs, Procs: d.Procs, Triggers: d.Triggers},
 			scanFuncs,
 		); err != nil {
 			return err
@@ -84,13 +87,38 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 func (c *Codec) MarshalSpec(v any) ([]byte, error) {
 	return specutil.Marshal(v, c.State, specutil.RealmFuncs{
 		Schema: schemaSpec,
+		Roles:  roleFuncs,
 	})
 }
 
+// roleFuncs converts the MySQL-specific attributes of users. Users that
+// were declared without a host can connect from any host, similar to MySQL.
+var roleFuncs = &specutil.RoleFuncs{
+	User: func(_ *sqlspec.User, u *schema.User) error {
+		if u.Host == "" {
+			u.Host = "%"
+		}
+		return nil
+	},
+	UserSpec: func(_ *schema.User, spec *sqlspec.User) error {
+		if spec.Host == "%" {
+			spec.Host = ""
+		}
+		return nil
+	},
+}
+
 var (
 	registrySpecs     = TypeRegistry.Specs()
 	sharedSpecOptions = []schemahcl.Option{
 		schemahcl.WithTypes("table.column.type", registrySpecs),
+		schemahcl.WithTypes("view.column.type", registrySpecs),
+		schemahcl.WithTypes("function.return", registrySpecs),
+		schemahcl.WithTypes("function.arg.type", registrySpecs),
+		schemahcl.WithTypes("procedure.arg.type", registrySpecs),
+		schemahcl.WithScopedEnums("procedure.arg.mode", string(schema.FuncArgModeIn), string(schema.FuncArgModeOut), string(schema.FuncArgModeInOut)),
+		schemahcl.WithScopedEnums("view.check_option", specutil.ViewCheckOptions...),
+		schemahcl.WithScopedEnums("trigger.for", schema.TriggerForRow),
 		schemahcl.WithScopedEnums("table.engine", EngineInnoDB, EngineMyISAM, EngineMemory, EngineCSV, EngineNDB),
 		schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeHash, IndexTypeFullText, IndexTypeSpatial),
 		schemahcl.WithScopedEnums("table.index.parser", IndexParserNGram, IndexParserMeCab),
@@ -98,6 +126,15 @@ var (
 		schemahcl.WithScopedEnums("table.column.as.type", stored, persistent, virtual),
 		schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 		schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
+		schemahcl.WithScopedEnums("permission.privileges", specutil.Var(PrivilegeAll), specutil.Var(PrivilegeAlter),
+			specutil.Var(PrivilegeAlterRoutine), specutil.Var(PrivilegeCreate), specutil.Var(PrivilegeCreateRoutine),
+			specutil.Var(PrivilegeCreateTempTables), specutil.Var(PrivilegeCreateView), specutil.Var(PrivilegeDelete),
+			specutil.Var(PrivilegeDrop), specutil.Var(PrivilegeEvent), specutil.Var(PrivilegeExecute), specutil.Var(PrivilegeIndex),
+			specutil.Var(PrivilegeInsert), specutil.Var(PrivilegeLockTables), specutil.Var(PrivilegeReferences),
+			specutil.Var(PrivilegeSelect), specutil.Var(PrivilegeShowView), specutil.Var(PrivilegeTrigger), specutil.Var(PrivilegeUpdate)),
+		schemahcl.WithScopedEnums("table.partition.type", specutil.Var(PartitionTypeRange), specutil.Var(PartitionTypeRangeColumns),
+			specutil.Var(PartitionTypeList), specutil.Var(PartitionTypeListColumns), specutil.Var(PartitionTypeHash),
+			specutil.Var(PartitionTypeLinearHash), specutil.Var(PartitionTypeKey), specutil.Var(PartitionTypeLinearKey)),
 	}
 	codec = &Codec{
 		State: schemahcl.New(
@@ -127,10 +164,18 @@ var (
 	EvalMariaHCLBytes             = specutil.HCLBytesFunc(EvalMariaHCL)
 	specOptions, mariaSpecOptions []schemahcl.Option
 	specFuncs = &specutil.SchemaFuncs{
-		Table: tableSpec,
+		Table:   tableSpec,
+		View:    viewSpec,
+		Func:    funcSpec,
+		Proc:    procSpec,
+		Trigger: triggerSpec,
 	}
 	scanFuncs = &specutil.ScanFuncs{
-		Table: convertTable,
+		Table:   convertTable,
+		View:    convertView,
+		Func:    convertFunc,
+		Proc:    convertProc,
+		Trigger: convertTrigger,
 	}
 )
 
@@ -161,9 +206,82 @@ func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, er
 		}
 		t.AddAttrs(&Engine{V: v})
 	}
+	if err := convertPartition(spec.Extra, t); err != nil {
+		return nil, err
+	}
 	return t, nil
 }
 
+// convertPartition converts and appends the partition block into the table attributes if exists.
+func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
+	r, ok := spec.Resource("partition")
+	if !ok {
+		return nil
+	}
+	var p struct {
+		Type    string           `spec:"type"`
+		Columns []*schemahcl.Ref `spec:"columns"`
+		Parts   []*struct {
+			Expr   string         `spec:"expr"`
+			Column *schemahcl.Ref `spec:"column"`
+		} `spec:"by"`
+		Count int `spec:"partitions"`
+		Defs  []*struct {
+			Name   string `spec:",name"`
+			Values string `spec:"values"`
+		} `spec:"part"`
+	}
+	if err := r.As(&p); err != nil {
+		return fmt.Errorf("parsing %s.partition: %w", table.Name, err)
+	}
+	if p.Type == "" {
+		return fmt.Errorf("missing attribute %s.partition.type", table.Name)
+	}
+	key := &Partition{T: specutil.FromVar(p.Type), Count: p.Count}
+	switch n, m := len(p.Columns), len(p.Parts); {
+	case n == 0 && m == 0 && !strings.HasSuffix(strings.ToUpper(key.T), PartitionTypeKey):
+		return fmt.Errorf("missing columns or expressions for %s.partition", table.Name)
+	case n > 0 && m > 0:
+		return fmt.Errorf(`multiple definitions for %s.partition, use "columns" or "by"`, table.Name)
+	case n > 0:
+		for _, r := range p.Columns {
+			c, err := specutil.ColumnByRef(table, r)
+			if err != nil {
+				return err
+			}
+			key.Parts = append(key.Parts, &PartitionPart{C: c})
+		}
+	case m > 0:
+		for i, p := range p.Parts {
+			switch {
+			case p.Column == nil && p.Expr == "":
+				return fmt.Errorf("missing column or expression for %s.partition.by at position %d", table.Name, i)
+			case p.Column != nil && p.Expr != "":
+				return fmt.Errorf("multiple definitions for %s.partition.by at position %d", table.Name, i)
+			case p.Column != nil:
+				c, err := specutil.ColumnByRef(table, p.Column)
+				if err != nil {
+					return err
+				}
+				key.Parts = append(key.Parts, &PartitionPart{C: c})
+			case p.Expr != "":
+				key.Parts = append(key.Parts, &PartitionPart{X: &schema.RawExpr{X: p.Expr}})
+			}
+		}
+	}
+	if p.Count > 0 && len(p.Defs) > 0 {
+		return fmt.Errorf(`multiple definitions for %s.partition, use "partitions" or "part"`, table.Name)
+	}
+	for _, d := range p.Defs {
+		key.Defs = append(key.Defs, &PartitionDef{Name: d.Name, Values: d.Values})
+	}
+	if !key.hashed() && len(key.Defs) == 0 {
+		return fmt.Errorf("missing partition definitions for %s.partition", table.Name)
+	}
+	table.AddAttrs(key)
+	return nil
+}
+
 // convertPK converts a sqlspec.PrimaryKey into a schema.Index.
 func convertPK(spec *sqlspec.PrimaryKey, parent *schema.Table) (*schema.Index, error) {
 	return convertIndex(&sqlspec.Index{
@@ -240,6 +358,13 @@ func convertCheck(spec *sqlspec.Check) (*schema.Check, error) {
 	return c, nil
 }
 
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, func(c *sqlspec.Column, _ *schema.View) (*schema.Column, error) {
+		return specutil.Column(c, convertColumnType)
+	})
+}
+
 // convertColumn converts a sqlspec.Column into a schema.Column.
 func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
 	c, err := specutil.Column(spec, convertColumnType)
@@ -271,6 +396,42 @@ func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error
 	return c, err
 }
 
+// convertFunc converts a sqlspec.Func to a schema.Func.
+func convertFunc(spec *sqlspec.Func, parent *schema.Schema) (*schema.Func, error) {
+	f, err := specutil.Func(spec, parent, convertColumnType)
+	if err != nil {
+		return nil, err
+	}
+	if a, ok := spec.Attr("deterministic"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return nil, fmt.Errorf("expect bool value for attribute function.%s.deterministic: %w", spec.Name, err)
+		}
+		if b {
+			f.SetVolatility(Deterministic)
+		}
+	}
+	return f, nil
+}
+
+// convertProc converts a sqlspec.Proc to a schema.Proc.
+func convertProc(spec *sqlspec.Proc, parent *schema.Schema) (*schema.Proc, error) {
+	return specutil.Proc(spec, parent, convertColumnType)
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger. MySQL supports
+// only row-level triggers, and therefore, the "for" attribute defaults to ROW.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	if t.For == "" {
+		t.For = schema.TriggerForRow
+	}
+	return t, nil
+}
+
 // convertColumnType converts a sqlspec.Column into a concrete MySQL schema.Type.
 func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 	return TypeRegistry.Type(spec.Type, spec.Extra.Attrs)
@@ -321,9 +482,57 @@ func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
 		}
 		ts.Extra.Attrs = append(ts.Extra.Attrs, attr)
 	}
+	if p := tablePartition(t); p != nil {
+		ts.Extra.Children = append(ts.Extra.Children, fromPartition(p))
+	}
 	return ts, nil
 }
 
+// fromPartition returns the resource spec for representing the partition block.
+func fromPartition(p *Partition) *schemahcl.Resource {
+	key := &schemahcl.Resource{
+		Type: "partition",
+		Attrs: []*schemahcl.Attr{
+			specutil.VarAttr("type", strings.ToUpper(specutil.Var(p.T))),
+		},
+	}
+	columns, ok := func() ([]*schemahcl.Ref, bool) {
+		parts := make([]*schemahcl.Ref, 0, len(p.Parts))
+		for _, p := range p.Parts {
+			if p.C == nil {
+				return nil, false
+			}
+			parts = append(parts, specutil.ColumnRef(p.C.Name))
+		}
+		return parts, len(parts) > 0
+	}()
+	if ok {
+		key.Attrs = append(key.Attrs, schemahcl.RefsAttr("columns", columns...))
+	} else {
+		for _, p := range p.Parts {
+			part := &schemahcl.Resource{Type: "by"}
+			switch {
+			case p.C != nil:
+				part.Attrs = append(part.Attrs, schemahcl.RefAttr("column", specutil.ColumnRef(p.C.Name)))
+			case p.X != nil:
+				part.Attrs = append(part.Attrs, schemahcl.StringAttr("expr", p.X.(*schema.RawExpr).X))
+			}
+			key.Children = append(key.Children, part)
+		}
+	}
+	if p.Count > 0 {
+		key.Attrs = append(key.Attrs, schemahcl.IntAttr("partitions", p.Count))
+	}
+	for _, d := range p.Defs {
+		def := &schemahcl.Resource{Type: "part", Name: d.Name}
+		if d.Values != "" {
+			def.Attrs = append(def.Attrs, schemahcl.StringAttr("values", d.Values))
+		}
+		key.Children = append(key.Children, def)
+	}
+	return key
+}
+
 func pkSpec(idx *schema.Index) (*sqlspec.PrimaryKey, error) {
 	spec, err := indexSpec(idx)
 	if err != nil {
@@ -372,6 +581,13 @@ func partAttr(_ *schema.Index, part *schema.IndexPart, spec *sqlspec.IndexPart)
 	return nil
 }
 
+// viewSpec converts from a concrete MySQL schema.View to a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, func(c *schema.Column, _ *schema.View) (*sqlspec.Column, error) {
+		return specutil.FromColumn(c, columnTypeSpec)
+	})
+}
+
 // columnSpec converts from a concrete MySQL schema.Column into a sqlspec.Column.
 func columnSpec(c *schema.Column, t *schema.Table) (*sqlspec.Column, error) {
 	spec, err := specutil.FromColumn(c, columnTypeSpec)
@@ -419,6 +635,25 @@ func checkSpec(s *schema.Check) *sqlspec.Check {
 	return c
 }
 
+// funcSpec converts from a concrete MySQL schema.Func to a sqlspec.Func.
+func funcSpec(f *schema.Func) (*sqlspec.Func, error) {
+	var attrs []*schemahcl.Attr
+	if v := (schema.FuncVolatility{}); sqlx.Has(f.Attrs, &v) && strings.EqualFold(v.V, Deterministic) {
+		attrs = append(attrs, schemahcl.BoolAttr("deterministic", true))
+	}
+	return specutil.FromFunc(f, columnTypeSpec, attrs...)
+}
+
+// procSpec converts from a concrete MySQL schema.Proc to a sqlspec.Proc.
+func procSpec(p *schema.Proc) (*sqlspec.Proc, error) {
+	return specutil.FromProc(p, columnTypeSpec)
+}
+
+// triggerSpec converts from a concrete MySQL schema.Trigger to a sqlspec.Trigger.
+func triggerSpec(t *schema.Trigger) (*sqlspec.Trigger, error) {
+	return specutil.FromTrigger(t, nil)
+}
+
 // columnTypeSpec converts from a concrete MySQL schema.Type into sqlspec.Column Type.
 func columnTypeSpec(t schema.Type) (*sqlspec.Column, error) {
 	st, err := TypeRegistry.Convert(t)
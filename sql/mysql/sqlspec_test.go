@@ -13,6 +13,7 @@ import (
 	"ariga.io/atlas/sql/internal/spectest"
 	"ariga.io/atlas/sql/schema"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestSQLSpec(t *testing.T) {
@@ -493,6 +494,222 @@ schema "test" {
 	require.EqualValues(t, expected, string(buf))
 }
 
+func TestMarshalSpec_Func(t *testing.T) {
+	s := schema.New("test")
+	s.AddObjects(
+		schema.NewFunc("add", "RETURN a + 1").
+			AddArgs(&schema.FuncArg{Name: "a", Type: &schema.IntegerType{T: TypeInt}}).
+			SetReturn(&schema.IntegerType{T: TypeInt}).
+			SetVolatility(Deterministic),
+		schema.NewProc("reset", "BEGIN\n  SET n = 0;\nEND").
+			AddArgs(&schema.FuncArg{Name: "n", Type: &schema.IntegerType{T: TypeInt}, Mode: schema.FuncArgModeInOut}).
+			SetComment("reset"),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `function "add" {
+  schema = schema.test
+  arg "a" {
+    type = int
+  }
+  return        = int
+  deterministic = true
+  as            = "RETURN a + 1"
+}
+procedure "reset" {
+  schema = schema.test
+  arg "n" {
+    type = int
+    mode = INOUT
+  }
+  as      = <<-SQL
+  BEGIN
+    SET n = 0;
+  END
+  SQL
+  comment = "reset"
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	require.Len(t, got.Objects, 2)
+	f, ok := got.Func("add")
+	require.True(t, ok)
+	require.Equal(t, s.Objects[0].(*schema.Func).Args, f.Args)
+	require.Equal(t, []schema.Attr{&schema.FuncVolatility{V: Deterministic}}, f.Attrs)
+	p, ok := got.Proc("reset")
+	require.True(t, ok)
+	require.Equal(t, "BEGIN\n  SET n = 0;\nEND\n", p.Body)
+	require.Equal(t, schema.FuncArgModeInOut, p.Args[0].Mode)
+}
+
+func TestMarshalSpec_Trigger(t *testing.T) {
+	s := schema.New("test")
+	users := schema.NewTable("users").
+		AddColumns(schema.NewIntColumn("id", TypeInt))
+	s.AddTables(users)
+	users.AddTriggers(
+		schema.NewTrigger("users_ins").
+			SetActionTime(schema.TriggerTimeBefore).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody("SET NEW.id = NEW.id + 1"),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+}
+trigger "users_ins" {
+  on  = table.users
+  for = ROW
+  as  = "SET NEW.id = NEW.id + 1"
+  before {
+    insert = true
+  }
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	var got schema.Schema
+	require.NoError(t, EvalHCLBytes(buf, &got, nil))
+	tb, ok := got.Table("users")
+	require.True(t, ok)
+	require.Len(t, tb.Triggers, 1)
+	tr := tb.Triggers[0]
+	require.Equal(t, tb, tr.Table)
+	require.Equal(t, schema.TriggerTimeBefore, tr.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert}, tr.Events)
+	require.Equal(t, schema.TriggerForRow, tr.For)
+	require.Equal(t, "SET NEW.id = NEW.id + 1", tr.Body)
+
+	// The "for" attribute defaults to ROW.
+	require.NoError(t, EvalHCLBytes([]byte(`
+schema "test" {}
+table "users" {
+  schema = schema.test
+  column "id" {
+    type = int
+  }
+}
+trigger "users_ins" {
+  on = table.users
+  after {
+    delete = true
+  }
+  as = "SET @n = 1"
+}
+`), &got, nil))
+	tb, ok = got.Table("users")
+	require.True(t, ok)
+	require.Equal(t, schema.TriggerForRow, tb.Triggers[0].For)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventDelete}, tb.Triggers[0].Events)
+}
+
+func TestMarshalSpec_Role(t *testing.T) {
+	test := schema.New("test")
+	users := schema.NewTable("users").
+		AddColumns(schema.NewIntColumn("id", TypeInt), schema.NewStringColumn("name", TypeText))
+	test.AddTables(users)
+	reader := &schema.Role{Name: "reader"}
+	app := &schema.User{Name: "app", Host: "localhost", MemberOf: []*schema.Role{reader}}
+	admin := &schema.User{Name: "admin", Host: "%"}
+	r := schema.NewRealm(test).AddObjects(
+		reader, app, admin,
+		&schema.Permission{To: reader, Schema: test, Privileges: []string{PrivilegeCreateView, PrivilegeSelect}},
+		&schema.Permission{To: admin, Schema: test, Privileges: []string{PrivilegeAll}, Grantable: true},
+		&schema.Permission{To: app, Schema: test, Table: users, Columns: users.Columns[1:], Privileges: []string{PrivilegeUpdate}},
+	)
+	buf, err := MarshalHCL(r)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+}
+role "reader" {
+}
+user "app" {
+  host      = "localhost"
+  member_of = [role.reader]
+}
+user "admin" {
+}
+permission "reader_test" {
+  to         = role.reader
+  for        = schema.test
+  privileges = [CREATE_VIEW, SELECT]
+}
+permission "admin_test" {
+  to         = user.admin
+  for        = schema.test
+  grantable  = true
+  privileges = [ALL]
+}
+permission "app_test_users_name" {
+  to         = user.app
+  for        = table.users
+  columns    = [table.users.column.name]
+  privileges = [UPDATE]
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+
+	// Users without a host can connect from any host.
+	var got schema.Realm
+	require.NoError(t, EvalHCLBytes([]byte(expected+`
+user "ops" {
+}
+`), &got, nil))
+	require.Len(t, got.Objects, 7)
+	_, ok := got.User("admin", "%")
+	require.True(t, ok)
+	_, ok = got.User("ops", "%")
+	require.True(t, ok)
+	gotR, ok := got.Role("reader")
+	require.True(t, ok)
+	u, ok := got.User("app", "localhost")
+	require.True(t, ok)
+	require.Equal(t, []*schema.Role{gotR}, u.MemberOf)
+	p := got.Objects[4].(*schema.Permission)
+	require.Equal(t, gotR, p.To)
+	require.Equal(t, got.Schemas[0], p.Schema)
+	require.Equal(t, []string{PrivilegeCreateView, PrivilegeSelect}, p.Privileges)
+	p = got.Objects[6].(*schema.Permission)
+	require.Len(t, p.Columns, 1)
+	require.Equal(t, "name", p.Columns[0].Name)
+	require.Equal(t, got.Schemas[0].Tables[0], p.Table)
+
+	// Passwords are rejected, as they would be written to planned statements.
+	err = EvalHCLBytes([]byte(`
+variable "password" {
+  type = string
+}
+user "ops" {
+  password = var.password
+}
+`), &schema.Realm{}, map[string]cty.Value{"password": cty.StringVal("pass")})
+	require.EqualError(t, err, `mysql: failed converting roles: user "ops": passwords are not supported and should be set separately`)
+}
+
 func TestMarshalSpec_AutoIncrement(t *testing.T) {
 	s := &schema.Schema{
 		Name: "test",
@@ -675,6 +892,169 @@ schema "a8m" {}
 	}
 }
 
+func TestMarshalSpec_Partition(t *testing.T) {
+	var (
+		c1 = schema.NewIntColumn("created", TypeInt)
+		c2 = schema.NewIntColumn("id", TypeInt)
+		s  = schema.New("a8m").
+			AddTables(
+				schema.NewTable("logs").AddColumns(c1).AddAttrs(&Partition{
+					T:     PartitionTypeRange,
+					Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(`created`)"}}},
+					Defs: []*PartitionDef{
+						{Name: "p2020", Values: "LESS THAN (2021)"},
+						{Name: "pmax", Values: "LESS THAN MAXVALUE"},
+					},
+				}),
+				schema.NewTable("users").AddColumns(c2).AddAttrs(&Partition{
+					T:     PartitionTypeLinearHash,
+					Parts: []*PartitionPart{{C: c2}},
+					Count: 4,
+				}),
+			)
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	expected := `table "logs" {
+  schema = schema.a8m
+  column "created" {
+    null = false
+    type = int
+  }
+  partition {
+    type = RANGE
+    by {
+      expr = "year(` + "`created`" + `)"
+    }
+    part "p2020" {
+      values = "LESS THAN (2021)"
+    }
+    part "pmax" {
+      values = "LESS THAN MAXVALUE"
+    }
+  }
+}
+table "users" {
+  schema = schema.a8m
+  column "id" {
+    null = false
+    type = int
+  }
+  partition {
+    type       = LINEAR_HASH
+    columns    = [column.id]
+    partitions = 4
+  }
+}
+schema "a8m" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+}
+
+func TestUnmarshalSpec_Partition(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `table "logs" {
+  schema = schema.a8m
+  column "created" {
+    null = false
+    type = int
+  }
+  partition {
+    type = RANGE
+    by {
+      expr = "year(created)"
+    }
+    part "p2020" {
+      values = "LESS THAN (2021)"
+    }
+    part "pmax" {
+      values = "LESS THAN MAXVALUE"
+    }
+  }
+}
+table "events" {
+  schema = schema.a8m
+  column "region" {
+    null = false
+    type = int
+  }
+  partition {
+    type    = LIST_COLUMNS
+    columns = [column.region]
+    part "eu" {
+      values = "IN (1, 2)"
+    }
+  }
+}
+table "users" {
+  schema = schema.a8m
+  column "id" {
+    null = false
+    type = int
+  }
+  partition {
+    type       = KEY
+    partitions = 4
+  }
+}
+schema "a8m" {}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	logs, events, users := s.Tables[0], s.Tables[1], s.Tables[2]
+	require.Equal(t, []schema.Attr{
+		&Partition{
+			T:     PartitionTypeRange,
+			Parts: []*PartitionPart{{X: &schema.RawExpr{X: "year(created)"}}},
+			Defs: []*PartitionDef{
+				{Name: "p2020", Values: "LESS THAN (2021)"},
+				{Name: "pmax", Values: "LESS THAN MAXVALUE"},
+			},
+		},
+	}, logs.Attrs)
+	require.Equal(t, []schema.Attr{
+		&Partition{
+			T:     PartitionTypeListColumns,
+			Parts: []*PartitionPart{{C: events.Columns[0]}},
+			Defs:  []*PartitionDef{{Name: "eu", Values: "IN (1, 2)"}},
+		},
+	}, events.Attrs)
+	require.Equal(t, []schema.Attr{&Partition{T: PartitionTypeKey, Count: 4}}, users.Attrs)
+
+	for _, f := range []string{
+		// Missing partition definitions.
+		`table "logs" {
+  schema = schema.a8m
+  column "c" {
+    type = int
+  }
+  partition {
+    type    = RANGE
+    columns = [column.c]
+  }
+}
+schema "a8m" {}
+`,
+		// Missing partitioning key.
+		`table "logs" {
+  schema = schema.a8m
+  column "c" {
+    type = int
+  }
+  partition {
+    type       = HASH
+    partitions = 2
+  }
+}
+schema "a8m" {}
+`,
+	} {
+		require.Error(t, EvalHCLBytes([]byte(f), &schema.Schema{}, nil))
+	}
+}
+
 func TestUnmarshalSpec_IndexParts(t *testing.T) {
 	var (
 		s schema.Schema
@@ -8,7 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
@@ -72,6 +75,9 @@ func (d *diff) TableAttrDiff(from, to *schema.Table, opts *schema.DiffOptions) (
 	if err := d.partitionChanged(from, to); err != nil {
 		return nil, err
 	}
+	if change := partitionOfDiff(from, to); change != nil {
+		changes = append(changes, change)
+	}
 	change, err := d.tableAttrDiff(from, to)
 	if err != nil {
 		return nil, err
@@ -130,6 +136,13 @@ func (d *diff) defaultChanged(from, to *schema.Column) (bool, error) {
 	if !ok1 && !ok2 || trimCast(d1) == trimCast(d2) || quote(d1) == quote(d2) {
 		return false, nil
 	}
+	// Sequence references are compared by their names, as evaluating
+	// them in the database advances the referenced sequences.
+	if ns1, n1, ok := seqRef(d1); ok {
+		if ns2, n2, ok := seqRef(d2); ok {
+			return n1 != n2 || ns1 != "" && ns2 != "" && ns1 != ns2, nil
+		}
+	}
 	var (
 		_, fromX = from.Default.(*schema.RawExpr)
 		_, toX   = to.Default.(*schema.RawExpr)
@@ -185,6 +198,35 @@ func (*diff) partitionChanged(from, to *schema.Table) error {
 	return nil
 }
 
+// partitionOfDiff returns the change for attaching a table to a partitioned table,
+// detaching it from its partitioned table, or moving it between partition bounds.
+func partitionOfDiff(from, to *schema.Table) schema.Change {
+	switch fromP, toP := partitionOf(from), partitionOf(to); {
+	case fromP == nil && toP != nil:
+		return &schema.AddAttr{A: toP}
+	case fromP != nil && toP == nil:
+		return &schema.DropAttr{A: fromP}
+	case fromP != nil && toP != nil && (!sqlx.SameTable(fromP.T, toP.T) || fromP.Default != toP.Default || partitionBound(fromP.Values) != partitionBound(toP.Values)):
+		return &schema.ModifyAttr{From: fromP, To: toP}
+	}
+	return nil
+}
+
+// partitionOf returns the PartitionOf attribute of the table, if exists.
+func partitionOf(t *schema.Table) *PartitionOf {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*PartitionOf); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// partitionBound returns the partition bound in a normalized form for comparison.
+func partitionBound(v string) string {
+	return strings.Join(strings.Fields(v), " ")
+}
+
 // IsGeneratedIndexName reports if the index name was generated by the database.
 func (d *diff) IsGeneratedIndexName(t *schema.Table, idx *schema.Index) bool {
 	names := make([]string, len(idx.Parts))
@@ -289,6 +331,12 @@ func (*diff) ForeignKeyAttrChanged(_, _ []schema.Attr) bool {
 	return false
 }
 
+// ViewAttrChanged reports if the view attributes were changed.
+// For example, the tablespace of a materialized view.
+func (*diff) ViewAttrChanged(from, to *schema.View) bool {
+	return tablespace(from.Attrs) != tablespace(to.Attrs)
+}
+
 // DiffOptions defines PostgreSQL specific schema diffing process.
 type DiffOptions struct {
 	ConcurrentIndex struct {
@@ -298,6 +346,9 @@ type DiffOptions struct {
 		Add    bool `spec:"add"`
 		Create bool `spec:"create"`
 	} `spec:"concurrent_index"`
+	ConcurrentPartition struct {
+		Detach bool `spec:"detach"`
+	} `spec:"concurrent_partition"`
 }
 
 // AnnotateChanges implements the sqlx.ChangeAnnotator interface.
@@ -328,12 +379,27 @@ func (*diff) AnnotateChanges(changes []schema.Change, opts *schema.DiffOptions)
 				if extra.ConcurrentIndex.Drop {
 					c.Extra = append(c.Extra, &Concurrently{})
 				}
+			case *schema.DropAttr:
+				if p, ok := c.A.(*PartitionOf); ok && extra.ConcurrentPartition.Detach {
+					c.A = concurrentDetach(p)
+				}
+			case *schema.ModifyAttr:
+				if p, ok := c.From.(*PartitionOf); ok && extra.ConcurrentPartition.Detach {
+					c.From = concurrentDetach(p)
+				}
 			}
 		}
 	}
 	return changes, nil
 }
 
+// concurrentDetach returns a copy of the given attribute, marked to be detached concurrently.
+func concurrentDetach(p *PartitionOf) *PartitionOf {
+	p1 := *p
+	p1.Concurrently = true
+	return &p1
+}
+
 func (d *diff) typeChanged(from, to *schema.Column) (bool, error) {
 	return typeChanged(from, to, d.conn.schema)
 }
@@ -580,3 +646,742 @@ func trimCast(s string) string {
 	}
 	return s[:i]
 }
+
+// funcsDiff returns the changes for migrating the functions and procedures of a schema
+// from one state to the other. Since PostgreSQL supports overloading, functions and
+// procedures are identified by their name and the types of their input arguments.
+func funcsDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	fromO, err := funcsBySig(from)
+	if err != nil {
+		return nil, err
+	}
+	toO, err := funcsBySig(to)
+	if err != nil {
+		return nil, err
+	}
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		k, ok := fromO.key(o1)
+		if !ok {
+			continue
+		}
+		o2, ok := toO.objs[k]
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: o1})
+			continue
+		}
+		changed, err := funcChanged(o1, o2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: o1, To: o2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if k, ok := toO.key(o2); ok && fromO.objs[k] == nil {
+			changes = append(changes, &schema.AddObject{O: o2})
+		}
+	}
+	return changes, nil
+}
+
+// sigObjects holds the functions and procedures of a schema by their signature.
+type sigObjects struct {
+	keys map[schema.Object]string
+	objs map[string]schema.Object
+}
+
+// key returns the signature key of the given object, if it is a function or a procedure.
+func (s *sigObjects) key(o schema.Object) (string, bool) {
+	k, ok := s.keys[o]
+	return k, ok
+}
+
+// funcsBySig returns the functions and procedures of the schema by their signature.
+func funcsBySig(s *schema.Schema) (*sigObjects, error) {
+	so := &sigObjects{keys: make(map[schema.Object]string), objs: make(map[string]schema.Object)}
+	for _, o := range s.Objects {
+		var k string
+		switch o := o.(type) {
+		case *schema.Func:
+			sig, err := funcSig(o.Name, o.Args)
+			if err != nil {
+				return nil, err
+			}
+			k = "function/" + sig
+		case *schema.Proc:
+			sig, err := funcSig(o.Name, o.Args)
+			if err != nil {
+				return nil, err
+			}
+			k = "procedure/" + sig
+		default:
+			continue
+		}
+		so.keys[o], so.objs[k] = k, o
+	}
+	return so, nil
+}
+
+// funcSig returns the signature of a function or a procedure, which is its
+// name and the types of its input arguments. e.g., "add(integer, integer)".
+func funcSig(name string, args []*schema.FuncArg) (string, error) {
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('(')
+	for _, a := range inArgs(args) {
+		t, err := funcTypeString(a.Type)
+		if err != nil {
+			return "", err
+		}
+		if b.Len() > len(name)+1 {
+			b.WriteString(", ")
+		}
+		b.WriteString(t)
+	}
+	b.WriteByte(')')
+	return b.String(), nil
+}
+
+// inArgs returns the input arguments of a function or a procedure.
+func inArgs(args []*schema.FuncArg) []*schema.FuncArg {
+	in := make([]*schema.FuncArg, 0, len(args))
+	for _, a := range args {
+		if a.Mode != schema.FuncArgModeOut {
+			in = append(in, a)
+		}
+	}
+	return in
+}
+
+// funcTypeString returns the string representation of an argument or a return type.
+func funcTypeString(t schema.Type) (string, error) {
+	switch t := t.(type) {
+	case nil:
+		return "", errors.New("postgres: missing function argument or return type")
+	case *schema.EnumType:
+		return t.T, nil
+	case *DomainType:
+		return t.T, nil
+	case *CompositeType:
+		return t.T, nil
+	case *schema.UnsupportedType:
+		return t.T, nil
+	}
+	return FormatType(t)
+}
+
+// funcChanged reports if the function or procedure o1 was changed to o2.
+func funcChanged(o1, o2 schema.Object) (bool, error) {
+	switch f1 := o1.(type) {
+	case *schema.Func:
+		f2 := o2.(*schema.Func)
+		if changed, err := argsChanged(f1.Args, f2.Args); changed || err != nil {
+			return changed, err
+		}
+		if changed, err := retChanged(f1, f2); changed || err != nil {
+			return changed, err
+		}
+		return !strings.EqualFold(funcLangOr(f1.Lang), funcLangOr(f2.Lang)) ||
+			volatility(f1.Attrs) != volatility(f2.Attrs) ||
+			sqlx.BodyDefChanged(f1.Body, f2.Body) ||
+			sqlx.CommentChange(f1.Attrs, f2.Attrs) != schema.NoChange, nil
+	case *schema.Proc:
+		p2 := o2.(*schema.Proc)
+		if changed, err := argsChanged(f1.Args, p2.Args); changed || err != nil {
+			return changed, err
+		}
+		return !strings.EqualFold(funcLangOr(f1.Lang), funcLangOr(p2.Lang)) ||
+			sqlx.BodyDefChanged(f1.Body, p2.Body) ||
+			sqlx.CommentChange(f1.Attrs, p2.Attrs) != schema.NoChange, nil
+	}
+	return false, nil
+}
+
+// retChanged reports if the return type of the function was changed.
+func retChanged(f1, f2 *schema.Func) (bool, error) {
+	if f1.Ret == nil || f2.Ret == nil {
+		return f1.Ret != f2.Ret, nil
+	}
+	t1, err := funcTypeString(f1.Ret)
+	if err != nil {
+		return false, err
+	}
+	t2, err := funcTypeString(f2.Ret)
+	if err != nil {
+		return false, err
+	}
+	return t1 != t2, nil
+}
+
+// argsChanged reports if the names, modes, types or default values of the arguments were changed.
+func argsChanged(from, to []*schema.FuncArg) (bool, error) {
+	if len(from) != len(to) {
+		return true, nil
+	}
+	for i := range from {
+		if from[i].Name != to[i].Name || argMode(from[i]) != argMode(to[i]) || argDefault(from[i]) != argDefault(to[i]) {
+			return true, nil
+		}
+		t1, err := funcTypeString(from[i].Type)
+		if err != nil {
+			return false, err
+		}
+		t2, err := funcTypeString(to[i].Type)
+		if err != nil {
+			return false, err
+		}
+		if t1 != t2 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// argMode returns the mode of the argument, defaults to IN.
+func argMode(a *schema.FuncArg) schema.FuncArgMode {
+	if a.Mode == "" {
+		return schema.FuncArgModeIn
+	}
+	return schema.FuncArgMode(strings.ToUpper(string(a.Mode)))
+}
+
+// argDefault returns the normalized default value of the argument, if exists.
+func argDefault(a *schema.FuncArg) string {
+	var x string
+	switch d := schema.UnderlyingExpr(a.Default).(type) {
+	case *schema.Literal:
+		x = d.V
+	case *schema.RawExpr:
+		x = d.X
+	}
+	if x = trimCast(strings.TrimSpace(x)); sqlx.IsQuoted(x, '\'') {
+		if u, err := sqlx.Unquote(x); err == nil {
+			x = u
+		}
+	}
+	return x
+}
+
+// funcLangOr returns the language of the function, defaults to SQL.
+func funcLangOr(l string) string {
+	if l == "" {
+		return LangSQL
+	}
+	return l
+}
+
+// volatility returns the volatility of the function, defaults to VOLATILE.
+func volatility(attrs []schema.Attr) string {
+	if v := (schema.FuncVolatility{}); sqlx.Has(attrs, &v) && v.V != "" {
+		return strings.ToUpper(v.V)
+	}
+	return VolatilityVolatile
+}
+
+// sequencesDiff returns the changes for migrating the standalone
+// sequences of a schema from one state to the other.
+func sequencesDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		s1, ok := o1.(*Sequence)
+		if !ok {
+			continue
+		}
+		s2, ok := schemaSequence(to, s1.Name)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: s1})
+			continue
+		}
+		changed, err := sequenceChanged(s1, s2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: s1, To: s2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if s2, ok := o2.(*Sequence); ok {
+			if _, ok := schemaSequence(from, s2.Name); !ok {
+				changes = append(changes, &schema.AddObject{O: s2})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// schemaSequence returns the sequence with the given name from the schema, if exists.
+func schemaSequence(s *schema.Schema, name string) (*Sequence, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		seq, ok := o.(*Sequence)
+		return ok && seq.Name == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Sequence), true
+}
+
+// sequenceChanged reports if the options, the owner or the comment of a sequence were changed.
+func sequenceChanged(from, to *Sequence) (bool, error) {
+	o1, err := sequenceOptions(from)
+	if err != nil {
+		return false, err
+	}
+	o2, err := sequenceOptions(to)
+	if err != nil {
+		return false, err
+	}
+	return *o1 != *o2 || !sameOwner(from, to) || sqlx.CommentDiff(from.Attrs, to.Attrs) != nil, nil
+}
+
+// sameOwner reports if the two sequences are owned by the same column.
+func sameOwner(s1, s2 *Sequence) bool {
+	if s1.Owner.T == nil || s2.Owner.T == nil {
+		return s1.Owner.T == s2.Owner.T
+	}
+	return s1.Owner.T.Name == s2.Owner.T.Name && s1.Owner.C.Name == s2.Owner.C.Name
+}
+
+// seqOptions holds the options of a sequence, where
+// the unset ones are filled with the database defaults.
+type seqOptions struct {
+	typ                         string
+	start, inc, min, max, cache int64
+	cycle                       bool
+}
+
+// sequenceOptions returns the effective options of the sequence.
+func sequenceOptions(s *Sequence) (*seqOptions, error) {
+	o := &seqOptions{typ: TypeBigInt, start: s.Start, inc: s.Increment, cache: s.Cache, cycle: s.Cycle}
+	if s.Type != nil {
+		t, err := FormatType(s.Type)
+		if err != nil {
+			return nil, fmt.Errorf("format type of sequence %q: %w", s.Name, err)
+		}
+		o.typ = t
+	}
+	if o.inc == 0 {
+		o.inc = defaultSeqIncrement
+	}
+	o.min, o.max = sequenceBounds(s.Type, o.inc)
+	if s.Min != nil {
+		o.min = *s.Min
+	}
+	if s.Max != nil {
+		o.max = *s.Max
+	}
+	if o.start == 0 {
+		o.start = o.defaultStart()
+	}
+	if o.cache == 0 {
+		o.cache = 1
+	}
+	return o, nil
+}
+
+// defaultStart returns the default start value of the sequence. Ascending
+// sequences start at their minimum value, and descending ones at their maximum.
+func (o *seqOptions) defaultStart() int64 {
+	if o.inc < 0 {
+		return o.max
+	}
+	return o.min
+}
+
+// sequenceBounds returns the default minimum and maximum
+// values of a sequence based on its type and direction.
+func sequenceBounds(t schema.Type, inc int64) (lo, hi int64) {
+	hi = math.MaxInt64
+	if it, ok := t.(*schema.IntegerType); ok {
+		switch strings.ToLower(it.T) {
+		case TypeSmallInt, TypeInt2:
+			hi = math.MaxInt16
+		case TypeInteger, TypeInt, TypeInt4:
+			hi = math.MaxInt32
+		}
+	}
+	if inc < 0 {
+		return -hi - 1, -1
+	}
+	return 1, hi
+}
+
+// reSeqRef extracts the (optionally qualified) sequence name from a "nextval" call.
+var reSeqRef = regexp.MustCompile(`(?i)nextval\('(?:"?([\w$]+)"?\.)?"?([\w$]+)"?'(?:::regclass)?\)`)
+
+// seqRef returns the schema (if qualified) and the name of
+// the sequence referenced by a "nextval" call in x.
+func seqRef(x string) (ns, name string, ok bool) {
+	m := reSeqRef.FindStringSubmatch(x)
+	if len(m) != 3 {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// schemaObject returns the object of type T with the given spec name from the schema, if exists.
+func schemaObject[T interface {
+	schema.Object
+	SpecName() string
+}](s *schema.Schema, name string) (T, bool) {
+	for _, o := range s.Objects {
+		if t, ok := o.(T); ok && t.SpecName() == name {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// domainsDiff returns the changes for migrating the
+// domains of a schema from one state to the other.
+func (d *diff) domainsDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		d1, ok := o1.(*DomainType)
+		if !ok {
+			continue
+		}
+		d2, ok := schemaObject[*DomainType](to, d1.T)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: d1})
+			continue
+		}
+		changed, err := d.domainChanged(d1, d2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: d1, To: d2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if d2, ok := o2.(*DomainType); ok {
+			if _, ok := schemaObject[*DomainType](from, d2.T); !ok {
+				changes = append(changes, &schema.AddObject{O: d2})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// domainChanged reports if the underlying type, the nullability, the default
+// value, the checks or the comment of a domain were changed.
+func (d *diff) domainChanged(from, to *DomainType) (bool, error) {
+	c1 := &schema.Column{Name: from.T, Type: &schema.ColumnType{Type: from.Type}, Default: from.Default}
+	c2 := &schema.Column{Name: to.T, Type: &schema.ColumnType{Type: to.Type}, Default: to.Default}
+	if changed, err := d.typeChanged(c1, c2); changed || err != nil {
+		return changed, err
+	}
+	if changed, err := d.defaultChanged(c1, c2); changed || err != nil {
+		return changed, err
+	}
+	drop, add := domainChecksDiff(from, to)
+	return from.Null != to.Null || len(drop) > 0 || len(add) > 0 || sqlx.CommentDiff(from.Attrs, to.Attrs) != nil, nil
+}
+
+// domainChecksDiff returns the checks that were dropped from the domain and the ones that
+// were added to it. Checks are matched by their names and expressions, or by their expressions
+// only in case one of them is unnamed.
+func domainChecksDiff(from, to *DomainType) (drop, add []*schema.Check) {
+	same := func(c1, c2 *schema.Check) bool {
+		if c1.Name != "" && c2.Name != "" && c1.Name != c2.Name {
+			return false
+		}
+		return c1.Expr == c2.Expr || sqlx.MayWrap(c1.Expr) == sqlx.MayWrap(c2.Expr)
+	}
+	for _, c1 := range from.Checks {
+		if !slices.ContainsFunc(to.Checks, func(c2 *schema.Check) bool { return same(c1, c2) }) {
+			drop = append(drop, c1)
+		}
+	}
+	for _, c2 := range to.Checks {
+		if !slices.ContainsFunc(from.Checks, func(c1 *schema.Check) bool { return same(c1, c2) }) {
+			add = append(add, c2)
+		}
+	}
+	return drop, add
+}
+
+// compositesDiff returns the changes for migrating the
+// composite types of a schema from one state to the other.
+func (d *diff) compositesDiff(from, to *schema.Schema) ([]schema.Change, error) {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		c1, ok := o1.(*CompositeType)
+		if !ok {
+			continue
+		}
+		c2, ok := schemaObject[*CompositeType](to, c1.T)
+		if !ok {
+			changes = append(changes, &schema.DropObject{O: c1})
+			continue
+		}
+		changed, err := d.compositeChanged(c1, c2)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, &schema.ModifyObject{From: c1, To: c2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if c2, ok := o2.(*CompositeType); ok {
+			if _, ok := schemaObject[*CompositeType](from, c2.T); !ok {
+				changes = append(changes, &schema.AddObject{O: c2})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// compositeChanged reports if the fields or the comment of a composite type were changed.
+// Fields are matched by their names, as their order cannot be changed in the database.
+func (d *diff) compositeChanged(from, to *CompositeType) (bool, error) {
+	if len(from.Fields) != len(to.Fields) || sqlx.CommentDiff(from.Attrs, to.Attrs) != nil {
+		return true, nil
+	}
+	for _, f1 := range from.Fields {
+		idx := slices.IndexFunc(to.Fields, func(f2 *schema.Column) bool { return f1.Name == f2.Name })
+		if idx == -1 {
+			return true, nil
+		}
+		if changed, err := d.typeChanged(f1, to.Fields[idx]); changed || err != nil {
+			return changed, err
+		}
+	}
+	return false, nil
+}
+
+// extensionsDiff returns the changes for migrating the
+// extensions of a realm from one state to the other.
+func extensionsDiff(from, to *schema.Realm) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		e1, ok := o1.(*Extension)
+		if !ok {
+			continue
+		}
+		e2, ok := realmExtension(to, e1.T)
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropObject{O: e1})
+		case extensionChanged(e1, e2):
+			changes = append(changes, &schema.ModifyObject{From: e1, To: e2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if e2, ok := o2.(*Extension); ok {
+			if _, ok := realmExtension(from, e2.T); !ok {
+				changes = append(changes, &schema.AddObject{O: e2})
+			}
+		}
+	}
+	return changes
+}
+
+// realmExtension returns the extension with the given name from the realm, if exists.
+func realmExtension(r *schema.Realm, name string) (*Extension, bool) {
+	o, ok := r.Object(func(o schema.Object) bool {
+		e, ok := o.(*Extension)
+		return ok && e.T == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Extension), true
+}
+
+// extensionChanged reports if the version, the schema or the comment of an extension were
+// changed. The version and the schema are compared only if they are set on both sides, as
+// they are optional in the desired state.
+func extensionChanged(from, to *Extension) bool {
+	return from.Version != "" && to.Version != "" && from.Version != to.Version ||
+		from.Schema != nil && to.Schema != nil && from.Schema.Name != to.Schema.Name ||
+		sqlx.CommentDiff(from.Attrs, to.Attrs) != nil
+}
+
+// policiesDiff returns the changes for migrating the row-level
+// security policies of a schema from one state to the other.
+func policiesDiff(from, to *schema.Schema) []schema.Change {
+	var changes []schema.Change
+	for _, o1 := range from.Objects {
+		p1, ok := o1.(*Policy)
+		if !ok {
+			continue
+		}
+		p2, ok := schemaPolicy(to, p1.Table.Name, p1.Name)
+		switch {
+		case !ok:
+			changes = append(changes, &schema.DropObject{O: p1})
+		case policyChanged(p1, p2):
+			changes = append(changes, &schema.ModifyObject{From: p1, To: p2})
+		}
+	}
+	for _, o2 := range to.Objects {
+		if p2, ok := o2.(*Policy); ok {
+			if _, ok := schemaPolicy(from, p2.Table.Name, p2.Name); !ok {
+				changes = append(changes, &schema.AddObject{O: p2})
+			}
+		}
+	}
+	return changes
+}
+
+// schemaPolicy returns the policy with the given name defined on the given table, if exists.
+func schemaPolicy(s *schema.Schema, table, name string) (*Policy, bool) {
+	o, ok := s.Object(func(o schema.Object) bool {
+		p, ok := o.(*Policy)
+		return ok && p.Table.Name == table && p.Name == name
+	})
+	if !ok {
+		return nil, false
+	}
+	return o.(*Policy), true
+}
+
+// policyChanged reports if the definition or the comment of a policy were changed.
+func policyChanged(from, to *Policy) bool {
+	return policyAs(from) != policyAs(to) || policyFor(from) != policyFor(to) ||
+		!slices.Equal(policyRoles(from), policyRoles(to)) ||
+		exprChanged(from.Using, to.Using) || exprChanged(from.Check, to.Check) ||
+		sqlx.CommentDiff(from.Attrs, to.Attrs) != nil
+}
+
+// policyRecreated reports if the policy must be dropped and created again to
+// be migrated, as its kind and command cannot be changed with ALTER POLICY, and
+// its expressions can be replaced, but not removed.
+func policyRecreated(from, to *Policy) bool {
+	return policyAs(from) != policyAs(to) || policyFor(from) != policyFor(to) ||
+		from.Using != "" && to.Using == "" || from.Check != "" && to.Check == ""
+}
+
+// exprChanged reports if the two optional expressions are different.
+func exprChanged(x1, x2 string) bool {
+	return x1 != x2 && sqlx.MayWrap(x1) != sqlx.MayWrap(x2)
+}
+
+// policyAs returns the kind of the policy, or its default.
+func policyAs(p *Policy) string {
+	if p.As == "" {
+		return PolicyAsPermissive
+	}
+	return strings.ToUpper(p.As)
+}
+
+// policyFor returns the command of the policy, or its default.
+func policyFor(p *Policy) string {
+	if p.For == "" {
+		return PolicyForAll
+	}
+	return strings.ToUpper(p.For)
+}
+
+// policyRoles returns the sorted roles of the policy, or its default.
+func policyRoles(p *Policy) []string {
+	if len(p.To) == 0 {
+		return []string{"PUBLIC"}
+	}
+	roles := make([]string, len(p.To))
+	for i, r := range p.To {
+		if roleKeyword(r) {
+			r = strings.ToUpper(r)
+		}
+		roles[i] = r
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// roleKeyword reports if the role name is a keyword and not an identifier.
+func roleKeyword(r string) bool {
+	switch strings.ToUpper(r) {
+	case "PUBLIC", "CURRENT_ROLE", "CURRENT_USER", "SESSION_USER":
+		return true
+	}
+	return false
+}
+
+// rowSecurity returns the row-level security options of the table. A zero
+// value, describing a disabled row-level security, is returned if not set.
+func rowSecurity(t *schema.Table) *RowSecurity {
+	rs := &RowSecurity{}
+	sqlx.Has(t.Attrs, rs)
+	return rs
+}
+
+// roleChanged reports if the options of a role or a user were changed.
+func roleChanged(from, to schema.Object) bool {
+	var fromA, toA []schema.Attr
+	switch from := from.(type) {
+	case *schema.Role:
+		fromA, toA = from.Attrs, to.(*schema.Role).Attrs
+	case *schema.User:
+		fromA, toA = from.Attrs, to.(*schema.User).Attrs
+	}
+	return roleOptions(fromA) != roleOptions(toA) || connLimit(fromA) != connLimit(toA)
+}
+
+// roleOptions returns the options of a role or a user, or their defaults.
+func roleOptions(attrs []schema.Attr) (o RoleOptions) {
+	sqlx.Has(attrs, &o)
+	return RoleOptions{
+		Superuser:   o.Superuser,
+		CreateDB:    o.CreateDB,
+		CreateRole:  o.CreateRole,
+		Replication: o.Replication,
+		BypassRLS:   o.BypassRLS,
+		NoInherit:   o.NoInherit,
+	}
+}
+
+// connLimit returns the connection limit of a role or a user, or -1 if it is not limited.
+func connLimit(attrs []schema.Attr) int {
+	if l := (ConnLimit{}); sqlx.Has(attrs, &l) {
+		return l.V
+	}
+	return -1
+}
+
+// permissionPrivileges returns the sorted privileges of the permission,
+// with ALL expanded to the privileges it grants on the resource.
+func permissionPrivileges(p *schema.Permission) []string {
+	var privs []string
+	for _, pv := range sqlx.Privileges(p) {
+		if pv == PrivilegeAll || pv == "ALL PRIVILEGES" {
+			privs = append(privs, allPrivileges(p)...)
+		} else {
+			privs = append(privs, pv)
+		}
+	}
+	slices.Sort(privs)
+	return slices.Compact(privs)
+}
+
+// allPrivileges returns the sorted privileges ALL grants on the permission resource.
+func allPrivileges(p *schema.Permission) []string {
+	switch {
+	case len(p.Columns) > 0:
+		return []string{PrivilegeInsert, PrivilegeReferences, PrivilegeSelect, PrivilegeUpdate}
+	case p.Table != nil:
+		return []string{PrivilegeDelete, PrivilegeInsert, PrivilegeReferences, PrivilegeSelect, PrivilegeTrigger, PrivilegeTruncate, PrivilegeUpdate}
+	default:
+		return []string{PrivilegeCreate, PrivilegeUsage}
+	}
+}
+
+// privilegesOrAll returns the sorted privileges of the permission,
+// or ALL if all privileges were granted on its resource.
+func privilegesOrAll(p *schema.Permission) []string {
+	privs := sqlx.Privileges(p)
+	// MAINTAIN was added to the table privileges in PostgreSQL 17.
+	if rest := slices.DeleteFunc(slices.Clone(privs), func(pv string) bool {
+		return slices.Contains(allPrivileges(p), pv)
+	}); len(privs)-len(rest) == len(allPrivileges(p)) && (len(rest) == 0 || slices.Equal(rest, []string{PrivilegeMaintain})) {
+		return []string{PrivilegeAll}
+	}
+	return privs
+}
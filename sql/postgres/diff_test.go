@@ -68,6 +68,72 @@ func TestDiff_TableDiff(t *testing.T) {
 			to:      schema.NewTable("logs"),
 			wantErr: true,
 		},
+		func() testcase {
+			var (
+				parent = schema.NewTable("logs").SetSchema(schema.New("public"))
+				p1     = &PartitionOf{T: parent, Values: "FROM (1) TO (10)"}
+				p2     = &PartitionOf{T: parent, Default: true}
+			)
+			return testcase{
+				name: "partition bound",
+				from: schema.NewTable("logs_1").AddAttrs(p1),
+				to:   schema.NewTable("logs_1").AddAttrs(p2),
+				wantChanges: []schema.Change{
+					&schema.ModifyAttr{From: p1, To: p2},
+				},
+			}
+		}(),
+		func() testcase {
+			parent := schema.NewTable("logs").SetSchema(schema.New("public"))
+			return testcase{
+				name: "partition bound formatting",
+				from: schema.NewTable("logs_1").AddAttrs(&PartitionOf{T: parent, Values: "FROM (1) TO (10)"}),
+				to:   schema.NewTable("logs_1").AddAttrs(&PartitionOf{T: parent, Values: "FROM (1)  TO\n(10)"}),
+			}
+		}(),
+		func() testcase {
+			p := &PartitionOf{T: schema.NewTable("logs"), Values: "IN (1)"}
+			return testcase{
+				name: "attach partition",
+				from: schema.NewTable("logs_1"),
+				to:   schema.NewTable("logs_1").AddAttrs(p),
+				wantChanges: []schema.Change{
+					&schema.AddAttr{A: p},
+				},
+			}
+		}(),
+		func() testcase {
+			p := &PartitionOf{T: schema.NewTable("logs"), Values: "IN (1)"}
+			return testcase{
+				name: "detach partition",
+				from: schema.NewTable("logs_1").AddAttrs(p),
+				to:   schema.NewTable("logs_1"),
+				wantChanges: []schema.Change{
+					&schema.DropAttr{A: p},
+				},
+			}
+		}(),
+		{
+			name: "enable row-level security",
+			from: schema.NewTable("users"),
+			to:   schema.NewTable("users").AddAttrs(&RowSecurity{Enabled: true}),
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{From: &RowSecurity{}, To: &RowSecurity{Enabled: true}},
+			},
+		},
+		{
+			name: "unchanged row-level security",
+			from: schema.NewTable("users").AddAttrs(&RowSecurity{}),
+			to:   schema.NewTable("users"),
+		},
+		{
+			name: "force row-level security",
+			from: schema.NewTable("users").AddAttrs(&RowSecurity{Enabled: true}),
+			to:   schema.NewTable("users").AddAttrs(&RowSecurity{Enabled: true, Enforced: true}),
+			wantChanges: []schema.Change{
+				&schema.ModifyAttr{From: &RowSecurity{Enabled: true}, To: &RowSecurity{Enabled: true, Enforced: true}},
+			},
+		},
 		{
 			name: "add partition key",
 			from: schema.NewTable("logs"),
@@ -503,6 +569,51 @@ func TestDiff_RealmDiff(t *testing.T) {
 		&schema.AddObject{O: to.Objects[0]},
 		&schema.AddTable{T: to.Tables[0]},
 	}, changes)
+
+	// Extensions are compared by their names, and their versions
+	// and schemas are compared only if they are set on both sides.
+	public := schema.New("public")
+	from := schema.NewRealm(public).AddObjects(
+		&Extension{T: "hstore", Version: "1.7"},
+		&Extension{T: "citext", Schema: public, Version: "1.6"},
+		&Extension{T: "dropped"},
+	)
+	toR := schema.NewRealm(schema.New("public")).AddObjects(
+		&Extension{T: "hstore", Schema: public},
+		&Extension{T: "citext", Version: "1.5"},
+		&Extension{T: "added"},
+	)
+	changes, err = drv.RealmDiff(from, toR)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: toR.Objects[1]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.AddObject{O: toR.Objects[2]},
+	}, changes)
+
+	// ALL is equal to the privileges it grants on the resource.
+	fromReader, toReader := &schema.Role{Name: "reader"}, &schema.Role{Name: "reader"}
+	from = schema.NewRealm(schema.New("public"))
+	from.AddObjects(
+		fromReader,
+		&schema.Role{Name: "admin", Attrs: []schema.Attr{&RoleOptions{Superuser: true}}},
+		&schema.User{Name: "app"},
+		&schema.Permission{To: fromReader, Schema: from.Schemas[0], Privileges: []string{"CREATE", "USAGE"}},
+	)
+	toR = schema.NewRealm(schema.New("public"))
+	toR.AddObjects(
+		toReader,
+		&schema.Role{Name: "admin"},
+		&schema.User{Name: "app"},
+		&schema.Permission{To: toReader, Schema: toR.Schemas[0], Privileges: []string{"ALL"}},
+		&schema.User{Name: "new"},
+	)
+	changes, err = drv.RealmDiff(from, toR)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: toR.Objects[1]},
+		&schema.AddObject{O: toR.Objects[4]},
+	}, changes)
 }
 
 func TestDiff_SchemaDiff(t *testing.T) {
@@ -527,6 +638,95 @@ func TestDiff_SchemaDiff(t *testing.T) {
 		&schema.AddTable{T: to.Tables[1]},
 	}, changes)
 
+	// Functions are identified by their name and input arguments.
+	intT := &schema.IntegerType{T: TypeInteger}
+	from = schema.New("public").AddObjects(
+		schema.NewFunc("f", "SELECT a").AddArgs(&schema.FuncArg{Name: "a", Type: intT}).SetReturn(intT).SetLang("sql"),
+		schema.NewFunc("f", "SELECT 1").SetReturn(intT).SetLang("sql"),
+		schema.NewProc("p", "BEGIN END").SetLang(LangPLpgSQL),
+	)
+	to = schema.New("public").AddObjects(
+		schema.NewFunc("f", "  SELECT a  ").AddArgs(&schema.FuncArg{Name: "a", Type: intT, Mode: schema.FuncArgModeIn}).SetReturn(intT).SetLang(LangSQL),
+		schema.NewFunc("f", "SELECT 1").SetReturn(intT).SetLang("sql").SetVolatility(VolatilityStable),
+		schema.NewFunc("f", "SELECT b").AddArgs(&schema.FuncArg{Name: "b", Type: &schema.StringType{T: TypeText}}).SetReturn(intT).SetLang("sql"),
+	)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
+
+	// Sequences are compared by their effective options.
+	from = schema.New("public").
+		AddTables(schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "bigint").SetDefault(&schema.RawExpr{X: "nextval('public.s1'::regclass)"}))).
+		AddObjects(
+			&Sequence{Name: "s1", Type: &schema.IntegerType{T: TypeBigInt}, Start: 1, Increment: 1, Cache: 1},
+			&Sequence{Name: "s2", Start: 1},
+			&Sequence{Name: "s3"},
+		)
+	to = schema.New("public").
+		AddTables(schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "bigint").SetDefault(&schema.RawExpr{X: "nextval('s1')"}))).
+		AddObjects(
+			&Sequence{Name: "s1"},
+			&Sequence{Name: "s2", Start: 10},
+			&Sequence{Name: "s4", Type: &schema.IntegerType{T: TypeInteger}, Increment: -1},
+		)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.AddObject{O: to.Objects[2]},
+	}, changes)
+
+	// Domains and composite types are identified by their names.
+	from = schema.New("public").AddObjects(
+		&DomainType{T: "d1", Type: intT, Default: &schema.Literal{V: "1"}, Checks: []*schema.Check{{Name: "positive", Expr: "(VALUE > 0)"}}},
+		&DomainType{T: "d2", Type: intT, Null: true},
+		&DomainType{T: "d3", Type: intT},
+		&CompositeType{T: "c1", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: intT}}}},
+		&CompositeType{T: "c2", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: intT}}}},
+	)
+	to = schema.New("public").AddObjects(
+		&DomainType{T: "d1", Type: &schema.IntegerType{T: "int"}, Default: &schema.Literal{V: "1"}, Checks: []*schema.Check{{Name: "positive", Expr: "VALUE > 0"}}},
+		&DomainType{T: "d2", Type: intT},
+		&DomainType{T: "d4", Type: intT},
+		&CompositeType{T: "c1", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: "int4"}}}}},
+		&CompositeType{T: "c2", Fields: []*schema.Column{{Name: "b", Type: &schema.ColumnType{Type: intT}}}},
+	)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.DropObject{O: from.Objects[2]},
+		&schema.AddObject{O: to.Objects[2]},
+		&schema.ModifyObject{From: from.Objects[4], To: to.Objects[4]},
+	}, changes)
+
+	// Policies are identified by their tables and names.
+	users, posts := schema.NewTable("users"), schema.NewTable("posts")
+	from = schema.New("public").AddTables(users, posts).AddObjects(
+		&Policy{Name: "p1", Table: users, As: PolicyAsPermissive, For: PolicyForAll, To: []string{"PUBLIC"}, Using: "(id > 0)"},
+		&Policy{Name: "p1", Table: posts, Using: "(id > 0)"},
+		&Policy{Name: "p2", Table: users, To: []string{"b", "a"}},
+		&Policy{Name: "p3", Table: users},
+	)
+	to = schema.New("public").AddTables(users, posts).AddObjects(
+		&Policy{Name: "p1", Table: users, Using: "id > 0"},
+		&Policy{Name: "p1", Table: posts, Using: "(id > 1)"},
+		&Policy{Name: "p2", Table: users, To: []string{"a", "b"}},
+		&Policy{Name: "p4", Table: users, For: PolicyForSelect},
+	)
+	changes, err = drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.EqualValues(t, []schema.Change{
+		&schema.ModifyObject{From: from.Objects[1], To: to.Objects[1]},
+		&schema.DropObject{O: from.Objects[3]},
+		&schema.AddObject{O: to.Objects[3]},
+	}, changes)
+
 	// Add comment.
 	from, to = schema.New("public"), schema.New("public").SetComment("comment")
 	changes, err = drv.SchemaDiff(from, to)
@@ -660,3 +860,29 @@ concurrent_index {
 	require.Equal(t, `CREATE INDEX CONCURRENTLY "users_pkey_new" ON "public"."users" ("id")`, plan.Changes[1].Cmd)
 	require.Equal(t, `DROP INDEX CONCURRENTLY "public"."users_pkey_new"`, plan.Changes[1].Reverse)
 }
+
+func TestDiff_AnnotateDetachPartition(t *testing.T) {
+	var cfg struct {
+		schemahcl.DefaultExtension
+	}
+	// language=hcl
+	err := schemahcl.New().EvalBytes([]byte(`
+concurrent_partition {
+  detach = true
+}
+`), &cfg, nil)
+	require.NoError(t, err)
+	from, to := schema.New("public"), schema.New("public")
+	from.AddTables(schema.NewTable("logs"), schema.NewTable("logs_1"))
+	from.Tables[1].AddAttrs(&PartitionOf{T: from.Tables[0], Values: "IN (1)"})
+	to.AddTables(schema.NewTable("logs"), schema.NewTable("logs_1"))
+	changes, err := DefaultDiff.SchemaDiff(from, to, func(opts *schema.DiffOptions) { opts.Extra = cfg.DefaultExtension })
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	plan, err := DefaultPlan.PlanChanges(context.Background(), "changes", changes)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_1" CONCURRENTLY`, plan.Changes[0].Cmd)
+	require.Equal(t, `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_1" FOR VALUES IN (1)`, plan.Changes[0].Reverse)
+	require.False(t, from.Tables[1].Attrs[0].(*PartitionOf).Concurrently, "inspected attribute should not be modified")
+}
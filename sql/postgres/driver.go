@@ -11,7 +11,9 @@ import (
 	"hash/fnv"
 	"math/rand"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"ariga.io/atlas/schemahcl"
@@ -589,37 +591,132 @@ const (
 	PartitionTypeHash  = "HASH"
 )
 
+// List of policy kinds.
+const (
+	PolicyAsPermissive  = "PERMISSIVE"
+	PolicyAsRestrictive = "RESTRICTIVE"
+)
+
+// List of commands a policy applies to.
+const (
+	PolicyForAll    = "ALL"
+	PolicyForSelect = "SELECT"
+	PolicyForInsert = "INSERT"
+	PolicyForUpdate = "UPDATE"
+	PolicyForDelete = "DELETE"
+)
+
+// List of privileges that can be granted on schemas, tables and columns.
+const (
+	PrivilegeAll        = "ALL"
+	PrivilegeSelect     = "SELECT"
+	PrivilegeInsert     = "INSERT"
+	PrivilegeUpdate     = "UPDATE"
+	PrivilegeDelete     = "DELETE"
+	PrivilegeTruncate   = "TRUNCATE"
+	PrivilegeReferences = "REFERENCES"
+	PrivilegeTrigger    = "TRIGGER"
+	PrivilegeMaintain   = "MAINTAIN"
+	PrivilegeCreate     = "CREATE"
+	PrivilegeUsage      = "USAGE"
+)
+
+// List of function volatility classifications.
+const (
+	VolatilityVolatile  = "VOLATILE"
+	VolatilityStable    = "STABLE"
+	VolatilityImmutable = "IMMUTABLE"
+)
+
+// List of common procedural languages.
+const (
+	LangSQL     = "SQL"
+	LangPLpgSQL = "PLpgSQL"
+)
+
 var (
 	specOptions []schemahcl.Option
 	specFuncs   = &specutil.SchemaFuncs{
-		Table: tableSpec,
+		Table:        tableSpec,
+		View:         viewSpec,
+		Materialized: materializedSpec,
+		Func:         funcSpec,
+		Proc:         procSpec,
+		Trigger:      triggerSpec,
 	}
 	scanFuncs = &specutil.ScanFuncs{
-		Table: convertTable,
+		Table:        convertTable,
+		View:         convertView,
+		Materialized: convertMaterialized,
+		Func:         convertFunc,
+		Proc:         convertProc,
+		Trigger:      convertTrigger,
 	}
 )
 
-func tableAttrsSpec(*schema.Table, *sqlspec.Table) {
-	// unimplemented.
+func tableAttrsSpec(t *schema.Table, spec *sqlspec.Table) {
+	rs := rowSecurity(t)
+	if !rs.Enabled && !rs.Enforced {
+		return
+	}
+	r := &schemahcl.Resource{Type: "row_security"}
+	if rs.Enabled {
+		r.Attrs = append(r.Attrs, schemahcl.BoolAttr("enabled", true))
+	}
+	if rs.Enforced {
+		r.Attrs = append(r.Attrs, schemahcl.BoolAttr("enforced", true))
+	}
+	spec.Extra.Children = append(spec.Extra.Children, r)
 }
 
-func convertTableAttrs(*sqlspec.Table, *schema.Table) error {
-	return nil // unimplemented.
+func convertTableAttrs(spec *sqlspec.Table, t *schema.Table) error {
+	r, ok := spec.Extra.Resource("row_security")
+	if !ok {
+		return nil
+	}
+	rs := &RowSecurity{}
+	for _, o := range []struct {
+		k string
+		v *bool
+	}{
+		{"enabled", &rs.Enabled},
+		{"enforced", &rs.Enforced},
+	} {
+		if a, ok := r.Attr(o.k); ok {
+			b, err := a.Bool()
+			if err != nil {
+				return fmt.Errorf("table %q: row_security: attribute %q: %w", t.Name, o.k, err)
+			}
+			*o.v = b
+		}
+	}
+	t.AddAttrs(rs)
+	return nil
 }
 
 // tableAttrDiff allows extending table attributes diffing with build-specific logic.
-func (*diff) tableAttrDiff(_, _ *schema.Table) ([]schema.Change, error) {
-	return nil, nil // unimplemented.
+func (*diff) tableAttrDiff(from, to *schema.Table) ([]schema.Change, error) {
+	var changes []schema.Change
+	if rs1, rs2 := rowSecurity(from), rowSecurity(to); rs1.Enabled != rs2.Enabled || rs1.Enforced != rs2.Enforced {
+		changes = append(changes, &schema.ModifyAttr{From: rs1, To: rs2})
+	}
+	return changes, nil
 }
 
 // addTableAttrs allows extending table attributes creation with build-specific logic.
-func (*state) addTableAttrs(_ *schema.AddTable) {
-	// unimplemented.
+func (s *state) addTableAttrs(add *schema.AddTable) {
+	if rs := rowSecurity(add.T); rs.Enabled || rs.Enforced {
+		s.append(s.alterRowSecurity(add, add.T, &RowSecurity{}, rs))
+	}
 }
 
 // alterTableAttr allows extending table attributes alteration with build-specific logic.
-func (s *state) alterTableAttr(*sqlx.Builder, *schema.ModifyAttr) {
-	// unimplemented.
+func (s *state) alterTableAttr(b *sqlx.Builder, m *schema.ModifyAttr) {
+	from, ok1 := m.From.(*RowSecurity)
+	to, ok2 := m.To.(*RowSecurity)
+	if ok1 && ok2 {
+		rowSecurityActions(b, from, to)
+	}
 }
 
 
@@ -633,6 +730,24 @@ func (s *state) addObject(add *schema.AddObject) error {
 			Reverse: drop,
 			Comment: fmt.Sprintf("create enum type %q", o.T),
 		})
+	case *schema.Func, *schema.Proc:
+		r, _ := routineOf(o)
+		return s.addRoutine(add, r)
+	case *Sequence:
+		return s.addSequence(add, o)
+	case *DomainType:
+		return s.addDomain(add, o)
+	case *CompositeType:
+		return s.addComposite(add, o)
+	case *Extension:
+		return s.addExtension(add, o)
+	case *Policy:
+		s.addPolicy(add, o)
+	case *schema.Role, *schema.User:
+		g, _ := granteeOf(o)
+		s.addRole(add, g)
+	case *schema.Permission:
+		return s.addPermission(add, o)
 	default:
 		// unsupported object type.
 	}
@@ -649,6 +764,24 @@ func (s *state) dropObject(drop *schema.DropObject) error {
 			Reverse: create,
 			Comment: fmt.Sprintf("drop enum type %q", o.T),
 		})
+	case *schema.Func, *schema.Proc:
+		r, _ := routineOf(o)
+		return s.dropRoutine(drop, r)
+	case *Sequence:
+		return s.dropSequence(drop, o)
+	case *DomainType:
+		return s.dropDomain(drop, o)
+	case *CompositeType:
+		return s.dropComposite(drop, o)
+	case *Extension:
+		return s.dropExtension(drop, o)
+	case *Policy:
+		s.dropPolicy(drop, o)
+	case *schema.Role, *schema.User:
+		g, _ := granteeOf(o)
+		s.dropRole(drop, g)
+	case *schema.Permission:
+		return s.dropPermission(drop, o)
 	default:
 		// unsupported object type.
 	}
@@ -659,19 +792,60 @@ func (s *state) modifyObject(modify *schema.ModifyObject) error {
 	if _, ok := modify.From.(*schema.EnumType); ok {
 		return s.alterEnum(modify)
 	}
+	if from, ok := routineOf(modify.From); ok {
+		if to, ok := routineOf(modify.To); ok {
+			return s.modifyRoutine(modify, from, to)
+		}
+	}
+	if from, ok := granteeOf(modify.From); ok {
+		if to, ok := granteeOf(modify.To); ok {
+			s.modifyRole(modify, from, to)
+			return nil
+		}
+	}
+	switch from := modify.From.(type) {
+	case *schema.Permission:
+		if to, ok := modify.To.(*schema.Permission); ok {
+			return s.modifyPermission(modify, from, to)
+		}
+	case *Sequence:
+		if to, ok := modify.To.(*Sequence); ok {
+			return s.modifySequence(modify, from, to)
+		}
+	case *DomainType:
+		if to, ok := modify.To.(*DomainType); ok {
+			return s.modifyDomain(modify, from, to)
+		}
+	case *CompositeType:
+		if to, ok := modify.To.(*CompositeType); ok {
+			return s.modifyComposite(modify, from, to)
+		}
+	case *Extension:
+		if to, ok := modify.To.(*Extension); ok {
+			return s.modifyExtension(modify, from, to)
+		}
+	case *Policy:
+		if to, ok := modify.To.(*Policy); ok {
+			return s.modifyPolicy(modify, from, to)
+		}
+	}
 	return nil // unimplemented.
 }
 
 
 // RealmObjectDiff returns a changeset for migrating realm (database) objects
 // from one state to the other. For example, adding extensions or users.
-func (*diff) RealmObjectDiff(_, _ *schema.Realm) ([]schema.Change, error) {
-	return nil, nil // unimplemented.
+func (*diff) RealmObjectDiff(from, to *schema.Realm) ([]schema.Change, error) {
+	changes := extensionsDiff(from, to)
+	return append(changes, sqlx.RolesDiff(from, to, &sqlx.RoleDiffFuncs{
+		Changed:    roleChanged,
+		Privileges: permissionPrivileges,
+	})...), nil
 }
 
 // SchemaObjectDiff returns a changeset for migrating schema objects from
 // one state to the other.
-func (*diff) SchemaObjectDiff(from, to *schema.Schema, _ *schema.DiffOptions) ([]schema.Change, error) {
+func (d *diff) SchemaObjectDiff(from, to *schema.Schema, _ *schema.DiffOptions) ([]schema.Change, error) {
 	var changes []schema.Change
 	// Drop or modify enums.
 	for _, o1 := range from.Objects {
@@ -704,16 +878,226 @@ func (*diff) SchemaObjectDiff(from, to *schema.Schema, _ *schema.DiffOptions) ([
 			changes = append(changes, &schema.AddObject{O: e1})
 		}
 	}
-	return changes, nil
+	// Add, drop or modify domains and composite types.
+	domains, err := d.domainsDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, domains...)
+	composites, err := d.compositesDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, composites...)
+	// Add, drop or modify functions and procedures.
+	funcs, err := funcsDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, funcs...)
+	// Add, drop or modify sequences.
+	seqs, err := sequencesDiff(from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, seqs...)
+	// Add, drop or modify row-level security policies.
+	return append(changes, policiesDiff(from, to)...), nil
+}
+
+// convertDomains converts the domain specs to domain types and adds them to their schemas.
+// The underlying types of the domains are resolved after all domains were added, as they
+// might be based on other domains.
+func convertDomains(_ []*sqlspec.Table, domains []*domain, r *schema.Realm) error {
+	types := make([]*DomainType, 0, len(domains))
+	for _, spec := range domains {
+		ns, err := typeSchema(r, "domain", spec.Name, spec.Schema)
+		if err != nil {
+			return err
+		}
+		d := &DomainType{T: spec.Name, Schema: ns, Null: spec.Null}
+		if d.Default, err = specutil.Default(spec.Default); err != nil {
+			return fmt.Errorf("domain %q: %w", spec.Name, err)
+		}
+		for _, c := range spec.Checks {
+			ck, err := specutil.Check(c)
+			if err != nil {
+				return fmt.Errorf("domain %q: %w", spec.Name, err)
+			}
+			d.Checks = append(d.Checks, ck)
+		}
+		if err := specComment(spec.Extra, &d.Attrs); err != nil {
+			return fmt.Errorf("domain %q: %w", spec.Name, err)
+		}
+		ns.AddObjects(d)
+		types = append(types, d)
+	}
+	for i, spec := range domains {
+		if spec.Type == nil {
+			return fmt.Errorf("missing type definition for domain %q", spec.Name)
+		}
+		t, err := specType(r, types[i].Schema, spec.Type)
+		if err != nil {
+			return fmt.Errorf("domain %q: %w", spec.Name, err)
+		}
+		types[i].Type = t
+	}
+	return nil
 }
 
-func convertDomains(_ []*sqlspec.Table, domains []*domain, _ *schema.Realm) error {
-	if len(domains) > 0 {
-		return fmt.Errorf("postgres: domains are not supported by this version. Use: https://atlasgo.io/getting-started")
+// convertComposites converts the composite specs to composite types and adds them to their
+// schemas. The types of the fields are resolved after all composite types were added, as
+// they might be based on other composite types.
+func convertComposites(composites []*composite, r *schema.Realm) error {
+	types := make([]*CompositeType, 0, len(composites))
+	for _, spec := range composites {
+		ns, err := typeSchema(r, "composite", spec.Name, spec.Schema)
+		if err != nil {
+			return err
+		}
+		c := &CompositeType{T: spec.Name, Schema: ns}
+		if err := specComment(spec.Extra, &c.Attrs); err != nil {
+			return fmt.Errorf("composite %q: %w", spec.Name, err)
+		}
+		ns.AddObjects(c)
+		types = append(types, c)
+	}
+	for i, spec := range composites {
+		for _, f := range spec.Fields {
+			if f.Type == nil {
+				return fmt.Errorf("missing type definition for field %q of composite %q", f.Name, spec.Name)
+			}
+			t, err := specType(r, types[i].Schema, f.Type)
+			if err != nil {
+				return fmt.Errorf("composite %q: %w", spec.Name, err)
+			}
+			types[i].Fields = append(types[i].Fields, &schema.Column{
+				Name: f.Name,
+				Type: &schema.ColumnType{Type: t},
+			})
+		}
+	}
+	return nil
+}
+
+// typeSchema returns the schema of a user-defined type spec and
+// ensures the type is not defined twice in the same schema.
+func typeSchema(r *schema.Realm, typ, name string, ref *schemahcl.Ref) (*schema.Schema, error) {
+	n, err := specutil.SchemaName(ref)
+	if err != nil {
+		return nil, fmt.Errorf("extract schema name from %s reference: %w", typ, err)
+	}
+	ns, ok := r.Schema(n)
+	if !ok {
+		return nil, fmt.Errorf("schema %q defined on %s %q was not found in realm", n, typ, name)
+	}
+	if _, ok := ns.Object(func(o schema.Object) bool {
+		o1, ok := o.(specutil.SpecTypeNamer)
+		return ok && o1.SpecType() == typ && o1.SpecName() == name
+	}); ok {
+		return nil, fmt.Errorf("duplicate %s %q in schema %q", typ, name, n)
+	}
+	return ns, nil
+}
+
+// specType converts a type spec of a domain or a composite field into a schema.Type.
+func specType(r *schema.Realm, ns *schema.Schema, t *schemahcl.Type) (schema.Type, error) {
+	switch ut, err := userType(r, ns, t); {
+	case err != nil:
+		return nil, err
+	case ut != nil:
+		return ut, nil
+	}
+	return convertColumnType(&sqlspec.Column{Type: t})
+}
+
+// userType resolves a reference to a user-defined type (an enum, a domain or a composite type),
+// or returns nil if the given type is not a reference. Unqualified references are searched in
+// the given schema first, and then in the rest of the realm schemas.
+func userType(r *schema.Realm, ns *schema.Schema, t *schemahcl.Type) (schema.Type, error) {
+	for _, typ := range []string{"enum", "domain", "composite"} {
+		if !t.IsRefTo(typ) {
+			continue
+		}
+		q, name, err := specutil.RefName(&schemahcl.Ref{V: t.T}, typ)
+		if err != nil {
+			return nil, err
+		}
+		schemas := r.Schemas
+		switch {
+		case q != "":
+			s, ok := r.Schema(q)
+			if !ok {
+				return nil, fmt.Errorf("schema %q of %s %q was not found in realm", q, typ, name)
+			}
+			schemas = []*schema.Schema{s}
+		case ns != nil:
+			schemas = append([]*schema.Schema{ns}, schemas...)
+		}
+		for _, s := range schemas {
+			if o, ok := s.Object(func(o schema.Object) bool {
+				o1, ok := o.(specutil.SpecTypeNamer)
+				_, isT := o.(schema.Type)
+				return ok && isT && o1.SpecType() == typ && o1.SpecName() == name
+			}); ok {
+				return o.(schema.Type), nil
+			}
+		}
+		return nil, fmt.Errorf("%s %q was not found in realm", typ, name)
+	}
+	return nil, nil
+}
+
+// specComment appends the comment attribute of the spec (if exists) to the given attributes.
+func specComment(r schemahcl.Resource, attrs *[]schema.Attr) error {
+	a, ok := r.Attr("comment")
+	if !ok {
+		return nil
+	}
+	v, err := a.String()
+	if err != nil {
+		return fmt.Errorf("attribute \"comment\": %w", err)
 	}
+	*attrs = append(*attrs, &schema.Comment{Text: v})
 	return nil
 }
 
+// domainSpec converts a domain type to its spec.
+func domainSpec(d *DomainType, ns string) (*domain, error) {
+	t, err := columnTypeSpec(d.Type)
+	if err != nil {
+		return nil, fmt.Errorf("domain %q: %w", d.T, err)
+	}
+	def, err := specutil.ColumnDefault(&schema.Column{Name: d.T, Type: &schema.ColumnType{Type: d.Type}, Default: d.Default})
+	if err != nil {
+		return nil, err
+	}
+	spec := &domain{Name: d.T, Schema: specutil.SchemaRef(ns), Type: t.Type, Null: d.Null, Default: def}
+	for _, c := range d.Checks {
+		spec.Checks = append(spec.Checks, specutil.FromCheck(c))
+	}
+	if c := (schema.Comment{}); sqlx.Has(d.Attrs, &c) && c.Text != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec, nil
+}
+
+// compositeSpec converts a composite type to its spec.
+func compositeSpec(c *CompositeType, ns string) (*composite, error) {
+	spec := &composite{Name: c.T, Schema: specutil.SchemaRef(ns)}
+	for _, f := range c.Fields {
+		t, err := columnTypeSpec(f.Type.Type)
+		if err != nil {
+			return nil, fmt.Errorf("composite %q: %w", c.T, err)
+		}
+		spec.Fields = append(spec.Fields, &compositeField{Name: f.Name, Type: t.Type})
+	}
+	if cm := (schema.Comment{}); sqlx.Has(c.Attrs, &cm) && cm.Text != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", cm.Text))
+	}
+	return spec, nil
+}
+
 func convertAggregate(d *doc, _ *schema.Realm) error {
 	if len(d.Aggregates) > 0 {
 		return fmt.Errorf("postgres: aggregates are not supported by this version. Use: https://atlasgo.io/getting-started")
@@ -721,27 +1105,377 @@ func convertAggregate(d *doc, _ *schema.Realm) error {
 	return nil
 }
 
-func convertSequences(_ []*sqlspec.Table, seqs []*sqlspec.Sequence, _ *schema.Realm) error {
-	if len(seqs) > 0 {
-		return fmt.Errorf("postgres: sequences are not supported by this version. Use: https://atlasgo.io/getting-started")
+// convertSequences converts the sequence specs to sequences and adds them to their schemas.
+func convertSequences(_ []*sqlspec.Table, seqs []*sqlspec.Sequence, r *schema.Realm) error {
+	for _, spec := range seqs {
+		n, err := specutil.SchemaName(spec.Schema)
+		if err != nil {
+			return fmt.Errorf("extract schema name from sequence reference: %w", err)
+		}
+		ns, ok := r.Schema(n)
+		if !ok {
+			return fmt.Errorf("schema %q defined on sequence %q was not found in realm", n, spec.Name)
+		}
+		if _, ok := schemaSequence(ns, spec.Name); ok {
+			return fmt.Errorf("duplicate sequence %q in schema %q", spec.Name, n)
+		}
+		seq, err := convertSequence(spec, ns)
+		if err != nil {
+			return fmt.Errorf("sequence %q: %w", spec.Name, err)
+		}
+		ns.AddObjects(seq)
 	}
 	return nil
 }
 
-func convertPolicies(_ []*sqlspec.Table, ps []*policy, _ *schema.Realm) error {
-	if len(ps) > 0 {
-		return fmt.Errorf("postgres: policies are not supported by this version. Use: https://atlasgo.io/getting-started")
+// convertSequence converts a sequence spec to a sequence in the given schema.
+func convertSequence(spec *sqlspec.Sequence, ns *schema.Schema) (*Sequence, error) {
+	seq := &Sequence{Name: spec.Name, Schema: ns}
+	if a, ok := spec.Attr("type"); ok {
+		t, err := a.Type()
+		if err != nil {
+			return nil, err
+		}
+		if seq.Type, err = TypeRegistry.Type(t, nil); err != nil {
+			return nil, err
+		}
+		if _, ok := seq.Type.(*schema.IntegerType); !ok {
+			return nil, fmt.Errorf("unexpected type %q, expect smallint, integer or bigint", t.T)
+		}
+	}
+	for _, o := range []struct {
+		k string
+		v *int64
+	}{
+		{"start", &seq.Start},
+		{"increment", &seq.Increment},
+		{"cache", &seq.Cache},
+	} {
+		if a, ok := spec.Attr(o.k); ok {
+			v, err := a.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", o.k, err)
+			}
+			*o.v = v
+		}
+	}
+	for _, o := range []struct {
+		k string
+		v **int64
+	}{
+		{"min_value", &seq.Min},
+		{"max_value", &seq.Max},
+	} {
+		if a, ok := spec.Attr(o.k); ok {
+			v, err := a.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", o.k, err)
+			}
+			*o.v = &v
+		}
+	}
+	if a, ok := spec.Attr("cycle"); ok {
+		v, err := a.Bool()
+		if err != nil {
+			return nil, fmt.Errorf("attribute \"cycle\": %w", err)
+		}
+		seq.Cycle = v
+	}
+	if a, ok := spec.Attr("owner"); ok {
+		ref, err := a.Ref()
+		if err != nil {
+			return nil, fmt.Errorf("attribute \"owner\": %w", err)
+		}
+		q, n, err := specutil.TableName(&schemahcl.Ref{V: ref})
+		if err != nil {
+			return nil, err
+		}
+		// The owner table must be in the same schema as the sequence.
+		t, ok := ns.Table(n)
+		if !ok || q != "" && q != ns.Name {
+			return nil, fmt.Errorf("owner table %q was not found in schema %q", n, ns.Name)
+		}
+		c, err := specutil.ColumnByRef(t, &schemahcl.Ref{V: ref})
+		if err != nil {
+			return nil, err
+		}
+		seq.Owner.T, seq.Owner.C = t, c
+	}
+	if a, ok := spec.Attr("comment"); ok {
+		v, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("attribute \"comment\": %w", err)
+		}
+		seq.Attrs = append(seq.Attrs, &schema.Comment{Text: v})
+	}
+	return seq, nil
+}
+
+// sequenceSpec converts a sequence to its spec. Options
+// that are set to the database defaults are omitted.
+func sequenceSpec(seq *Sequence, ns string) (*sqlspec.Sequence, error) {
+	o, err := sequenceOptions(seq)
+	if err != nil {
+		return nil, err
+	}
+	spec := &sqlspec.Sequence{Name: seq.Name, Schema: specutil.SchemaRef(ns)}
+	if seq.Type != nil && o.typ != TypeBigInt {
+		t, err := TypeRegistry.Convert(seq.Type)
+		if err != nil {
+			return nil, err
+		}
+		spec.Extra.Attrs = append(spec.Extra.Attrs, &schemahcl.Attr{K: "type", V: schemahcl.TypeValue(t)})
+	}
+	if o.start != o.defaultStart() {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("start", o.start))
+	}
+	if o.inc != defaultSeqIncrement {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("increment", o.inc))
+	}
+	if seq.Min != nil {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("min_value", o.min))
+	}
+	if seq.Max != nil {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("max_value", o.max))
+	}
+	if o.cache != 1 {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.Int64Attr("cache", o.cache))
+	}
+	if o.cycle {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.BoolAttr("cycle", true))
+	}
+	if seq.Owner.T != nil && seq.Owner.C != nil {
+		path, err := specutil.TableSpecRef(seq.Owner.T).Path()
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, schemahcl.PathIndex{T: "column", V: []string{seq.Owner.C.Name}})
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.RefAttr("owner", schemahcl.BuildRef(path)))
+	}
+	if c := (schema.Comment{}); sqlx.Has(seq.Attrs, &c) && c.Text != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec, nil
+}
+
+// convertPolicies converts the policy specs to policies and adds them to the schemas of their tables.
+func convertPolicies(_ []*sqlspec.Table, ps []*policy, r *schema.Realm) error {
+	for _, spec := range ps {
+		if spec.On == nil {
+			return fmt.Errorf("policy %q: missing table reference", spec.Name)
+		}
+		if len(r.Schemas) == 0 {
+			return fmt.Errorf("policy %q: table %q was not found in realm", spec.Name, spec.On.V)
+		}
+		t, err := specutil.TableByRef(r.Schemas[0], spec.On)
+		if err != nil {
+			return fmt.Errorf("policy %q: %w", spec.Name, err)
+		}
+		if _, ok := schemaPolicy(t.Schema, t.Name, spec.Name); ok {
+			return fmt.Errorf("duplicate policy %q on table %q", spec.Name, t.Name)
+		}
+		p := &Policy{Name: spec.Name, Table: t}
+		for _, o := range []struct {
+			k string
+			v *string
+		}{
+			{"as", &p.As},
+			{"for", &p.For},
+			{"using", &p.Using},
+			{"check", &p.Check},
+		} {
+			if a, ok := spec.Attr(o.k); ok {
+				if *o.v, err = a.String(); err != nil {
+					return fmt.Errorf("policy %q: attribute %q: %w", spec.Name, o.k, err)
+				}
+			}
+		}
+		p.As, p.For = strings.ToUpper(p.As), strings.ToUpper(p.For)
+		if a, ok := spec.Attr("to"); ok {
+			if p.To, err = a.Strings(); err != nil {
+				return fmt.Errorf("policy %q: attribute \"to\": %w", spec.Name, err)
+			}
+		}
+		if err := specComment(spec.Extra, &p.Attrs); err != nil {
+			return fmt.Errorf("policy %q: %w", spec.Name, err)
+		}
+		t.Schema.AddObjects(p)
 	}
 	return nil
 }
 
-func convertExtensions(exs []*extension, _ *schema.Realm) error {
-	if len(exs) > 0 {
-		return fmt.Errorf("postgres: extensions are not supported by this version. Use: https://atlasgo.io/getting-started")
+// policySpec converts a row-level security policy to its spec.
+// Options that are set to the database defaults are omitted.
+func policySpec(p *Policy) *policy {
+	spec := &policy{Name: p.Name, On: specutil.TableSpecRef(p.Table)}
+	if as := policyAs(p); as != PolicyAsPermissive {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.VarAttr("as", as))
+	}
+	if cmd := policyFor(p); cmd != PolicyForAll {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, specutil.VarAttr("for", cmd))
+	}
+	if roles := policyRoles(p); !slices.Equal(roles, []string{"PUBLIC"}) {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringsAttr("to", p.To...))
+	}
+	if p.Using != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("using", p.Using))
+	}
+	if p.Check != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("check", p.Check))
+	}
+	if c := (schema.Comment{}); sqlx.Has(p.Attrs, &c) && c.Text != "" {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+	}
+	return spec
+}
+
+// convertExtensions converts the extension specs to extensions and adds them to the realm.
+func convertExtensions(exs []*extension, r *schema.Realm) error {
+	for _, spec := range exs {
+		if _, ok := realmExtension(r, spec.Name); ok {
+			return fmt.Errorf("duplicate extension %q", spec.Name)
+		}
+		e := &Extension{T: spec.Name}
+		if a, ok := spec.Attr("schema"); ok {
+			ref, err := a.Ref()
+			if err != nil {
+				return fmt.Errorf("extension %q: attribute \"schema\": %w", spec.Name, err)
+			}
+			n, err := specutil.SchemaName(&schemahcl.Ref{V: ref})
+			if err != nil {
+				return fmt.Errorf("extension %q: %w", spec.Name, err)
+			}
+			if e.Schema, ok = r.Schema(n); !ok {
+				return fmt.Errorf("schema %q defined on extension %q was not found in realm", n, spec.Name)
+			}
+		}
+		if a, ok := spec.Attr("version"); ok {
+			v, err := a.String()
+			if err != nil {
+				return fmt.Errorf("extension %q: attribute \"version\": %w", spec.Name, err)
+			}
+			e.Version = v
+		}
+		if err := specComment(spec.Extra, &e.Attrs); err != nil {
+			return fmt.Errorf("extension %q: %w", spec.Name, err)
+		}
+		r.AddObjects(e)
+	}
+	return nil
+}
+
+// realmObjectSpec converts the realm-level objects into specs.
+func realmObjectSpec(d *doc, r *schema.Realm) error {
+	for _, o := range r.Objects {
+		e, ok := o.(*Extension)
+		if !ok {
+			continue
+		}
+		spec := &extension{Name: e.T}
+		if e.Schema != nil {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.RefAttr("schema", specutil.SchemaRef(e.Schema.Name)))
+		}
+		if e.Version != "" {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("version", e.Version))
+		}
+		if c := (schema.Comment{}); sqlx.Has(e.Attrs, &c) && c.Text != "" {
+			spec.Extra.Attrs = append(spec.Extra.Attrs, schemahcl.StringAttr("comment", c.Text))
+		}
+		d.Extensions = append(d.Extensions, spec)
+	}
+	var err error
+	d.Roles, d.Users, d.Permissions, err = specutil.FromRoles(r, roleFuncs)
+	return err
+}
+
+// roleFuncs converts the role and user options from and to their specs.
+var roleFuncs = &specutil.RoleFuncs{
+	Role: func(spec *sqlspec.Role, r *schema.Role) error {
+		return convertRoleOptions(spec.Extra, &r.Attrs)
+	},
+	User: func(spec *sqlspec.User, u *schema.User) error {
+		return convertRoleOptions(spec.Extra, &u.Attrs)
+	},
+	RoleSpec: func(r *schema.Role, spec *sqlspec.Role) error {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, roleOptionsSpec(r.Attrs)...)
+		return nil
+	},
+	UserSpec: func(u *schema.User, spec *sqlspec.User) error {
+		spec.Extra.Attrs = append(spec.Extra.Attrs, roleOptionsSpec(u.Attrs)...)
+		return nil
+	},
+}
+
+// convertRoleOptions converts the options of a role or a user spec into attributes.
+func convertRoleOptions(r schemahcl.Resource, attrs *[]schema.Attr) error {
+	var (
+		opts    RoleOptions
+		inherit = true
+	)
+	for _, o := range []struct {
+		k string
+		v *bool
+	}{
+		{"superuser", &opts.Superuser},
+		{"create_db", &opts.CreateDB},
+		{"create_role", &opts.CreateRole},
+		{"replication", &opts.Replication},
+		{"bypass_rls", &opts.BypassRLS},
+		{"inherit", &inherit},
+	} {
+		if a, ok := r.Attr(o.k); ok {
+			v, err := a.Bool()
+			if err != nil {
+				return fmt.Errorf("attribute %q: %w", o.k, err)
+			}
+			*o.v = v
+		}
+	}
+	if opts.NoInherit = !inherit; opts != (RoleOptions{}) {
+		*attrs = append(*attrs, &opts)
+	}
+	if a, ok := r.Attr("conn_limit"); ok {
+		v, err := a.Int()
+		if err != nil {
+			return fmt.Errorf("attribute \"conn_limit\": %w", err)
+		}
+		if v != -1 {
+			*attrs = append(*attrs, &ConnLimit{V: v})
+		}
 	}
 	return nil
 }
 
+// roleOptionsSpec converts the options of a role or a user into spec attributes.
+// Options that are set to the database defaults are omitted.
+func roleOptionsSpec(attrs []schema.Attr) []*schemahcl.Attr {
+	var (
+		specA []*schemahcl.Attr
+		opts  = roleOptions(attrs)
+	)
+	for _, o := range []struct {
+		k string
+		v bool
+	}{
+		{"superuser", opts.Superuser},
+		{"create_db", opts.CreateDB},
+		{"create_role", opts.CreateRole},
+		{"replication", opts.Replication},
+		{"bypass_rls", opts.BypassRLS},
+	} {
+		if o.v {
+			specA = append(specA, schemahcl.BoolAttr(o.k, true))
+		}
+	}
+	if opts.NoInherit {
+		specA = append(specA, schemahcl.BoolAttr("inherit", false))
+	}
+	if l := connLimit(attrs); l != -1 {
+		specA = append(specA, schemahcl.IntAttr("conn_limit", l))
+	}
+	return specA
+}
+
 func convertEventTriggers(evs []*eventTrigger, _ *schema.Realm) error {
 	if len(evs) > 0 {
 		return fmt.Errorf("postgres: event triggers are not supported by this version. Use: https://atlasgo.io/getting-started")
@@ -756,12 +1490,33 @@ func normalizeRealm(*schema.Realm) error {
 // objectSpec converts from a concrete schema objects into specs.
 func objectSpec(d *doc, spec *specutil.SchemaSpec, s *schema.Schema) error {
 	for _, o := range s.Objects {
-		if e, ok := o.(*schema.EnumType); ok {
+		switch o := o.(type) {
+		case *schema.EnumType:
 			d.Enums = append(d.Enums, &enum{
-				Name:   e.T,
-				Values: e.Values,
+				Name:   o.T,
+				Values: o.Values,
 				Schema: specutil.SchemaRef(spec.Schema.Name),
 			})
+		case *DomainType:
+			dm, err := domainSpec(o, spec.Schema.Name)
+			if err != nil {
+				return err
+			}
+			d.Domains = append(d.Domains, dm)
+		case *CompositeType:
+			c, err := compositeSpec(o, spec.Schema.Name)
+			if err != nil {
+				return err
+			}
+			d.Composites = append(d.Composites, c)
+		case *Sequence:
+			seq, err := sequenceSpec(o, spec.Schema.Name)
+			if err != nil {
+				return err
+			}
+			d.Sequences = append(d.Sequences, seq)
+		case *Policy:
+			d.Policies = append(d.Policies, policySpec(o))
 		}
 	}
 	return nil
@@ -770,7 +1525,7 @@ func objectSpec(d *doc, spec *specutil.SchemaSpec, s *schema.Schema) error {
 // convertEnums converts possibly referenced column types (like enums) to
 // an actual schema.Type and sets it on the correct schema.Column.
 func convertTypes(d *doc, r *schema.Realm) error {
-	if len(d.Enums) == 0 {
+	if len(d.Enums) == 0 && len(d.Domains) == 0 && len(d.Composites) == 0 {
 		return nil
 	}
 	byName := make(map[string]*schema.EnumType)
@@ -790,9 +1545,18 @@ func convertTypes(d *doc, r *schema.Realm) error {
 		es.AddObjects(e1)
 		byName[e.Name] = e1
 	}
+	if err := funcEnums(r, byName); err != nil {
+		return err
+	}
+	if err := convertDomains(d.Tables, d.Domains, r); err != nil {
+		return err
+	}
+	if err := convertComposites(d.Composites, r); err != nil {
+		return err
+	}
 	for _, t := range d.Tables {
 		for _, c := range t.Columns {
-			var enum *schema.EnumType
+			var typ schema.Type
 			switch {
 			case c.Type.IsRefTo("enum"):
 				n, err := enumName(c.Type)
@@ -803,13 +1567,21 @@ func convertTypes(d *doc, r *schema.Realm) error {
 				if !ok {
 					return fmt.Errorf("enum %q was not found in realm", n)
 				}
-				enum = e
+				typ = e
+			case c.Type.IsRefTo("domain"), c.Type.IsRefTo("composite"):
+				ut, err := userType(r, nil, c.Type)
+				if err != nil {
+					return err
+				}
+				typ = ut
 			default:
 				if n, ok := arrayType(c.Type.T); ok {
-					enum = byName[n]
+					if e, ok := byName[n]; ok {
+						typ = e
+					}
 				}
 			}
-			if enum == nil {
+			if typ == nil {
 				continue
 			}
 			schemaT, err := specutil.SchemaName(t.Schema)
@@ -830,9 +1602,51 @@ func convertTypes(d *doc, r *schema.Realm) error {
 			}
 			switch t := cc.Type.Type.(type) {
 			case *ArrayType:
-				t.Type = enum
+				t.Type = typ
 			default:
-				cc.Type.Type = enum
+				cc.Type.Type = typ
+			}
+		}
+	}
+	return nil
+}
+
+// funcEnums replaces the enum placeholders in the functions and
+// procedures arguments and return types with the actual enums.
+func funcEnums(r *schema.Realm, byName map[string]*schema.EnumType) error {
+	resolve := func(t schema.Type) (schema.Type, error) {
+		e, ok := t.(*schema.EnumType)
+		if !ok || e.Schema != nil {
+			return t, nil
+		}
+		e1, ok := byName[e.T]
+		if !ok {
+			return nil, fmt.Errorf("enum %q was not found in realm", e.T)
+		}
+		return e1, nil
+	}
+	for _, s := range r.Schemas {
+		for _, o := range s.Objects {
+			var args []*schema.FuncArg
+			switch o := o.(type) {
+			case *schema.Func:
+				args = o.Args
+				if o.Ret != nil {
+					t, err := resolve(o.Ret)
+					if err != nil {
+						return err
+					}
+					o.Ret = t
+				}
+			case *schema.Proc:
+				args = o.Args
+			}
+			for _, a := range args {
+				t, err := resolve(a.Type)
+				if err != nil {
+					return err
+				}
+				a.Type = t
 			}
 		}
 	}
@@ -873,7 +1687,7 @@ func excludeSpec(*sqlspec.Table, *sqlspec.Index, *schema.Index, *Constraint) err
 
 const (
 	// Query to list tables information.
-	// Note, 'attrs' are not supported in this version.
+	// Note, 'attrs' holds the row-level security options of the table.
 	tablesQuery = `
 SELECT
 	t3.oid,
@@ -883,7 +1697,7 @@ SELECT
 	t4.partattrs AS partition_attrs,
 	t4.partstrat AS partition_strategy,
 	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs,
-	'{}' AS attrs
+	json_build_object('row_security', json_build_object('enabled', t3.relrowsecurity, 'enforced', t3.relforcerowsecurity)) AS attrs
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
@@ -899,7 +1713,7 @@ ORDER BY
 	t1.table_schema, t1.table_name
 `
 	// Query to list tables by their names.
-	// Note, 'attrs' are not supported in this version.
+	// Note, 'attrs' holds the row-level security options of the table.
 	tablesQueryArgs = `
 SELECT
 	t3.oid,
@@ -909,7 +1723,7 @@ SELECT
 	t4.partattrs AS partition_attrs,
 	t4.partstrat AS partition_strategy,
 	pg_get_expr(t4.partexprs, t4.partrelid) AS partition_exprs,
-	'{}' AS attrs
+	json_build_object('row_security', json_build_object('enabled', t3.relrowsecurity, 'enforced', t3.relforcerowsecurity)) AS attrs
 FROM
 	INFORMATION_SCHEMA.TABLES AS t1
 	JOIN pg_catalog.pg_namespace AS t2 ON t2.nspname = t1.table_schema
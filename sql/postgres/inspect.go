@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -51,6 +52,12 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 			if err := i.inspectEnums(ctx, r); err != nil {
 				return nil, err
 			}
+			if err := i.inspectDomains(ctx, r); err != nil {
+				return nil, err
+			}
+			if err := i.inspectComposites(ctx, r); err != nil {
+				return nil, err
+			}
 		}
 		if mode.Is(schema.InspectTables) {
 			if err := i.inspectTables(ctx, r, nil); err != nil {
@@ -58,106 +65,887 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 			}
 			sqlx.LinkSchemaTables(schemas)
 		}
+		if mode.Is(schema.InspectViews) {
+			if err := i.inspectViews(ctx, r, nil); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectFuncs) {
+			if err := i.inspectFuncs(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectTriggers) {
+			if err := i.inspectTriggers(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if mode.Is(schema.InspectObjects) {
+			if err := i.inspectSequences(ctx, r); err != nil {
+				return nil, err
+			}
+			if err := i.inspectPolicies(ctx, r); err != nil {
+				return nil, err
+			}
+			if err := i.inspectExtensions(ctx, r, opts); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if mode.Is(schema.InspectRoles) {
+		if err := i.inspectRoles(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if r, err = schema.IncludeRealm(r, opts.Include); err != nil {
+		return nil, err
+	}
+	return schema.ExcludeRealm(r, opts.Exclude)
+}
+
+// noSearchPath ensures the session search_path is clean when inspecting realms to ensures all
+// referenced objects in the public schema (or any other default search_path) are returned
+// qualified in the inspection.
+func (i *inspect) noSearchPath(ctx context.Context) (func() error, error) {
+	if i.crdb {
+		// Skip logic for CockroachDB.
+		return func() error { return nil }, nil
+	}
+	rows, err := i.QueryContext(ctx, "SELECT current_setting('search_path'), set_config('search_path', '', false)")
+	if err != nil {
+		return nil, err
+	}
+	var prev sql.NullString
+	if err := sqlx.ScanOne(rows, &prev, &sql.NullString{}); err != nil {
+		return nil, err
+	}
+	return func() error {
+		if sqlx.ValidString(prev) {
+			rows, err := i.QueryContext(ctx, "SELECT set_config('search_path', $1, false)", prev.String)
+			if err != nil {
+				return err
+			}
+			return rows.Close()
+		}
+		return nil
+	}, nil
+}
+
+// InspectSchema returns schema descriptions of the tables in the given schema.
+// If the schema name is empty, the result will be the attached schema.
+func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.InspectOptions) (s *schema.Schema, err error) {
+	if name == "" && i.schema != "" {
+		name = i.schema // Otherwise, the "current_schema()" is used.
+	}
+	schemas, err := i.schemas(ctx, &schema.InspectRealmOption{Schemas: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	switch n := len(schemas); {
+	case n == 0:
+		// Empty string indicates current connected schema.
+		if name == "" {
+			return nil, &schema.NotExistError{Err: errors.New("postgres: current_schema() defined in search_path was not found")}
+		}
+		return nil, &schema.NotExistError{Err: fmt.Errorf("postgres: schema %q was not found", name)}
+	case n > 1:
+		return nil, fmt.Errorf("postgres: %d schemas were found for %q", n, name)
+	}
+	if opts == nil {
+		opts = &schema.InspectOptions{}
+	}
+	var (
+		r    = schema.NewRealm(schemas...)
+		mode = sqlx.ModeInspectSchema(opts)
+	)
+	if mode.Is(schema.InspectTypes) {
+		if err := i.inspectEnums(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.inspectDomains(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.inspectComposites(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTables) {
+		if err := i.inspectTables(ctx, r, opts); err != nil {
+			return nil, err
+		}
+		sqlx.LinkSchemaTables(schemas)
+	}
+	if mode.Is(schema.InspectViews) {
+		if err := i.inspectViews(ctx, r, opts); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectFuncs) {
+		if err := i.inspectFuncs(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectObjects) {
+		if err := i.inspectSequences(ctx, r); err != nil {
+			return nil, err
+		}
+		if err := i.inspectPolicies(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+	if s, err = schema.IncludeSchema(r.Schemas[0], opts.Include); err != nil {
+		return nil, err
+	}
+	return schema.ExcludeSchema(s, opts.Exclude)
+}
+
+func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	if err := i.tables(ctx, r, opts); err != nil {
+		return err
+	}
+	if err := i.partitionTables(ctx, r); err != nil {
+		return err
+	}
+	for _, s := range r.Schemas {
+		if len(s.Tables) == 0 {
+			continue
+		}
+		if err := i.columns(ctx, s); err != nil {
+			return err
+		}
+		if err := i.indexes(ctx, s); err != nil {
+			return err
+		}
+		if err := i.partitions(s); err != nil {
+			return err
+		}
+		if err := i.fks(ctx, s); err != nil {
+			return err
+		}
+		if err := i.checks(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inspectViews queries the views and the materialized views of the given realm
+// schemas, their columns, indexes and dependencies.
+func (i *inspect) inspectViews(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	if err := i.views(ctx, r, opts); err != nil {
+		return err
+	}
+	// Materialized views are not supported by CockroachDB.
+	if !i.crdb {
+		if err := i.matViews(ctx, r, opts); err != nil {
+			return err
+		}
+	}
+	for _, s := range r.Schemas {
+		if len(s.Views) == 0 {
+			continue
+		}
+		if err := i.viewColumns(ctx, s); err != nil {
+			return err
+		}
+		if err := i.matViewIndexes(ctx, s); err != nil {
+			return err
+		}
+	}
+	return i.viewDeps(ctx, r)
+}
+
+// views queries and appends the views of the given realm schemas.
+func (i *inspect) views(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	var (
+		args  []any
+		query = fmt.Sprintf(viewsQuery, nArgs(0, len(r.Schemas)))
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	if opts != nil && len(opts.Tables) > 0 {
+		for _, t := range opts.Tables {
+			args = append(args, t)
+		}
+		query = fmt.Sprintf(viewsQueryArgs, nArgs(0, len(r.Schemas)), nArgs(len(r.Schemas), len(opts.Tables)))
+	}
+	rows, err := i.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, name, def, checkOpt, comment sql.NullString
+		if err := rows.Scan(&vSchema, &name, &def, &checkOpt, &comment); err != nil {
+			return fmt.Errorf("scan view information: %w", err)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewView(name.String, strings.TrimSpace(def.String))
+		s.AddViews(v)
+		if sqlx.ValidString(checkOpt) {
+			v.SetCheckOption(checkOpt.String)
+		}
+		if sqlx.ValidString(comment) {
+			v.SetComment(comment.String)
+		}
+	}
+	return rows.Err()
+}
+
+// matViews queries and appends the materialized views of the given realm schemas.
+func (i *inspect) matViews(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
+	var (
+		args  []any
+		query = fmt.Sprintf(matViewsQuery, nArgs(0, len(r.Schemas)))
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	if opts != nil && len(opts.Tables) > 0 {
+		for _, t := range opts.Tables {
+			args = append(args, t)
+		}
+		query = fmt.Sprintf(matViewsQueryArgs, nArgs(0, len(r.Schemas)), nArgs(len(r.Schemas), len(opts.Tables)))
+	}
+	rows, err := i.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying materialized views: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			populated                          bool
+			vSchema, name, def, space, comment sql.NullString
+		)
+		if err := rows.Scan(&vSchema, &name, &def, &populated, &space, &comment); err != nil {
+			return fmt.Errorf("scan materialized view information: %w", err)
+		}
+		s, ok := r.Schema(vSchema.String)
+		if !ok {
+			return fmt.Errorf("schema %q was not found in realm", vSchema.String)
+		}
+		v := schema.NewView(name.String, strings.TrimSpace(def.String)).
+			AddAttrs(&schema.Materialized{NoData: !populated})
+		s.AddViews(v)
+		if sqlx.ValidString(space) {
+			v.AddAttrs(&Tablespace{N: space.String})
+		}
+		if sqlx.ValidString(comment) {
+			v.SetComment(comment.String)
+		}
+	}
+	return rows.Err()
+}
+
+// viewColumns queries and appends the columns of the schema views. Columns of materialized
+// views are queried from the system catalog, as they are not part of the information schema.
+func (i *inspect) viewColumns(ctx context.Context, s *schema.Schema) error {
+	var views, mats []string
+	for _, v := range s.Views {
+		if v.Materialized() {
+			mats = append(mats, v.Name)
+		} else {
+			views = append(views, v.Name)
+		}
+	}
+	query := columnsQuery
+	if i.crdb {
+		query = crdbColumnsQuery
+	}
+	if err := i.queryViewColumns(ctx, s, query, views); err != nil {
+		return err
+	}
+	return i.queryViewColumns(ctx, s, matViewColumnsQuery, mats)
+}
+
+// queryViewColumns executes the given columns query on the given views.
+func (i *inspect) queryViewColumns(ctx context.Context, s *schema.Schema, query string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	args := []any{s.Name}
+	for _, n := range names {
+		args = append(args, n)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(query, nArgs(1, len(names))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q view columns: %w", s.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := i.addColumn(s, rows); err != nil {
+			return fmt.Errorf("postgres: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+// matViewIndexes queries and appends the indexes of the schema materialized views.
+func (i *inspect) matViewIndexes(ctx context.Context, s *schema.Schema) error {
+	args := []any{s.Name}
+	for _, v := range s.Views {
+		if v.Materialized() {
+			args = append(args, v.Name)
+		}
+	}
+	if len(args) == 1 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(i.indexesQuery(), nArgs(1, len(args)-1)), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying schema %q materialized view indexes: %w", s.Name, err)
+	}
+	defer rows.Close()
+	matView := func(name string) (*schema.View, bool) {
+		v, ok := s.View(name)
+		return v, ok && v.Materialized()
+	}
+	if err := i.addIndexes(s, rows, queryScope{
+		hasT: func(tv string) bool {
+			_, ok := matView(tv)
+			return ok
+		},
+		setPK: func(tv string, _ *schema.Index) error {
+			return fmt.Errorf("postgres: unexpected primary key for materialized view %q", tv)
+		},
+		addIndex: func(tv string, idx *schema.Index) error {
+			if v, ok := matView(tv); ok {
+				v.AddIndexes(idx)
+				return nil
+			}
+			return fmt.Errorf("postgres: materialized view %q for index was not found in schema", tv)
+		},
+		column: func(tv, name string) (*schema.Column, bool) {
+			if v, ok := matView(tv); ok {
+				return v.Column(name)
+			}
+			return nil, false
+		},
+	}); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// viewDeps queries the tables and views that the inspected views depend on, and
+// links them together. Objects that reside outside the realm are ignored.
+func (i *inspect) viewDeps(ctx context.Context, r *schema.Realm) error {
+	var args []any
+	for _, s := range r.Schemas {
+		if len(s.Views) > 0 {
+			args = append(args, s.Name)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(viewDepsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying view dependencies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var vSchema, vName, dSchema, dName, dKind string
+		if err := rows.Scan(&vSchema, &vName, &dSchema, &dName, &dKind); err != nil {
+			return fmt.Errorf("scan view dependency: %w", err)
+		}
+		vs, ok := r.Schema(vSchema)
+		if !ok {
+			continue
+		}
+		v, ok := vs.View(vName)
+		if !ok {
+			continue
+		}
+		ds, ok := r.Schema(dSchema)
+		if !ok {
+			continue
+		}
+		switch dKind {
+		case "v", "m":
+			if dv, ok := ds.View(dName); ok {
+				v.AddDeps(dv)
+			}
+		default:
+			if dt, ok := ds.Table(dName); ok {
+				v.AddDeps(dt)
+			}
+		}
+	}
+	return rows.Err()
+}
+
+// inspectFuncs queries the functions and procedures of the given realm schemas, and their arguments.
+// Note, functions and procedures that were created by extensions are ignored.
+func (i *inspect) inspectFuncs(ctx context.Context, r *schema.Realm) error {
+	// The 'prokind' column was added in PostgreSQL 11,
+	// along with the support for stored procedures.
+	if i.crdb || i.version < 11_00_00 || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(funcsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying functions: %w", err)
+	}
+	objs, defaults, err := i.addFuncs(r, rows)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	rows, err = i.QueryContext(ctx, fmt.Sprintf(funcArgsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying function arguments: %w", err)
+	}
+	if err := i.addFuncArgs(objs, rows); err != nil {
+		return err
+	}
+	for id, d := range defaults {
+		if err := funcDefaults(objs[id], d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addFuncs scans the functions and procedures returned by the funcsQuery and appends
+// them to their schemas. The returned maps hold the objects and the default values
+// of their arguments by the function identifier.
+func (i *inspect) addFuncs(r *schema.Realm, rows *sql.Rows) (map[int64]schema.Object, map[int64]string, error) {
+	defer rows.Close()
+	var (
+		objs     = make(map[int64]schema.Object)
+		defaults = make(map[int64]string)
+	)
+	for rows.Next() {
+		var (
+			id                               int64
+			ns, name, kind, lang, volatility string
+			ret, defs, body, comment         sql.NullString
+		)
+		if err := rows.Scan(&id, &ns, &name, &kind, &lang, &volatility, &ret, &defs, &body, &comment); err != nil {
+			return nil, nil, fmt.Errorf("postgres: scan function information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return nil, nil, fmt.Errorf("postgres: schema %q for function %q was not found in inspection", ns, name)
+		}
+		if sqlx.ValidString(defs) {
+			defaults[id] = defs.String
+		}
+		switch kind {
+		case "p":
+			p := schema.NewProc(name, body.String).SetSchema(s).SetLang(lang)
+			if sqlx.ValidString(comment) {
+				p.SetComment(comment.String)
+			}
+			s.AddObjects(p)
+			objs[id] = p
+		default:
+			f := schema.NewFunc(name, body.String).SetSchema(s).SetLang(lang)
+			if v := volatilityName(volatility); v != VolatilityVolatile {
+				f.SetVolatility(v)
+			}
+			if sqlx.ValidString(ret) {
+				t, err := i.funcType(s, ret.String)
+				if err != nil {
+					return nil, nil, fmt.Errorf("postgres: parse return type of function %q: %w", name, err)
+				}
+				f.SetReturn(t)
+			}
+			if sqlx.ValidString(comment) {
+				f.SetComment(comment.String)
+			}
+			s.AddObjects(f)
+			objs[id] = f
+		}
+	}
+	return objs, defaults, rows.Err()
+}
+
+// inspectTriggers queries the triggers of the given realm schemas, and attaches
+// them to the tables and views they are defined on. Internal triggers, such as
+// the ones that are created for foreign-keys constraints, are ignored.
+func (i *inspect) inspectTriggers(ctx context.Context, r *schema.Realm) error {
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(triggersQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying triggers: %w", err)
+	}
+	return i.addTriggers(r, rows)
+}
+
+// addTriggers scans the rows returned by the triggersQuery.
+func (i *inspect) addTriggers(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, table, name, kind, def, fns, fn string
+			comment                             sql.NullString
+		)
+		if err := rows.Scan(&ns, &table, &name, &kind, &def, &fns, &fn, &comment); err != nil {
+			return fmt.Errorf("postgres: scan trigger information: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for trigger %q was not found in inspection", ns, name)
+		}
+		t := schema.NewTrigger(name)
+		// Triggers of excluded (or not inspected) tables and views are skipped.
+		switch kind {
+		case "v":
+			v, ok := s.View(table)
+			if !ok {
+				continue
+			}
+			v.AddTriggers(t)
+		default:
+			tb, ok := s.Table(table)
+			if !ok {
+				continue
+			}
+			tb.AddTriggers(t)
+		}
+		if err := parseTrigger(t, def); err != nil {
+			return fmt.Errorf("postgres: parse trigger %q definition: %w", name, err)
+		}
+		if s1, ok := r.Schema(fns); ok {
+			if f, ok := s1.Func(fn); ok {
+				t.AddDeps(f)
+			}
+		}
+		if sqlx.ValidString(comment) {
+			t.SetComment(comment.String)
+		}
+	}
+	return rows.Err()
+}
+
+// reTrigger parses the output of pg_get_triggerdef. For example:
+//
+//	CREATE TRIGGER t BEFORE INSERT OR UPDATE OF c ON public.users FOR EACH ROW WHEN ((new.c > 0)) EXECUTE FUNCTION f()
+var reTrigger = regexp.MustCompile(`(?is)^CREATE\s+(?:CONSTRAINT\s+)?TRIGGER\s+.+?\s+(BEFORE|AFTER|INSTEAD OF)\s+(.+?)\s+ON\s+.+?\s+FOR EACH (ROW|STATEMENT)(?:\s+WHEN\s+\((.+)\))?\s+(EXECUTE\s+(?:FUNCTION|PROCEDURE)\s+.+)$`)
+
+// parseTrigger parses the trigger definition and sets its action time, events, and body.
+func parseTrigger(t *schema.Trigger, def string) error {
+	matches := reTrigger.FindStringSubmatch(strings.TrimSpace(def))
+	if len(matches) != 6 {
+		return fmt.Errorf("unexpected definition: %q", def)
+	}
+	t.ActionTime = schema.TriggerTime(strings.ToUpper(matches[1]))
+	for _, e := range strings.Split(matches[2], " OR ") {
+		name, columns, ok := strings.Cut(strings.TrimSpace(e), " OF ")
+		if !ok {
+			t.AddEvents(schema.TriggerEvent{Name: strings.ToUpper(name)})
+			continue
+		}
+		if t.Table == nil {
+			return fmt.Errorf("unexpected %s OF event on view %q", name, t.View.Name)
+		}
+		var cs []*schema.Column
+		for _, c := range strings.Split(columns, ",") {
+			c = strings.Trim(strings.TrimSpace(c), `"`)
+			column, ok := t.Table.Column(c)
+			if !ok {
+				return fmt.Errorf("column %q was not found in table %q", c, t.Table.Name)
+			}
+			cs = append(cs, column)
+		}
+		t.AddEvents(schema.TriggerEventUpdateOf(cs...))
+	}
+	t.For = schema.TriggerFor(strings.ToUpper(matches[3]))
+	if matches[4] != "" {
+		t.SetWhen(matches[4])
+	}
+	t.Body = matches[5]
+	return nil
+}
+
+// inspectSequences queries the standalone sequences of the given realm schemas.
+// Sequences that back identity or serial columns are ignored, as they are managed
+// by their columns. Note, the owner of a sequence is resolved only if its table was
+// inspected.
+func (i *inspect) inspectSequences(ctx context.Context, r *schema.Realm) error {
+	// The pg_sequences view was added in PostgreSQL 10.
+	if i.crdb || i.version < 10_00_00 || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(sequencesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying sequences: %w", err)
+	}
+	return i.addSequences(r, rows)
+}
+
+// addSequences scans the rows returned by the sequencesQuery.
+func (i *inspect) addSequences(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, name, typ                 string
+			start, minV, maxV, inc, cache int64
+			cycle                         bool
+			last                          sql.NullInt64
+			ownerT, ownerC, comment       sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &typ, &start, &minV, &maxV, &inc, &cycle, &cache, &last, &ownerT, &ownerC, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning sequence: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for sequence %q was not found in inspection", ns, name)
+		}
+		if serialSequence(s, name) {
+			continue
+		}
+		t, err := ParseType(typ)
+		if err != nil {
+			return fmt.Errorf("postgres: parsing type of sequence %q: %w", name, err)
+		}
+		seq := &Sequence{
+			Name:      name,
+			Schema:    s,
+			Type:      t,
+			Start:     start,
+			Increment: inc,
+			Cache:     cache,
+			Cycle:     cycle,
+			Last:      last.Int64,
+		}
+		// Bounds are set only if they differ from the defaults.
+		lo, hi := sequenceBounds(t, inc)
+		if minV != lo {
+			seq.Min = &minV
+		}
+		if maxV != hi {
+			seq.Max = &maxV
+		}
+		if sqlx.ValidString(ownerT) && sqlx.ValidString(ownerC) {
+			if t, ok := s.Table(ownerT.String); ok {
+				if c, ok := t.Column(ownerC.String); ok {
+					seq.Owner.T, seq.Owner.C = t, c
+				}
+			}
+		}
+		if sqlx.ValidString(comment) {
+			seq.Attrs = append(seq.Attrs, &schema.Comment{Text: comment.String})
+		}
+		s.AddObjects(seq)
 	}
-	return schema.ExcludeRealm(r, opts.Exclude)
+	return rows.Err()
 }
 
-// noSearchPath ensures the session search_path is clean when inspecting realms to ensures all
-// referenced objects in the public schema (or any other default search_path) are returned
-// qualified in the inspection.
-func (i *inspect) noSearchPath(ctx context.Context) (func() error, error) {
-	if i.crdb {
-		// Skip logic for CockroachDB.
-		return func() error { return nil }, nil
+// inspectPolicies queries the row-level security policies of the inspected
+// tables. Policies are added to the schemas of their tables.
+func (i *inspect) inspectPolicies(ctx context.Context, r *schema.Realm) error {
+	// Permissive and restrictive policies were added in PostgreSQL 10.
+	if i.crdb || i.version < 10_00_00 || !slices.ContainsFunc(r.Schemas, func(s *schema.Schema) bool { return len(s.Tables) > 0 }) {
+		return nil
 	}
-	rows, err := i.QueryContext(ctx, "SELECT current_setting('search_path'), set_config('search_path', '', false)")
-	if err != nil {
-		return nil, err
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
 	}
-	var prev sql.NullString
-	if err := sqlx.ScanOne(rows, &prev, &sql.NullString{}); err != nil {
-		return nil, err
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(policiesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying policies: %w", err)
 	}
-	return func() error {
-		if sqlx.ValidString(prev) {
-			rows, err := i.QueryContext(ctx, "SELECT set_config('search_path', $1, false)", prev.String)
-			if err != nil {
-				return err
-			}
-			return rows.Close()
-		}
-		return nil
-	}, nil
+	return i.addPolicies(r, rows)
 }
 
-// InspectSchema returns schema descriptions of the tables in the given schema.
-// If the schema name is empty, the result will be the attached schema.
-func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.InspectOptions) (s *schema.Schema, err error) {
-	if name == "" && i.schema != "" {
-		name = i.schema // Otherwise, the "current_schema()" is used.
-	}
-	schemas, err := i.schemas(ctx, &schema.InspectRealmOption{Schemas: []string{name}})
-	if err != nil {
-		return nil, err
-	}
-	switch n := len(schemas); {
-	case n == 0:
-		// Empty string indicates current connected schema.
-		if name == "" {
-			return nil, &schema.NotExistError{Err: errors.New("postgres: current_schema() defined in search_path was not found")}
+// addPolicies scans the rows returned by the policiesQuery.
+func (i *inspect) addPolicies(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			ns, tname, name, cmd         string
+			permissive                   bool
+			roles, using, check, comment sql.NullString
+		)
+		if err := rows.Scan(&ns, &tname, &name, &permissive, &cmd, &roles, &using, &check, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning policy: %w", err)
 		}
-		return nil, &schema.NotExistError{Err: fmt.Errorf("postgres: schema %q was not found", name)}
-	case n > 1:
-		return nil, fmt.Errorf("postgres: %d schemas were found for %q", n, name)
-	}
-	if opts == nil {
-		opts = &schema.InspectOptions{}
-	}
-	var (
-		r    = schema.NewRealm(schemas...)
-		mode = sqlx.ModeInspectSchema(opts)
-	)
-	if mode.Is(schema.InspectTypes) {
-		if err := i.inspectEnums(ctx, r); err != nil {
-			return nil, err
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for table %q was not found in inspection", ns, tname)
 		}
-	}
-	if mode.Is(schema.InspectTables) {
-		if err := i.inspectTables(ctx, r, opts); err != nil {
-			return nil, err
+		// Skip tables that were not inspected (e.g., excluded).
+		t, ok := s.Table(tname)
+		if !ok {
+			continue
 		}
-		sqlx.LinkSchemaTables(schemas)
+		p := &Policy{
+			Name:  name,
+			Table: t,
+			As:    PolicyAsPermissive,
+			For:   policyCommand(cmd),
+			Using: using.String,
+			Check: check.String,
+		}
+		if !permissive {
+			p.As = PolicyAsRestrictive
+		}
+		if roles.String != "" {
+			p.To = strings.Split(roles.String, ",")
+		}
+		if sqlx.ValidString(comment) {
+			p.Attrs = append(p.Attrs, &schema.Comment{Text: comment.String})
+		}
+		s.AddObjects(p)
 	}
-	return schema.ExcludeSchema(r.Schemas[0], opts.Exclude)
+	return rows.Err()
 }
 
-func (i *inspect) inspectTables(ctx context.Context, r *schema.Realm, opts *schema.InspectOptions) error {
-	if err := i.tables(ctx, r, opts); err != nil {
-		return err
+// policyCommand maps the pg_policy.polcmd value to its SQL command.
+func policyCommand(c string) string {
+	switch c {
+	case "r":
+		return PolicyForSelect
+	case "a":
+		return PolicyForInsert
+	case "w":
+		return PolicyForUpdate
+	case "d":
+		return PolicyForDelete
+	default:
+		return PolicyForAll
 	}
-	for _, s := range r.Schemas {
-		if len(s.Tables) == 0 {
-			continue
-		}
-		if err := i.columns(ctx, s); err != nil {
-			return err
+}
+
+// serialSequence reports if the sequence is used by a serial column of the schema.
+func serialSequence(s *schema.Schema, name string) bool {
+	return slices.ContainsFunc(s.Tables, func(t *schema.Table) bool {
+		return slices.ContainsFunc(t.Columns, func(c *schema.Column) bool {
+			st, ok := c.Type.Type.(*SerialType)
+			return ok && st.sequence(t, c) == name
+		})
+	})
+}
+
+// addFuncArgs scans the function arguments returned by the funcArgsQuery.
+func (i *inspect) addFuncArgs(objs map[int64]schema.Object, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id              int64
+			name, mode, typ string
+		)
+		if err := rows.Scan(&id, &name, &mode, &typ); err != nil {
+			return fmt.Errorf("postgres: scan function argument: %w", err)
 		}
-		if err := i.indexes(ctx, s); err != nil {
-			return err
+		o, ok := objs[id]
+		if !ok {
+			continue
 		}
-		if err := i.partitions(s); err != nil {
-			return err
+		a := &schema.FuncArg{Name: name}
+		switch mode {
+		case "o":
+			a.Mode = schema.FuncArgModeOut
+		case "b":
+			a.Mode = schema.FuncArgModeInOut
+		case "v":
+			a.Mode = schema.FuncArgModeVariadic
+		case "t":
+			continue // Columns of RETURNS TABLE are part of the return type.
 		}
-		if err := i.fks(ctx, s); err != nil {
-			return err
+		switch o := o.(type) {
+		case *schema.Func:
+			t, err := i.funcType(o.Schema, typ)
+			if err != nil {
+				return fmt.Errorf("postgres: parse argument type of function %q: %w", o.Name, err)
+			}
+			a.Type = t
+			o.AddArgs(a)
+		case *schema.Proc:
+			t, err := i.funcType(o.Schema, typ)
+			if err != nil {
+				return fmt.Errorf("postgres: parse argument type of procedure %q: %w", o.Name, err)
+			}
+			a.Type = t
+			o.AddArgs(a)
 		}
-		if err := i.checks(ctx, s); err != nil {
-			return err
+	}
+	return rows.Err()
+}
+
+// funcType parses the type of function argument or its return type.
+// Set-returning and table functions are kept as-is in their raw form.
+func (i *inspect) funcType(s *schema.Schema, typ string) (schema.Type, error) {
+	if setOfType(typ) {
+		return &schema.UnsupportedType{T: typ}, nil
+	}
+	return i.parseType(s, typ)
+}
+
+// setOfType reports if the given return type is a set of rows.
+func setOfType(t string) bool {
+	t = strings.ToUpper(t)
+	return strings.HasPrefix(t, "SETOF ") || strings.HasPrefix(t, "TABLE(") || strings.HasPrefix(t, "TABLE (")
+}
+
+// funcDefaults sets the default values of the function input
+// arguments. Default values are given as a comma-separated list,
+// and correspond to the last N input arguments of the function.
+func funcDefaults(o schema.Object, defs string) error {
+	var args []*schema.FuncArg
+	switch o := o.(type) {
+	case *schema.Func:
+		args = o.Args
+	case *schema.Proc:
+		args = o.Args
+	}
+	var in []*schema.FuncArg
+	for _, a := range args {
+		if a.Mode != schema.FuncArgModeOut {
+			in = append(in, a)
 		}
 	}
+	xs := sqlx.SplitExprs(defs)
+	if len(xs) > len(in) {
+		return fmt.Errorf("postgres: unexpected number of default values: %q", defs)
+	}
+	for j, x := range xs {
+		in[len(in)-len(xs)+j].Default = &schema.RawExpr{X: x}
+	}
 	return nil
 }
 
+// volatilityName returns the volatility name of the given 'provolatile' value.
+func volatilityName(v string) string {
+	switch v {
+	case "i":
+		return VolatilityImmutable
+	case "s":
+		return VolatilityStable
+	default:
+		return VolatilityVolatile
+	}
+}
+
 // table returns the table from the database, or a NotExistError if the table was not found.
 func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.InspectOptions) error {
 	var (
@@ -208,6 +996,20 @@ func (i *inspect) tables(ctx context.Context, realm *schema.Realm, opts *schema.
 				exprs: partexprs.String,
 			})
 		}
+		if sqlx.ValidString(extra) {
+			var attrs struct {
+				RowSecurity struct {
+					Enabled  bool `json:"enabled"`
+					Enforced bool `json:"enforced"`
+				} `json:"row_security"`
+			}
+			if err := json.Unmarshal([]byte(extra.String), &attrs); err != nil {
+				return fmt.Errorf("postgres: unmarshal attributes of table %q: %w", name.String, err)
+			}
+			if rs := attrs.RowSecurity; rs.Enabled || rs.Enforced {
+				t.AddAttrs(&RowSecurity{Enabled: rs.Enabled, Enforced: rs.Enforced})
+			}
+		}
 	}
 	return rows.Err()
 }
@@ -243,9 +1045,13 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) (err error) {
 	); err != nil {
 		return err
 	}
-	t, ok := s.Table(table.String)
-	if !ok {
-		return fmt.Errorf("table %q was not found in schema", table.String)
+	var add func(...*schema.Column)
+	if t, ok := s.Table(table.String); ok {
+		add = func(cs ...*schema.Column) { t.AddColumns(cs...) }
+	} else if v, ok := s.View(table.String); ok {
+		add = func(cs ...*schema.Column) { v.AddColumns(cs...) }
+	} else {
+		return fmt.Errorf("table or view %q was not found in schema", table.String)
 	}
 	c := &schema.Column{
 		Name: name.String,
@@ -311,7 +1117,7 @@ func (i *inspect) addColumn(s *schema.Schema, rows *sql.Rows) (err error) {
 	if sqlx.ValidString(collate) {
 		c.SetCollation(collate.String)
 	}
-	t.AddColumns(c)
+	add(c)
 	return nil
 }
 
@@ -369,49 +1175,385 @@ func (i *inspect) underlyingType(s *schema.Schema, u *UserDefinedType) schema.Ty
 			}
 		}
 	}
-	// No match.
-	return u
+	// No match.
+	return u
+}
+
+// enumValues fills enum columns with their values from the database.
+func (i *inspect) inspectEnums(ctx context.Context, r *schema.Realm) error {
+	var (
+		ids  = make(map[int64]*schema.EnumType)
+		args = make([]any, 0, len(r.Schemas))
+	)
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(enumsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying enum values: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id       int64
+			ns, n, v string
+		)
+		if err := rows.Scan(&ns, &id, &n, &v); err != nil {
+			return fmt.Errorf("postgres: scanning enum label: %w", err)
+		}
+		e, ok := ids[id]
+		if !ok {
+			e = &schema.EnumType{T: n}
+			ids[id] = e
+		}
+		if e.Schema == nil {
+			s, ok := r.Schema(ns)
+			if !ok {
+				return fmt.Errorf("postgres: schema %q for enum %q was not found in inspection", ns, e.T)
+			}
+			e.Schema = s
+			s.Objects = append(s.Objects, e)
+		}
+		e.Values = append(e.Values, v)
+	}
+	return nil
+}
+
+// inspectDomains queries the domain types of the given realm schemas and
+// adds them to their schemas. Domains that were created by extensions are
+// skipped, as they are managed by their extensions.
+func (i *inspect) inspectDomains(ctx context.Context, r *schema.Realm) error {
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(domainsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying domains: %w", err)
+	}
+	return i.addDomains(r, rows)
+}
+
+// addDomains scans the rows returned by the domainsQuery.
+func (i *inspect) addDomains(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	var domains []*DomainType
+	for rows.Next() {
+		var (
+			ns, name, typ                  string
+			notNull                        bool
+			def, comment, check, checkExpr sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &typ, &notNull, &def, &comment, &check, &checkExpr); err != nil {
+			return fmt.Errorf("postgres: scanning domain: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for domain %q was not found in inspection", ns, name)
+		}
+		// Domains with multiple checks are returned in multiple rows.
+		if n := len(domains); n == 0 || domains[n-1].Schema != s || domains[n-1].T != name {
+			t, err := i.parseType(s, typ)
+			if err != nil {
+				return fmt.Errorf("postgres: parsing type of domain %q: %w", name, err)
+			}
+			d := &DomainType{T: name, Schema: s, Type: t, Null: !notNull}
+			if sqlx.ValidString(def) {
+				d.Default = defaultExpr(t, def.String)
+			}
+			if sqlx.ValidString(comment) {
+				d.Attrs = append(d.Attrs, &schema.Comment{Text: comment.String})
+			}
+			domains = append(domains, d)
+			s.AddObjects(d)
+		}
+		if sqlx.ValidString(check) {
+			d := domains[len(domains)-1]
+			d.Checks = append(d.Checks, &schema.Check{Name: check.String, Expr: checkExpr.String})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Domains that are based on other domains are resolved
+	// after all domains were added to their schemas.
+	for _, d := range domains {
+		if u, ok := d.Type.(*UserDefinedType); ok {
+			d.Type = i.underlyingType(d.Schema, u)
+		}
+	}
+	return nil
+}
+
+// inspectComposites queries the composite types of the given realm schemas
+// and adds them to their schemas. Composite types that were created by
+// extensions are skipped, as they are managed by their extensions.
+func (i *inspect) inspectComposites(ctx context.Context, r *schema.Realm) error {
+	if i.crdb || len(r.Schemas) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(compositesQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying composite types: %w", err)
+	}
+	return i.addComposites(r, rows)
+}
+
+// addComposites scans the rows returned by the compositesQuery.
+func (i *inspect) addComposites(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	var types []*CompositeType
+	for rows.Next() {
+		var (
+			ns, name                 string
+			comment, field, fieldTyp sql.NullString
+		)
+		if err := rows.Scan(&ns, &name, &comment, &field, &fieldTyp); err != nil {
+			return fmt.Errorf("postgres: scanning composite type: %w", err)
+		}
+		s, ok := r.Schema(ns)
+		if !ok {
+			return fmt.Errorf("postgres: schema %q for composite type %q was not found in inspection", ns, name)
+		}
+		// Composite types are returned in multiple rows, one per field.
+		if n := len(types); n == 0 || types[n-1].Schema != s || types[n-1].T != name {
+			c := &CompositeType{T: name, Schema: s}
+			if sqlx.ValidString(comment) {
+				c.Attrs = append(c.Attrs, &schema.Comment{Text: comment.String})
+			}
+			types = append(types, c)
+			s.AddObjects(c)
+		}
+		if sqlx.ValidString(field) {
+			c := types[len(types)-1]
+			t, err := ParseType(fieldTyp.String)
+			if err != nil {
+				return fmt.Errorf("postgres: parsing type of field %q in composite type %q: %w", field.String, name, err)
+			}
+			c.Fields = append(c.Fields, &schema.Column{
+				Name: field.String,
+				Type: &schema.ColumnType{Type: t, Raw: fieldTyp.String},
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Fields that are based on other user-defined types are resolved
+	// after all composite types were added to their schemas.
+	for _, c := range types {
+		for _, f := range c.Fields {
+			switch t := f.Type.Type.(type) {
+			case *ArrayType:
+				if u, ok := t.Underlying().(*UserDefinedType); ok {
+					t.Type = i.underlyingType(c.Schema, u)
+				}
+			case *UserDefinedType:
+				f.Type.Type = i.underlyingType(c.Schema, t)
+			}
+		}
+	}
+	return nil
+}
+
+// inspectExtensions queries the extensions installed in the database and adds them to the
+// realm. In case the inspection is limited to specific schemas, only extensions that were
+// installed in one of them are returned. Note, the builtin plpgsql extension is ignored.
+func (i *inspect) inspectExtensions(ctx context.Context, r *schema.Realm, opts *schema.InspectRealmOption) error {
+	if i.crdb {
+		return nil
+	}
+	rows, err := i.QueryContext(ctx, extensionsQuery)
+	if err != nil {
+		return fmt.Errorf("postgres: querying extensions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			name, ns, version string
+			comment           sql.NullString
+		)
+		if err := rows.Scan(&name, &ns, &version, &comment); err != nil {
+			return fmt.Errorf("postgres: scanning extension: %w", err)
+		}
+		e := &Extension{T: name, Version: version}
+		switch s, ok := r.Schema(ns); {
+		case ok:
+			e.Schema = s
+		case len(opts.Schemas) > 0:
+			continue
+		}
+		if sqlx.ValidString(comment) {
+			e.Attrs = append(e.Attrs, &schema.Comment{Text: comment.String})
+		}
+		r.AddObjects(e)
+	}
+	return rows.Err()
 }
 
-// enumValues fills enum columns with their values from the database.
-func (i *inspect) inspectEnums(ctx context.Context, r *schema.Realm) error {
-	var (
-		ids  = make(map[int64]*schema.EnumType)
-		args = make([]any, 0, len(r.Schemas))
-	)
-	for _, s := range r.Schemas {
-		args = append(args, s.Name)
+// inspectRoles queries the roles and the users defined in the database and their
+// privileges on the inspected schemas, and adds them to the realm. Roles that can
+// log in are returned as users, and builtin roles (pg_*) are ignored.
+func (i *inspect) inspectRoles(ctx context.Context, r *schema.Realm) error {
+	if i.crdb {
+		return nil
 	}
-	if len(args) == 0 {
+	rows, err := i.QueryContext(ctx, rolesQuery)
+	if err != nil {
+		return fmt.Errorf("postgres: querying roles: %w", err)
+	}
+	if err := i.addRoles(r, rows); err != nil {
+		return err
+	}
+	if rows, err = i.QueryContext(ctx, roleMembersQuery); err != nil {
+		return fmt.Errorf("postgres: querying role members: %w", err)
+	}
+	if err := i.addRoleMembers(r, rows); err != nil {
+		return err
+	}
+	if len(r.Schemas) == 0 {
 		return nil
 	}
-	rows, err := i.QueryContext(ctx, fmt.Sprintf(enumsQuery, nArgs(0, len(args))), args...)
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err = i.QueryContext(ctx, strings.ReplaceAll(privilegesQuery, "%s", nArgs(0, len(args))), args...)
 	if err != nil {
-		return fmt.Errorf("postgres: querying enum values: %w", err)
+		return fmt.Errorf("postgres: querying privileges: %w", err)
 	}
+	return i.addPermissions(r, rows)
+}
+
+// addRoles scans the rows returned by the rolesQuery.
+func (i *inspect) addRoles(r *schema.Realm, rows *sql.Rows) error {
 	defer rows.Close()
 	for rows.Next() {
 		var (
-			id       int64
-			ns, n, v string
+			name      string
+			login     bool
+			opts      RoleOptions
+			inherit   bool
+			connLimit int
 		)
-		if err := rows.Scan(&ns, &id, &n, &v); err != nil {
-			return fmt.Errorf("postgres: scanning enum label: %w", err)
+		if err := rows.Scan(&name, &login, &opts.Superuser, &opts.CreateDB, &opts.CreateRole, &opts.Replication, &opts.BypassRLS, &inherit, &connLimit); err != nil {
+			return fmt.Errorf("postgres: scanning role: %w", err)
 		}
-		e, ok := ids[id]
+		var attrs []schema.Attr
+		if opts.NoInherit = !inherit; opts != (RoleOptions{}) {
+			attrs = append(attrs, &opts)
+		}
+		if connLimit != -1 {
+			attrs = append(attrs, &ConnLimit{V: connLimit})
+		}
+		if login {
+			r.AddObjects(&schema.User{Name: name, Attrs: attrs})
+		} else {
+			r.AddObjects(&schema.Role{Name: name, Attrs: attrs})
+		}
+	}
+	return rows.Err()
+}
+
+// addRoleMembers scans the rows returned by the roleMembersQuery.
+func (i *inspect) addRoleMembers(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	for rows.Next() {
+		var member, name string
+		if err := rows.Scan(&member, &name); err != nil {
+			return fmt.Errorf("postgres: scanning role member: %w", err)
+		}
+		// Memberships in roles that can log in are not supported.
+		role, ok := r.Role(name)
 		if !ok {
-			e = &schema.EnumType{T: n}
-			ids[id] = e
+			continue
 		}
-		if e.Schema == nil {
-			s, ok := r.Schema(ns)
-			if !ok {
-				return fmt.Errorf("postgres: schema %q for enum %q was not found in inspection", ns, e.T)
+		if m, ok := r.Role(member); ok {
+			m.MemberOf = append(m.MemberOf, role)
+		} else if u, ok := r.User(member, ""); ok {
+			u.MemberOf = append(u.MemberOf, role)
+		}
+	}
+	return rows.Err()
+}
+
+// addPermissions scans the rows returned by the privilegesQuery. Column privileges
+// that were granted with the same privileges are grouped into a single permission.
+func (i *inspect) addPermissions(r *schema.Realm, rows *sql.Rows) error {
+	defer rows.Close()
+	var perms []*schema.Permission
+	for rows.Next() {
+		var (
+			ns, grantee, priv string
+			tname, cname      sql.NullString
+			grantable         bool
+		)
+		if err := rows.Scan(&ns, &tname, &cname, &grantee, &priv, &grantable); err != nil {
+			return fmt.Errorf("postgres: scanning privilege: %w", err)
+		}
+		p := &schema.Permission{Grantable: grantable}
+		if role, ok := r.Role(grantee); ok {
+			p.To = role
+		} else if u, ok := r.User(grantee, ""); ok {
+			p.To = u
+		} else {
+			continue
+		}
+		var ok bool
+		if p.Schema, ok = r.Schema(ns); !ok {
+			continue
+		}
+		if sqlx.ValidString(tname) {
+			if p.Table, ok = p.Schema.Table(tname.String); !ok {
+				continue
+			}
+			if sqlx.ValidString(cname) {
+				c, ok := p.Table.Column(cname.String)
+				if !ok {
+					continue
+				}
+				p.Columns = []*schema.Column{c}
+			}
+		}
+		// Rows are ordered by their resource and grantee.
+		if n := len(perms); n > 0 && sqlx.SameRoleObject(perms[n-1], p) {
+			perms[n-1].Privileges = append(perms[n-1].Privileges, priv)
+		} else {
+			p.Privileges = []string{priv}
+			perms = append(perms, p)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for i := 0; i < len(perms); i++ {
+		p := perms[i]
+		if len(p.Columns) == 0 {
+			continue
+		}
+		// Group column permissions of the same table and grantee.
+		for j := i + 1; j < len(perms); j++ {
+			p1 := perms[j]
+			if len(p1.Columns) > 0 && p1.Table == p.Table && p1.To == p.To && p1.Grantable == p.Grantable && slices.Equal(p1.Privileges, p.Privileges) {
+				p.Columns = append(p.Columns, p1.Columns...)
+				perms = slices.Delete(perms, j, j+1)
+				j--
 			}
-			e.Schema = s
-			s.Objects = append(s.Objects, e)
 		}
-		e.Values = append(e.Values, v)
+	}
+	for _, p := range perms {
+		p.Privileges = privilegesOrAll(p)
+		r.AddObjects(p)
 	}
 	return nil
 }
@@ -658,6 +1800,98 @@ func (i *inspect) partitions(s *schema.Schema) error {
 	return nil
 }
 
+// partitionTables queries and appends the partitions of the partitioned tables in
+// the realm. Partitions of partitions (sub-partitions) are appended as well, and
+// partitions whose parent table was not inspected are ignored.
+func (i *inspect) partitionTables(ctx context.Context, r *schema.Realm) error {
+	// Declarative partitioning is not supported by CockroachDB.
+	if i.crdb || !slices.ContainsFunc(r.Schemas, func(s *schema.Schema) bool {
+		return slices.ContainsFunc(s.Tables, func(t *schema.Table) bool {
+			return sqlx.Has(t.Attrs, &Partition{})
+		})
+	}) {
+		return nil
+	}
+	args := make([]any, 0, len(r.Schemas))
+	for _, s := range r.Schemas {
+		args = append(args, s.Name)
+	}
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(partitionsQuery, nArgs(0, len(args))), args...)
+	if err != nil {
+		return fmt.Errorf("postgres: querying partition tables: %w", err)
+	}
+	defer rows.Close()
+	type partition struct {
+		t            *schema.Table
+		schema, name string // Parent table.
+		bound        string
+	}
+	var parts []*partition
+	for rows.Next() {
+		var (
+			oid                                           sql.NullInt64
+			tSchema, name, pSchema, pName, bound, comment sql.NullString
+			partattrs, partstart, partexprs               sql.NullString
+		)
+		if err := rows.Scan(&oid, &tSchema, &name, &pSchema, &pName, &bound, &comment, &partattrs, &partstart, &partexprs); err != nil {
+			return fmt.Errorf("scan partition information: %w", err)
+		}
+		t := schema.NewTable(name.String).SetSchema(schema.New(tSchema.String))
+		if oid.Valid {
+			t.AddAttrs(&OID{V: oid.Int64})
+		}
+		if sqlx.ValidString(comment) {
+			t.SetComment(comment.String)
+		}
+		if sqlx.ValidString(partattrs) {
+			t.AddAttrs(&Partition{
+				start: partstart.String,
+				attrs: partattrs.String,
+				exprs: partexprs.String,
+			})
+		}
+		parts = append(parts, &partition{t: t, schema: pSchema.String, name: pName.String, bound: bound.String})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Partitions are linked to their parents iteratively, as
+	// sub-partitions are linked only after their parents were.
+	for added := true; added; {
+		added = false
+		for i, p := range parts {
+			if p == nil {
+				continue
+			}
+			s, ok := r.Schema(p.t.Schema.Name)
+			if !ok {
+				return fmt.Errorf("schema %q was not found in realm", p.t.Schema.Name)
+			}
+			ps, ok := r.Schema(p.schema)
+			if !ok {
+				continue
+			}
+			pt, ok := ps.Table(p.name)
+			if !ok {
+				continue
+			}
+			s.AddTables(p.t)
+			p.t.AddAttrs(newPartitionOf(pt, p.bound)).AddDeps(pt)
+			parts[i], added = nil, true
+		}
+	}
+	return nil
+}
+
+// newPartitionOf returns the PartitionOf attribute of a partition
+// from its parent table and the partition bound expression.
+func newPartitionOf(parent *schema.Table, bound string) *PartitionOf {
+	if strings.EqualFold(bound, "DEFAULT") {
+		return &PartitionOf{T: parent, Default: true}
+	}
+	return &PartitionOf{T: parent, Values: strings.TrimSpace(strings.TrimPrefix(bound, "FOR VALUES"))}
+}
+
 // fks queries and appends the foreign keys of the given table.
 func (i *inspect) fks(ctx context.Context, s *schema.Schema) error {
 	rows, err := i.querySchema(ctx, fksQuery, s)
@@ -1037,6 +2271,56 @@ type (
 		}
 	}
 
+	// Extension defines an extension installed in the database.
+	// https://postgresql.org/docs/current/sql-createextension.html
+	Extension struct {
+		schema.Object
+		T       string         // Extension name.
+		Schema  *schema.Schema // Optional schema the extension objects are installed in.
+		Version string         // Optional version.
+		Attrs   []schema.Attr  // Additional attributes (e.g., comments).
+	}
+
+	// RowSecurity describes the row-level security options of a table.
+	// https://postgresql.org/docs/current/ddl-rowsecurity.html
+	RowSecurity struct {
+		schema.Attr
+		Enabled  bool // ENABLE ROW LEVEL SECURITY.
+		Enforced bool // FORCE ROW LEVEL SECURITY.
+	}
+
+	// Policy defines a row-level security policy of a table.
+	// https://postgresql.org/docs/current/sql-createpolicy.html
+	Policy struct {
+		schema.Object
+		Name  string        // Policy name.
+		Table *schema.Table // Table the policy is defined on.
+		As    string        // PERMISSIVE or RESTRICTIVE.
+		For   string        // ALL, SELECT, INSERT, UPDATE or DELETE.
+		To    []string      // Roles the policy applies to.
+		Using string        // Optional USING expression.
+		Check string        // Optional WITH CHECK expression.
+		Attrs []schema.Attr // Additional attributes (e.g., comments).
+	}
+
+	// RoleOptions describes the options of a role or a user that are not set to their defaults.
+	// https://postgresql.org/docs/current/sql-createrole.html
+	RoleOptions struct {
+		schema.Attr
+		Superuser   bool // SUPERUSER.
+		CreateDB    bool // CREATEDB.
+		CreateRole  bool // CREATEROLE.
+		Replication bool // REPLICATION.
+		BypassRLS   bool // BYPASSRLS.
+		NoInherit   bool // NOINHERIT.
+	}
+
+	// ConnLimit describes the connection limit of a role or a user.
+	ConnLimit struct {
+		schema.Attr
+		V int
+	}
+
 	// Identity defines an identity column.
 	Identity struct {
 		schema.Attr
@@ -1151,6 +2435,29 @@ type (
 		Attrs []schema.Attr
 	}
 
+	// PartitionOf describes a table that is a partition of a partitioned
+	// table, i.e., was created using the PARTITION OF clause or attached
+	// to its parent table using the ATTACH PARTITION command.
+	PartitionOf struct {
+		schema.Attr
+		// T is the parent (partitioned) table.
+		T *schema.Table
+		// Values holds the partition bound of the FOR VALUES clause.
+		// For example: "IN (1, 2)" or "FROM (1) TO (10)".
+		Values string
+		// Default reports if this is the DEFAULT partition.
+		Default bool
+		// Concurrently indicates the partition should be detached
+		// concurrently. Set by the diff options, not by inspection.
+		Concurrently bool
+	}
+
+	// Tablespace describes the tablespace an object, such as a materialized view, is stored in.
+	Tablespace struct {
+		schema.Attr
+		N string
+	}
+
 	// Cascade describes that a CASCADE clause should be added to the DROP [TABLE|SCHEMA]
 	// operation. Note, this clause is automatically added to DROP SCHEMA by the planner.
 	Cascade struct {
@@ -1288,6 +2595,24 @@ func (s *SerialType) sequence(t *schema.Table, c *schema.Column) string {
 	return fmt.Sprintf("%s_%s_seq", t.Name, c.Name)
 }
 
+// SpecType returns the type of the sequence in the spec.
+func (*Sequence) SpecType() string { return "sequence" }
+
+// SpecName returns the name of the sequence in the spec.
+func (s *Sequence) SpecName() string { return s.Name }
+
+// SpecType returns the type of the policy in the spec.
+func (*Policy) SpecType() string { return "policy" }
+
+// SpecName returns the name of the policy in the spec.
+func (p *Policy) SpecName() string { return p.Name }
+
+// SpecType returns the type of the extension in the spec.
+func (*Extension) SpecType() string { return "extension" }
+
+// SpecName returns the name of the extension in the spec.
+func (e *Extension) SpecName() string { return e.T }
+
 var (
 	opsOnce    sync.Once
 	defaultOps map[postgresop.Class]bool
@@ -1514,6 +2839,279 @@ WHERE
 	t1.table_schema = $1 AND t1.table_name IN (%s)
 ORDER BY
 	t1.table_name, t1.ordinal_position
+`
+	// Query to list schema views.
+	viewsQuery = `
+SELECT
+	n.nspname AS view_schema,
+	c.relname AS view_name,
+	pg_catalog.pg_get_viewdef(c.oid) AS view_definition,
+	NULLIF(v.check_option, 'NONE') AS check_option,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN "information_schema"."views" AS v ON v.table_schema = n.nspname AND v.table_name = c.relname
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	c.relkind = 'v'
+	AND n.nspname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	n.nspname, c.relname
+`
+	// Query to list schema views by their names.
+	viewsQueryArgs = `
+SELECT
+	n.nspname AS view_schema,
+	c.relname AS view_name,
+	pg_catalog.pg_get_viewdef(c.oid) AS view_definition,
+	NULLIF(v.check_option, 'NONE') AS check_option,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN "information_schema"."views" AS v ON v.table_schema = n.nspname AND v.table_name = c.relname
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	c.relkind = 'v'
+	AND n.nspname IN (%s)
+	AND c.relname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	n.nspname, c.relname
+`
+	// Query to list the partitions of partitioned tables.
+	partitionsQuery = `
+SELECT
+	c.oid,
+	n.nspname AS table_schema,
+	c.relname AS table_name,
+	pn.nspname AS parent_schema,
+	p.relname AS parent_name,
+	pg_catalog.pg_get_expr(c.relpartbound, c.oid) AS partition_bound,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment,
+	pt.partattrs AS partition_attrs,
+	pt.partstrat AS partition_strategy,
+	pg_get_expr(pt.partexprs, pt.partrelid) AS partition_exprs
+FROM
+	pg_catalog.pg_inherits AS i
+	JOIN pg_catalog.pg_class AS c ON c.oid = i.inhrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_class AS p ON p.oid = i.inhparent
+	JOIN pg_catalog.pg_namespace AS pn ON pn.oid = p.relnamespace
+	LEFT JOIN pg_catalog.pg_partitioned_table AS pt ON pt.partrelid = c.oid
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	c.relispartition
+	AND c.relkind IN ('r', 'p')
+	AND n.nspname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	n.nspname, c.relname
+`
+	// Query to list schema materialized views.
+	matViewsQuery = `
+SELECT
+	m.schemaname AS view_schema,
+	m.matviewname AS view_name,
+	m.definition AS view_definition,
+	m.ispopulated AS populated,
+	m.tablespace,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_matviews AS m
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = m.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = m.matviewname
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	m.schemaname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	m.schemaname, m.matviewname
+`
+	// Query to list schema materialized views by their names.
+	matViewsQueryArgs = `
+SELECT
+	m.schemaname AS view_schema,
+	m.matviewname AS view_name,
+	m.definition AS view_definition,
+	m.ispopulated AS populated,
+	m.tablespace,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_matviews AS m
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = m.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = m.matviewname
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.deptype = 'e'
+WHERE
+	m.schemaname IN (%s)
+	AND m.matviewname IN (%s)
+	AND d.objid IS NULL
+ORDER BY
+	m.schemaname, m.matviewname
+`
+	// Query to list the columns of materialized views. The result set has the same shape
+	// as columnsQuery, as materialized views are not listed in the information schema.
+	matViewColumnsQuery = `
+SELECT
+	c.relname AS table_name,
+	a.attname AS column_name,
+	(CASE WHEN t.typelem <> 0 AND t.typlen = -1 THEN 'ARRAY' WHEN tn.nspname = 'pg_catalog' THEN pg_catalog.format_type(a.atttypid, NULL) ELSE 'USER-DEFINED' END) AS data_type,
+	pg_catalog.format_type(a.atttypid, a.atttypmod) AS format_type,
+	(CASE WHEN a.attnotnull THEN 'NO' ELSE 'YES' END) AS is_nullable,
+	NULL AS column_default,
+	information_schema._pg_char_max_length(information_schema._pg_truetypid(a, t), information_schema._pg_truetypmod(a, t)) AS character_maximum_length,
+	information_schema._pg_numeric_precision(information_schema._pg_truetypid(a, t), information_schema._pg_truetypmod(a, t)) AS numeric_precision,
+	information_schema._pg_datetime_precision(information_schema._pg_truetypid(a, t), information_schema._pg_truetypmod(a, t)) AS datetime_precision,
+	information_schema._pg_numeric_scale(information_schema._pg_truetypid(a, t), information_schema._pg_truetypmod(a, t)) AS numeric_scale,
+	information_schema._pg_interval_type(information_schema._pg_truetypid(a, t), information_schema._pg_truetypmod(a, t)) AS interval_type,
+	NULL AS character_set_name,
+	co.collname AS collation_name,
+	'NO' AS is_identity,
+	NULL AS identity_start,
+	NULL AS identity_increment,
+	NULL AS identity_last,
+	NULL AS identity_generation,
+	NULL AS generation_expression,
+	pg_catalog.col_description(c.oid, a.attnum) AS comment,
+	t.typtype,
+	t.typelem,
+	t.oid,
+	a.attnum
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_attribute AS a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+	JOIN pg_catalog.pg_type AS t ON t.oid = a.atttypid
+	JOIN pg_catalog.pg_namespace AS tn ON tn.oid = t.typnamespace
+	LEFT JOIN pg_catalog.pg_collation AS co ON co.oid = a.attcollation AND co.collname <> 'default'
+WHERE
+	n.nspname = $1 AND c.relkind = 'm' AND c.relname IN (%s)
+ORDER BY
+	c.relname, a.attnum
+`
+	// Query to list the tables and views that views depend on.
+	viewDepsQuery = `
+SELECT DISTINCT
+	vn.nspname AS view_schema,
+	v.relname AS view_name,
+	tn.nspname AS dep_schema,
+	t.relname AS dep_name,
+	t.relkind AS dep_kind
+FROM
+	pg_catalog.pg_depend AS d
+	JOIN pg_catalog.pg_rewrite AS r ON r.oid = d.objid
+	JOIN pg_catalog.pg_class AS v ON v.oid = r.ev_class
+	JOIN pg_catalog.pg_namespace AS vn ON vn.oid = v.relnamespace
+	JOIN pg_catalog.pg_class AS t ON t.oid = d.refobjid
+	JOIN pg_catalog.pg_namespace AS tn ON tn.oid = t.relnamespace
+WHERE
+	d.classid = 'pg_catalog.pg_rewrite'::regclass::oid
+	AND d.refclassid = 'pg_catalog.pg_class'::regclass::oid
+	AND v.relkind IN ('v', 'm')
+	AND t.oid <> v.oid
+	AND vn.nspname IN (%s)
+ORDER BY
+	1, 2, 3, 4
+`
+	// Query to list schema functions and procedures.
+	funcsQuery = `
+SELECT
+	p.oid AS func_id,
+	n.nspname AS schema_name,
+	p.proname AS func_name,
+	p.prokind AS func_kind,
+	l.lanname AS func_lang,
+	p.provolatile AS func_volatility,
+	CASE WHEN p.prokind = 'p' THEN NULL ELSE pg_catalog.pg_get_function_result(p.oid) END AS func_return,
+	pg_catalog.pg_get_expr(p.proargdefaults, 0) AS func_defaults,
+	p.prosrc AS func_body,
+	pg_catalog.obj_description(p.oid, 'pg_proc') AS comment
+FROM
+	pg_catalog.pg_proc AS p
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = p.pronamespace
+	JOIN pg_catalog.pg_language AS l ON l.oid = p.prolang
+	LEFT JOIN pg_depend AS d ON d.classid = 'pg_catalog.pg_proc'::regclass::oid AND d.objid = p.oid AND d.deptype = 'e'
+WHERE
+	n.nspname IN (%s)
+	AND p.prokind IN ('f', 'p')
+	AND d.objid IS NULL
+ORDER BY
+	n.nspname, p.proname, p.oid
+`
+	// Query to list the (non-internal) triggers of schema tables and views.
+	triggersQuery = `
+SELECT
+	n.nspname AS schema_name,
+	c.relname AS table_name,
+	t.tgname AS trigger_name,
+	c.relkind AS table_kind,
+	pg_catalog.pg_get_triggerdef(t.oid) AS trigger_def,
+	pn.nspname AS func_schema,
+	p.proname AS func_name,
+	pg_catalog.obj_description(t.oid, 'pg_trigger') AS comment
+FROM
+	pg_catalog.pg_trigger AS t
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.tgrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_proc AS p ON p.oid = t.tgfoid
+	JOIN pg_catalog.pg_namespace AS pn ON pn.oid = p.pronamespace
+WHERE
+	n.nspname IN (%s)
+	AND NOT t.tgisinternal
+ORDER BY
+	n.nspname, c.relname, t.tgname
+`
+	// Query to list the standalone sequences. Sequences that are
+	// owned by identity columns or created by extensions are ignored.
+	sequencesQuery = `
+SELECT
+	s.schemaname AS schema_name,
+	s.sequencename AS sequence_name,
+	s.data_type::text AS data_type,
+	s.start_value,
+	s.min_value,
+	s.max_value,
+	s.increment_by,
+	s.cycle,
+	s.cache_size,
+	s.last_value,
+	t.relname AS owner_table,
+	a.attname AS owner_column,
+	pg_catalog.obj_description(c.oid, 'pg_class') AS comment
+FROM
+	pg_catalog.pg_sequences AS s
+	JOIN pg_catalog.pg_namespace AS n ON n.nspname = s.schemaname
+	JOIN pg_catalog.pg_class AS c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+	LEFT JOIN pg_catalog.pg_depend AS d ON d.classid = 'pg_catalog.pg_class'::regclass::oid AND d.objid = c.oid AND d.refclassid = 'pg_catalog.pg_class'::regclass::oid AND d.deptype IN ('a', 'i')
+	LEFT JOIN pg_catalog.pg_class AS t ON t.oid = d.refobjid
+	LEFT JOIN pg_catalog.pg_attribute AS a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+	LEFT JOIN pg_depend AS e ON e.classid = 'pg_catalog.pg_class'::regclass::oid AND e.objid = c.oid AND e.deptype = 'e'
+WHERE
+	s.schemaname IN (%s)
+	AND COALESCE(d.deptype, 'a') <> 'i'
+	AND e.objid IS NULL
+ORDER BY
+	s.schemaname, s.sequencename
+`
+	// Query to list the arguments of schema functions and procedures.
+	funcArgsQuery = `
+SELECT
+	p.oid AS func_id,
+	COALESCE(a.name, '') AS arg_name,
+	COALESCE(a.mode, 'i') AS arg_mode,
+	pg_catalog.format_type(a.type, NULL) AS arg_type
+FROM
+	pg_catalog.pg_proc AS p
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = p.pronamespace,
+	unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[]), p.proargnames, p.proargmodes) WITH ORDINALITY AS a(type, name, mode, ord)
+WHERE
+	n.nspname IN (%s)
+	AND p.prokind IN ('f', 'p')
+	AND a.type IS NOT NULL
+ORDER BY
+	p.oid, a.ord
 `
 	// Query to list enum values.
 	enumsQuery = `
@@ -1531,7 +3129,169 @@ WHERE
 ORDER BY
     n.nspname, e.enumtypid, e.enumsortorder
 `
-	// Query to list foreign-keys.
+	// Query to list the domain types and their checks. Domains
+	// that were created by extensions are skipped.
+	domainsQuery = `
+SELECT
+	n.nspname AS schema_name,
+	t.typname AS domain_name,
+	format_type(t.typbasetype, t.typtypmod) AS base_type,
+	t.typnotnull AS not_null,
+	t.typdefault AS default_value,
+	obj_description(t.oid, 'pg_type') AS comment,
+	c.conname AS check_name,
+	pg_get_expr(c.conbin, 0) AS check_expr
+FROM
+	pg_type t
+	JOIN pg_namespace n ON n.oid = t.typnamespace
+	LEFT JOIN pg_constraint c ON c.contypid = t.oid AND c.contype = 'c'
+WHERE
+	t.typtype = 'd'
+	AND n.nspname IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	n.nspname, t.typname, c.conname
+`
+	// Query to list the composite types and their fields. Composite
+	// types that were created by extensions are skipped.
+	compositesQuery = `
+SELECT
+	n.nspname AS schema_name,
+	t.typname AS type_name,
+	obj_description(t.oid, 'pg_type') AS comment,
+	a.attname AS field_name,
+	format_type(a.atttypid, a.atttypmod) AS field_type
+FROM
+	pg_type t
+	JOIN pg_namespace n ON n.oid = t.typnamespace
+	JOIN pg_class c ON c.oid = t.typrelid AND c.relkind = 'c'
+	LEFT JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+WHERE
+	t.typtype = 'c'
+	AND n.nspname IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.classid = 'pg_type'::regclass AND d.objid = t.oid AND d.deptype = 'e')
+ORDER BY
+	n.nspname, t.typname, a.attnum
+`
+	// Query to list the installed extensions.
+	extensionsQuery = `
+SELECT
+	e.extname AS name,
+	n.nspname AS schema_name,
+	e.extversion AS version,
+	obj_description(e.oid, 'pg_extension') AS comment
+FROM
+	pg_extension e
+	JOIN pg_namespace n ON n.oid = e.extnamespace
+WHERE
+	e.extname <> 'plpgsql'
+ORDER BY
+	e.extname
+`
+	// Query to list the row-level security policies of tables.
+	policiesQuery = `
+SELECT
+	n.nspname AS schema_name,
+	c.relname AS table_name,
+	p.polname AS policy_name,
+	p.polpermissive AS permissive,
+	p.polcmd AS command,
+	array_to_string(ARRAY(SELECT CASE WHEN r = 0 THEN 'PUBLIC' ELSE pg_catalog.pg_get_userbyid(r) END FROM unnest(p.polroles) AS r ORDER BY 1), ',') AS roles,
+	pg_catalog.pg_get_expr(p.polqual, p.polrelid) AS using_expr,
+	pg_catalog.pg_get_expr(p.polwithcheck, p.polrelid) AS check_expr,
+	pg_catalog.obj_description(p.oid, 'pg_policy') AS comment
+FROM
+	pg_catalog.pg_policy AS p
+	JOIN pg_catalog.pg_class AS c ON c.oid = p.polrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace
+WHERE
+	n.nspname IN (%s)
+ORDER BY
+	n.nspname, c.relname, p.polname
+`
+	// Query to list the roles and the users. Builtin roles and
+	// the bootstrap superuser that owns the cluster are ignored.
+	rolesQuery = `
+SELECT
+	r.rolname,
+	r.rolcanlogin,
+	r.rolsuper,
+	r.rolcreatedb,
+	r.rolcreaterole,
+	r.rolreplication,
+	r.rolbypassrls,
+	r.rolinherit,
+	r.rolconnlimit
+FROM
+	pg_catalog.pg_roles AS r
+WHERE
+	r.rolname !~ '^pg_' AND r.oid <> 10
+ORDER BY
+	r.rolname
+`
+	// Query to list the role memberships.
+	roleMembersQuery = `
+SELECT
+	m.rolname AS member,
+	r.rolname AS role
+FROM
+	pg_catalog.pg_auth_members AS a
+	JOIN pg_catalog.pg_roles AS r ON r.oid = a.roleid
+	JOIN pg_catalog.pg_roles AS m ON m.oid = a.member
+WHERE
+	r.rolname !~ '^pg_' AND m.rolname !~ '^pg_'
+ORDER BY
+	m.rolname, r.rolname
+`
+	// Query to list the privileges granted on schemas, tables and columns. Privileges
+	// granted to PUBLIC and the implicit privileges of the owners are ignored.
+	privilegesQuery = `
+SELECT
+	n.nspname AS schema_name,
+	NULL AS table_name,
+	NULL AS column_name,
+	pg_catalog.pg_get_userbyid(a.grantee) AS grantee,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_namespace AS n,
+	LATERAL aclexplode(n.nspacl) AS a
+WHERE
+	n.nspname IN (%s) AND a.grantee <> 0 AND a.grantee <> n.nspowner
+UNION ALL
+SELECT
+	n.nspname AS schema_name,
+	c.relname AS table_name,
+	NULL AS column_name,
+	pg_catalog.pg_get_userbyid(a.grantee) AS grantee,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_class AS c
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace,
+	LATERAL aclexplode(c.relacl) AS a
+WHERE
+	n.nspname IN (%s) AND c.relkind IN ('r', 'p') AND a.grantee <> 0 AND a.grantee <> c.relowner
+UNION ALL
+SELECT
+	n.nspname AS schema_name,
+	c.relname AS table_name,
+	t.attname AS column_name,
+	pg_catalog.pg_get_userbyid(a.grantee) AS grantee,
+	a.privilege_type,
+	a.is_grantable
+FROM
+	pg_catalog.pg_attribute AS t
+	JOIN pg_catalog.pg_class AS c ON c.oid = t.attrelid
+	JOIN pg_catalog.pg_namespace AS n ON n.oid = c.relnamespace,
+	LATERAL aclexplode(t.attacl) AS a
+WHERE
+	n.nspname IN (%s) AND c.relkind IN ('r', 'p') AND t.attnum > 0 AND NOT t.attisdropped AND a.grantee <> 0
+ORDER BY
+	1, 2, 3, 4, 6, 5
+`
+	// Query to list foreign-keys. Foreign keys that were cloned
+	// by partitions from their parent tables are skipped.
 	fksQuery = `
 SELECT 
     fk.constraint_name,
@@ -1566,6 +3326,11 @@ SELECT
 	    	WHERE ns1.nspname = $1
 	    	AND t1.relname IN (%s)
 	    	AND con.contype = 'f'
+	    	AND NOT (t1.relispartition AND EXISTS (
+	    		SELECT 1 FROM pg_constraint pc
+	    		JOIN pg_inherits ih ON ih.inhparent = pc.conrelid
+	    		WHERE ih.inhrelid = con.conrelid AND pc.contype = 'f' AND pc.conname = con.conname
+	    	))
 	) AS fk
 	JOIN pg_attribute a1 ON a1.attnum = fk.conkey AND a1.attrelid = fk.conrelid
 	JOIN pg_attribute a2 ON a2.attnum = fk.confkey AND a2.attrelid = fk.confrelid
@@ -1573,7 +3338,8 @@ SELECT
 	    fk.conrelid, fk.constraint_name, fk.ord
 `
 
-	// Query to list table check constraints.
+	// Query to list table check constraints. Constraints that were
+	// inherited by partitions from their parent tables are skipped.
 	checksQuery = `
 SELECT
 	rel.relname AS table_name,
@@ -1595,12 +3361,14 @@ WHERE
 	t1.contype = 'c'
 	AND nsp.nspname = $1
 	AND rel.relname IN (%s)
+	AND (t1.conislocal OR NOT rel.relispartition)
 ORDER BY
 	t1.conname, array_position(t1.conkey, t2.attnum)
 `
 )
 
 var (
+	// Indexes of partitions that were attached to the index of their parent table are skipped.
 	indexesBelow11   = fmt.Sprintf(indexesQueryTmpl, "false", "false", "%s")
 	indexesAbove11   = fmt.Sprintf(indexesQueryTmpl, "(a.attname <> '' AND idx.indnatts > idx.indnkeyatts AND idx.ord > idx.indnkeyatts)", "false", "%s")
 	indexesAbove15   = fmt.Sprintf(indexesQueryTmpl, "(a.attname <> '' AND idx.indnatts > idx.indnkeyatts AND idx.ord > idx.indnkeyatts)", "idx.indnullsnotdistinct", "%s")
@@ -1650,6 +3418,7 @@ FROM
 WHERE
 	n.nspname = $1
 	AND t.relname IN (%s)
+	AND NOT EXISTS (SELECT 1 FROM pg_inherits WHERE inhrelid = idx.indexrelid)
 ORDER BY
 	table_name, index_name, idx.ord
 `
@@ -7,6 +7,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"ariga.io/atlas/sql/internal/sqltest"
@@ -48,6 +49,7 @@ func TestDriver_InspectTable(t *testing.T) {
  public      |   16774 |  state  | off
  public      |   16775 |  status | unknown
 `))
+				m.noTypes()
 				m.tableExists("public", "users", true)
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
@@ -164,6 +166,7 @@ func TestDriver_InspectTable(t *testing.T) {
 			name: "table indexes",
 			before: func(m mock) {
 				m.noEnums()
+				m.noTypes()
 				m.tableExists("public", "users", true)
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
@@ -239,6 +242,7 @@ users           | tsx             | gist        | ts          | f        | f
 			name: "fks",
 			before: func(m mock) {
 				m.noEnums()
+				m.noTypes()
 				m.tableExists("public", "users", true)
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
@@ -287,6 +291,7 @@ self_reference  | users      | uid         | public       | users
 			name: "check",
 			before: func(m mock) {
 				m.noEnums()
+				m.noTypes()
 				m.tableExists("public", "users", true)
 				m.ExpectQuery(queryColumns).
 					WithArgs("public", "users").
@@ -375,6 +380,7 @@ func TestDriver_InspectPartitionedTable(t *testing.T) {
  public      | nil
 `))
 	mk.noEnums()
+	mk.noTypes()
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(tablesQuery, "$1"))).
 		WithArgs("public").
 		WillReturnRows(sqltest.Rows(`
@@ -385,8 +391,17 @@ func TestDriver_InspectPartitionedTable(t *testing.T) {
  114  | public       | logs3       |         | 2 0 0           | l                   | (a + b), (a + (b * 2))                             |                              
 
 `))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3, $4"))).
-		WithArgs("public", "logs1", "logs2", "logs3").
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(partitionsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ oid | table_schema | table_name    | parent_schema | parent_name | partition_bound                | comment | partition_attrs | partition_strategy | partition_exprs
+-----+--------------+---------------+---------------+-------------+--------------------------------+---------+-----------------+--------------------+-----------------
+ 116 | public       | logs2_default | public        | logs2       | DEFAULT                        |         |                 |                    |
+ 115 | public       | logs2_a       | public        | logs2       | FOR VALUES FROM (1) TO (10)    | part    |                 |                    |
+ 117 | public       | other         | public        | skipped     | FOR VALUES IN (1)              |         |                 |                    |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, "$2, $3, $4, $5, $6"))).
+		WithArgs("public", "logs1", "logs2", "logs3", "logs2_default", "logs2_a").
 		WillReturnRows(sqltest.Rows(`
 table_name |column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment |   identity_last  | identity_generation | generation_expression | comment | typtype | typelem |  oid |  attnum 
 -----------+------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+------------------+---------------------+-----------------------+---------+---------+---------+------+--------
@@ -395,12 +410,14 @@ logs2      | c2         | integer   | integer   | NO          |                |
 logs2      | c3         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |   23 |  
 logs3      | c4         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |   23 |  
 logs3      | c5         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |   23 |  
+logs2_a    | c2         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |   23 |  
+logs2_a    | c3         | integer   | integer   | NO          |                |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |                  |                     |                       |         | b       |         |   23 |  
 `))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesAbove15, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesAbove15, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "index_name", "column_name", "primary", "unique", "constraint_type", "predicate", "expression", "options", "indnullsnotdistinct"}))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(fksQuery, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"constraint_name", "table_name", "column_name", "referenced_table_name", "referenced_column_name", "referenced_table_schema", "update_rule", "delete_rule"}))
-	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3, $4"))).
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(checksQuery, "$2, $3, $4, $5, $6"))).
 		WillReturnRows(sqlmock.NewRows([]string{"table_name", "constraint_name", "expression", "column_name", "column_indexes"}))
 	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
 		Mode: schema.InspectSchemas | schema.InspectTables | schema.InspectTypes,
@@ -430,6 +447,19 @@ logs3      | c5         | integer   | integer   | NO          |                |
 		{X: &schema.RawExpr{X: "(a + b)"}},
 		{X: &schema.RawExpr{X: "(a + (b * 2))"}},
 	}, key.Parts)
+
+	// Partitions are linked to their parent tables.
+	require.Len(t, s.Tables, 5)
+	_, ok = s.Table("other")
+	require.False(t, ok, "parent table was not inspected")
+	p1, ok := s.Table("logs2_default")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&OID{V: 116}, &PartitionOf{T: t2, Default: true}}, p1.Attrs)
+	require.Equal(t, []schema.Object{t2}, p1.Deps)
+	p2, ok := s.Table("logs2_a")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&OID{V: 115}, &schema.Comment{Text: "part"}, &PartitionOf{T: t2, Values: "FROM (1) TO (10)"}}, p2.Attrs)
+	require.Len(t, p2.Columns, 2)
 }
 
 func TestDriver_InspectCRDBSchema(t *testing.T) {
@@ -535,6 +565,492 @@ func TestDriver_InspectSchema(t *testing.T) {
 	}(), s)
 }
 
+func TestDriver_InspectFuncs(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("130000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= CURRENT_SCHEMA()"))).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ test        | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(funcsQuery, "$1"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
+ func_id | schema_name | func_name | func_kind | func_lang | func_volatility | func_return          | func_defaults   | func_body                  | comment
+---------+-------------+-----------+-----------+-----------+-----------------+----------------------+-----------------+----------------------------+---------
+ 10      | test        | add       | f         | sql       | i               | integer              | 1, '2'::integer | SELECT a + b + c           | sum
+ 11      | test        | items     | f         | sql       | s               | SETOF items          | nil             | SELECT * FROM items        | nil
+ 12      | test        | reset     | p         | plpgsql   | v               | nil                  | nil             | BEGIN n := 0; END          | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(funcArgsQuery, "$1"))).
+		WithArgs("test").
+		WillReturnRows(sqltest.Rows(`
+ func_id | arg_name | arg_mode | arg_type
+---------+----------+----------+----------
+ 10      | a        | i        | integer
+ 10      | b        | i        | integer
+ 10      | c        | i        | integer
+ 12      | n        | b        | integer
+`))
+	s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectFuncs,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 3)
+	add, ok := s.Func("add")
+	require.True(t, ok)
+	require.Equal(t, "sql", add.Lang)
+	require.Equal(t, "SELECT a + b + c", add.Body)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, add.Ret)
+	require.Equal(t, []schema.Attr{&schema.FuncVolatility{V: VolatilityImmutable}, &schema.Comment{Text: "sum"}}, add.Attrs)
+	require.Len(t, add.Args, 3)
+	require.Nil(t, add.Args[0].Default)
+	require.Equal(t, &schema.RawExpr{X: "1"}, add.Args[1].Default)
+	require.Equal(t, &schema.RawExpr{X: "'2'::integer"}, add.Args[2].Default)
+	items, ok := s.Func("items")
+	require.True(t, ok)
+	require.Equal(t, &schema.UnsupportedType{T: "SETOF items"}, items.Ret)
+	require.Empty(t, items.Args)
+	reset, ok := s.Proc("reset")
+	require.True(t, ok)
+	require.Equal(t, "plpgsql", reset.Lang)
+	require.Len(t, reset.Args, 1)
+	require.Equal(t, schema.FuncArgModeInOut, reset.Args[0].Mode)
+}
+
+func TestDriver_InspectTriggers(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	mk.tableExists("public", "users", true)
+	mk.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+ table_name | column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment | identity_last | identity_generation | generation_expression | comment | typtype | typelem | oid | attnum
+------------+-------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+---------------+---------------------+-----------------------+---------+---------+---------+-----+--------
+ users      | id          | bigint    | int8      | NO          |                |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  20 |
+ users      | name        | text      | text      | NO          |                |                          |                   |                    |               |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  25 |
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(triggersQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | table_name | trigger_name | table_kind | trigger_def                                                                                                                           | func_schema | func_name | comment
+-------------+------------+--------------+------------+---------------------------------------------------------------------------------------------------------------------------------------+-------------+-----------+---------
+ public      | users      | t1           | r          | CREATE TRIGGER t1 BEFORE INSERT OR UPDATE OF name ON public.users FOR EACH ROW WHEN ((new.id > 0)) EXECUTE FUNCTION public.audit()   | public      | audit     | audit
+ public      | users      | t2           | r          | CREATE TRIGGER t2 AFTER DELETE ON public.users FOR EACH STATEMENT EXECUTE FUNCTION public.audit('deleted')                            | public      | audit     | nil
+ public      | other      | t3           | r          | CREATE TRIGGER t3 AFTER DELETE ON public.other FOR EACH STATEMENT EXECUTE FUNCTION public.audit()                                      | public      | audit     | nil
+`))
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTables | schema.InspectTriggers,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, users.Triggers, 2)
+	t1 := users.Triggers[0]
+	require.Equal(t, "t1", t1.Name)
+	require.Equal(t, users, t1.Table)
+	require.Equal(t, schema.TriggerTimeBefore, t1.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert, schema.TriggerEventUpdateOf(users.Columns[1])}, t1.Events)
+	require.Equal(t, schema.TriggerForRow, t1.For)
+	require.Equal(t, "EXECUTE FUNCTION public.audit()", t1.Body)
+	require.Equal(t, []schema.Attr{&schema.TriggerWhen{X: "(new.id > 0)"}, &schema.Comment{Text: "audit"}}, t1.Attrs)
+	t2 := users.Triggers[1]
+	require.Equal(t, "t2", t2.Name)
+	require.Equal(t, schema.TriggerTimeAfter, t2.ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventDelete}, t2.Events)
+	require.Equal(t, schema.TriggerForStmt, t2.For)
+	require.Equal(t, "EXECUTE FUNCTION public.audit('deleted')", t2.Body)
+	require.Empty(t, t2.Attrs)
+}
+
+func TestDriver_InspectSequences(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	mk.tableExists("public", "users", true)
+	mk.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqltest.Rows(`
+ table_name | column_name | data_type | formatted | is_nullable | column_default                            | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment | identity_last | identity_generation | generation_expression | comment | typtype | typelem | oid | attnum
+------------+-------------+-----------+-----------+-------------+-------------------------------------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+---------------+---------------------+-----------------------+---------+---------+---------+-----+--------
+ users      | id          | bigint    | int8      | NO          | nextval('public.users_id_seq'::regclass)  |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  20 |
+ users      | counter     | integer   | int4      | NO          | nextval('public.counter'::regclass)       |                          |                32 |                    |             0 |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  23 |
+`))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(sequencesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | sequence_name | data_type | start_value | min_value | max_value           | increment_by | cycle | cache_size | last_value | owner_table | owner_column | comment
+-------------+---------------+-----------+-------------+-----------+---------------------+--------------+-------+------------+------------+-------------+--------------+------------
+ public      | counter       | integer   | 10          | 1         | 2147483647          | 1            | false | 1          | 12         | users       | counter      | nil
+ public      | down          | bigint    | -1          | -100      | -1                  | -1           | true  | 5          | nil        | nil         | nil          | descending
+ public      | users_id_seq  | bigint    | 1           | 1         | 9223372036854775807 | 1            | false | 1          | nil        | users       | id           | nil
+`))
+	mk.noPolicies()
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTables | schema.InspectObjects,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, s.Objects, 2)
+	counter := s.Objects[0].(*Sequence)
+	require.Equal(t, "counter", counter.Name)
+	require.Equal(t, s, counter.Schema)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, counter.Type)
+	require.EqualValues(t, 10, counter.Start)
+	require.EqualValues(t, 1, counter.Increment)
+	require.EqualValues(t, 12, counter.Last)
+	require.Nil(t, counter.Min)
+	require.Nil(t, counter.Max)
+	require.Equal(t, users, counter.Owner.T)
+	require.Equal(t, users.Columns[1], counter.Owner.C)
+	require.Equal(t, &schema.RawExpr{X: "nextval('public.counter'::regclass)"}, users.Columns[1].Default)
+	down := s.Objects[1].(*Sequence)
+	require.Equal(t, "down", down.Name)
+	require.EqualValues(t, -1, down.Increment)
+	require.Equal(t, int64(-100), *down.Min)
+	require.Nil(t, down.Max)
+	require.True(t, down.Cycle)
+	require.EqualValues(t, 5, down.Cache)
+	require.Nil(t, down.Owner.T)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "descending"}}, down.Attrs)
+}
+
+func TestDriver_InspectPolicies(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	m.ExpectQuery(queryTables).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ oid | table_schema | table_name | comment | partition_attrs | partition_strategy | partition_exprs | extra
+-----+--------------+------------+---------+-----------------+--------------------+-----------------+---------------------------------------------------
+ nil | public       | users      | nil     | nil             | nil                | nil             | {"row_security":{"enabled":true,"enforced":true}}
+`))
+	m.ExpectQuery(queryColumns).
+		WithArgs("public", "users").
+		WillReturnRows(sqlmock.NewRows([]string{"table_name", "column_name", "data_type", "formatted", "is_nullable", "column_default", "character_maximum_length", "numeric_precision", "datetime_precision", "numeric_scale", "interval_type", "character_set_name", "collation_name", "is_identity", "identity_start", "identity_increment", "identity_last", "identity_generation", "generation_expression", "comment", "typtype", "typelem", "oid", "attnum"}))
+	mk.noIndexes()
+	mk.noFKs()
+	mk.noChecks()
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(sequencesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "sequence_name", "data_type", "start_value", "min_value", "max_value", "increment_by", "cycle", "cache_size", "last_value", "owner_table", "owner_column", "comment"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(policiesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | table_name | policy_name | permissive | command | roles     | using_expr             | check_expr | comment
+-------------+------------+-------------+------------+---------+-----------+------------------------+------------+---------
+ public      | posts      | own         | true       | *       | PUBLIC    | (owner = CURRENT_USER) | nil        | nil
+ public      | users      | read        | false      | r       | admin,app | (id > 0)               | nil        | readers
+ public      | users      | write       | true       | a       | app       | nil                    | (id > 0)   | nil
+`))
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTables | schema.InspectObjects,
+	})
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&RowSecurity{Enabled: true, Enforced: true}}, users.Attrs)
+	// Policies of tables that were not inspected are skipped.
+	require.Equal(t, []schema.Object{
+		&Policy{Name: "read", Table: users, As: PolicyAsRestrictive, For: PolicyForSelect, To: []string{"admin", "app"}, Using: "(id > 0)", Attrs: []schema.Attr{&schema.Comment{Text: "readers"}}},
+		&Policy{Name: "write", Table: users, As: PolicyAsPermissive, For: PolicyForInsert, To: []string{"app"}, Check: "(id > 0)"},
+	}, s.Objects)
+}
+
+func TestDriver_InspectRoles(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape("SELECT current_setting('search_path'), set_config('search_path', '', false)")).
+		WillReturnRows(sqltest.Rows(`
+ current_setting | set_config
+-----------------+------------
+                 |
+`))
+	mk.ExpectQuery(sqltest.Escape(schemasQuery)).
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	m.ExpectQuery(sqltest.Escape(rolesQuery)).
+		WillReturnRows(sqltest.Rows(`
+ rolname | rolcanlogin | rolsuper | rolcreatedb | rolcreaterole | rolreplication | rolbypassrls | rolinherit | rolconnlimit
+---------+-------------+----------+-------------+---------------+----------------+--------------+------------+--------------
+ admin   | false       | true     | true        | false         | false          | false        | true       | -1
+ app     | true        | false    | false       | false         | false          | false        | false      | 10
+ reader  | false       | false    | false       | false         | false          | false        | true       | -1
+`))
+	m.ExpectQuery(sqltest.Escape(roleMembersQuery)).
+		WillReturnRows(sqltest.Rows(`
+ member | role
+--------+--------
+ admin  | reader
+ app    | reader
+ reader | app
+`))
+	m.ExpectQuery(sqltest.Escape(strings.ReplaceAll(privilegesQuery, "%s", "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | table_name | column_name | grantee | privilege_type | is_grantable
+-------------+------------+-------------+---------+----------------+--------------
+ public      | nil        | nil         | admin   | CREATE         | false
+ public      | nil        | nil         | admin   | USAGE          | false
+ public      | nil        | nil         | reader  | USAGE          | true
+ public      | users      | nil         | app     | SELECT         | false
+`))
+	r, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
+		Mode: schema.InspectSchemas | schema.InspectRoles,
+	})
+	require.NoError(t, err)
+	admin, ok := r.Role("admin")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&RoleOptions{Superuser: true, CreateDB: true}}, admin.Attrs)
+	reader, ok := r.Role("reader")
+	require.True(t, ok)
+	require.Empty(t, reader.Attrs)
+	app, ok := r.User("app", "")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&RoleOptions{NoInherit: true}, &ConnLimit{V: 10}}, app.Attrs)
+	// Memberships in roles that can log in are skipped.
+	require.Equal(t, []*schema.Role{reader}, admin.MemberOf)
+	require.Equal(t, []*schema.Role{reader}, app.MemberOf)
+	require.Empty(t, reader.MemberOf)
+	// Privileges on tables that were not inspected are skipped.
+	require.Equal(t, []schema.Object{
+		admin, app, reader,
+		&schema.Permission{To: admin, Schema: r.Schemas[0], Privileges: []string{PrivilegeAll}},
+		&schema.Permission{To: reader, Schema: r.Schemas[0], Privileges: []string{PrivilegeUsage}, Grantable: true},
+	}, r.Objects)
+}
+
+func TestDriver_InspectTypes(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	m.ExpectQuery(queryEnums).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | enum_id | type    | enum_value
+-------------+---------+---------+------------
+ public      |   16774 |  status | on
+ public      |   16774 |  status | off
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(domainsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | domain_name | base_type | not_null | default_value | comment | check_name | check_expr
+-------------+-------------+-----------+----------+---------------+---------+------------+---------------------
+ public      | code        | text      | true     | 'x'::text     | codes   | len        | (length(VALUE) > 0)
+ public      | code        | text      | true     | 'x'::text     | codes   | short      | (length(VALUE) < 9)
+ public      | state       | status    | false    | nil           | nil     | nil        | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(compositesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | type_name | comment | field_name | field_type
+-------------+-----------+---------+------------+------------
+ public      | empty     | nil     | nil        | nil
+ public      | pair      | nil     | c          | code
+ public      | pair      | nil     | n          | integer
+`))
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectTypes,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 5)
+	st := s.Objects[0].(*schema.EnumType)
+	code := s.Objects[1].(*DomainType)
+	require.Equal(t, "code", code.T)
+	require.Equal(t, s, code.Schema)
+	require.Equal(t, &schema.StringType{T: TypeText}, code.Type)
+	require.False(t, code.Null)
+	require.Equal(t, &schema.Literal{V: "'x'"}, code.Default)
+	require.Equal(t, []*schema.Check{{Name: "len", Expr: "(length(VALUE) > 0)"}, {Name: "short", Expr: "(length(VALUE) < 9)"}}, code.Checks)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "codes"}}, code.Attrs)
+	state := s.Objects[2].(*DomainType)
+	require.Equal(t, "state", state.T)
+	require.Equal(t, st, state.Type)
+	require.True(t, state.Null)
+	require.Nil(t, state.Default)
+	require.Empty(t, state.Checks)
+	empty := s.Objects[3].(*CompositeType)
+	require.Equal(t, "empty", empty.T)
+	require.Empty(t, empty.Fields)
+	pair := s.Objects[4].(*CompositeType)
+	require.Equal(t, "pair", pair.T)
+	require.Len(t, pair.Fields, 2)
+	require.Equal(t, "c", pair.Fields[0].Name)
+	require.Equal(t, code, pair.Fields[0].Type.Type)
+	require.Equal(t, "n", pair.Fields[1].Name)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, pair.Fields[1].Type.Type)
+}
+
+func TestDriver_InspectExtensions(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape("SELECT current_setting('search_path'), set_config('search_path', '', false)")).
+		WillReturnRows(sqltest.Rows(`
+ current_setting | set_config
+-----------------+------------
+                 |
+`))
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(sequencesQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "sequence_name", "data_type", "start_value", "min_value", "max_value", "increment_by", "cycle", "cache_size", "last_value", "owner_table", "owner_column", "comment"}))
+	m.ExpectQuery(sqltest.Escape(extensionsQuery)).
+		WillReturnRows(sqltest.Rows(`
+ name     | schema_name | version | comment
+----------+-------------+---------+------------------
+ citext   | public      | 1.6     | case-insensitive
+ pgcrypto | extensions  | 1.3     | nil
+`))
+	r, err := drv.InspectRealm(context.Background(), &schema.InspectRealmOption{
+		Schemas: []string{"public"},
+		Mode:    schema.InspectSchemas | schema.InspectObjects,
+	})
+	require.NoError(t, err)
+	// Extensions installed in schemas that were not inspected are skipped.
+	require.Equal(t, []schema.Object{
+		&Extension{T: "citext", Schema: r.Schemas[0], Version: "1.6", Attrs: []schema.Attr{&schema.Comment{Text: "case-insensitive"}}},
+	}, r.Objects)
+}
+
+func TestDriver_InspectMaterializedViews(t *testing.T) {
+	db, m, err := sqlmock.New()
+	require.NoError(t, err)
+	mk := mock{m}
+	mk.version("150000")
+	drv, err := Open(db)
+	require.NoError(t, err)
+	mk.ExpectQuery(sqltest.Escape(fmt.Sprintf(schemasQueryArgs, "= $1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | comment
+-------------+---------
+ public      | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqlmock.NewRows([]string{"table_schema", "table_name", "view_definition", "check_option", "comment"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(matViewsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ schema_name | view_name | definition          | ispopulated | tablespace | comment
+-------------+-----------+---------------------+-------------+------------+---------
+ public      | mv1       | SELECT id FROM t    | true        | nil        | cached
+ public      | mv2       | SELECT id FROM mv1  | false       | fast       | nil
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(matViewColumnsQuery, "$2, $3"))).
+		WithArgs("public", "mv1", "mv2").
+		WillReturnRows(sqltest.Rows(`
+ table_name | column_name | data_type | formatted | is_nullable | column_default | character_maximum_length | numeric_precision | datetime_precision | numeric_scale | interval_type | character_set_name | collation_name | is_identity | identity_start | identity_increment | identity_last | identity_generation | generation_expression | comment | typtype | typelem | oid | attnum
+------------+-------------+-----------+-----------+-------------+----------------+--------------------------+-------------------+--------------------+---------------+---------------+--------------------+----------------+-------------+----------------+--------------------+---------------+---------------------+-----------------------+---------+---------+---------+-----+--------
+ mv1        | id          | bigint    | int8      | YES         |                |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  20 |
+ mv2        | id          | bigint    | int8      | YES         |                |                          |                64 |                    |             0 |               |                    |                | NO          |                |                    |               |                     |                       |         | b       |         |  20 |
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(indexesAbove15, "$2, $3"))).
+		WithArgs("public", "mv1", "mv2").
+		WillReturnRows(sqltest.Rows(`
+ table_name | index_name | index_type | column_name | included | primary | unique | opexpr | constraints | predicate | expression | desc | nulls_first | nulls_last | comment | options | opclass_name | opclass_schema | opclass_default | opclass_params | indnullsnotdistinct
+------------+------------+------------+-------------+----------+---------+--------+--------+-------------+-----------+------------+------+-------------+------------+---------+---------+--------------+----------------+-----------------+----------------+---------------------
+ mv1        | mv1_id     | btree      | id          | f        | f       | t      |        |             |           | id         | f    | f           | f          |         |         | int8_ops     | pg_catalog     | t               |                | f
+`))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(viewDepsQuery, "$1"))).
+		WithArgs("public").
+		WillReturnRows(sqltest.Rows(`
+ view_schema | view_name | dep_schema | dep_name | dep_kind
+-------------+-----------+------------+----------+----------
+ public      | mv2       | public     | mv1      | m
+`))
+	s, err := drv.InspectSchema(context.Background(), "public", &schema.InspectOptions{
+		Mode: schema.InspectSchemas | schema.InspectViews,
+	})
+	require.NoError(t, err)
+	require.Len(t, s.Views, 2)
+	mv1, mv2 := s.Views[0], s.Views[1]
+	require.True(t, mv1.Materialized())
+	require.Equal(t, "SELECT id FROM t", mv1.Def)
+	require.Equal(t, []schema.Attr{&schema.Materialized{}, &schema.Comment{Text: "cached"}}, mv1.Attrs)
+	require.Len(t, mv1.Columns, 1)
+	require.Len(t, mv1.Indexes, 1)
+	require.Equal(t, "mv1_id", mv1.Indexes[0].Name)
+	require.True(t, mv1.Indexes[0].Unique)
+	require.Equal(t, mv1, mv1.Indexes[0].View)
+	require.Equal(t, mv1.Columns[0], mv1.Indexes[0].Parts[0].C)
+	require.Equal(t, []schema.Attr{&schema.Materialized{NoData: true}, &Tablespace{N: "fast"}}, mv2.Attrs)
+	require.Empty(t, mv2.Indexes)
+	require.Equal(t, []schema.Object{mv1}, mv2.Deps)
+}
+
 func TestDriver_Realm(t *testing.T) {
 	db, m, err := sqlmock.New()
 	require.NoError(t, err)
@@ -752,3 +1268,15 @@ func (m mock) noEnums() {
 	m.ExpectQuery(queryEnums).
 		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "enum_name", "comment", "enum_type", "enum_value"}))
 }
+
+func (m mock) noPolicies() {
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(policiesQuery, "$1"))).
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "table_name", "policy_name", "permissive", "command", "roles", "using_expr", "check_expr", "comment"}))
+}
+
+func (m mock) noTypes() {
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(domainsQuery, "$1"))).
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "domain_name", "base_type", "not_null", "default_value", "comment", "check_name", "check_expr"}))
+	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(compositesQuery, "$1"))).
+		WillReturnRows(sqlmock.NewRows([]string{"schema_name", "type_name", "comment", "field_name", "field_type"}))
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -60,6 +61,10 @@ type state struct {
 	*conn
 	migrate.Plan
 	migrate.PlanOptions
+	// Views that are recreated as dependents of
+	// modified materialized views, keyed by their
+	// qualified names.
+	recreated map[string]bool
 }
 
 // Exec executes the changes on the database. An error is returned
@@ -74,13 +79,20 @@ func (s *state) plan(changes []schema.Change) error {
 	if err != nil {
 		return err
 	}
+	planned = detachOwners(planned)
 	if s.PlanOptions.Mode != migrate.PlanModeUnsortedDump {
 		if planned, err = s.detachCycles(planned); err != nil {
 			return err
 		}
 		planned = s.sortChanges(planned)
 	}
+	s.recreated = recreatedViews(planned)
 	for _, c := range planned {
+		// Changes of views that are recreated along
+		// with the views they depend on, are skipped.
+		if s.onRecreated(c) {
+			continue
+		}
 		switch c := c.(type) {
 		case *schema.AddTable:
 			err = s.addTable(c)
@@ -90,6 +102,22 @@ func (s *state) plan(changes []schema.Change) error {
 			s.renameTable(c)
 		case *schema.DropTable:
 			err = s.dropTable(c)
+		case *schema.AddView:
+			err = s.addView(c)
+		case *schema.DropView:
+			err = s.dropView(c)
+		case *schema.ModifyView:
+			err = s.modifyView(c)
+		case *schema.RenameView:
+			s.renameView(c)
+		case *schema.AddTrigger:
+			err = s.addTrigger(c)
+		case *schema.DropTrigger:
+			err = s.dropTrigger(c)
+		case *schema.ModifyTrigger:
+			err = s.modifyTrigger(c)
+		case *schema.RenameTrigger:
+			s.renameTrigger(c)
 		case *schema.AddObject:
 			err = s.addObject(c)
 		case *schema.ModifyObject:
@@ -192,39 +220,46 @@ func (s *state) addTable(add *schema.AddTable) error {
 		b.P("IF NOT EXISTS")
 	}
 	b.Table(add.T)
-	b.WrapIndent(func(b *sqlx.Builder) {
-		b.MapIndent(add.T.Columns, func(i int, b *sqlx.Builder) {
-			if err := s.column(b, add.T.Columns[i]); err != nil {
-				errs = append(errs, err.Error())
-			}
-		})
-		if pk := add.T.PrimaryKey; pk != nil {
-			b.Comma().NL().P("PRIMARY KEY")
-			if err := s.index(b, pk); err != nil {
-				errs = append(errs, err.Error())
-			}
+	if p := partitionOf(add.T); p != nil {
+		// Partitions inherit their columns from their parent table.
+		if err := s.partitionOf(b, add.T, p); err != nil {
+			errs = append(errs, err.Error())
 		}
-		for _, idx := range add.T.Indexes {
-			_, okU := uniqueConst(idx.Attrs)
-			_, okE := excludeConst(idx.Attrs)
-			if okU || okE {
-				b.Comma().NL()
-				if err := s.constraint(b, idx); err != nil {
+	} else {
+		b.WrapIndent(func(b *sqlx.Builder) {
+			b.MapIndent(add.T.Columns, func(i int, b *sqlx.Builder) {
+				if err := s.column(b, add.T.Columns[i]); err != nil {
+					errs = append(errs, err.Error())
+				}
+			})
+			if pk := add.T.PrimaryKey; pk != nil {
+				b.Comma().NL().P("PRIMARY KEY")
+				if err := s.index(b, pk); err != nil {
 					errs = append(errs, err.Error())
 				}
 			}
-		}
-		if len(add.T.ForeignKeys) > 0 {
-			b.Comma()
-			s.fks(b, add.T.ForeignKeys...)
-		}
-		for _, attr := range add.T.Attrs {
-			if c, ok := attr.(*schema.Check); ok {
-				b.Comma().NL()
-				check(b, c)
+			for _, idx := range add.T.Indexes {
+				_, okU := uniqueConst(idx.Attrs)
+				_, okE := excludeConst(idx.Attrs)
+				if okU || okE {
+					b.Comma().NL()
+					if err := s.constraint(b, idx); err != nil {
+						errs = append(errs, err.Error())
+					}
+				}
 			}
-		}
-	})
+			if len(add.T.ForeignKeys) > 0 {
+				b.Comma()
+				s.fks(b, add.T.ForeignKeys...)
+			}
+			for _, attr := range add.T.Attrs {
+				if c, ok := attr.(*schema.Check); ok {
+					b.Comma().NL()
+					check(b, c)
+				}
+			}
+		})
+	}
 	if p := (Partition{}); sqlx.Has(add.T.Attrs, &p) {
 		s, err := formatPartition(p)
 		if err != nil {
@@ -302,10 +337,24 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 		addI    []*schema.AddIndex
 		dropI   []*schema.DropIndex
 		changes []*migrate.Change
+		// Partitions are detached before the table is
+		// altered, and attached back after it.
+		detach, attach []*migrate.Change
 	)
 	for _, change := range skipAutoChanges(modify.Changes) {
 		switch change := change.(type) {
 		case *schema.ModifyAttr:
+			if from, ok := change.From.(*PartitionOf); ok {
+				to, ok := change.To.(*PartitionOf)
+				if !ok {
+					return fmt.Errorf("unexpected partition attribute change: %T", change.To)
+				}
+				// Partitions cannot be moved between tables or bounds. Hence,
+				// they are detached from their table and attached back.
+				detach = append(detach, s.detachPartition(modify, modify.T, from))
+				attach = append(attach, s.attachPartition(modify, modify.T, to))
+				continue
+			}
 			if _, ok := change.From.(*schema.Comment); !ok {
 				alter = append(alter, change)
 				continue
@@ -317,6 +366,10 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			// Comments are not part of the ALTER command.
 			changes = append(changes, s.tableComment(modify, modify.T, to, from))
 		case *schema.AddAttr:
+			if p, ok := change.A.(*PartitionOf); ok {
+				attach = append(attach, s.attachPartition(modify, modify.T, p))
+				continue
+			}
 			from, to, err := commentChange(change)
 			if err != nil {
 				return err
@@ -324,7 +377,11 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			// Comments are not part of the ALTER command.
 			changes = append(changes, s.tableComment(modify, modify.T, to, from))
 		case *schema.DropAttr:
-			return fmt.Errorf("unsupported change type: %T", change)
+			p, ok := change.A.(*PartitionOf)
+			if !ok {
+				return fmt.Errorf("unsupported change type: %T", change)
+			}
+			detach = append(detach, s.detachPartition(modify, modify.T, p))
 		case *schema.AddIndex:
 			if c := (schema.Comment{}); sqlx.Has(change.I.Attrs, &c) {
 				changes = append(changes, s.indexComment(modify, modify.T, change.I, c.Text, ""))
@@ -436,6 +493,7 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 			alter = append(alter, change)
 		}
 	}
+	s.append(detach...)
 	if err := s.dropIndexes(modify, modify.T, dropI...); err != nil {
 		return err
 	}
@@ -447,10 +505,96 @@ func (s *state) modifyTable(modify *schema.ModifyTable) error {
 	if err := s.addIndexes(modify, modify.T, addI...); err != nil {
 		return err
 	}
+	s.append(attach...)
 	s.append(changes...)
 	return nil
 }
 
+// partitionOf writes the PARTITION OF clause of the given partition table. Columns are
+// inherited from the parent table, and therefore, only table constraints are defined.
+func (s *state) partitionOf(b *sqlx.Builder, t *schema.Table, p *PartitionOf) error {
+	var (
+		errs  []string
+		elems []func(*sqlx.Builder)
+	)
+	if pk := t.PrimaryKey; pk != nil {
+		elems = append(elems, func(b *sqlx.Builder) {
+			b.NL().P("PRIMARY KEY")
+			if err := s.index(b, pk); err != nil {
+				errs = append(errs, err.Error())
+			}
+		})
+	}
+	for _, idx := range t.Indexes {
+		_, okU := uniqueConst(idx.Attrs)
+		_, okE := excludeConst(idx.Attrs)
+		if okU || okE {
+			elems = append(elems, func(b *sqlx.Builder) {
+				if err := s.constraint(b.NL(), idx); err != nil {
+					errs = append(errs, err.Error())
+				}
+			})
+		}
+	}
+	if len(t.ForeignKeys) > 0 {
+		elems = append(elems, func(b *sqlx.Builder) {
+			s.fks(b, t.ForeignKeys...)
+		})
+	}
+	for _, attr := range t.Attrs {
+		if c, ok := attr.(*schema.Check); ok {
+			elems = append(elems, func(b *sqlx.Builder) {
+				check(b.NL(), c)
+			})
+		}
+	}
+	b.P("PARTITION OF").Table(p.T)
+	if len(elems) > 0 {
+		b.WrapIndent(func(b *sqlx.Builder) {
+			b.MapComma(elems, func(i int, b *sqlx.Builder) {
+				elems[i](b)
+			})
+		})
+	}
+	b.P(p.bound())
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// attachPartition returns the change for attaching the table to its partitioned table.
+func (s *state) attachPartition(src schema.Change, t *schema.Table, p *PartitionOf) *migrate.Change {
+	return &migrate.Change{
+		Source:  src,
+		Comment: fmt.Sprintf("attach %q to partitioned table %q", t.Name, p.T.Name),
+		Cmd:     s.Build("ALTER TABLE").Table(p.T).P("ATTACH PARTITION").Table(t).P(p.bound()).String(),
+		Reverse: s.Build("ALTER TABLE").Table(p.T).P("DETACH PARTITION").Table(t).String(),
+	}
+}
+
+// detachPartition returns the change for detaching the table from its partitioned table.
+func (s *state) detachPartition(src schema.Change, t *schema.Table, p *PartitionOf) *migrate.Change {
+	b := s.Build("ALTER TABLE").Table(p.T).P("DETACH PARTITION").Table(t)
+	if p.Concurrently {
+		b.P("CONCURRENTLY")
+	}
+	return &migrate.Change{
+		Source:  src,
+		Comment: fmt.Sprintf("detach %q from partitioned table %q", t.Name, p.T.Name),
+		Cmd:     b.String(),
+		Reverse: s.Build("ALTER TABLE").Table(p.T).P("ATTACH PARTITION").Table(t).P(p.bound()).String(),
+	}
+}
+
+// bound returns the partition bound specification of the partition.
+func (p *PartitionOf) bound() string {
+	if p.Default {
+		return "DEFAULT"
+	}
+	return "FOR VALUES " + p.Values
+}
+
 type (
 	// AddUniqueConstraint to the table using the given index. Note, if the index
 	// name does not match the unique constraint name, PostgreSQL implicitly renames
@@ -797,39 +941,1914 @@ func (s *state) renameTable(c *schema.RenameTable) {
 	})
 }
 
-func (s *state) addComments(src schema.Change, t *schema.Table) {
-	var c schema.Comment
-	if sqlx.Has(t.Attrs, &c) && c.Text != "" {
-		s.append(s.tableComment(src, t, c.Text, ""))
+// addView builds and executes the query for creating a view.
+func (s *state) addView(add *schema.AddView) error {
+	s.append(&migrate.Change{
+		Cmd:     s.createView(add.V, sqlx.Has(add.Extra, &schema.OrReplace{})),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q %s", add.V.Name, strings.ToLower(viewKind(add.V))),
+		Reverse: s.Build("DROP", viewKind(add.V)).View(add.V).String(),
+	})
+	if len(add.V.Indexes) > 0 {
+		adds := make([]*schema.AddIndex, len(add.V.Indexes))
+		for i, idx := range add.V.Indexes {
+			adds[i] = &schema.AddIndex{I: idx}
+		}
+		if err := s.addIndexes(add, viewTable(add.V), adds...); err != nil {
+			return err
+		}
 	}
-	for i := range t.Columns {
-		if sqlx.Has(t.Columns[i].Attrs, &c) && c.Text != "" {
-			s.append(s.columnComment(src, t, t.Columns[i], c.Text, ""))
+	if c := (schema.Comment{}); sqlx.Has(add.V.Attrs, &c) && c.Text != "" {
+		s.append(s.viewComment(add, add.V, c.Text, ""))
+	}
+	return nil
+}
+
+// dropView builds and executes the query for dropping a view.
+func (s *state) dropView(drop *schema.DropView) error {
+	b := s.Build("DROP", viewKind(drop.V))
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	b.View(drop.V)
+	if sqlx.Has(drop.Extra, &Cascade{}) {
+		b.P("CASCADE")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q %s", drop.V.Name, strings.ToLower(viewKind(drop.V))),
+		Reverse: s.createView(drop.V, false),
+	})
+	return nil
+}
+
+// modifyView builds the statements that bring the view into its modified state.
+// Definition changes are applied using 'CREATE OR REPLACE VIEW', unless the change
+// renames, removes or changes the type of existing columns. In this case, the view
+// is dropped and recreated along with the views that depend on it.
+func (s *state) modifyView(modify *schema.ModifyView) error {
+	if modify.From.Materialized() || modify.To.Materialized() {
+		return s.modifyMatView(modify)
+	}
+	if sqlx.ViewDefChanged(modify) {
+		// The comment of the recreated view is set by its
+		// creation. Hence, comment changes are not applied.
+		if !replaceableView(modify.From, modify.To) {
+			return s.recreateView(modify)
 		}
+		s.append(&migrate.Change{
+			Cmd:     s.createView(modify.To, true),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q view", modify.To.Name),
+			Reverse: s.createView(modify.From, true),
+		})
 	}
-	for i := range t.Indexes {
-		if sqlx.Has(t.Indexes[i].Attrs, &c) && c.Text != "" {
-			s.append(s.indexComment(src, t, t.Indexes[i], c.Text, ""))
+	for _, c := range modify.Changes {
+		from, to, err := commentChange(c)
+		if err != nil {
+			return err
 		}
+		s.append(s.viewComment(modify, modify.To, to, from))
 	}
+	return nil
 }
 
-func (s *state) schemaComment(src schema.Change, sc *schema.Schema, to, from string) *migrate.Change {
-	b := s.Build("COMMENT ON SCHEMA").Ident(sc.Name).P("IS")
-	return &migrate.Change{
-		Cmd:     b.Clone().P(quote(to)).String(),
-		Source:  src,
-		Comment: fmt.Sprintf("set comment to schema: %q", sc.Name),
-		Reverse: b.Clone().P(quote(from)).String(),
+// modifyMatView builds the statements that bring a materialized view into its modified state.
+// Materialized views cannot be replaced. Therefore, definition changes are applied by dropping
+// and recreating the view along with its indexes and the views that depend on it.
+func (s *state) modifyMatView(modify *schema.ModifyView) error {
+	if sqlx.ViewDefChanged(modify) {
+		return s.recreateView(modify)
+	}
+	var (
+		addI  []*schema.AddIndex
+		dropI []*schema.DropIndex
+	)
+	for _, c := range modify.Changes {
+		switch c := c.(type) {
+		case *schema.AddIndex:
+			if cm := (schema.Comment{}); sqlx.Has(c.I.Attrs, &cm) {
+				s.append(s.indexComment(modify, viewTable(modify.To), c.I, cm.Text, ""))
+			}
+			addI = append(addI, c)
+		case *schema.DropIndex:
+			dropI = append(dropI, c)
+		case *schema.ModifyIndex:
+			k := c.Change
+			if k.Is(schema.ChangeComment) {
+				from, to, err := commentChange(sqlx.CommentDiff(c.From.Attrs, c.To.Attrs))
+				if err != nil {
+					return err
+				}
+				s.append(s.indexComment(modify, viewTable(modify.To), c.To, to, from))
+				// If only the comment of the index was changed.
+				if k &= ^schema.ChangeComment; k.Is(schema.NoChange) {
+					continue
+				}
+			}
+			dropI = append(dropI, &schema.DropIndex{I: c.From})
+			addI = append(addI, &schema.AddIndex{I: c.To})
+		default:
+			from, to, err := commentChange(c)
+			if err != nil {
+				return err
+			}
+			s.append(s.viewComment(modify, modify.To, to, from))
+		}
+	}
+	if err := s.dropIndexes(modify, viewTable(modify.From), dropI...); err != nil {
+		return err
+	}
+	if err := s.addIndexes(modify, viewTable(modify.To), addI...); err != nil {
+		return err
+	}
+	if from, to := tablespace(modify.From.Attrs), tablespace(modify.To.Attrs); from != to {
+		b := s.Build("ALTER MATERIALIZED VIEW").View(modify.To).P("SET TABLESPACE")
+		s.append(&migrate.Change{
+			Cmd:     b.Clone().Ident(to).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("set tablespace of materialized view %q", modify.To.Name),
+			Reverse: b.Clone().Ident(from).String(),
+		})
 	}
+	return nil
 }
 
-func (s *state) tableComment(src schema.Change, t *schema.Table, to, from string) *migrate.Change {
-	b := s.Build("COMMENT ON TABLE").Table(t).P("IS")
-	return &migrate.Change{
-		Cmd:     b.Clone().P(quote(to)).String(),
-		Source:  src,
-		Comment: fmt.Sprintf("set comment to table: %q", t.Name),
+// recreateView drops and recreates a view whose definition cannot be replaced (e.g., a
+// materialized view). The views that depend on it (directly or indirectly) are dropped
+// before it, and created after it using their desired state, as PostgreSQL does not
+// allow dropping them implicitly.
+func (s *state) recreateView(modify *schema.ModifyView) error {
+	deps := viewDependents(modify.From)
+	for _, v := range deps {
+		if err := s.dropView(&schema.DropView{V: v}); err != nil {
+			return err
+		}
+	}
+	if err := s.dropView(&schema.DropView{V: modify.From}); err != nil {
+		return err
+	}
+	if err := s.addView(&schema.AddView{V: modify.To}); err != nil {
+		return err
+	}
+	for i := len(deps) - 1; i >= 0; i-- {
+		v, ok := desiredView(modify.To, deps[i])
+		// Dependent views that were removed from
+		// the desired state are not recreated.
+		if !ok {
+			continue
+		}
+		if err := s.addView(&schema.AddView{V: v}); err != nil {
+			return err
+		}
+		for _, t := range v.Triggers {
+			if err := s.addTrigger(&schema.AddTrigger{T: t}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// onRecreated reports if the given change is applied on a view
+// that is recreated along with the view it depends on.
+func (s *state) onRecreated(c schema.Change) bool {
+	var v *schema.View
+	switch c := c.(type) {
+	case *schema.DropView:
+		v = c.V
+	case *schema.ModifyView:
+		v = c.From
+	case *schema.AddTrigger:
+		v = c.T.View
+	case *schema.DropTrigger:
+		v = c.T.View
+	case *schema.ModifyTrigger:
+		v = c.From.View
+	case *schema.RenameTrigger:
+		v = c.From.View
+	}
+	return v != nil && s.recreated[viewName(v)]
+}
+
+// recreatedViews returns the views that are recreated as dependents
+// of views whose definition was changed and cannot be replaced.
+func recreatedViews(changes []schema.Change) map[string]bool {
+	var recreated map[string]bool
+	for _, c := range changes {
+		m, ok := c.(*schema.ModifyView)
+		if !ok || !sqlx.ViewDefChanged(m) || !m.From.Materialized() && !m.To.Materialized() && replaceableView(m.From, m.To) {
+			continue
+		}
+		for _, v := range viewDependents(m.From) {
+			if recreated == nil {
+				recreated = make(map[string]bool)
+			}
+			recreated[viewName(v)] = true
+		}
+	}
+	return recreated
+}
+
+// viewDependents returns the views that depend on the given view, directly or
+// indirectly, ordered such that each view comes before the views it depends on.
+func viewDependents(v *schema.View) []*schema.View {
+	var (
+		deps  []*schema.View
+		visit func(*schema.View)
+		seen  = map[*schema.View]bool{v: true}
+	)
+	visit = func(v *schema.View) {
+		for _, r := range v.Refs {
+			if r, ok := r.(*schema.View); ok && !seen[r] {
+				seen[r] = true
+				visit(r)
+				deps = append(deps, r)
+			}
+		}
+	}
+	visit(v)
+	return deps
+}
+
+// desiredView returns the desired state of the given (current) view, if
+// it exists. The view is looked up in the realm of the "to" view.
+func desiredView(to, v *schema.View) (*schema.View, bool) {
+	ns := to.Schema
+	if ns == nil {
+		return nil, false
+	}
+	if v.Schema != nil && v.Schema.Name != ns.Name {
+		if ns.Realm == nil {
+			return nil, false
+		}
+		var ok bool
+		if ns, ok = ns.Realm.Schema(v.Schema.Name); !ok {
+			return nil, false
+		}
+	}
+	return ns.View(v.Name)
+}
+
+// viewName returns the qualified name of the view.
+func viewName(v *schema.View) string {
+	if v.Schema == nil {
+		return v.Name
+	}
+	return v.Schema.Name + "." + v.Name
+}
+
+// renameView builds the statement for renaming a view.
+func (s *state) renameView(c *schema.RenameView) {
+	s.append(&migrate.Change{
+		Source:  c,
+		Comment: fmt.Sprintf("rename a %s from %q to %q", strings.ToLower(viewKind(c.From)), c.From.Name, c.To.Name),
+		Cmd:     s.Build("ALTER", viewKind(c.From)).View(c.From).P("RENAME TO").Ident(c.To.Name).String(),
+		Reverse: s.Build("ALTER", viewKind(c.From)).View(c.To).P("RENAME TO").Ident(c.From.Name).String(),
+	})
+}
+
+// createView returns the 'CREATE [OR REPLACE] VIEW' statement of the given view,
+// or the 'CREATE MATERIALIZED VIEW' statement in case it is a materialized view.
+func (s *state) createView(v *schema.View, replace bool) string {
+	b := s.Build("CREATE")
+	if v.Materialized() {
+		b.P("MATERIALIZED VIEW").View(v)
+		if t := (Tablespace{}); sqlx.Has(v.Attrs, &t) && t.N != "" {
+			b.P("TABLESPACE").Ident(t.N)
+		}
+		b.P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";"))
+		if m := (schema.Materialized{}); sqlx.Has(v.Attrs, &m) && m.NoData {
+			b.P("WITH NO DATA")
+		}
+		return b.String()
+	}
+	if replace {
+		b.P("OR REPLACE")
+	}
+	b.P("VIEW").View(v).P("AS", strings.TrimSuffix(strings.TrimSpace(v.Def), ";"))
+	if c := (schema.ViewCheckOption{}); sqlx.Has(v.Attrs, &c) && c.V != "" {
+		b.P("WITH", strings.ToUpper(c.V), "CHECK OPTION")
+	}
+	return b.String()
+}
+
+// viewKind returns the object kind of the view as used in DDL statements.
+func viewKind(v *schema.View) string {
+	if v.Materialized() {
+		return "MATERIALIZED VIEW"
+	}
+	return "VIEW"
+}
+
+// viewTable returns a table that represents the given materialized
+// view in index statements, which are shared with tables.
+func viewTable(v *schema.View) *schema.Table {
+	return schema.NewTable(v.Name).SetSchema(v.Schema)
+}
+
+// tablespace returns the tablespace of the given attributes,
+// or "pg_default" in case it is not set explicitly.
+func tablespace(attrs []schema.Attr) string {
+	if t := (Tablespace{}); sqlx.Has(attrs, &t) && t.N != "" {
+		return t.N
+	}
+	return "pg_default"
+}
+
+// replaceableView reports if the view can be replaced using 'CREATE OR REPLACE VIEW'.
+// PostgreSQL requires the new definition to keep the existing columns, in the same
+// order and with the same names and types, but allows appending new columns at the end.
+func replaceableView(from, to *schema.View) bool {
+	if len(from.Columns) > len(to.Columns) {
+		return false
+	}
+	for i, c1 := range from.Columns {
+		c2 := to.Columns[i]
+		if c1.Name != c2.Name {
+			return false
+		}
+		if c1.Type == nil || c2.Type == nil || c1.Type.Type == nil || c2.Type.Type == nil {
+			continue
+		}
+		t1, err1 := FormatType(c1.Type.Type)
+		t2, err2 := FormatType(c2.Type.Type)
+		if err1 != nil || err2 != nil || t1 != t2 {
+			return false
+		}
+	}
+	return true
+}
+
+// addTrigger builds and executes the query for creating a trigger.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	create, err := s.createTrigger(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create trigger %q", add.T.Name),
+		Reverse: s.triggerOn(s.Build("DROP TRIGGER").Ident(add.T.Name).P("ON"), add.T).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(add.T.Attrs, &c) && c.Text != "" {
+		s.append(s.triggerComment(add, add.T, c.Text, ""))
+	}
+	return nil
+}
+
+// dropTrigger builds and executes the query for dropping a trigger.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	create, err := s.createTrigger(drop.T)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TRIGGER")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.triggerOn(b.Ident(drop.T.Name).P("ON"), drop.T).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop trigger %q", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyTrigger builds the statements that bring the trigger into its modified state.
+// Definition changes are applied by dropping and recreating the trigger, as 'CREATE OR
+// REPLACE TRIGGER' is supported only by PostgreSQL 14 and above.
+func (s *state) modifyTrigger(modify *schema.ModifyTrigger) error {
+	if sqlx.TriggerDefChanged(modify) {
+		if err := s.dropTrigger(&schema.DropTrigger{T: modify.From}); err != nil {
+			return err
+		}
+		return s.addTrigger(&schema.AddTrigger{T: modify.To})
+	}
+	for _, c := range modify.Changes {
+		from, to, err := commentChange(c)
+		if err != nil {
+			return err
+		}
+		s.append(s.triggerComment(modify, modify.To, to, from))
+	}
+	return nil
+}
+
+// renameTrigger builds the statement for renaming a trigger.
+func (s *state) renameTrigger(c *schema.RenameTrigger) {
+	s.append(&migrate.Change{
+		Source:  c,
+		Comment: fmt.Sprintf("rename a trigger from %q to %q", c.From.Name, c.To.Name),
+		Cmd:     s.triggerOn(s.Build("ALTER TRIGGER").Ident(c.From.Name).P("ON"), c.From).P("RENAME TO").Ident(c.To.Name).String(),
+		Reverse: s.triggerOn(s.Build("ALTER TRIGGER").Ident(c.To.Name).P("ON"), c.To).P("RENAME TO").Ident(c.From.Name).String(),
+	})
+}
+
+// createTrigger returns the 'CREATE TRIGGER' statement of the given trigger.
+func (s *state) createTrigger(t *schema.Trigger) (string, error) {
+	if t.ActionTime == "" || len(t.Events) == 0 || t.Body == "" {
+		return "", fmt.Errorf("incomplete trigger definition: %q", t.Name)
+	}
+	b := s.Build("CREATE TRIGGER").Ident(t.Name).P(string(t.ActionTime))
+	for i, e := range t.Events {
+		if i > 0 {
+			b.P("OR")
+		}
+		b.P(e.Name)
+		if len(e.Columns) > 0 {
+			b.MapComma(e.Columns, func(i int, b *sqlx.Builder) {
+				b.Ident(e.Columns[i].Name)
+			})
+		}
+	}
+	s.triggerOn(b.P("ON"), t)
+	if t.For != "" {
+		b.P("FOR EACH", string(t.For))
+	}
+	if w := (schema.TriggerWhen{}); sqlx.Has(t.Attrs, &w) && w.X != "" {
+		b.P("WHEN").Wrap(func(b *sqlx.Builder) {
+			b.WriteString(w.X)
+		})
+	}
+	return b.P(strings.TrimSuffix(strings.TrimSpace(t.Body), ";")).String(), nil
+}
+
+// triggerOn writes the table or the view the trigger is defined on.
+func (s *state) triggerOn(b *sqlx.Builder, t *schema.Trigger) *sqlx.Builder {
+	if t.View != nil {
+		return b.View(t.View)
+	}
+	return b.Table(t.Table)
+}
+
+func (s *state) triggerComment(src schema.Change, t *schema.Trigger, to, from string) *migrate.Change {
+	b := s.triggerOn(s.Build("COMMENT ON TRIGGER").Ident(t.Name).P("ON"), t).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to trigger: %q", t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// routine is a unified representation of functions and procedures used by the planner.
+type routine struct {
+	kind   string // FUNCTION or PROCEDURE.
+	name   string
+	schema *schema.Schema
+	args   []*schema.FuncArg
+	ret    schema.Type
+	lang   string
+	body   string
+	attrs  []schema.Attr
+}
+
+// routineOf returns the routine representation of the given object, if it is a function or a procedure.
+func routineOf(o schema.Object) (*routine, bool) {
+	switch o := o.(type) {
+	case *schema.Func:
+		return &routine{kind: "FUNCTION", name: o.Name, schema: o.Schema, args: o.Args, ret: o.Ret, lang: o.Lang, body: o.Body, attrs: o.Attrs}, true
+	case *schema.Proc:
+		return &routine{kind: "PROCEDURE", name: o.Name, schema: o.Schema, args: o.Args, lang: o.Lang, body: o.Body, attrs: o.Attrs}, true
+	}
+	return nil, false
+}
+
+// addRoutine builds and executes the query for creating a function or a procedure.
+func (s *state) addRoutine(add *schema.AddObject, r *routine) error {
+	create, err := s.createRoutine(r, sqlx.Has(add.Extra, &schema.OrReplace{}))
+	if err != nil {
+		return err
+	}
+	drop, err := s.routineIdent(s.Build("DROP", r.kind), r)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create %q %s", r.name, strings.ToLower(r.kind)),
+		Reverse: drop.String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(r.attrs, &c) && c.Text != "" {
+		cm, err := s.routineComment(add, r, c.Text, "")
+		if err != nil {
+			return err
+		}
+		s.append(cm)
+	}
+	return nil
+}
+
+// dropRoutine builds and executes the query for dropping a function or a procedure.
+func (s *state) dropRoutine(drop *schema.DropObject, r *routine) error {
+	b := s.Build("DROP", r.kind)
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	b, err := s.routineIdent(b, r)
+	if err != nil {
+		return err
+	}
+	if sqlx.Has(drop.Extra, &Cascade{}) {
+		b.P("CASCADE")
+	}
+	create, err := s.createRoutine(r, false)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q %s", r.name, strings.ToLower(r.kind)),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyRoutine builds the statements that bring the function or the procedure into its modified
+// state. Changes are applied using 'CREATE OR REPLACE', unless the change modifies the return type,
+// changes the types of arguments, renames them or removes their default values. In this case, the
+// routine is dropped and recreated.
+func (s *state) modifyRoutine(modify *schema.ModifyObject, from, to *routine) error {
+	from1, to1 := *from, *to
+	from1.attrs, to1.attrs = nil, nil
+	c1, err := s.createRoutine(&from1, true)
+	if err != nil {
+		return err
+	}
+	c2, err := s.createRoutine(&to1, true)
+	if err != nil {
+		return err
+	}
+	if c1 != c2 {
+		if !s.replaceableRoutine(from, to) {
+			if err := s.dropRoutine(&schema.DropObject{O: modify.From}, from); err != nil {
+				return err
+			}
+			return s.addRoutine(&schema.AddObject{O: modify.To}, to)
+		}
+		s.append(&migrate.Change{
+			Cmd:     c2,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %q %s", to.name, strings.ToLower(to.kind)),
+			Reverse: c1,
+		})
+	}
+	if change := sqlx.CommentDiff(from.attrs, to.attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		cm, err := s.routineComment(modify, to, toC, fromC)
+		if err != nil {
+			return err
+		}
+		s.append(cm)
+	}
+	return nil
+}
+
+// createRoutine returns the 'CREATE [OR REPLACE] FUNCTION|PROCEDURE' statement of the given routine.
+func (s *state) createRoutine(r *routine, replace bool) (string, error) {
+	b := s.Build("CREATE")
+	if replace {
+		b.P("OR REPLACE")
+	}
+	b.P(r.kind)
+	b.SchemaResource(r.schema, r.name)
+	err := b.WrapErr(func(b *sqlx.Builder) error {
+		return b.MapCommaErr(r.args, func(i int, b *sqlx.Builder) error {
+			a := r.args[i]
+			if m := argMode(a); m != schema.FuncArgModeIn {
+				b.P(string(m))
+			}
+			if a.Name != "" {
+				b.Ident(a.Name)
+			}
+			t, err := s.routineType(a.Type)
+			if err != nil {
+				return err
+			}
+			b.P(t)
+			if a.Default != nil {
+				s.formatDefault(b, a.Type, a.Default)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	if r.ret != nil {
+		t, err := s.routineType(r.ret)
+		if err != nil {
+			return "", err
+		}
+		b.P("RETURNS", t)
+	}
+	b.P("LANGUAGE", funcLangOr(r.lang))
+	if v := volatility(r.attrs); r.kind == "FUNCTION" && v != VolatilityVolatile {
+		b.P(v)
+	}
+	b.P("AS", dollarQuote(r.body))
+	return b.String(), nil
+}
+
+// routineIdent writes the qualified identifier of the routine, followed by
+// the types of its input arguments to the builder. e.g., "public"."f"(integer).
+func (s *state) routineIdent(b *sqlx.Builder, r *routine) (*sqlx.Builder, error) {
+	b.SchemaResource(r.schema, r.name)
+	err := b.WrapErr(func(b *sqlx.Builder) error {
+		in := inArgs(r.args)
+		return b.MapCommaErr(in, func(i int, b *sqlx.Builder) error {
+			t, err := s.routineType(in[i].Type)
+			if err != nil {
+				return err
+			}
+			b.WriteString(t)
+			return nil
+		})
+	})
+	return b, err
+}
+
+// routineType formats the argument or the return type of a routine.
+func (s *state) routineType(t schema.Type) (string, error) {
+	if u, ok := t.(*schema.UnsupportedType); ok {
+		return u.T, nil
+	}
+	return s.formatType(t)
+}
+
+func (s *state) routineComment(src schema.Change, r *routine, to, from string) (*migrate.Change, error) {
+	b, err := s.routineIdent(s.Build("COMMENT ON", r.kind), r)
+	if err != nil {
+		return nil, err
+	}
+	b.P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to %s: %q", strings.ToLower(r.kind), r.name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}, nil
+}
+
+// replaceableRoutine reports if the routine can be replaced using 'CREATE OR REPLACE'.
+// PostgreSQL does not allow changing the return type of an existing function, renaming
+// its arguments or removing their default values. Changing the types of its input
+// arguments creates a new overload, and therefore requires dropping the old routine.
+func (s *state) replaceableRoutine(from, to *routine) bool {
+	if len(from.args) != len(to.args) {
+		return false
+	}
+	for i, a := range from.args {
+		if a.Name != to.args[i].Name || argMode(a) != argMode(to.args[i]) || a.Default != nil && to.args[i].Default == nil {
+			return false
+		}
+		if !s.sameRoutineType(a.Type, to.args[i].Type) {
+			return false
+		}
+	}
+	if from.ret == nil || to.ret == nil {
+		return from.ret == to.ret
+	}
+	return s.sameRoutineType(from.ret, to.ret)
+}
+
+// sameRoutineType reports if the two argument or return types are formatted the same.
+func (s *state) sameRoutineType(t1, t2 schema.Type) bool {
+	s1, err1 := s.routineType(t1)
+	s2, err2 := s.routineType(t2)
+	return err1 == nil && err2 == nil && strings.EqualFold(s1, s2)
+}
+
+// dollarQuote wraps the given body with dollar quotes. A tagged quote
+// is used in case the body already contains the default delimiter.
+func dollarQuote(body string) string {
+	tag := "$$"
+	if strings.Contains(body, tag) {
+		tag = "$fn$"
+		for i := 1; strings.Contains(body, tag); i++ {
+			tag = fmt.Sprintf("$fn%d$", i)
+		}
+	}
+	return tag + body + tag
+}
+
+// addSequence builds and executes the query for creating a sequence.
+func (s *state) addSequence(add *schema.AddObject, seq *Sequence) error {
+	b := s.Build("CREATE SEQUENCE")
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	if err := s.sequenceOptions(b.P(s.seqIdent(seq)), seq); err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create sequence %q", seq.Name),
+		Reverse: s.Build("DROP SEQUENCE").P(s.seqIdent(seq)).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(seq.Attrs, &c) && c.Text != "" {
+		s.append(s.seqComment(add, seq, c.Text, ""))
+	}
+	return nil
+}
+
+// dropSequence builds and executes the query for dropping a sequence.
+func (s *state) dropSequence(drop *schema.DropObject, seq *Sequence) error {
+	b := s.Build("DROP SEQUENCE")
+	// Sequences that are owned by a column are dropped along
+	// with their tables, which might be dropped before them.
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) || seq.Owner.T != nil {
+		b.P("IF EXISTS")
+	}
+	create := s.Build("CREATE SEQUENCE").P(s.seqIdent(seq))
+	if err := s.sequenceOptions(create, seq); err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.seqIdent(seq)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop sequence %q", seq.Name),
+		Reverse: create.String(),
+	})
+	return nil
+}
+
+// modifySequence builds and executes the queries for altering a sequence.
+func (s *state) modifySequence(modify *schema.ModifyObject, from, to *Sequence) error {
+	cmd, err := s.alterSequence(from, to)
+	if err != nil {
+		return err
+	}
+	if cmd != "" {
+		reverse, err := s.alterSequence(to, from)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify sequence %q", to.Name),
+			Reverse: reverse,
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.seqComment(modify, to, toC, fromC))
+	}
+	return nil
+}
+
+// alterSequence returns the 'ALTER SEQUENCE' statement for migrating the options
+// and the owner of a sequence from one state to the other, or an empty string if
+// there is nothing to alter.
+func (s *state) alterSequence(from, to *Sequence) (string, error) {
+	o1, err := sequenceOptions(from)
+	if err != nil {
+		return "", err
+	}
+	o2, err := sequenceOptions(to)
+	if err != nil {
+		return "", err
+	}
+	var (
+		clauses []string
+		format  = func(i int64) string { return strconv.FormatInt(i, 10) }
+	)
+	if o1.typ != o2.typ {
+		clauses = append(clauses, "AS "+o2.typ)
+	}
+	if o1.inc != o2.inc {
+		clauses = append(clauses, "INCREMENT BY "+format(o2.inc))
+	}
+	switch {
+	case o1.min == o2.min:
+	case to.Min == nil:
+		clauses = append(clauses, "NO MINVALUE")
+	default:
+		clauses = append(clauses, "MINVALUE "+format(o2.min))
+	}
+	switch {
+	case o1.max == o2.max:
+	case to.Max == nil:
+		clauses = append(clauses, "NO MAXVALUE")
+	default:
+		clauses = append(clauses, "MAXVALUE "+format(o2.max))
+	}
+	if o1.start != o2.start {
+		clauses = append(clauses, "START WITH "+format(o2.start))
+	}
+	if o1.cache != o2.cache {
+		clauses = append(clauses, "CACHE "+format(o2.cache))
+	}
+	switch {
+	case o1.cycle == o2.cycle:
+	case o2.cycle:
+		clauses = append(clauses, "CYCLE")
+	default:
+		clauses = append(clauses, "NO CYCLE")
+	}
+	if !sameOwner(from, to) {
+		clauses = append(clauses, "OWNED BY "+s.seqOwner(to))
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return s.Build("ALTER SEQUENCE").P(s.seqIdent(to)).P(clauses...).String(), nil
+}
+
+// sequenceOptions writes the options of the sequence that differ from the database defaults.
+func (s *state) sequenceOptions(b *sqlx.Builder, seq *Sequence) error {
+	o, err := sequenceOptions(seq)
+	if err != nil {
+		return err
+	}
+	if o.typ != TypeBigInt {
+		b.P("AS", o.typ)
+	}
+	if o.inc != defaultSeqIncrement {
+		b.P("INCREMENT BY", strconv.FormatInt(o.inc, 10))
+	}
+	if seq.Min != nil {
+		b.P("MINVALUE", strconv.FormatInt(o.min, 10))
+	}
+	if seq.Max != nil {
+		b.P("MAXVALUE", strconv.FormatInt(o.max, 10))
+	}
+	if o.start != o.defaultStart() {
+		b.P("START WITH", strconv.FormatInt(o.start, 10))
+	}
+	if o.cache != 1 {
+		b.P("CACHE", strconv.FormatInt(o.cache, 10))
+	}
+	if o.cycle {
+		b.P("CYCLE")
+	}
+	if seq.Owner.T != nil {
+		b.P("OWNED BY", s.seqOwner(seq))
+	}
+	return nil
+}
+
+// seqOwner returns the owner of the sequence in the 'OWNED BY' format.
+func (s *state) seqOwner(seq *Sequence) string {
+	if seq.Owner.T == nil || seq.Owner.C == nil {
+		return "NONE"
+	}
+	return fmt.Sprintf("%s%q.%q", s.schemaPrefix(seq.Owner.T.Schema), seq.Owner.T.Name, seq.Owner.C.Name)
+}
+
+func (s *state) seqIdent(seq *Sequence) string {
+	return s.typeIdent(seq.Schema, seq.Name)
+}
+
+func (s *state) seqComment(src schema.Change, seq *Sequence, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON SEQUENCE").P(s.seqIdent(seq)).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to sequence: %q", seq.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// detachOwners splits the creation of sequences that are owned by columns of tables
+// that are created or modified in the same plan, into creating the sequence and then
+// setting its owner. That is because the column might reference the sequence (e.g.,
+// using nextval), and the 'OWNED BY' clause requires the column to exist.
+func detachOwners(changes []schema.Change) []schema.Change {
+	for i, c := range changes {
+		add, ok := c.(*schema.AddObject)
+		if !ok {
+			continue
+		}
+		seq, ok := add.O.(*Sequence)
+		if !ok || seq.Owner.T == nil || !slices.ContainsFunc(changes, func(c schema.Change) bool {
+			return tableChange(c, seq.Owner.T)
+		}) {
+			continue
+		}
+		unowned := *seq
+		unowned.Owner.T, unowned.Owner.C = nil, nil
+		changes[i] = &schema.AddObject{O: &unowned, Extra: add.Extra}
+		changes = append(changes, &schema.ModifyObject{From: &unowned, To: seq})
+	}
+	return changes
+}
+
+// tableChange reports if the change creates or modifies the given table.
+func tableChange(c schema.Change, t *schema.Table) bool {
+	switch c := c.(type) {
+	case *schema.AddTable:
+		return sqlx.SameTable(c.T, t)
+	case *schema.ModifyTable:
+		return sqlx.SameTable(c.T, t)
+	}
+	return false
+}
+
+// DependsOn reports if the given change depends on the other change.
+func (s *Sequence) DependsOn(change, other schema.Change) bool {
+	switch change.(type) {
+	case *schema.ModifyObject:
+		// Setting the owner requires the owner column to exist.
+		return s.Owner.T != nil && tableChange(other, s.Owner.T)
+	case *schema.DropObject:
+		// Sequences are dropped after the columns that use them.
+		switch other := other.(type) {
+		case *schema.DropTable:
+			return slices.ContainsFunc(other.T.Columns, s.usedBy)
+		case *schema.ModifyTable:
+			return slices.ContainsFunc(other.Changes, func(c schema.Change) bool {
+				switch c := c.(type) {
+				case *schema.DropColumn:
+					return s.usedBy(c.C)
+				case *schema.ModifyColumn:
+					return s.usedBy(c.From)
+				}
+				return false
+			})
+		}
+	}
+	return false
+}
+
+// DependencyOf reports if the given change is a dependency of the other change.
+func (s *Sequence) DependencyOf(change, other schema.Change) bool {
+	if _, ok := change.(*schema.AddObject); !ok {
+		return false
+	}
+	// Sequences are created before the columns that use them.
+	switch other := other.(type) {
+	case *schema.AddTable:
+		return slices.ContainsFunc(other.T.Columns, s.usedBy)
+	case *schema.ModifyTable:
+		return slices.ContainsFunc(other.Changes, func(c schema.Change) bool {
+			switch c := c.(type) {
+			case *schema.AddColumn:
+				return s.usedBy(c.C)
+			case *schema.ModifyColumn:
+				return s.usedBy(c.To)
+			}
+			return false
+		})
+	}
+	return false
+}
+
+// usedBy reports if the default value of the column references the sequence.
+func (s *Sequence) usedBy(c *schema.Column) bool {
+	x, ok := c.Default.(*schema.RawExpr)
+	if !ok {
+		return false
+	}
+	ns, name, ok := seqRef(x.X)
+	return ok && name == s.Name && (ns == "" || s.Schema == nil || ns == s.Schema.Name)
+}
+
+// addDomain builds and executes the query for creating a domain.
+func (s *state) addDomain(add *schema.AddObject, d *DomainType) error {
+	create, err := s.createDomain(d)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create domain type %q", d.T),
+		Reverse: s.Build("DROP DOMAIN").P(s.domainIdent(d)).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(d.Attrs, &c) && c.Text != "" {
+		s.append(s.domainComment(add, d, c.Text, ""))
+	}
+	return nil
+}
+
+// dropDomain builds and executes the query for dropping a domain.
+func (s *state) dropDomain(drop *schema.DropObject, d *DomainType) error {
+	create, err := s.createDomain(d)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP DOMAIN")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.domainIdent(d)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop domain type %q", d.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyDomain builds and executes the queries for altering a domain. Note, each
+// action is executed in a separate statement, as ALTER DOMAIN accepts only one.
+func (s *state) modifyDomain(modify *schema.ModifyObject, from, to *DomainType) error {
+	changed, err := typeChanged(
+		&schema.Column{Name: from.T, Type: &schema.ColumnType{Type: from.Type}},
+		&schema.Column{Name: to.T, Type: &schema.ColumnType{Type: to.Type}},
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return fmt.Errorf("changing the underlying type of domain %q is not supported", to.T)
+	}
+	var (
+		changes []*migrate.Change
+		alter   = func(cmd, reverse *sqlx.Builder) {
+			c := &migrate.Change{
+				Cmd:     cmd.String(),
+				Source:  modify,
+				Comment: fmt.Sprintf("modify domain type %q", to.T),
+			}
+			if reverse != nil {
+				c.Reverse = reverse.String()
+			}
+			changes = append(changes, c)
+		}
+		b     = func() *sqlx.Builder { return s.Build("ALTER DOMAIN").P(s.domainIdent(to)) }
+		x1, _ = sqlx.DefaultValue(&schema.Column{Default: from.Default})
+		x2, _ = sqlx.DefaultValue(&schema.Column{Default: to.Default})
+	)
+	switch {
+	case trimCast(x1) == trimCast(x2):
+	case to.Default == nil:
+		rb := b()
+		s.formatDefault(rb.P("SET"), from.Type, from.Default)
+		alter(b().P("DROP DEFAULT"), rb)
+	default:
+		cb, rb := b(), b().P("DROP DEFAULT")
+		s.formatDefault(cb.P("SET"), to.Type, to.Default)
+		if from.Default != nil {
+			rb = b()
+			s.formatDefault(rb.P("SET"), from.Type, from.Default)
+		}
+		alter(cb, rb)
+	}
+	switch {
+	case from.Null == to.Null:
+	case to.Null:
+		alter(b().P("DROP NOT NULL"), b().P("SET NOT NULL"))
+	default:
+		alter(b().P("SET NOT NULL"), b().P("DROP NOT NULL"))
+	}
+	drop, add := domainChecksDiff(from, to)
+	for _, c := range drop {
+		if c.Name == "" {
+			return fmt.Errorf("cannot drop unnamed check constraint %q of domain %q", c.Expr, from.T)
+		}
+		rb := b().P("ADD")
+		check(rb, c)
+		alter(b().P("DROP CONSTRAINT").Ident(c.Name), rb)
+	}
+	for _, c := range add {
+		cb := b().P("ADD")
+		check(cb, c)
+		var rb *sqlx.Builder
+		if c.Name != "" {
+			rb = b().P("DROP CONSTRAINT").Ident(c.Name)
+		}
+		alter(cb, rb)
+	}
+	s.append(changes...)
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.domainComment(modify, to, toC, fromC))
+	}
+	return nil
+}
+
+// createDomain returns the 'CREATE DOMAIN' statement of the given domain.
+func (s *state) createDomain(d *DomainType) (string, error) {
+	if d.Type == nil {
+		return "", fmt.Errorf("missing underlying type for domain %q", d.T)
+	}
+	t, err := s.formatType(d.Type)
+	if err != nil {
+		return "", err
+	}
+	b := s.Build("CREATE DOMAIN").P(s.domainIdent(d), "AS", t)
+	if d.Default != nil {
+		s.formatDefault(b, d.Type, d.Default)
+	}
+	if !d.Null {
+		b.P("NOT NULL")
+	}
+	for _, c := range d.Checks {
+		check(b, c)
+	}
+	return b.String(), nil
+}
+
+func (s *state) domainComment(src schema.Change, d *DomainType, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON DOMAIN").P(s.domainIdent(d)).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to domain type: %q", d.T),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// addComposite builds and executes the query for creating a composite type.
+func (s *state) addComposite(add *schema.AddObject, c *CompositeType) error {
+	create, err := s.createComposite(c)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create composite type %q", c.T),
+		Reverse: s.Build("DROP TYPE").P(s.compositeIdent(c)).String(),
+	})
+	if cm := (schema.Comment{}); sqlx.Has(c.Attrs, &cm) && cm.Text != "" {
+		s.append(s.compositeComment(add, c, cm.Text, ""))
+	}
+	return nil
+}
+
+// dropComposite builds and executes the query for dropping a composite type.
+func (s *state) dropComposite(drop *schema.DropObject, c *CompositeType) error {
+	create, err := s.createComposite(c)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TYPE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.P(s.compositeIdent(c)).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop composite type %q", c.T),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyComposite builds and executes the queries for altering a composite type.
+func (s *state) modifyComposite(modify *schema.ModifyObject, from, to *CompositeType) error {
+	cmd, err := s.alterComposite(from, to)
+	if err != nil {
+		return err
+	}
+	if cmd != "" {
+		reverse, err := s.alterComposite(to, from)
+		if err != nil {
+			return err
+		}
+		s.append(&migrate.Change{
+			Cmd:     cmd,
+			Source:  modify,
+			Comment: fmt.Sprintf("modify composite type %q", to.T),
+			Reverse: reverse,
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.compositeComment(modify, to, toC, fromC))
+	}
+	return nil
+}
+
+// alterComposite returns the 'ALTER TYPE' statement for migrating the fields of a
+// composite type from one state to the other, or an empty string if there is nothing
+// to alter. Fields are matched by their names, and new fields are added at the end.
+func (s *state) alterComposite(from, to *CompositeType) (string, error) {
+	var actions []string
+	for _, f1 := range from.Fields {
+		if !slices.ContainsFunc(to.Fields, func(f2 *schema.Column) bool { return f1.Name == f2.Name }) {
+			actions = append(actions, s.Build("DROP ATTRIBUTE").Ident(f1.Name).String())
+		}
+	}
+	for _, f2 := range to.Fields {
+		t, err := s.formatType(f2.Type.Type)
+		if err != nil {
+			return "", err
+		}
+		idx := slices.IndexFunc(from.Fields, func(f1 *schema.Column) bool { return f1.Name == f2.Name })
+		if idx == -1 {
+			actions = append(actions, s.Build("ADD ATTRIBUTE").Ident(f2.Name).P(t).String())
+			continue
+		}
+		changed, err := typeChanged(from.Fields[idx], f2, "")
+		if err != nil {
+			return "", err
+		}
+		if changed {
+			actions = append(actions, s.Build("ALTER ATTRIBUTE").Ident(f2.Name).P("TYPE", t).String())
+		}
+	}
+	if len(actions) == 0 {
+		return "", nil
+	}
+	return s.Build("ALTER TYPE").P(s.compositeIdent(to)).P(strings.Join(actions, ", ")).String(), nil
+}
+
+// createComposite returns the 'CREATE TYPE' statement of the given composite type.
+func (s *state) createComposite(c *CompositeType) (string, error) {
+	fields := make([]string, len(c.Fields))
+	for i, f := range c.Fields {
+		if f.Type == nil || f.Type.Type == nil {
+			return "", fmt.Errorf("missing type for field %q of composite type %q", f.Name, c.T)
+		}
+		t, err := s.formatType(f.Type.Type)
+		if err != nil {
+			return "", err
+		}
+		fields[i] = s.Build().Ident(f.Name).P(t).String()
+	}
+	return s.Build("CREATE TYPE").P(s.compositeIdent(c), "AS").Wrap(func(b *sqlx.Builder) {
+		b.WriteString(strings.Join(fields, ", "))
+	}).String(), nil
+}
+
+func (s *state) compositeComment(src schema.Change, c *CompositeType, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON TYPE").P(s.compositeIdent(c)).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to composite type: %q", c.T),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// usesType reports if the given object (a domain or a composite type) is based on the type t.
+func usesType(o schema.Object, t schema.Type) bool {
+	switch o := o.(type) {
+	case *DomainType:
+		return o.Type != nil && schema.IsType(o.Type, t)
+	case *CompositeType:
+		return slices.ContainsFunc(o.Fields, func(f *schema.Column) bool {
+			return f.Type != nil && schema.IsType(f.Type.Type, t)
+		})
+	}
+	return false
+}
+
+// typeDependsOn reports if the change of the user-defined type t depends on the
+// other change. Types are created after the types they are based on, and dropped
+// after the types and the columns that use them.
+func typeDependsOn(t interface {
+	schema.Object
+	schema.Type
+}, change, other schema.Change) bool {
+	switch change.(type) {
+	case *schema.AddObject, *schema.ModifyObject:
+		add, ok := other.(*schema.AddObject)
+		if !ok {
+			return false
+		}
+		t1, ok := add.O.(schema.Type)
+		return ok && usesType(t, t1)
+	case *schema.DropObject:
+		switch other := other.(type) {
+		case *schema.DropObject:
+			return usesType(other.O, t)
+		case *schema.ModifyTable:
+			// Columns that are changed to other types.
+			return slices.ContainsFunc(other.Changes, func(c schema.Change) bool {
+				m, ok := c.(*schema.ModifyColumn)
+				return ok && m.From.Type != nil && schema.IsType(m.From.Type.Type, t)
+			})
+		}
+	}
+	return false
+}
+
+// DependsOn reports if the given change depends on the other change.
+func (d *DomainType) DependsOn(change, other schema.Change) bool {
+	return typeDependsOn(d, change, other)
+}
+
+// DependencyOf reports if the given change is a dependency of the other change.
+func (*DomainType) DependencyOf(_, _ schema.Change) bool {
+	return false
+}
+
+// DependsOn reports if the given change depends on the other change.
+func (c *CompositeType) DependsOn(change, other schema.Change) bool {
+	return typeDependsOn(c, change, other)
+}
+
+// DependencyOf reports if the given change is a dependency of the other change.
+func (*CompositeType) DependencyOf(_, _ schema.Change) bool {
+	return false
+}
+
+// addExtension builds and executes the query for creating an extension.
+func (s *state) addExtension(add *schema.AddObject, e *Extension) error {
+	b := s.Build("CREATE EXTENSION")
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     s.extensionOptions(b.Ident(e.T), e).String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create extension %q", e.T),
+		Reverse: s.Build("DROP EXTENSION").Ident(e.T).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(e.Attrs, &c) && c.Text != "" {
+		s.append(s.extensionComment(add, e, c.Text, ""))
+	}
+	return nil
+}
+
+// dropExtension builds and executes the query for dropping an extension.
+func (s *state) dropExtension(drop *schema.DropObject, e *Extension) error {
+	b := s.Build("DROP EXTENSION")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(e.T).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop extension %q", e.T),
+		Reverse: s.extensionOptions(s.Build("CREATE EXTENSION").Ident(e.T), e).String(),
+	})
+	return nil
+}
+
+// modifyExtension builds and executes the queries for updating the
+// version of an extension, moving it to another schema or changing
+// its comment.
+func (s *state) modifyExtension(modify *schema.ModifyObject, from, to *Extension) error {
+	b := func() *sqlx.Builder { return s.Build("ALTER EXTENSION").Ident(to.T) }
+	if from.Version != "" && to.Version != "" && from.Version != to.Version {
+		s.append(&migrate.Change{
+			Cmd:     b().P("UPDATE TO", quote(to.Version)).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("update extension %q to version %q", to.T, to.Version),
+			Reverse: b().P("UPDATE TO", quote(from.Version)).String(),
+		})
+	}
+	if from.Schema != nil && to.Schema != nil && from.Schema.Name != to.Schema.Name {
+		s.append(&migrate.Change{
+			Cmd:     b().P("SET SCHEMA").Ident(to.Schema.Name).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("move extension %q to schema %q", to.T, to.Schema.Name),
+			Reverse: b().P("SET SCHEMA").Ident(from.Schema.Name).String(),
+		})
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.extensionComment(modify, to, toC, fromC))
+	}
+	return nil
+}
+
+// extensionOptions writes the schema and the version of the extension, if they were set.
+func (s *state) extensionOptions(b *sqlx.Builder, e *Extension) *sqlx.Builder {
+	if e.Schema != nil && e.Schema.Name != "" {
+		b.P("SCHEMA").Ident(e.Schema.Name)
+	}
+	if e.Version != "" {
+		b.P("VERSION", quote(e.Version))
+	}
+	return b
+}
+
+func (s *state) extensionComment(src schema.Change, e *Extension, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON EXTENSION").Ident(e.T).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to extension: %q", e.T),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// extensionChange reports if the given change creates, modifies or drops an extension.
+func extensionChange(c schema.Change) bool {
+	var o schema.Object
+	switch c := c.(type) {
+	case *schema.AddObject:
+		o = c.O
+	case *schema.ModifyObject:
+		o = c.To
+	case *schema.DropObject:
+		o = c.O
+	}
+	_, ok := o.(*Extension)
+	return ok
+}
+
+// DependsOn reports if the given change depends on the other change.
+func (*Extension) DependsOn(change, other schema.Change) bool {
+	// Extensions are dropped after all other changes,
+	// as the dropped objects might have used them.
+	_, ok := change.(*schema.DropObject)
+	return ok && !extensionChange(other)
+}
+
+// DependencyOf reports if the given change is a dependency of the other change.
+func (*Extension) DependencyOf(change, other schema.Change) bool {
+	// Extensions are created before all other changes,
+	// as the created objects might use them.
+	_, ok := change.(*schema.AddObject)
+	return ok && !extensionChange(other)
+}
+
+// grantee is a common representation of roles and users. In PostgreSQL, users
+// are roles that can log in, and both are managed using the ROLE commands.
+type grantee struct {
+	kind     string // role or user.
+	name     string
+	login    bool
+	memberOf []*schema.Role
+	attrs    []schema.Attr
+}
+
+// granteeOf returns the grantee representation of the given object, if it is a role or a user.
+func granteeOf(o schema.Object) (*grantee, bool) {
+	switch o := o.(type) {
+	case *schema.Role:
+		return &grantee{kind: "role", name: o.Name, memberOf: o.MemberOf, attrs: o.Attrs}, true
+	case *schema.User:
+		return &grantee{kind: "user", name: o.Name, login: true, memberOf: o.MemberOf, attrs: o.Attrs}, true
+	}
+	return nil, false
+}
+
+// addRole builds and executes the queries for creating a role or
+// a user, and granting it the roles it is a member of.
+func (s *state) addRole(add *schema.AddObject, g *grantee) {
+	s.append(&migrate.Change{
+		Cmd:     s.createRole(g).String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %s %q", g.kind, g.name),
+		Reverse: s.Build("DROP ROLE").Ident(g.name).String(),
+	})
+	if len(g.memberOf) > 0 {
+		s.append(s.grantRoles(add, g, sqlx.RoleNames(g.memberOf)))
+	}
+}
+
+// dropRole builds and executes the query for dropping a role or a user. The change
+// is irreversible, as the password, the memberships and the privileges of the role
+// are lost once it is dropped.
+func (s *state) dropRole(drop *schema.DropObject, g *grantee) {
+	b := s.Build("DROP ROLE")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(g.name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %s %q", g.kind, g.name),
+	})
+}
+
+// modifyRole builds and executes the queries for altering the options
+// of a role or a user, and the roles it is a member of.
+func (s *state) modifyRole(modify *schema.ModifyObject, from, to *grantee) {
+	if opts := roleClauses(from, to); len(opts) > 0 {
+		s.append(&migrate.Change{
+			Cmd:     s.Build("ALTER ROLE").Ident(to.name).P("WITH").P(opts...).String(),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify %s %q", to.kind, to.name),
+			Reverse: s.Build("ALTER ROLE").Ident(to.name).P("WITH").P(roleClauses(to, from)...).String(),
+		})
+	}
+	fromR, toR := sqlx.RoleNames(from.memberOf), sqlx.RoleNames(to.memberOf)
+	if added := slices.DeleteFunc(slices.Clone(toR), func(n string) bool { return slices.Contains(fromR, n) }); len(added) > 0 {
+		s.append(s.grantRoles(modify, to, added))
+	}
+	if removed := slices.DeleteFunc(slices.Clone(fromR), func(n string) bool { return slices.Contains(toR, n) }); len(removed) > 0 {
+		c := s.grantRoles(modify, to, removed)
+		c.Cmd, c.Reverse = c.Reverse.(string), c.Cmd
+		c.Comment = fmt.Sprintf("revoke roles from %s %q", to.kind, to.name)
+		s.append(c)
+	}
+}
+
+// createRole returns the 'CREATE ROLE' statement of the given role or user.
+func (s *state) createRole(g *grantee) *sqlx.Builder {
+	b := s.Build("CREATE ROLE").Ident(g.name)
+	if opts := roleClauses(&grantee{}, g); len(opts) > 0 {
+		b.P("WITH").P(opts...)
+	}
+	return b
+}
+
+// grantRoles returns the change for granting the given roles to the role or the user.
+func (s *state) grantRoles(src schema.Change, g *grantee, roles []string) *migrate.Change {
+	idents := func(b *sqlx.Builder) *sqlx.Builder {
+		return b.MapComma(roles, func(i int, b *sqlx.Builder) { b.Ident(roles[i]) })
+	}
+	return &migrate.Change{
+		Cmd:     idents(s.Build("GRANT")).P("TO").Ident(g.name).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("grant roles to %s %q", g.kind, g.name),
+		Reverse: idents(s.Build("REVOKE")).P("FROM").Ident(g.name).String(),
+	}
+}
+
+// roleClauses returns the clauses for migrating the options of a role or a user
+// from one state to the other. The connection limit of -1 means no limit.
+func roleClauses(from, to *grantee) []string {
+	var (
+		clauses        []string
+		fromO, toO     = roleOptions(from.attrs), roleOptions(to.attrs)
+		fromL, toL     = connLimit(from.attrs), connLimit(to.attrs)
+		fromLo, toLo   = from.login, to.login
+		fromInh, toInh = !fromO.NoInherit, !toO.NoInherit
+	)
+	for _, o := range []struct {
+		k        string
+		from, to bool
+	}{
+		{"SUPERUSER", fromO.Superuser, toO.Superuser},
+		{"CREATEDB", fromO.CreateDB, toO.CreateDB},
+		{"CREATEROLE", fromO.CreateRole, toO.CreateRole},
+		{"INHERIT", fromInh, toInh},
+		{"LOGIN", fromLo, toLo},
+		{"REPLICATION", fromO.Replication, toO.Replication},
+		{"BYPASSRLS", fromO.BypassRLS, toO.BypassRLS},
+	} {
+		switch {
+		case o.from == o.to:
+		case o.to:
+			clauses = append(clauses, o.k)
+		default:
+			clauses = append(clauses, "NO"+o.k)
+		}
+	}
+	if fromL != toL {
+		clauses = append(clauses, "CONNECTION LIMIT", strconv.Itoa(toL))
+	}
+	return clauses
+}
+
+// addPermission builds and executes the query for granting the privileges of a permission.
+func (s *state) addPermission(src schema.Change, p *schema.Permission) error {
+	privs := sqlx.Privileges(p)
+	grant, err := s.grant(p, privs)
+	if err != nil {
+		return err
+	}
+	revoke, err := s.revoke(p, privs)
+	if err != nil {
+		return err
+	}
+	g, _ := granteeOf(p.To)
+	s.append(&migrate.Change{
+		Cmd:     grant,
+		Source:  src,
+		Comment: fmt.Sprintf("grant privileges on %s to %s %q", permissionResource(p), g.kind, g.name),
+		Reverse: revoke,
+	})
+	return nil
+}
+
+// dropPermission builds and executes the query for revoking the privileges of a permission.
+func (s *state) dropPermission(src schema.Change, p *schema.Permission) error {
+	privs := sqlx.Privileges(p)
+	grant, err := s.grant(p, privs)
+	if err != nil {
+		return err
+	}
+	revoke, err := s.revoke(p, privs)
+	if err != nil {
+		return err
+	}
+	g, _ := granteeOf(p.To)
+	s.append(&migrate.Change{
+		Cmd:     revoke,
+		Source:  src,
+		Comment: fmt.Sprintf("revoke privileges on %s from %s %q", permissionResource(p), g.kind, g.name),
+		Reverse: grant,
+	})
+	return nil
+}
+
+// modifyPermission builds and executes the queries for granting the
+// added privileges of a permission and revoking the removed ones.
+func (s *state) modifyPermission(modify *schema.ModifyObject, from, to *schema.Permission) error {
+	fromP, toP := permissionPrivileges(from), permissionPrivileges(to)
+	if added := slices.DeleteFunc(slices.Clone(toP), func(p string) bool { return slices.Contains(fromP, p) }); len(added) > 0 {
+		if err := s.addPermission(modify, &schema.Permission{To: to.To, Schema: to.Schema, Table: to.Table, Columns: to.Columns, Privileges: added, Grantable: to.Grantable}); err != nil {
+			return err
+		}
+	}
+	if removed := slices.DeleteFunc(slices.Clone(fromP), func(p string) bool { return slices.Contains(toP, p) }); len(removed) > 0 {
+		if err := s.dropPermission(modify, &schema.Permission{To: to.To, Schema: to.Schema, Table: to.Table, Columns: to.Columns, Privileges: removed, Grantable: to.Grantable}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grant returns the 'GRANT' statement of the given privileges of a permission.
+func (s *state) grant(p *schema.Permission, privs []string) (string, error) {
+	b, err := s.privileges(s.Build("GRANT"), p, privs)
+	if err != nil {
+		return "", err
+	}
+	g, _ := granteeOf(p.To)
+	b.P("TO").Ident(g.name)
+	if p.Grantable {
+		b.P("WITH GRANT OPTION")
+	}
+	return b.String(), nil
+}
+
+// revoke returns the 'REVOKE' statement of the given privileges of a permission.
+func (s *state) revoke(p *schema.Permission, privs []string) (string, error) {
+	b, err := s.privileges(s.Build("REVOKE"), p, privs)
+	if err != nil {
+		return "", err
+	}
+	g, _ := granteeOf(p.To)
+	return b.P("FROM").Ident(g.name).String(), nil
+}
+
+// privileges writes the privileges of a permission and the resource they are granted on.
+func (s *state) privileges(b *sqlx.Builder, p *schema.Permission, privs []string) (*sqlx.Builder, error) {
+	if _, ok := granteeOf(p.To); !ok {
+		return nil, fmt.Errorf("unexpected permission grantee: %T", p.To)
+	}
+	b.MapComma(privs, func(i int, b *sqlx.Builder) {
+		b.P(privs[i])
+		if len(p.Columns) > 0 {
+			b.Wrap(func(b *sqlx.Builder) {
+				b.MapComma(p.Columns, func(i int, b *sqlx.Builder) { b.Ident(p.Columns[i].Name) })
+			})
+		}
+	})
+	switch {
+	case p.Table != nil:
+		b.P("ON TABLE").Table(p.Table)
+	case p.Schema != nil:
+		b.P("ON SCHEMA").Ident(p.Schema.Name)
+	default:
+		return nil, errors.New("missing resource for permission")
+	}
+	return b, nil
+}
+
+// permissionResource returns a description of the permission resource.
+func permissionResource(p *schema.Permission) string {
+	switch {
+	case p.Table != nil && len(p.Columns) > 0:
+		return fmt.Sprintf("columns of table %q", p.Table.Name)
+	case p.Table != nil:
+		return fmt.Sprintf("table %q", p.Table.Name)
+	default:
+		return fmt.Sprintf("schema %q", p.Schema.Name)
+	}
+}
+
+// alterRowSecurity returns the 'ALTER TABLE' statement for migrating
+// the row-level security options of a table from one state to the other.
+func (s *state) alterRowSecurity(src schema.Change, t *schema.Table, from, to *RowSecurity) *migrate.Change {
+	return &migrate.Change{
+		Cmd:     rowSecurityActions(s.Build("ALTER TABLE").Table(t), from, to).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set row-level security options of table %q", t.Name),
+		Reverse: rowSecurityActions(s.Build("ALTER TABLE").Table(t), to, from).String(),
+	}
+}
+
+// rowSecurityActions writes the actions for migrating the
+// row-level security options of a table to the builder.
+func rowSecurityActions(b *sqlx.Builder, from, to *RowSecurity) *sqlx.Builder {
+	var actions []string
+	switch {
+	case from.Enabled == to.Enabled:
+	case to.Enabled:
+		actions = append(actions, "ENABLE ROW LEVEL SECURITY")
+	default:
+		actions = append(actions, "DISABLE ROW LEVEL SECURITY")
+	}
+	switch {
+	case from.Enforced == to.Enforced:
+	case to.Enforced:
+		actions = append(actions, "FORCE ROW LEVEL SECURITY")
+	default:
+		actions = append(actions, "NO FORCE ROW LEVEL SECURITY")
+	}
+	return b.P(strings.Join(actions, ", "))
+}
+
+// addPolicy builds and executes the query for creating a row-level security policy.
+func (s *state) addPolicy(add *schema.AddObject, p *Policy) {
+	s.append(&migrate.Change{
+		Cmd:     s.createPolicy(p),
+		Source:  add,
+		Comment: fmt.Sprintf("create policy %q on table %q", p.Name, p.Table.Name),
+		Reverse: s.Build("DROP POLICY").Ident(p.Name).P("ON").Table(p.Table).String(),
+	})
+	if c := (schema.Comment{}); sqlx.Has(p.Attrs, &c) && c.Text != "" {
+		s.append(s.policyComment(add, p, c.Text, ""))
+	}
+}
+
+// dropPolicy builds and executes the query for dropping a row-level security policy.
+func (s *state) dropPolicy(drop *schema.DropObject, p *Policy) {
+	b := s.Build("DROP POLICY")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(p.Name).P("ON").Table(p.Table).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop policy %q from table %q", p.Name, p.Table.Name),
+		Reverse: s.createPolicy(p),
+	})
+}
+
+// modifyPolicy builds the statements that bring the policy into its modified state.
+func (s *state) modifyPolicy(modify *schema.ModifyObject, from, to *Policy) error {
+	switch {
+	case policyRecreated(from, to):
+		drop := s.Build("DROP POLICY").Ident(from.Name).P("ON").Table(from.Table).String()
+		s.append(&migrate.Change{
+			Cmd:     drop,
+			Source:  modify,
+			Comment: fmt.Sprintf("drop policy %q from table %q", from.Name, from.Table.Name),
+			Reverse: s.createPolicy(from),
+		}, &migrate.Change{
+			Cmd:     s.createPolicy(to),
+			Source:  modify,
+			Comment: fmt.Sprintf("create policy %q on table %q", to.Name, to.Table.Name),
+			Reverse: drop,
+		})
+	case !slices.Equal(policyRoles(from), policyRoles(to)) || exprChanged(from.Using, to.Using) || exprChanged(from.Check, to.Check):
+		c := &migrate.Change{
+			Cmd:     s.alterPolicy(from, to),
+			Source:  modify,
+			Comment: fmt.Sprintf("modify policy %q on table %q", to.Name, to.Table.Name),
+		}
+		// Expressions cannot be removed using ALTER POLICY.
+		if !policyRecreated(to, from) {
+			c.Reverse = s.alterPolicy(to, from)
+		}
+		s.append(c)
+	}
+	if change := sqlx.CommentDiff(from.Attrs, to.Attrs); change != nil {
+		fromC, toC, err := commentChange(change)
+		if err != nil {
+			return err
+		}
+		s.append(s.policyComment(modify, to, toC, fromC))
+	}
+	return nil
+}
+
+// createPolicy returns the 'CREATE POLICY' statement of the given policy.
+func (s *state) createPolicy(p *Policy) string {
+	b := s.Build("CREATE POLICY").Ident(p.Name).P("ON").Table(p.Table)
+	if as := policyAs(p); as != PolicyAsPermissive {
+		b.P("AS", as)
+	}
+	if cmd := policyFor(p); cmd != PolicyForAll {
+		b.P("FOR", cmd)
+	}
+	if len(p.To) > 0 {
+		policyTo(b, p.To)
+	}
+	if p.Using != "" {
+		b.P("USING", sqlx.MayWrap(p.Using))
+	}
+	if p.Check != "" {
+		b.P("WITH CHECK", sqlx.MayWrap(p.Check))
+	}
+	return b.String()
+}
+
+// alterPolicy returns the 'ALTER POLICY' statement for migrating the roles and the
+// expressions of a policy from one state to the other. Note, expressions that were
+// removed cannot be migrated using 'ALTER POLICY', and are handled by the caller.
+func (s *state) alterPolicy(from, to *Policy) string {
+	b := s.Build("ALTER POLICY").Ident(to.Name).P("ON").Table(to.Table)
+	if roles := policyRoles(to); !slices.Equal(policyRoles(from), roles) {
+		policyTo(b, roles)
+	}
+	if to.Using != "" && exprChanged(from.Using, to.Using) {
+		b.P("USING", sqlx.MayWrap(to.Using))
+	}
+	if to.Check != "" && exprChanged(from.Check, to.Check) {
+		b.P("WITH CHECK", sqlx.MayWrap(to.Check))
+	}
+	return b.String()
+}
+
+// policyTo writes the 'TO' clause of a policy to the builder.
+func policyTo(b *sqlx.Builder, roles []string) {
+	b.P("TO").MapComma(roles, func(i int, b *sqlx.Builder) {
+		if roleKeyword(roles[i]) {
+			b.P(strings.ToUpper(roles[i]))
+		} else {
+			b.Ident(roles[i])
+		}
+	})
+}
+
+func (s *state) policyComment(src schema.Change, p *Policy, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON POLICY").Ident(p.Name).P("ON").Table(p.Table).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to policy: %q on table %q", p.Name, p.Table.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+// DependsOn reports if the given change depends on the other change.
+func (p *Policy) DependsOn(change, other schema.Change) bool {
+	switch change.(type) {
+	case *schema.AddObject, *schema.ModifyObject:
+		// Policies are created after their tables and the columns they use.
+		return tableChange(other, p.Table)
+	}
+	return false
+}
+
+// DependencyOf reports if the given change is a dependency of the other change.
+func (p *Policy) DependencyOf(change, other schema.Change) bool {
+	if _, ok := change.(*schema.DropObject); !ok {
+		return false
+	}
+	// Policies are dropped before their tables and the columns they use.
+	switch other := other.(type) {
+	case *schema.DropTable:
+		return sqlx.SameTable(other.T, p.Table)
+	case *schema.ModifyTable:
+		return sqlx.SameTable(other.T, p.Table)
+	}
+	return false
+}
+
+func (s *state) addComments(src schema.Change, t *schema.Table) {
+	var c schema.Comment
+	if sqlx.Has(t.Attrs, &c) && c.Text != "" {
+		s.append(s.tableComment(src, t, c.Text, ""))
+	}
+	for i := range t.Columns {
+		if sqlx.Has(t.Columns[i].Attrs, &c) && c.Text != "" {
+			s.append(s.columnComment(src, t, t.Columns[i], c.Text, ""))
+		}
+	}
+	for i := range t.Indexes {
+		if sqlx.Has(t.Indexes[i].Attrs, &c) && c.Text != "" {
+			s.append(s.indexComment(src, t, t.Indexes[i], c.Text, ""))
+		}
+	}
+}
+
+func (s *state) schemaComment(src schema.Change, sc *schema.Schema, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON SCHEMA").Ident(sc.Name).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to schema: %q", sc.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+func (s *state) tableComment(src schema.Change, t *schema.Table, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON TABLE").Table(t).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to table: %q", t.Name),
+		Reverse: b.Clone().P(quote(from)).String(),
+	}
+}
+
+func (s *state) viewComment(src schema.Change, v *schema.View, to, from string) *migrate.Change {
+	b := s.Build("COMMENT ON", viewKind(v)).View(v).P("IS")
+	return &migrate.Change{
+		Cmd:     b.Clone().P(quote(to)).String(),
+		Source:  src,
+		Comment: fmt.Sprintf("set comment to %s: %q", strings.ToLower(viewKind(v)), v.Name),
 		Reverse: b.Clone().P(quote(from)).String(),
 	}
 }
@@ -18,13 +18,14 @@ import (
 )
 
 func TestPlanChanges(t *testing.T) {
-	tests := []struct {
+	type testCase struct {
 		changes  []schema.Change
 		options  []migrate.PlanOption
 		mock     func(mock)
 		wantPlan *migrate.Plan
 		wantErr  bool
-	}{
+	}
+	tests := []testCase{
 		{
 			changes: []schema.Change{
 				&schema.AddSchema{S: schema.New("public").SetComment("public schema")},
@@ -1845,6 +1846,874 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Views are created after the tables they depend on.
+		func() testCase {
+			users := schema.NewTable("users").SetSchema(schema.New("public")).AddColumns(schema.NewIntColumn("id", "int"))
+			v1 := schema.NewView("active_users", "SELECT id FROM users WHERE active").
+				SetSchema(users.Schema).
+				SetCheckOption(schema.ViewCheckOptionCascaded).
+				SetComment("active users").
+				AddColumns(schema.NewIntColumn("id", "int")).
+				AddDeps(users)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddView{V: v1},
+					&schema.AddTable{T: users},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL)`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."active_users" AS SELECT id FROM users WHERE active WITH CASCADED CHECK OPTION`,
+							Reverse: `DROP VIEW "public"."active_users"`,
+						},
+						{
+							Cmd:     `COMMENT ON VIEW "public"."active_users" IS 'active users'`,
+							Reverse: `COMMENT ON VIEW "public"."active_users" IS ''`,
+						},
+					},
+				},
+			}
+		}(),
+		// Views are replaced, unless their columns were renamed or removed.
+		func() testCase {
+			from := schema.NewView("v1", " SELECT 1 AS a;").AddColumns(schema.NewIntColumn("a", "int"))
+			to := schema.NewView("v1", "SELECT 1 AS a, 2 AS b").AddColumns(schema.NewIntColumn("a", "int"), schema.NewIntColumn("b", "int"))
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyView{From: from, To: to},
+					&schema.ModifyView{From: to, To: from},
+					&schema.RenameView{From: schema.NewView("v2", ""), To: schema.NewView("v3", "")},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE OR REPLACE VIEW "v1" AS SELECT 1 AS a, 2 AS b`,
+							Reverse: `CREATE OR REPLACE VIEW "v1" AS SELECT 1 AS a`,
+						},
+						{
+							Cmd:     `DROP VIEW "v1"`,
+							Reverse: `CREATE VIEW "v1" AS SELECT 1 AS a, 2 AS b`,
+						},
+						{
+							Cmd:     `CREATE VIEW "v1" AS SELECT 1 AS a`,
+							Reverse: `DROP VIEW "v1"`,
+						},
+						{
+							Cmd:     `ALTER VIEW "v2" RENAME TO "v3"`,
+							Reverse: `ALTER VIEW "v3" RENAME TO "v2"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Views are recreated along with their dependent views in case the type of a column was changed.
+		func() testCase {
+			v1 := func(typ string) (*schema.Schema, *schema.View) {
+				public := schema.New("public")
+				v1 := schema.NewView("v1", "SELECT 1::"+typ+" AS a").
+					SetComment("numbers").
+					AddColumns(schema.NewIntColumn("a", typ))
+				v2 := schema.NewView("v2", "SELECT a FROM v1").AddDeps(v1)
+				public.AddViews(v1, v2)
+				return public, v1
+			}
+			s1, from := v1("int")
+			s2, to := v1("bigint")
+			to.SetComment("big numbers")
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyView{From: from, To: to, Changes: []schema.Change{
+						&schema.ModifyAttr{From: &schema.Comment{Text: "numbers"}, To: &schema.Comment{Text: "big numbers"}},
+					}},
+					&schema.ModifyView{From: s1.Views[1], To: s2.Views[1]},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `DROP VIEW "public"."v2"`,
+							Reverse: `CREATE VIEW "public"."v2" AS SELECT a FROM v1`,
+						},
+						{
+							Cmd:     `DROP VIEW "public"."v1"`,
+							Reverse: `CREATE VIEW "public"."v1" AS SELECT 1::int AS a`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."v1" AS SELECT 1::bigint AS a`,
+							Reverse: `DROP VIEW "public"."v1"`,
+						},
+						{
+							Cmd:     `COMMENT ON VIEW "public"."v1" IS 'big numbers'`,
+							Reverse: `COMMENT ON VIEW "public"."v1" IS ''`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."v2" AS SELECT a FROM v1`,
+							Reverse: `DROP VIEW "public"."v2"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Materialized views are created along with their indexes and options.
+		func() testCase {
+			public := schema.New("public")
+			from := schema.NewView("mv", "SELECT id FROM users").
+				SetSchema(public).
+				AddAttrs(&schema.Materialized{NoData: true}, &Tablespace{N: "fast"}).
+				SetComment("cached users").
+				AddColumns(schema.NewIntColumn("id", "int"))
+			from.AddIndexes(schema.NewUniqueIndex("mv_id").AddColumns(from.Columns[0]))
+			to := schema.NewMaterializedView("mv", "SELECT id FROM users").
+				SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int"))
+			idx := schema.NewIndex("mv_id_desc").AddParts(schema.NewColumnPart(to.Columns[0]).SetDesc(true))
+			to.AddIndexes(idx)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddView{V: from},
+					&schema.ModifyView{From: from, To: to, Changes: []schema.Change{&schema.AddIndex{I: idx}}},
+					&schema.RenameView{From: schema.NewMaterializedView("mv1", ""), To: schema.NewMaterializedView("mv2", "")},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE MATERIALIZED VIEW "public"."mv" TABLESPACE "fast" AS SELECT id FROM users WITH NO DATA`,
+							Reverse: `DROP MATERIALIZED VIEW "public"."mv"`,
+						},
+						{
+							Cmd:     `CREATE UNIQUE INDEX "mv_id" ON "public"."mv" ("id")`,
+							Reverse: `DROP INDEX "public"."mv_id"`,
+						},
+						{
+							Cmd:     `COMMENT ON MATERIALIZED VIEW "public"."mv" IS 'cached users'`,
+							Reverse: `COMMENT ON MATERIALIZED VIEW "public"."mv" IS ''`,
+						},
+						{
+							Cmd:     `CREATE INDEX "mv_id_desc" ON "public"."mv" ("id" DESC)`,
+							Reverse: `DROP INDEX "public"."mv_id_desc"`,
+						},
+						{
+							Cmd:     `ALTER MATERIALIZED VIEW "public"."mv" SET TABLESPACE "pg_default"`,
+							Reverse: `ALTER MATERIALIZED VIEW "public"."mv" SET TABLESPACE "fast"`,
+						},
+						{
+							Cmd:     `ALTER MATERIALIZED VIEW "mv1" RENAME TO "mv2"`,
+							Reverse: `ALTER MATERIALIZED VIEW "mv2" RENAME TO "mv1"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Definition changes of materialized views recreate them, along with their indexes and dependent views.
+		func() testCase {
+			mv := func(def string) (*schema.Schema, *schema.View) {
+				public := schema.New("public")
+				mv := schema.NewMaterializedView("mv", def).AddColumns(schema.NewIntColumn("a", "int"))
+				mv.AddIndexes(schema.NewIndex("mv_a").AddColumns(mv.Columns[0]))
+				v1 := schema.NewView("v1", "SELECT a FROM mv").AddDeps(mv)
+				v2 := schema.NewView("v2", "SELECT a FROM v1").AddDeps(v1)
+				public.AddViews(mv, v1, v2)
+				return public, mv
+			}
+			s1, from := mv("SELECT 1 AS a")
+			s2, to := mv("SELECT 2 AS a")
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyView{From: s1.Views[2], To: s2.Views[2]},
+					&schema.ModifyView{From: from, To: to},
+					&schema.DropView{V: s1.Views[1]},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `DROP VIEW "public"."v2"`,
+							Reverse: `CREATE VIEW "public"."v2" AS SELECT a FROM v1`,
+						},
+						{
+							Cmd:     `DROP VIEW "public"."v1"`,
+							Reverse: `CREATE VIEW "public"."v1" AS SELECT a FROM mv`,
+						},
+						{
+							Cmd:     `DROP MATERIALIZED VIEW "public"."mv"`,
+							Reverse: `CREATE MATERIALIZED VIEW "public"."mv" AS SELECT 1 AS a`,
+						},
+						{
+							Cmd:     `CREATE MATERIALIZED VIEW "public"."mv" AS SELECT 2 AS a`,
+							Reverse: `DROP MATERIALIZED VIEW "public"."mv"`,
+						},
+						{
+							Cmd:     `CREATE INDEX "mv_a" ON "public"."mv" ("a")`,
+							Reverse: `DROP INDEX "public"."mv_a"`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."v1" AS SELECT a FROM mv`,
+							Reverse: `DROP VIEW "public"."v1"`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."v2" AS SELECT a FROM v1`,
+							Reverse: `DROP VIEW "public"."v2"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Partitions are created using the PARTITION OF clause, and attached or detached from their parents.
+		func() testCase {
+			public := schema.New("public")
+			logs := schema.NewTable("logs").
+				SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("region", "text")).
+				AddAttrs(&Partition{T: PartitionTypeList, Parts: []*PartitionPart{{X: &schema.RawExpr{X: "(id % 10)"}}}})
+			eu := schema.NewTable("logs_eu").
+				SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("region", "text"))
+			eu.AddChecks(schema.NewCheck().SetName("eu").SetExpr("region = 'eu'")).
+				AddIndexes(schema.NewIndex("logs_eu_id").AddColumns(eu.Columns[0])).
+				AddAttrs(
+					&PartitionOf{T: logs, Values: "IN (1, 2)"},
+					&Partition{T: PartitionTypeHash, Parts: []*PartitionPart{{C: eu.Columns[0]}}},
+				)
+			def := schema.NewTable("logs_default").SetSchema(public).AddAttrs(&PartitionOf{T: logs, Default: true})
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTable{T: eu},
+					&schema.AddTable{T: def},
+					&schema.ModifyTable{
+						T: schema.NewTable("logs_us").SetSchema(public),
+						Changes: []schema.Change{
+							&schema.AddAttr{A: &PartitionOf{T: logs, Values: "IN (3)"}},
+						},
+					},
+					&schema.ModifyTable{
+						T: schema.NewTable("logs_asia").SetSchema(public),
+						Changes: []schema.Change{
+							&schema.ModifyAttr{From: &PartitionOf{T: logs, Values: "IN (4)"}, To: &PartitionOf{T: logs, Values: "IN (4, 5)"}},
+						},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE TABLE "public"."logs_eu" PARTITION OF "public"."logs" (CONSTRAINT "eu" CHECK (region = 'eu')) FOR VALUES IN (1, 2) PARTITION BY HASH ("id")`,
+							Reverse: `DROP TABLE "public"."logs_eu"`,
+						},
+						{
+							Cmd:     `CREATE INDEX "logs_eu_id" ON "public"."logs_eu" ("id")`,
+							Reverse: `DROP INDEX "public"."logs_eu_id"`,
+						},
+						{
+							Cmd:     `CREATE TABLE "public"."logs_default" PARTITION OF "public"."logs" DEFAULT`,
+							Reverse: `DROP TABLE "public"."logs_default"`,
+						},
+						{
+							Cmd:     `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_us" FOR VALUES IN (3)`,
+							Reverse: `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_us"`,
+						},
+						{
+							Cmd:     `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_asia"`,
+							Reverse: `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_asia" FOR VALUES IN (4)`,
+						},
+						{
+							Cmd:     `ALTER TABLE "public"."logs" ATTACH PARTITION "public"."logs_asia" FOR VALUES IN (4, 5)`,
+							Reverse: `ALTER TABLE "public"."logs" DETACH PARTITION "public"."logs_asia"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Functions are created before the tables and views that call them.
+		func() testCase {
+			public := schema.New("public")
+			f := schema.NewFunc("next_id", "SELECT 1").
+				SetSchema(public).
+				SetReturn(&schema.IntegerType{T: TypeInteger}).
+				SetLang(LangSQL).
+				SetVolatility(VolatilityStable).
+				SetComment("next id")
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int").SetDefault(&schema.RawExpr{X: "next_id()"}))
+			v1 := schema.NewView("ids", "SELECT public.next_id() AS id").SetSchema(public)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddView{V: v1},
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: f},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE FUNCTION "public"."next_id" () RETURNS integer LANGUAGE SQL STABLE AS $$SELECT 1$$`,
+							Reverse: `DROP FUNCTION "public"."next_id" ()`,
+						},
+						{
+							Cmd:     `COMMENT ON FUNCTION "public"."next_id" () IS 'next id'`,
+							Reverse: `COMMENT ON FUNCTION "public"."next_id" () IS ''`,
+						},
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL DEFAULT next_id())`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `CREATE VIEW "public"."ids" AS SELECT public.next_id() AS id`,
+							Reverse: `DROP VIEW "public"."ids"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Functions are replaced, unless their return type, argument types or names were changed.
+		func() testCase {
+			from := schema.NewFunc("add", "SELECT a + b").
+				AddArgs(
+					&schema.FuncArg{Name: "a", Type: &schema.IntegerType{T: TypeInteger}},
+					&schema.FuncArg{Name: "b", Type: &schema.IntegerType{T: TypeInteger}, Default: &schema.Literal{V: "1"}},
+				).
+				SetReturn(&schema.IntegerType{T: TypeInteger}).
+				SetLang(LangSQL)
+			body := schema.NewFunc("add", "SELECT a + b + 0").
+				AddArgs(from.Args...).
+				SetReturn(from.Ret).
+				SetLang(LangSQL)
+			ret := schema.NewFunc("add", "SELECT a + b").
+				AddArgs(from.Args...).
+				SetReturn(&schema.IntegerType{T: TypeBigInt}).
+				SetLang(LangSQL)
+			args := schema.NewFunc("add", "SELECT a + b").
+				AddArgs(
+					&schema.FuncArg{Name: "a", Type: &schema.IntegerType{T: TypeBigInt}},
+					from.Args[1],
+				).
+				SetReturn(from.Ret).
+				SetLang(LangSQL)
+			proc := schema.NewProc("$reset", "BEGIN PERFORM $$x$$; END").
+				AddArgs(&schema.FuncArg{Name: "n", Type: &schema.IntegerType{T: TypeInteger}, Mode: schema.FuncArgModeInOut}).
+				SetLang(LangPLpgSQL)
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyObject{From: from, To: body},
+					&schema.ModifyObject{From: from, To: ret},
+					&schema.ModifyObject{From: from, To: args},
+					&schema.DropObject{O: proc, Extra: []schema.Clause{&schema.IfExists{}}},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE OR REPLACE FUNCTION "add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b + 0$$`,
+							Reverse: `CREATE OR REPLACE FUNCTION "add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b$$`,
+						},
+						{
+							Cmd:     `DROP FUNCTION "add" (integer, integer)`,
+							Reverse: `CREATE FUNCTION "add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b$$`,
+						},
+						{
+							Cmd:     `CREATE FUNCTION "add" ("a" integer, "b" integer DEFAULT 1) RETURNS bigint LANGUAGE SQL AS $$SELECT a + b$$`,
+							Reverse: `DROP FUNCTION "add" (integer, integer)`,
+						},
+						{
+							Cmd:     `DROP FUNCTION "add" (integer, integer)`,
+							Reverse: `CREATE FUNCTION "add" ("a" integer, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b$$`,
+						},
+						{
+							Cmd:     `CREATE FUNCTION "add" ("a" bigint, "b" integer DEFAULT 1) RETURNS integer LANGUAGE SQL AS $$SELECT a + b$$`,
+							Reverse: `DROP FUNCTION "add" (bigint, integer)`,
+						},
+						{
+							Cmd:     `DROP PROCEDURE IF EXISTS "$reset" (integer)`,
+							Reverse: `CREATE PROCEDURE "$reset" (INOUT "n" integer) LANGUAGE PLpgSQL AS $fn$BEGIN PERFORM $$x$$; END$fn$`,
+						},
+					},
+				},
+			}
+		}(),
+		// Triggers are created after their tables and the functions they execute.
+		func() testCase {
+			public := schema.New("public")
+			f := schema.NewFunc("audit", "BEGIN RETURN NEW; END").
+				SetSchema(public).
+				SetReturn(&schema.UnsupportedType{T: "trigger"}).
+				SetLang(LangPLpgSQL)
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+			tr := schema.NewTrigger("users_audit").
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventInsert, schema.TriggerEventUpdateOf(users.Columns[1])).
+				SetFor(schema.TriggerForRow).
+				SetWhen("NEW.id > 0").
+				SetBody("EXECUTE FUNCTION audit()").
+				SetComment("audit users").
+				AddDeps(f)
+			users.AddTriggers(tr)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTrigger{T: tr},
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: f},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL, "name" text NOT NULL)`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `CREATE FUNCTION "public"."audit" () RETURNS trigger LANGUAGE PLpgSQL AS $$BEGIN RETURN NEW; END$$`,
+							Reverse: `DROP FUNCTION "public"."audit" ()`,
+						},
+						{
+							Cmd:     `CREATE TRIGGER "users_audit" BEFORE INSERT OR UPDATE OF "name" ON "public"."users" FOR EACH ROW WHEN (NEW.id > 0) EXECUTE FUNCTION audit()`,
+							Reverse: `DROP TRIGGER "users_audit" ON "public"."users"`,
+						},
+						{
+							Cmd:     `COMMENT ON TRIGGER "users_audit" ON "public"."users" IS 'audit users'`,
+							Reverse: `COMMENT ON TRIGGER "users_audit" ON "public"."users" IS ''`,
+						},
+					},
+				},
+			}
+		}(),
+		// Triggers are recreated on definition changes.
+		func() testCase {
+			users := schema.NewTable("users").
+				AddColumns(schema.NewIntColumn("id", "int"))
+			from := schema.NewTrigger("t").
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventDelete).
+				SetFor(schema.TriggerForStmt).
+				SetBody("EXECUTE FUNCTION audit()")
+			to := schema.NewTrigger("t").
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventDelete, schema.TriggerEventTruncate).
+				SetFor(schema.TriggerForStmt).
+				SetBody("EXECUTE FUNCTION audit()")
+			users.AddTriggers(from)
+			to.SetTable(users)
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyTrigger{From: from, To: to},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `DROP TRIGGER "t" ON "users"`,
+							Reverse: `CREATE TRIGGER "t" AFTER DELETE ON "users" FOR EACH STATEMENT EXECUTE FUNCTION audit()`,
+						},
+						{
+							Cmd:     `CREATE TRIGGER "t" AFTER DELETE OR TRUNCATE ON "users" FOR EACH STATEMENT EXECUTE FUNCTION audit()`,
+							Reverse: `DROP TRIGGER "t" ON "users"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Sequences are created before the tables that use them,
+		// and their owner is set after the owner table is created.
+		func() testCase {
+			public := schema.New("public")
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "integer").SetDefault(&schema.RawExpr{X: "nextval('users_id')"}))
+			seq := &Sequence{Name: "users_id", Schema: public, Type: &schema.IntegerType{T: TypeInteger}, Start: 100, Cache: 10, Attrs: []schema.Attr{&schema.Comment{Text: "user ids"}}}
+			seq.Owner.T, seq.Owner.C = users, users.Columns[0]
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: seq},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE SEQUENCE "public"."users_id" AS integer START WITH 100 CACHE 10`,
+							Reverse: `DROP SEQUENCE "public"."users_id"`,
+						},
+						{
+							Cmd:     `COMMENT ON SEQUENCE "public"."users_id" IS 'user ids'`,
+							Reverse: `COMMENT ON SEQUENCE "public"."users_id" IS ''`,
+						},
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL DEFAULT nextval('users_id'))`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `ALTER SEQUENCE "public"."users_id" OWNED BY "public"."users"."id"`,
+							Reverse: `ALTER SEQUENCE "public"."users_id" OWNED BY NONE`,
+						},
+					},
+				},
+			}
+		}(),
+		// Modify and drop sequences.
+		func() testCase {
+			maxV := int64(100)
+			from := &Sequence{Name: "s1", Start: 1, Increment: 1}
+			to := &Sequence{Name: "s1", Start: 1, Increment: 2, Max: &maxV, Cycle: true}
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyObject{From: from, To: to},
+					&schema.DropObject{O: &Sequence{Name: "s2", Increment: -1}, Extra: []schema.Clause{&schema.IfExists{}}},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `ALTER SEQUENCE "s1" INCREMENT BY 2 MAXVALUE 100 CYCLE`,
+							Reverse: `ALTER SEQUENCE "s1" INCREMENT BY 1 NO MAXVALUE NO CYCLE`,
+						},
+						{
+							Cmd:     `DROP SEQUENCE IF EXISTS "s2"`,
+							Reverse: `CREATE SEQUENCE "s2" INCREMENT BY -1`,
+						},
+					},
+				},
+			}
+		}(),
+		// Extensions are created first, and types are created
+		// after the types they are based on and before their tables.
+		func() testCase {
+			public := schema.New("public")
+			st := &schema.EnumType{T: "status", Schema: public, Values: []string{"on", "off"}}
+			code := &DomainType{T: "code", Schema: public, Type: &schema.StringType{T: TypeText}, Default: &schema.Literal{V: "x"}, Checks: []*schema.Check{{Name: "len", Expr: "length(VALUE) > 0"}}, Attrs: []schema.Attr{&schema.Comment{Text: "codes"}}}
+			state := &DomainType{T: "state", Schema: public, Type: st, Null: true}
+			pair := &CompositeType{T: "pair", Schema: public, Fields: []*schema.Column{
+				{Name: "c", Type: &schema.ColumnType{Type: code}},
+				{Name: "n", Type: &schema.ColumnType{Type: &schema.IntegerType{T: TypeInteger}}},
+			}}
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewColumn("p").SetType(pair), schema.NewColumn("s").SetType(state))
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: pair},
+					&schema.AddObject{O: state},
+					&schema.AddObject{O: code},
+					&schema.AddObject{O: st},
+					&schema.AddObject{O: &Extension{T: "citext", Schema: public, Version: "1.6"}, Extra: []schema.Clause{&schema.IfNotExists{}}},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE EXTENSION IF NOT EXISTS "citext" SCHEMA "public" VERSION '1.6'`,
+							Reverse: `DROP EXTENSION "citext"`,
+						},
+						{
+							Cmd:     `CREATE DOMAIN "public"."code" AS text DEFAULT 'x' NOT NULL CONSTRAINT "len" CHECK (length(VALUE) > 0)`,
+							Reverse: `DROP DOMAIN "public"."code"`,
+						},
+						{
+							Cmd:     `COMMENT ON DOMAIN "public"."code" IS 'codes'`,
+							Reverse: `COMMENT ON DOMAIN "public"."code" IS ''`,
+						},
+						{
+							Cmd:     `CREATE TYPE "public"."pair" AS ("c" "public"."code", "n" integer)`,
+							Reverse: `DROP TYPE "public"."pair"`,
+						},
+						{
+							Cmd:     `CREATE TYPE "public"."status" AS ENUM ('on', 'off')`,
+							Reverse: `DROP TYPE "public"."status"`,
+						},
+						{
+							Cmd:     `CREATE DOMAIN "public"."state" AS "public"."status"`,
+							Reverse: `DROP DOMAIN "public"."state"`,
+						},
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("p" "public"."pair" NOT NULL, "s" "public"."state" NOT NULL)`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Modify and drop domains, composite types and extensions.
+		func() testCase {
+			intT := &schema.IntegerType{T: TypeInteger}
+			from := &DomainType{T: "d", Type: intT, Default: &schema.Literal{V: "1"}, Checks: []*schema.Check{{Name: "positive", Expr: "(VALUE > 0)"}}}
+			to := &DomainType{T: "d", Type: intT, Null: true, Checks: []*schema.Check{{Name: "small", Expr: "VALUE < 10"}}}
+			c1 := &CompositeType{T: "c", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: intT}}, {Name: "b", Type: &schema.ColumnType{Type: intT}}}}
+			c2 := &CompositeType{T: "c", Fields: []*schema.Column{{Name: "a", Type: &schema.ColumnType{Type: &schema.IntegerType{T: TypeBigInt}}}, {Name: "c", Type: &schema.ColumnType{Type: intT}}}}
+			return testCase{
+				changes: []schema.Change{
+					&schema.DropObject{O: &Extension{T: "hstore"}},
+					&schema.ModifyObject{From: from, To: to},
+					&schema.ModifyObject{From: c1, To: c2},
+					&schema.ModifyObject{From: &Extension{T: "citext", Version: "1.5"}, To: &Extension{T: "citext", Version: "1.6", Attrs: []schema.Attr{&schema.Comment{Text: "ci"}}}},
+					&schema.DropObject{O: &DomainType{T: "old", Type: intT, Null: true}},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `ALTER DOMAIN "d" DROP DEFAULT`,
+							Reverse: `ALTER DOMAIN "d" SET DEFAULT 1`,
+						},
+						{
+							Cmd:     `ALTER DOMAIN "d" DROP NOT NULL`,
+							Reverse: `ALTER DOMAIN "d" SET NOT NULL`,
+						},
+						{
+							Cmd:     `ALTER DOMAIN "d" DROP CONSTRAINT "positive"`,
+							Reverse: `ALTER DOMAIN "d" ADD CONSTRAINT "positive" CHECK (VALUE > 0)`,
+						},
+						{
+							Cmd:     `ALTER DOMAIN "d" ADD CONSTRAINT "small" CHECK (VALUE < 10)`,
+							Reverse: `ALTER DOMAIN "d" DROP CONSTRAINT "small"`,
+						},
+						{
+							Cmd:     `ALTER TYPE "c" DROP ATTRIBUTE "b", ALTER ATTRIBUTE "a" TYPE bigint, ADD ATTRIBUTE "c" integer`,
+							Reverse: `ALTER TYPE "c" DROP ATTRIBUTE "c", ALTER ATTRIBUTE "a" TYPE integer, ADD ATTRIBUTE "b" integer`,
+						},
+						{
+							Cmd:     `ALTER EXTENSION "citext" UPDATE TO '1.6'`,
+							Reverse: `ALTER EXTENSION "citext" UPDATE TO '1.5'`,
+						},
+						{
+							Cmd:     `COMMENT ON EXTENSION "citext" IS 'ci'`,
+							Reverse: `COMMENT ON EXTENSION "citext" IS ''`,
+						},
+						{
+							Cmd:     `DROP DOMAIN "old"`,
+							Reverse: `CREATE DOMAIN "old" AS integer`,
+						},
+						{
+							Cmd:     `DROP EXTENSION "hstore"`,
+							Reverse: `CREATE EXTENSION "hstore"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Row-level security is enabled, and policies are created after their tables.
+		func() testCase {
+			public := schema.New("public")
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int")).
+				AddAttrs(&RowSecurity{Enabled: true, Enforced: true})
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddObject{O: &Policy{Name: "read", Table: users, As: PolicyAsRestrictive, For: PolicyForSelect, To: []string{"app", "current_user"}, Using: "id > 0", Attrs: []schema.Attr{&schema.Comment{Text: "readers"}}}},
+					&schema.AddObject{O: &Policy{Name: "write", Table: users, For: PolicyForInsert, Check: "(id > 0)"}},
+					&schema.AddTable{T: users},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL)`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `ALTER TABLE "public"."users" ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY`,
+							Reverse: `ALTER TABLE "public"."users" DISABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY`,
+						},
+						{
+							Cmd:     `CREATE POLICY "read" ON "public"."users" AS RESTRICTIVE FOR SELECT TO "app", CURRENT_USER USING (id > 0)`,
+							Reverse: `DROP POLICY "read" ON "public"."users"`,
+						},
+						{
+							Cmd:     `COMMENT ON POLICY "read" ON "public"."users" IS 'readers'`,
+							Reverse: `COMMENT ON POLICY "read" ON "public"."users" IS ''`,
+						},
+						{
+							Cmd:     `CREATE POLICY "write" ON "public"."users" FOR INSERT WITH CHECK (id > 0)`,
+							Reverse: `DROP POLICY "write" ON "public"."users"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Policies are altered in place if possible, recreated otherwise, and dropped before their tables.
+		func() testCase {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+			posts := schema.NewTable("posts").AddColumns(schema.NewIntColumn("id", "int"))
+			return testCase{
+				changes: []schema.Change{
+					&schema.DropTable{T: posts},
+					&schema.DropObject{O: &Policy{Name: "p", Table: posts}},
+					&schema.ModifyTable{T: users, Changes: []schema.Change{
+						&schema.ModifyAttr{From: &RowSecurity{Enabled: true, Enforced: true}, To: &RowSecurity{Enabled: true}},
+					}},
+					&schema.ModifyObject{
+						From: &Policy{Name: "p1", Table: users, Using: "(id > 0)"},
+						To:   &Policy{Name: "p1", Table: users, To: []string{"app"}, Using: "(id > 1)", Check: "(id > 1)"},
+					},
+					&schema.ModifyObject{
+						From: &Policy{Name: "p2", Table: users, Using: "(id > 0)"},
+						To:   &Policy{Name: "p2", Table: users, As: PolicyAsRestrictive, Using: "(id > 0)"},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `ALTER TABLE "users" NO FORCE ROW LEVEL SECURITY`,
+							Reverse: `ALTER TABLE "users" FORCE ROW LEVEL SECURITY`,
+						},
+						{
+							// Expressions cannot be removed using ALTER POLICY.
+							Cmd: `ALTER POLICY "p1" ON "users" TO "app" USING (id > 1) WITH CHECK (id > 1)`,
+						},
+						{
+							Cmd:     `DROP POLICY "p2" ON "users"`,
+							Reverse: `CREATE POLICY "p2" ON "users" USING (id > 0)`,
+						},
+						{
+							Cmd:     `CREATE POLICY "p2" ON "users" AS RESTRICTIVE USING (id > 0)`,
+							Reverse: `DROP POLICY "p2" ON "users"`,
+						},
+						{
+							Cmd:     `DROP POLICY "p" ON "posts"`,
+							Reverse: `CREATE POLICY "p" ON "posts"`,
+						},
+						{
+							Cmd:     `DROP TABLE "posts"`,
+							Reverse: `CREATE TABLE "posts" ("id" integer NOT NULL)`,
+						},
+					},
+				},
+			}
+		}(),
+		// Roles and users are created before the permissions granted to them,
+		// and permissions are granted after the resources they are granted on.
+		func() testCase {
+			public := schema.New("public")
+			users := schema.NewTable("users").SetSchema(public).
+				AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("name", "text"))
+			admin := &schema.Role{Name: "admin", Attrs: []schema.Attr{&RoleOptions{Superuser: true}, &ConnLimit{V: 5}}}
+			reader := &schema.Role{Name: "reader", MemberOf: []*schema.Role{admin}}
+			app := &schema.User{Name: "app", MemberOf: []*schema.Role{reader}, Attrs: []schema.Attr{&RoleOptions{NoInherit: true}}}
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddObject{O: &schema.Permission{To: app, Schema: public, Table: users, Columns: users.Columns[1:], Privileges: []string{"UPDATE"}}},
+					&schema.AddObject{O: &schema.Permission{To: reader, Schema: public, Table: users, Privileges: []string{"select", "INSERT"}, Grantable: true}},
+					&schema.AddObject{O: app},
+					&schema.AddTable{T: users},
+					&schema.AddObject{O: reader},
+					&schema.AddObject{O: admin},
+				},
+				wantPlan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `CREATE ROLE "admin" WITH SUPERUSER CONNECTION LIMIT 5`,
+							Reverse: `DROP ROLE "admin"`,
+						},
+						{
+							Cmd:     `CREATE ROLE "reader"`,
+							Reverse: `DROP ROLE "reader"`,
+						},
+						{
+							Cmd:     `GRANT "admin" TO "reader"`,
+							Reverse: `REVOKE "admin" FROM "reader"`,
+						},
+						{
+							Cmd:     `CREATE ROLE "app" WITH NOINHERIT LOGIN`,
+							Reverse: `DROP ROLE "app"`,
+						},
+						{
+							Cmd:     `GRANT "reader" TO "app"`,
+							Reverse: `REVOKE "reader" FROM "app"`,
+						},
+						{
+							Cmd:     `CREATE TABLE "public"."users" ("id" integer NOT NULL, "name" text NOT NULL)`,
+							Reverse: `DROP TABLE "public"."users"`,
+						},
+						{
+							Cmd:     `GRANT UPDATE ("name") ON TABLE "public"."users" TO "app"`,
+							Reverse: `REVOKE UPDATE ("name") ON TABLE "public"."users" FROM "app"`,
+						},
+						{
+							Cmd:     `GRANT INSERT, SELECT ON TABLE "public"."users" TO "reader" WITH GRANT OPTION`,
+							Reverse: `REVOKE INSERT, SELECT ON TABLE "public"."users" FROM "reader"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Privileges are revoked before their grantees are dropped.
+		func() testCase {
+			public := schema.New("public")
+			admin, reader := &schema.Role{Name: "admin"}, &schema.Role{Name: "reader"}
+			return testCase{
+				changes: []schema.Change{
+					&schema.DropObject{O: admin},
+					&schema.DropObject{O: &schema.Permission{To: admin, Schema: public, Privileges: []string{"ALL"}}},
+					&schema.ModifyObject{
+						From: &schema.Role{Name: "app", MemberOf: []*schema.Role{admin}},
+						To:   &schema.Role{Name: "app", MemberOf: []*schema.Role{reader}, Attrs: []schema.Attr{&RoleOptions{CreateDB: true}, &ConnLimit{V: 1}}},
+					},
+					&schema.ModifyObject{
+						From: &schema.Permission{To: reader, Schema: public, Privileges: []string{"ALL"}},
+						To:   &schema.Permission{To: reader, Schema: public, Privileges: []string{"USAGE"}},
+					},
+				},
+				wantPlan: &migrate.Plan{
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     `ALTER ROLE "app" WITH CREATEDB CONNECTION LIMIT 1`,
+							Reverse: `ALTER ROLE "app" WITH NOCREATEDB CONNECTION LIMIT -1`,
+						},
+						{
+							Cmd:     `GRANT "reader" TO "app"`,
+							Reverse: `REVOKE "reader" FROM "app"`,
+						},
+						{
+							Cmd:     `REVOKE "admin" FROM "app"`,
+							Reverse: `GRANT "admin" TO "app"`,
+						},
+						{
+							Cmd:     `REVOKE CREATE ON SCHEMA "public" FROM "reader"`,
+							Reverse: `GRANT CREATE ON SCHEMA "public" TO "reader"`,
+						},
+						{
+							Cmd:     `REVOKE ALL ON SCHEMA "public" FROM "admin"`,
+							Reverse: `GRANT ALL ON SCHEMA "public" TO "admin"`,
+						},
+						{
+							Cmd: `DROP ROLE "admin"`,
+						},
+					},
+				},
+			}
+		}(),
+		// Changing the underlying type of a domain is not supported.
+		{
+			changes: []schema.Change{
+				&schema.ModifyObject{
+					From: &DomainType{T: "d", Type: &schema.IntegerType{T: TypeInteger}},
+					To:   &DomainType{T: "d", Type: &schema.StringType{T: TypeText}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for i, tt := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
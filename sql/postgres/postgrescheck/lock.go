@@ -0,0 +1,419 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package postgrescheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+// LockAnalyzer checks for changes that take ACCESS EXCLUSIVE (or SHARE)
+// locks on existing tables, and may block reads or writes while executed.
+type LockAnalyzer struct {
+	sqlcheck.Options
+}
+
+// NewLockAnalyzer creates a new locking changes Analyzer with the given options.
+func NewLockAnalyzer(r *schemahcl.Resource) (*LockAnalyzer, error) {
+	az := &LockAnalyzer{}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing locking check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codeCreateIndex   = sqlcheck.Code("PG101")
+	codeDropIndex     = sqlcheck.Code("PG102")
+	codeConcurrentTx  = sqlcheck.Code("PG103")
+	codeAddForeignKey = sqlcheck.Code("PG104")
+	codeRewriteType   = sqlcheck.Code("PG105")
+	codeSetNotNull    = sqlcheck.Code("PG106")
+)
+
+var (
+	reCreateIndex  = regexp.MustCompile(`(?i)^\s*CREATE\s+(?:UNIQUE\s+)?INDEX\b`)
+	reDropIndex    = regexp.MustCompile(`(?i)^\s*DROP\s+INDEX\b`)
+	reConcurrently = regexp.MustCompile(`(?i)^\s*(?:CREATE\s+(?:UNIQUE\s+)?|DROP\s+)INDEX\s+CONCURRENTLY\b`)
+	reDetachConc   = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\b.+\bDETACH\s+PARTITION\b.+\bCONCURRENTLY\s*;?\s*$`)
+	reNotValid     = regexp.MustCompile(`(?i)\bNOT\s+VALID\b`)
+	reValidate     = regexp.MustCompile(`(?i)\bVALIDATE\s+CONSTRAINT\s+"?([^"\s;]+)"?`)
+	reNotNullCheck = regexp.MustCompile(`(?i)^\(*"?([^"\s()]+)"?\s+IS\s+NOT\s+NULL\)*$`)
+	reAlterTable   = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(?:IF\s+EXISTS\s+)?(?:ONLY\s+)?(?:"(?:[^"]|"")*"|[^\s"])+\s+`)
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*LockAnalyzer) Name() string {
+	return "locking"
+}
+
+// Analyze implements sqlcheck.Analyzer.
+func (a *LockAnalyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
+	var (
+		diags      []sqlcheck.Diagnostic
+		concurrent *migrate.Stmt
+		concText   string
+	)
+	for _, sc := range p.File.Changes {
+		switch {
+		case concurrent != nil:
+		case reConcurrently.MatchString(sc.Stmt.Text):
+			concurrent, concText = sc.Stmt, "Indexes cannot be created or deleted concurrently within a transaction"
+		case reDetachConc.MatchString(sc.Stmt.Text):
+			concurrent, concText = sc.Stmt, "Partitions cannot be detached concurrently within a transaction"
+		}
+		for _, c := range sc.Changes {
+			m, ok := c.(*schema.ModifyTable)
+			if !ok || p.File.TableSpan(m.T)&sqlcheck.SpanAdded != 0 {
+				continue
+			}
+			for _, mc := range m.Changes {
+				var d *sqlcheck.Diagnostic
+				switch mc := mc.(type) {
+				case *schema.AddIndex:
+					d = a.createIndex(p, sc.Stmt, m, mc)
+				case *schema.DropIndex:
+					d = a.dropIndex(p, sc.Stmt, m, mc)
+				case *schema.AddForeignKey:
+					d = a.addForeignKey(p, sc.Stmt, m, mc)
+				case *schema.ModifyColumn:
+					switch {
+					case p.File.ColumnSpan(m.T, mc.From)&sqlcheck.SpanAdded != 0:
+					case mc.Change.Is(schema.ChangeType) && rewriteType(mc.From.Type.Type, mc.To.Type.Type):
+						d = a.alterType(p, sc.Stmt, m, mc)
+					case mc.Change.Is(schema.ChangeNull) && mc.From.Type.Null && !mc.To.Type.Null && !hasNotNullCheck(p, sc.Stmt, m.T, mc.To):
+						d = a.setNotNull(p, sc.Stmt, m, mc)
+					}
+				}
+				if d != nil {
+					diags = append(diags, *d)
+				}
+			}
+		}
+	}
+	if concurrent != nil && !txModeNone(p.File) {
+		d := sqlcheck.Diagnostic{
+			Code: codeConcurrentTx,
+			Pos:  concurrent.Pos,
+			Text: concText,
+		}
+		d.SuggestFix("Add the `atlas:txmode none` directive to the header to prevent this file from running in a transaction", directiveEdit(p.File))
+		diags = append(diags, d)
+	}
+	if len(diags) > 0 {
+		const reportText = "table locking changes detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+func (*LockAnalyzer) createIndex(p *sqlcheck.Pass, s *migrate.Stmt, m *schema.ModifyTable, c *schema.AddIndex) *sqlcheck.Diagnostic {
+	// Indexes created with ADD CONSTRAINT (e.g., UNIQUE) cannot be created concurrently.
+	if p.File.IndexSpan(m.T, c.I) == sqlcheck.SpanTemporary || !reCreateIndex.MatchString(s.Text) ||
+		reConcurrently.MatchString(s.Text) || sqlx.Has(c.Extra, &postgres.Concurrently{}) {
+		return nil
+	}
+	d := &sqlcheck.Diagnostic{
+		Code: codeCreateIndex,
+		Pos:  s.Pos,
+		Text: fmt.Sprintf("Creating index %q non-concurrently causes write locks on the %q table", c.I.Name, m.T.Name),
+	}
+	loc := reCreateIndex.FindStringIndex(s.Text)
+	d.SuggestFix(concurrentMsg("Create the index concurrently using the CONCURRENTLY option", p.File), concurrentEdit(p.File, s, s.Text[:loc[1]]+" CONCURRENTLY"+s.Text[loc[1]:]))
+	return d
+}
+
+func (*LockAnalyzer) dropIndex(p *sqlcheck.Pass, s *migrate.Stmt, m *schema.ModifyTable, c *schema.DropIndex) *sqlcheck.Diagnostic {
+	if p.File.IndexSpan(m.T, c.I) == sqlcheck.SpanTemporary || !reDropIndex.MatchString(s.Text) ||
+		reConcurrently.MatchString(s.Text) || sqlx.Has(c.Extra, &postgres.Concurrently{}) {
+		return nil
+	}
+	d := &sqlcheck.Diagnostic{
+		Code: codeDropIndex,
+		Pos:  s.Pos,
+		Text: fmt.Sprintf("Dropping index %q non-concurrently causes write locks on the %q table", c.I.Name, m.T.Name),
+	}
+	loc := reDropIndex.FindStringIndex(s.Text)
+	d.SuggestFix(concurrentMsg("Drop the index concurrently using the CONCURRENTLY option", p.File), concurrentEdit(p.File, s, s.Text[:loc[1]]+" CONCURRENTLY"+s.Text[loc[1]:]))
+	return d
+}
+
+func (*LockAnalyzer) addForeignKey(p *sqlcheck.Pass, s *migrate.Stmt, m *schema.ModifyTable, c *schema.AddForeignKey) *sqlcheck.Diagnostic {
+	if reNotValid.MatchString(s.Text) || sqlx.Has(c.Extra, &postgres.NotValid{}) {
+		return nil
+	}
+	d := &sqlcheck.Diagnostic{
+		Code: codeAddForeignKey,
+		Pos:  s.Pos,
+		Text: fmt.Sprintf("Adding foreign key %q on table %q validates all existing rows while locking both tables", c.F.Symbol, m.T.Name),
+	}
+	// Validating the constraint in the same transaction holds the locks
+	// taken by ADD CONSTRAINT during the validation. Hence, the VALIDATE
+	// statement is not part of the fix and is left for the user to add.
+	validate := "VALIDATE CONSTRAINT"
+	if c.F.Symbol != "" {
+		validate = strconv.Quote(builder().P("ALTER TABLE").Table(m.T).P("VALIDATE CONSTRAINT").Ident(c.F.Symbol).String())
+	}
+	var edit *sqlcheck.TextEdit
+	// The NOT VALID clause is appended to the ADD CONSTRAINT clause,
+	// which is split from the rest of the statement, if needed.
+	if c.F.Symbol != "" {
+		re := regexp.MustCompile(`(?is)^ADD\s+CONSTRAINT\s+"?` + regexp.QuoteMeta(c.F.Symbol) + `"?\s+FOREIGN\s+KEY\b`)
+		if alter, rest, clause, ok := splitClause(s, re); ok {
+			edit = p.File.StmtTextEdit(s, joinStmts(rest, alter+clause+" NOT VALID"))
+		}
+	}
+	d.SuggestFix(fmt.Sprintf("Add the foreign key as NOT VALID, and run %s in a separate migration file or in a file with the `atlas:txmode none` directive", validate), edit)
+	return d
+}
+
+func (*LockAnalyzer) alterType(p *sqlcheck.Pass, s *migrate.Stmt, m *schema.ModifyTable, c *schema.ModifyColumn) *sqlcheck.Diagnostic {
+	from, err := postgres.FormatType(c.From.Type.Type)
+	if err != nil {
+		return nil
+	}
+	to, err := postgres.FormatType(c.To.Type.Type)
+	if err != nil {
+		return nil
+	}
+	d := &sqlcheck.Diagnostic{
+		Code: codeRewriteType,
+		Pos:  s.Pos,
+		Text: fmt.Sprintf("Changing the type of column %q from %q to %q rewrites the %q table while holding an ACCESS EXCLUSIVE lock", c.To.Name, from, to, m.T.Name),
+	}
+	// The fix is message-only, as swapping the column with a backfilled
+	// one requires carrying over its constraints, indexes and dependents.
+	d.SuggestFix(fmt.Sprintf(
+		"Add a new %q column instead, backfill it from column %q in batches, and swap the two columns in a separate migration file",
+		to, c.To.Name,
+	), nil)
+	return d
+}
+
+func (*LockAnalyzer) setNotNull(p *sqlcheck.Pass, s *migrate.Stmt, m *schema.ModifyTable, c *schema.ModifyColumn) *sqlcheck.Diagnostic {
+	d := &sqlcheck.Diagnostic{
+		Code: codeSetNotNull,
+		Pos:  s.Pos,
+		Text: fmt.Sprintf("Setting column %q to NOT NULL scans the %q table while holding an ACCESS EXCLUSIVE lock", c.To.Name, m.T.Name),
+	}
+	var (
+		edit *sqlcheck.TextEdit
+		name = fmt.Sprintf("%s_%s_not_null", m.T.Name, c.To.Name)
+		re   = regexp.MustCompile(`(?is)^ALTER\s+(?:COLUMN\s+)?"?` + regexp.QuoteMeta(c.To.Name) + `"?\s+SET\s+NOT\s+NULL$`)
+	)
+	// Like foreign keys, validating the check constraint and setting the column to
+	// NOT NULL in the same transaction holds the lock taken by ADD CONSTRAINT. Hence,
+	// the statement is replaced with a NOT VALID check, and the rest is left for the user.
+	if alter, rest, _, ok := splitClause(s, re); ok {
+		edit = p.File.StmtTextEdit(s, joinStmts(rest, alter+builder().P("ADD CONSTRAINT").Ident(name).P("CHECK").Wrap(func(b *sqlx.Builder) {
+			b.Ident(c.To.Name).P("IS NOT NULL")
+		}).P("NOT VALID").String()))
+	}
+	validate := builder().P("ALTER TABLE").Table(m.T).P("VALIDATE CONSTRAINT").Ident(name).String()
+	notNull := builder().P("ALTER TABLE").Table(m.T).P("ALTER COLUMN").Ident(c.To.Name).P("SET NOT NULL").String()
+	d.SuggestFix(fmt.Sprintf(
+		"Add a NOT VALID check constraint instead, and run %s and %s in a separate migration file or in a file with the `atlas:txmode none` directive",
+		strconv.Quote(validate), strconv.Quote(notNull),
+	), edit)
+	return d
+}
+
+// rewriteType reports if changing the column type from one to another
+// requires rewriting the table (and its indexes) by PostgreSQL.
+func rewriteType(from, to schema.Type) bool {
+	switch from := from.(type) {
+	case *schema.StringType:
+		to, ok := to.(*schema.StringType)
+		if !ok {
+			return true
+		}
+		switch {
+		// Removing the length limit or changing to TEXT.
+		case isVarChar(from.T) && (to.T == postgres.TypeText || isVarChar(to.T) && to.Size == 0):
+			return false
+		// Increasing the length limit.
+		case isVarChar(from.T) && isVarChar(to.T):
+			return from.Size == 0 || to.Size < from.Size
+		case from.T == postgres.TypeText:
+			return !isVarChar(to.T) || to.Size != 0
+		}
+	case *schema.DecimalType:
+		to, ok := to.(*schema.DecimalType)
+		if !ok {
+			return true
+		}
+		// Unconstrained numeric, or increasing the precision without changing the scale.
+		return to.Precision != 0 && (from.Precision == 0 || to.Precision < from.Precision || to.Scale != from.Scale)
+	case *postgres.NetworkType:
+		to, ok := to.(*postgres.NetworkType)
+		return !ok || from.T != postgres.TypeCIDR || to.T != postgres.TypeInet
+	}
+	f1, err1 := postgres.FormatType(from)
+	f2, err2 := postgres.FormatType(to)
+	return err1 != nil || err2 != nil || f1 != f2
+}
+
+func isVarChar(t string) bool {
+	return t == postgres.TypeVarChar || t == postgres.TypeCharVar
+}
+
+// hasNotNullCheck reports if the table has a validated CHECK constraint
+// in the form of "c IS NOT NULL" that allows PostgreSQL to skip the table scan.
+func hasNotNullCheck(p *sqlcheck.Pass, s *migrate.Stmt, t *schema.Table, c *schema.Column) bool {
+	for _, a := range t.Attrs {
+		ck, ok := a.(*schema.Check)
+		if !ok {
+			continue
+		}
+		if m := reNotNullCheck.FindStringSubmatch(strings.TrimSpace(ck.Expr)); len(m) != 2 || m[1] != c.Name {
+			continue
+		}
+		if !notValidCheck(p, t, ck) || validatedBefore(p, s, ck.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// notValidCheck reports if the check constraint was added as NOT VALID in this file.
+func notValidCheck(p *sqlcheck.Pass, t *schema.Table, ck *schema.Check) bool {
+	for _, sc := range p.File.Changes {
+		for _, c := range sc.Changes {
+			m, ok := c.(*schema.ModifyTable)
+			if !ok || m.T.Name != t.Name {
+				continue
+			}
+			for _, mc := range m.Changes {
+				if add, ok := mc.(*schema.AddCheck); ok && add.C.Name == ck.Name {
+					return sqlx.Has(add.Extra, &postgres.NotValid{}) || reNotValid.MatchString(sc.Stmt.Text)
+				}
+			}
+		}
+	}
+	return false
+}
+
+// validatedBefore reports if the constraint was validated before the given statement.
+func validatedBefore(p *sqlcheck.Pass, s *migrate.Stmt, name string) bool {
+	for _, sc := range p.File.Changes {
+		if sc.Stmt.Pos >= s.Pos {
+			break
+		}
+		if m := reValidate.FindStringSubmatch(sc.Stmt.Text); len(m) == 2 && m[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// txModeNone reports if the file is configured to run without a transaction.
+func txModeNone(f *sqlcheck.File) bool {
+	d, ok := f.File.(interface{ Directive(string) []string })
+	if !ok {
+		return false
+	}
+	ds := d.Directive("txmode")
+	return len(ds) == 1 && ds[0] == "none"
+}
+
+// directiveEdit returns a TextEdit that adds the txmode directive to the file header.
+func directiveEdit(f *sqlcheck.File) *sqlcheck.TextEdit {
+	first, _, _ := strings.Cut(string(f.Bytes()), "\n")
+	return &sqlcheck.TextEdit{Line: 1, End: 1, NewText: directive(first) + first}
+}
+
+// directive returns the txmode directive to be added before the first line of the file.
+func directive(first string) string {
+	text := "-- atlas:txmode none\n"
+	// Directives are separated from the file content by an empty line.
+	if !strings.HasPrefix(first, "-- atlas:") {
+		text += "\n"
+	}
+	return text
+}
+
+// concurrentEdit returns a TextEdit that replaces the given statement with its
+// concurrent form. Since concurrent index changes cannot run in a transaction,
+// the edit also adds the txmode directive to the file header, if it is missing.
+func concurrentEdit(f *sqlcheck.File, s *migrate.Stmt, text string) *sqlcheck.TextEdit {
+	e := f.StmtTextEdit(s, text)
+	if e == nil || txModeNone(f) {
+		return e
+	}
+	// Both edits are merged into one, as they are not valid without each other.
+	lines := strings.SplitAfter(string(f.Bytes()), "\n")
+	return &sqlcheck.TextEdit{
+		Line:    1,
+		End:     e.End,
+		NewText: directive(strings.TrimSuffix(lines[0], "\n")) + strings.Join(lines[:e.Line-1], "") + e.NewText,
+	}
+}
+
+// concurrentMsg returns the message of a concurrent index fix for the given file.
+func concurrentMsg(msg string, f *sqlcheck.File) string {
+	if !txModeNone(f) {
+		msg += ", and add the `atlas:txmode none` directive to the header to prevent this file from running in a transaction"
+	}
+	return msg
+}
+
+// splitClause splits the given ALTER TABLE statement into its "ALTER TABLE <name> " part, the
+// clause that matches the given pattern, and the statement without this clause. The rest of
+// the statement is empty, in case the matched clause is the only one in the statement.
+func splitClause(s *migrate.Stmt, re *regexp.Regexp) (alter, rest, clause string, ok bool) {
+	text := strings.TrimSuffix(strings.TrimSpace(s.Text), ";")
+	loc := reAlterTable.FindStringIndex(text)
+	if loc == nil {
+		return "", "", "", false
+	}
+	alter = text[:loc[1]]
+	clauses := sqlx.SplitExprs(text[loc[1]:])
+	i := slices.IndexFunc(clauses, re.MatchString)
+	if i == -1 {
+		return "", "", "", false
+	}
+	clause = clauses[i]
+	if clauses = slices.Delete(clauses, i, i+1); len(clauses) > 0 {
+		rest = alter + strings.Join(clauses, ", ")
+	}
+	return alter, rest, clause, true
+}
+
+// joinStmts joins the given statements, skipping empty ones.
+func joinStmts(stmts ...string) string {
+	var b strings.Builder
+	for _, s := range stmts {
+		if s == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(s + ";")
+	}
+	return b.String()
+}
+
+func builder() *sqlx.Builder {
+	return &sqlx.Builder{QuoteOpening: '"', QuoteClosing: '"', Schema: sqlx.P("")}
+}
@@ -8,12 +8,17 @@ import (
 	"fmt"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/dataloss"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
+	"ariga.io/atlas/sql/sqlcheck/naming"
 )
 
 func addNotNull(p *datadepend.ColumnPass) (diags []sqlcheck.Diagnostic, err error) {
@@ -47,13 +52,45 @@ func analyzers(r *schemahcl.Resource) ([]sqlcheck.Analyzer, error) {
 	if err != nil {
 		return nil, err
 	}
+	dl, err := dataloss.New(r, dataloss.Handler{
+		Length: "char_length",
+	})
+	if err != nil {
+		return nil, err
+	}
 	bc, err := incompatible.New(r)
 	if err != nil {
 		return nil, err
 	}
-	return []sqlcheck.Analyzer{ds, dd, cd, bc}, nil
+	lk, err := NewLockAnalyzer(r)
+	if err != nil {
+		return nil, err
+	}
+	rls, err := NewRLSAnalyzer(r)
+	if err != nil {
+		return nil, err
+	}
+	// Identifiers longer than NAMEDATALEN-1 bytes are truncated by PostgreSQL.
+	nm, err := naming.New(r, 63)
+	if err != nil {
+		return nil, err
+	}
+	return []sqlcheck.Analyzer{ds, dd, dl, cd, bc, lk, rls, nm}, nil
+}
+
+func realmAnalyzers(r *schemahcl.Resource) ([]sqlcheck.RealmAnalyzer, error) {
+	sd, err := design.New(r, design.Handler{
+		Partial: func(idx *schema.Index) bool {
+			return sqlx.Has(idx.Attrs, &postgres.IndexPredicate{})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []sqlcheck.RealmAnalyzer{sd}, nil
 }
 
 func init() {
 	sqlcheck.Register(postgres.DriverName, analyzers)
+	sqlcheck.RegisterRealm(postgres.DriverName, realmAnalyzers)
 }
@@ -10,7 +10,7 @@ import (
 
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/postgres"
-	_ "ariga.io/atlas/sql/postgres/postgrescheck"
+	"ariga.io/atlas/sql/postgres/postgrescheck"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlcheck"
 
@@ -55,11 +55,351 @@ func TestDataDepend_MightFail(t *testing.T) {
 	require.Equal(t, report.Diagnostics[0].Text, `Adding a non-nullable "int" column "b" will fail in case table "users" is not empty`)
 }
 
+func TestLockAnalyzer(t *testing.T) {
+	const content = `CREATE INDEX "idx" ON "users" ("a");
+ALTER TABLE "users" ADD CONSTRAINT "fk" FOREIGN KEY ("b") REFERENCES "users" ("a");
+ALTER TABLE "users" ALTER COLUMN "a" TYPE bigint;
+ALTER TABLE "users" ALTER COLUMN "b" SET NOT NULL;
+DROP INDEX CONCURRENTLY "idx2";
+`
+	var (
+		report *sqlcheck.Report
+		users  = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(
+				schema.NewIntColumn("a", postgres.TypeInt),
+				schema.NewNullIntColumn("b", postgres.TypeInt),
+			)
+		stmts, _ = migrate.Stmts(content)
+		pass     = &sqlcheck.Pass{
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql", bytes: []byte(content)},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: stmts[0],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.AddIndex{I: schema.NewIndex("idx")}}},
+						},
+					},
+					{
+						Stmt: stmts[1],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.AddForeignKey{F: schema.NewForeignKey("fk")}}},
+						},
+					},
+					{
+						Stmt: stmts[2],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{
+								&schema.ModifyColumn{
+									From:   schema.NewIntColumn("a", postgres.TypeInt),
+									To:     schema.NewIntColumn("a", postgres.TypeBigInt),
+									Change: schema.ChangeType,
+								},
+							}},
+						},
+					},
+					{
+						Stmt: stmts[3],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{
+								&schema.ModifyColumn{
+									From:   schema.NewNullIntColumn("b", postgres.TypeInt),
+									To:     schema.NewIntColumn("b", postgres.TypeInt),
+									Change: schema.ChangeNull,
+								},
+							}},
+						},
+					},
+					{
+						Stmt: stmts[4],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.DropIndex{I: schema.NewIndex("idx2")}}},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := postgrescheck.NewLockAnalyzer(nil)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.NotNil(t, report)
+	require.Equal(t, "table locking changes detected", report.Text)
+	require.Len(t, report.Diagnostics, 5)
+
+	d := report.Diagnostics[0]
+	require.Equal(t, "PG101", d.Code)
+	require.Equal(t, `Creating index "idx" non-concurrently causes write locks on the "users" table`, d.Text)
+	require.Equal(t, &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "-- atlas:txmode none\n\n" + `CREATE INDEX CONCURRENTLY "idx" ON "users" ("a");`}, d.SuggestedFixes[0].TextEdit)
+	require.Contains(t, d.SuggestedFixes[0].Message, "add the `atlas:txmode none` directive")
+
+	d = report.Diagnostics[1]
+	require.Equal(t, "PG104", d.Code)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line:    2,
+		End:     2,
+		NewText: "ALTER TABLE \"users\" ADD CONSTRAINT \"fk\" FOREIGN KEY (\"b\") REFERENCES \"users\" (\"a\") NOT VALID;",
+	}, d.SuggestedFixes[0].TextEdit)
+	require.Contains(t, d.SuggestedFixes[0].Message, `run "ALTER TABLE \"users\" VALIDATE CONSTRAINT \"fk\"" in a separate migration file`)
+
+	d = report.Diagnostics[2]
+	require.Equal(t, "PG105", d.Code)
+	require.Equal(t, `Changing the type of column "a" from "integer" to "bigint" rewrites the "users" table while holding an ACCESS EXCLUSIVE lock`, d.Text)
+	require.Len(t, d.SuggestedFixes, 1)
+	require.Nil(t, d.SuggestedFixes[0].TextEdit)
+	require.Contains(t, d.SuggestedFixes[0].Message, `Add a new "bigint" column instead, backfill it from column "a"`)
+
+	d = report.Diagnostics[3]
+	require.Equal(t, "PG106", d.Code)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line:    4,
+		End:     4,
+		NewText: `ALTER TABLE "users" ADD CONSTRAINT "users_b_not_null" CHECK ("b" IS NOT NULL) NOT VALID;`,
+	}, d.SuggestedFixes[0].TextEdit)
+	require.Contains(t, d.SuggestedFixes[0].Message, `run "ALTER TABLE \"users\" VALIDATE CONSTRAINT \"users_b_not_null\"" and "ALTER TABLE \"users\" ALTER COLUMN \"b\" SET NOT NULL" in a separate migration file`)
+
+	d = report.Diagnostics[4]
+	require.Equal(t, "PG103", d.Code)
+	require.Equal(t, stmts[4].Pos, d.Pos)
+	require.Equal(t, &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "-- atlas:txmode none\n\n" + `CREATE INDEX "idx" ON "users" ("a");`}, d.SuggestedFixes[0].TextEdit)
+
+	// Validated NOT NULL check and a file that runs without a transaction.
+	users.AddChecks(schema.NewCheck().SetName("users_b_not_null").SetExpr(`("b" IS NOT NULL)`))
+	pass.File.File = testFile{name: "1.sql", bytes: []byte("-- atlas:txmode none\n\n" + content)}
+	pass.File.Changes = pass.File.Changes[3:]
+	report = nil
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Nil(t, report)
+}
+
+func TestLockAnalyzer_SplitStmts(t *testing.T) {
+	const content = `ALTER TABLE "users" ADD COLUMN "c" integer NULL, ADD CONSTRAINT "fk" FOREIGN KEY ("c") REFERENCES "users" ("a");
+ALTER TABLE "users" ALTER COLUMN "b" SET NOT NULL, ALTER COLUMN "a" SET DEFAULT 1;
+DROP INDEX "idx";
+`
+	var (
+		report *sqlcheck.Report
+		users  = schema.NewTable("users").
+			SetSchema(schema.New("public")).
+			AddColumns(
+				schema.NewIntColumn("a", postgres.TypeInt),
+				schema.NewNullIntColumn("b", postgres.TypeInt),
+			)
+		stmts, _ = migrate.Stmts(content)
+		pass     = &sqlcheck.Pass{
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql", bytes: []byte(content)},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: stmts[0],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{
+								&schema.AddColumn{C: schema.NewNullIntColumn("c", postgres.TypeInt)},
+								&schema.AddForeignKey{F: schema.NewForeignKey("fk")},
+							}},
+						},
+					},
+					{
+						Stmt: stmts[1],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{
+								&schema.ModifyColumn{
+									From:   schema.NewNullIntColumn("b", postgres.TypeInt),
+									To:     schema.NewIntColumn("b", postgres.TypeInt),
+									Change: schema.ChangeNull,
+								},
+								&schema.ModifyColumn{
+									From:   schema.NewIntColumn("a", postgres.TypeInt),
+									To:     schema.NewIntColumn("a", postgres.TypeInt).SetDefault(&schema.Literal{V: "1"}),
+									Change: schema.ChangeDefault,
+								},
+							}},
+						},
+					},
+					{
+						Stmt: stmts[2],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: users, Changes: schema.Changes{&schema.DropIndex{I: schema.NewIndex("idx")}}},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := postgrescheck.NewLockAnalyzer(nil)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.NotNil(t, report)
+	require.Len(t, report.Diagnostics, 3)
+
+	d := report.Diagnostics[0]
+	require.Equal(t, "PG104", d.Code)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line: 1,
+		End:  1,
+		NewText: `ALTER TABLE "users" ADD COLUMN "c" integer NULL;
+ALTER TABLE "users" ADD CONSTRAINT "fk" FOREIGN KEY ("c") REFERENCES "users" ("a") NOT VALID;`,
+	}, d.SuggestedFixes[0].TextEdit)
+
+	d = report.Diagnostics[1]
+	require.Equal(t, "PG106", d.Code)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line: 2,
+		End:  2,
+		NewText: `ALTER TABLE "users" ALTER COLUMN "a" SET DEFAULT 1;
+ALTER TABLE "users" ADD CONSTRAINT "users_b_not_null" CHECK ("b" IS NOT NULL) NOT VALID;`,
+	}, d.SuggestedFixes[0].TextEdit)
+
+	// The txmode directive is added along with the CONCURRENTLY option.
+	d = report.Diagnostics[2]
+	require.Equal(t, "PG102", d.Code)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line: 1,
+		End:  3,
+		NewText: `-- atlas:txmode none
+
+ALTER TABLE "users" ADD COLUMN "c" integer NULL, ADD CONSTRAINT "fk" FOREIGN KEY ("c") REFERENCES "users" ("a");
+ALTER TABLE "users" ALTER COLUMN "b" SET NOT NULL, ALTER COLUMN "a" SET DEFAULT 1;
+DROP INDEX CONCURRENTLY "idx";`,
+	}, d.SuggestedFixes[0].TextEdit)
+}
+
+func TestLockAnalyzer_DetachConcurrently(t *testing.T) {
+	const content = `ALTER TABLE "logs" DETACH PARTITION "logs_1" CONCURRENTLY;
+`
+	var (
+		report   *sqlcheck.Report
+		logs     = schema.NewTable("logs").SetSchema(schema.New("public"))
+		stmts, _ = migrate.Stmts(content)
+		pass     = &sqlcheck.Pass{
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql", bytes: []byte(content)},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: stmts[0],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: schema.NewTable("logs_1").SetSchema(logs.Schema), Changes: schema.Changes{
+								&schema.DropAttr{A: &postgres.PartitionOf{T: logs, Values: "IN (1)"}},
+							}},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := postgrescheck.NewLockAnalyzer(nil)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.NotNil(t, report)
+	require.Len(t, report.Diagnostics, 1)
+	require.Equal(t, "PG103", report.Diagnostics[0].Code)
+	require.Equal(t, "Partitions cannot be detached concurrently within a transaction", report.Diagnostics[0].Text)
+}
+
+func TestRLSAnalyzer(t *testing.T) {
+	const content = `CREATE POLICY "p1" ON "users" USING (id > 0);
+CREATE POLICY "p2" ON "posts" USING (id > 0);
+ALTER TABLE "posts" ENABLE ROW LEVEL SECURITY;
+ALTER TABLE "groups" DISABLE ROW LEVEL SECURITY;
+`
+	var (
+		report   *sqlcheck.Report
+		public   = schema.New("public")
+		users    = schema.NewTable("users").SetSchema(public)
+		posts    = schema.NewTable("posts").SetSchema(public)
+		groups   = schema.NewTable("groups").SetSchema(public)
+		stmts, _ = migrate.Stmts(content)
+		pass     = &sqlcheck.Pass{
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql", bytes: []byte(content)},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt:    stmts[0],
+						Changes: schema.Changes{&schema.AddObject{O: &postgres.Policy{Name: "p1", Table: users, Using: "(id > 0)"}}},
+					},
+					{
+						Stmt:    stmts[1],
+						Changes: schema.Changes{&schema.AddObject{O: &postgres.Policy{Name: "p2", Table: posts, Using: "(id > 0)"}}},
+					},
+					{
+						Stmt: stmts[2],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: posts, Changes: schema.Changes{
+								&schema.ModifyAttr{From: &postgres.RowSecurity{}, To: &postgres.RowSecurity{Enabled: true}},
+							}},
+						},
+					},
+					{
+						Stmt: stmts[3],
+						Changes: schema.Changes{
+							&schema.ModifyTable{T: groups, Changes: schema.Changes{
+								&schema.ModifyAttr{From: &postgres.RowSecurity{Enabled: true}, To: &postgres.RowSecurity{}},
+							}},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	// Policies that exist before the file.
+	public.AddTables(users, posts, groups).AddObjects(&postgres.Policy{Name: "p3", Table: groups})
+	az, err := postgrescheck.NewRLSAnalyzer(nil)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.NotNil(t, report)
+	require.Equal(t, "row-level security issues detected", report.Text)
+	require.Len(t, report.Diagnostics, 2)
+
+	d := report.Diagnostics[0]
+	require.Equal(t, "PG201", d.Code)
+	require.Equal(t, stmts[0].Pos, d.Pos)
+	require.Equal(t, `Policy "p1" is created on table "users", but row-level security is not enabled on it`, d.Text)
+	require.Equal(t, &sqlcheck.TextEdit{
+		Line:    1,
+		End:     1,
+		NewText: "CREATE POLICY \"p1\" ON \"users\" USING (id > 0);\nALTER TABLE \"users\" ENABLE ROW LEVEL SECURITY;",
+	}, d.SuggestedFixes[0].TextEdit)
+
+	d = report.Diagnostics[1]
+	require.Equal(t, "PG202", d.Code)
+	require.Equal(t, stmts[3].Pos, d.Pos)
+	require.Equal(t, `Disabling row-level security on table "groups" stops enforcing its policies`, d.Text)
+
+	// Policies on tables that have row-level security enabled.
+	users.AddAttrs(&postgres.RowSecurity{Enabled: true})
+	pass.File.Changes = pass.File.Changes[:3]
+	report = nil
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Nil(t, report)
+}
+
 type testFile struct {
-	name string
+	name  string
+	bytes []byte
 	migrate.File
 }
 
 func (t testFile) Name() string {
 	return t.name
 }
+
+func (t testFile) Bytes() []byte {
+	return t.bytes
+}
+
+func (t testFile) Directive(name string) []string {
+	return migrate.NewLocalFile(t.name, t.bytes).Directive(name)
+}
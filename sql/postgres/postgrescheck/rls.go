@@ -0,0 +1,154 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package postgrescheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/postgres"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+// RLSAnalyzer checks for tables that have row-level security policies, but
+// row-level security is not enabled on them. Policies of such tables are not
+// enforced, and all rows are accessible to roles that have access to the table.
+type RLSAnalyzer struct {
+	sqlcheck.Options
+}
+
+// NewRLSAnalyzer creates a new row-level security Analyzer with the given options.
+func NewRLSAnalyzer(r *schemahcl.Resource) (*RLSAnalyzer, error) {
+	az := &RLSAnalyzer{}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing rls check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codePolicyNoRLS = sqlcheck.Code("PG201")
+	codeDisableRLS  = sqlcheck.Code("PG202")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*RLSAnalyzer) Name() string {
+	return "rls"
+}
+
+// Analyze implements sqlcheck.Analyzer.
+func (a *RLSAnalyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
+	type policyAdd struct {
+		stmt *migrate.Stmt
+		key  string
+		p    *postgres.Policy
+	}
+	var (
+		diags    []sqlcheck.Diagnostic
+		added    []*policyAdd
+		enabled  = make(map[string]bool)
+		policies = make(map[string]bool)
+	)
+	for _, sc := range p.File.Changes {
+		for _, c := range sc.Changes {
+			switch c := c.(type) {
+			case *schema.AddTable:
+				enabled[tableKey(c.T)] = rlsEnabled(c.T)
+			case *schema.ModifyTable:
+				k := tableKey(c.T)
+				for _, mc := range c.Changes {
+					m, ok := mc.(*schema.ModifyAttr)
+					if !ok {
+						continue
+					}
+					rs, ok := m.To.(*postgres.RowSecurity)
+					if !ok {
+						continue
+					}
+					enabled[k] = rs.Enabled
+					if !rs.Enabled && (policies[k] || hasPolicies(c.T)) {
+						diags = append(diags, sqlcheck.Diagnostic{
+							Code: codeDisableRLS,
+							Pos:  sc.Stmt.Pos,
+							Text: fmt.Sprintf("Disabling row-level security on table %q stops enforcing its policies", c.T.Name),
+						})
+					}
+				}
+			case *schema.AddObject:
+				pl, ok := c.O.(*postgres.Policy)
+				if !ok || pl.Table == nil {
+					continue
+				}
+				k := tableKey(pl.Table)
+				if _, ok := enabled[k]; !ok {
+					enabled[k] = rlsEnabled(pl.Table)
+				}
+				if !policies[k] {
+					policies[k] = true
+					added = append(added, &policyAdd{stmt: sc.Stmt, key: k, p: pl})
+				}
+			}
+		}
+	}
+	// Row-level security can be enabled on the table
+	// after its policies were created in the same file.
+	for _, pa := range added {
+		if enabled[pa.key] {
+			continue
+		}
+		d := sqlcheck.Diagnostic{
+			Code: codePolicyNoRLS,
+			Pos:  pa.stmt.Pos,
+			Text: fmt.Sprintf("Policy %q is created on table %q, but row-level security is not enabled on it", pa.p.Name, pa.p.Table.Name),
+		}
+		d.SuggestFix("Enable row-level security on the table to enforce its policies", p.File.StmtTextEdit(pa.stmt, fmt.Sprintf(
+			"%s;\n%s;",
+			strings.TrimSuffix(strings.TrimSpace(pa.stmt.Text), ";"),
+			builder().P("ALTER TABLE").Table(pa.p.Table).P("ENABLE ROW LEVEL SECURITY").String(),
+		)))
+		diags = append(diags, d)
+	}
+	slices.SortStableFunc(diags, func(d1, d2 sqlcheck.Diagnostic) int { return d1.Pos - d2.Pos })
+	if len(diags) > 0 {
+		const reportText = "row-level security issues detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+// rlsEnabled reports if row-level security is enabled on the table.
+func rlsEnabled(t *schema.Table) bool {
+	var rs postgres.RowSecurity
+	return sqlx.Has(t.Attrs, &rs) && rs.Enabled
+}
+
+// hasPolicies reports if the schema of the table holds policies defined on it.
+func hasPolicies(t *schema.Table) bool {
+	return t.Schema != nil && slices.ContainsFunc(t.Schema.Objects, func(o schema.Object) bool {
+		pl, ok := o.(*postgres.Policy)
+		return ok && pl.Table != nil && pl.Table.Name == t.Name
+	})
+}
+
+// tableKey returns a key that identifies the table across the file changes.
+func tableKey(t *schema.Table) string {
+	if t.Schema != nil {
+		return t.Schema.Name + "." + t.Name
+	}
+	return t.Name
+}
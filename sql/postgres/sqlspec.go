@@ -5,8 +5,11 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -23,16 +26,24 @@ import (
 
 type (
 	doc struct {
-		Tables        []*sqlspec.Table    `spec:"table"`
-		Enums         []*enum             `spec:"enum"`
-		Domains       []*domain           `spec:"domain"`
-		Composites    []*composite        `spec:"composite"`
-		Sequences     []*sqlspec.Sequence `spec:"sequence"`
-		Aggregates    []*aggregate        `spec:"aggregate"`
-		Policies      []*policy           `spec:"policy"`
-		EventTriggers []*eventTrigger     `spec:"event_trigger"`
-		Extensions    []*extension        `spec:"extension"`
-		Schemas       []*sqlspec.Schema   `spec:"schema"`
+		Tables        []*sqlspec.Table      `spec:"table"`
+		Views         []*sqlspec.View       `spec:"view"`
+		Materialized  []*sqlspec.View       `spec:"materialized"`
+		Funcs         []*sqlspec.Func       `spec:"function"`
+		Procs         []*sqlspec.Proc       `spec:"procedure"`
+		Triggers      []*sqlspec.Trigger    `spec:"trigger"`
+		Enums         []*enum               `spec:"enum"`
+		Domains       []*domain             `spec:"domain"`
+		Composites    []*composite          `spec:"composite"`
+		Sequences     []*sqlspec.Sequence   `spec:"sequence"`
+		Aggregates    []*aggregate          `spec:"aggregate"`
+		Policies      []*policy             `spec:"policy"`
+		EventTriggers []*eventTrigger       `spec:"event_trigger"`
+		Extensions    []*extension          `spec:"extension"`
+		Roles         []*sqlspec.Role       `spec:"role"`
+		Users         []*sqlspec.User       `spec:"user"`
+		Permissions   []*sqlspec.Permission `spec:"permission"`
+		Schemas       []*sqlspec.Schema     `spec:"schema"`
 	}
 
 	// Enum holds a specification for an enum type.
@@ -124,6 +135,11 @@ type (
 func (d *doc) merge(d1 *doc) {
 	d.Enums = append(d.Enums, d1.Enums...)
 	d.Tables = append(d.Tables, d1.Tables...)
+	d.Views = append(d.Views, d1.Views...)
+	d.Materialized = append(d.Materialized, d1.Materialized...)
+	d.Funcs = append(d.Funcs, d1.Funcs...)
+	d.Procs = append(d.Procs, d1.Procs...)
+	d.Triggers = append(d.Triggers, d1.Triggers...)
 	d.Domains = append(d.Domains, d1.Domains...)
 	d.Composites = append(d.Composites, d1.Composites...)
 	d.Schemas = append(d.Schemas, d1.Schemas...)
@@ -132,12 +148,20 @@ func (d *doc) merge(d1 *doc) {
 	d.Extensions = append(d.Extensions, d1.Extensions...)
 	d.Policies = append(d.Policies, d1.Policies...)
 	d.EventTriggers = append(d.EventTriggers, d1.EventTriggers...)
+	d.Roles = append(d.Roles, d1.Roles...)
+	d.Users = append(d.Users, d1.Users...)
+	d.Permissions = append(d.Permissions, d1.Permissions...)
 }
 
 func (d *doc) ScanDoc() *specutil.ScanDoc {
 	return &specutil.ScanDoc{
-		Schemas: d.Schemas,
-		Tables:  d.Tables,
+		Schemas:      d.Schemas,
+		Tables:       d.Tables,
+		Views:        d.Views,
+		Materialized: d.Materialized,
+		Funcs:        d.Funcs,
+		Procs:        d.Procs,
+		Triggers:     d.Triggers,
 	}
 }
 
@@ -191,6 +215,7 @@ func (a *aggregate) SchemaRef() *schemahcl.Ref { return a.Schema }
 
 func init() {
 	schemahcl.Register("enum", &enum{})
+	schemahcl.Register("materialized", &sqlspec.View{})
 	schemahcl.Register("domain", &domain{})
 	schemahcl.Register("policy", &policy{})
 	schemahcl.Register("composite", &composite{})
@@ -220,6 +245,9 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		if err := specutil.Scan(v, d.ScanDoc(), scanFuncs); err != nil {
 			return fmt.Errorf("specutil: failed converting to *schema.Realm: %w", err)
 		}
+		if err := convertPartitions(d.Tables, v); err != nil {
+			return err
+		}
 		if err := convertTypes(&d, v); err != nil {
 			return err
 		}
@@ -238,6 +266,9 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		if err := convertEventTriggers(d.EventTriggers, v); err != nil {
 			return err
 		}
+		if err := specutil.Roles(v, d.Roles, d.Users, d.Permissions, roleFuncs); err != nil {
+			return err
+		}
 		if err := normalizeRealm(v); err != nil {
 			return err
 		}
@@ -253,6 +284,9 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		if err := specutil.Scan(r, d.ScanDoc(), scanFuncs); err != nil {
 			return err
 		}
+		if err := convertPartitions(d.Tables, r); err != nil {
+			return err
+		}
 		if err := convertTypes(&d, r); err != nil {
 			return err
 		}
@@ -265,7 +299,7 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		if err := convertPolicies(d.Tables, d.Policies, r); err != nil {
 			return err
 		}
-		// Extensions are skipped in schema scope.
+		// Extensions, roles and permissions are skipped in schema scope.
 		if err := normalizeRealm(r); err != nil {
 			return err
 		}
@@ -299,6 +333,18 @@ func (c *Codec) MarshalSpec(v any) ([]byte, error) {
 		if err := specutil.QualifyObjects(d.Tables); err != nil {
 			return nil, err
 		}
+		if err := specutil.QualifyObjects(d.Views); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyObjects(d.Materialized); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyObjects(d.Funcs); err != nil {
+			return nil, err
+		}
+		if err := specutil.QualifyObjects(d.Procs); err != nil {
+			return nil, err
+		}
 		if err := specutil.QualifyObjects(d.Aggregates); err != nil {
 			return nil, err
 		}
@@ -314,6 +360,9 @@ func (c *Codec) MarshalSpec(v any) ([]byte, error) {
 		if err := specutil.QualifyObjects(d.Sequences); err != nil {
 			return nil, err
 		}
+		if err := realmObjectSpec(&d, rv); err != nil {
+			return nil, err
+		}
 		if err := specutil.QualifyReferences(d.Tables, rv); err != nil {
 			return nil, err
 		}
@@ -327,18 +376,33 @@ var (
 	codec = &Codec{
 		State: schemahcl.New(append(specOptions,
 			schemahcl.WithTypes("table.column.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("view.column.type", TypeRegistry.Specs()),
+			schemahcl.WithScopedEnums("view.check_option", specutil.ViewCheckOptions...),
+			schemahcl.WithTypes("materialized.column.type", TypeRegistry.Specs()),
+			schemahcl.WithScopedEnums("materialized.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
+			schemahcl.WithTypes("function.return", TypeRegistry.Specs()),
+			schemahcl.WithTypes("function.arg.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("procedure.arg.type", TypeRegistry.Specs()),
+			schemahcl.WithScopedEnums("function.lang", LangSQL, LangPLpgSQL),
+			schemahcl.WithScopedEnums("procedure.lang", LangSQL, LangPLpgSQL),
+			schemahcl.WithScopedEnums("function.volatility", VolatilityVolatile, VolatilityStable, VolatilityImmutable),
+			schemahcl.WithScopedEnums("function.arg.mode", schema.FuncArgModeIn, schema.FuncArgModeOut, schema.FuncArgModeInOut, schema.FuncArgModeVariadic),
+			schemahcl.WithScopedEnums("procedure.arg.mode", schema.FuncArgModeIn, schema.FuncArgModeOut, schema.FuncArgModeInOut, schema.FuncArgModeVariadic),
+			schemahcl.WithScopedEnums("trigger.for", schema.TriggerForRow, schema.TriggerForStmt),
+			schemahcl.WithTypes("sequence.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("domain.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("composite.field.type", TypeRegistry.Specs()),
+			schemahcl.WithScopedEnums("policy.as", PolicyAsPermissive, PolicyAsRestrictive),
+			schemahcl.WithScopedEnums("policy.for", PolicyForAll, PolicyForSelect, PolicyForInsert, PolicyForUpdate, PolicyForDelete),
+			schemahcl.WithScopedEnums("permission.privileges", PrivilegeAll, PrivilegeSelect, PrivilegeInsert, PrivilegeUpdate, PrivilegeDelete, PrivilegeTruncate, PrivilegeReferences, PrivilegeTrigger, PrivilegeMaintain, PrivilegeCreate, PrivilegeUsage),
 			schemahcl.WithScopedEnums("table.index.type", IndexTypeBTree, IndexTypeBRIN, IndexTypeHash, IndexTypeGIN, IndexTypeGiST, "GiST", IndexTypeSPGiST, "SPGiST"),
 			schemahcl.WithScopedEnums("table.partition.type", PartitionTypeRange, PartitionTypeList, PartitionTypeHash),
 			schemahcl.WithScopedEnums("table.column.identity.generated", GeneratedTypeAlways, GeneratedTypeByDefault),
 			schemahcl.WithScopedEnums("table.column.as.type", "STORED"),
 			schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 			schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
-			schemahcl.WithScopedEnums("table.index.on.ops", func() (ops []string) {
-				for _, op := range postgresop.Classes {
-					ops = append(ops, op.Name)
-				}
-				return ops
-			}()...))...,
+			schemahcl.WithScopedEnums("table.index.on.ops", opClassNames()...),
+			schemahcl.WithScopedEnums("materialized.index.on.ops", opClassNames()...))...,
 		),
 	}
 	// MarshalHCL marshals v into an Atlas HCL DDL document.
@@ -350,6 +414,14 @@ var (
 	EvalHCLBytes = specutil.HCLBytesFunc(codec)
 )
 
+// opClassNames returns the names of the builtin operator classes.
+func opClassNames() (ops []string) {
+	for _, op := range postgresop.Classes {
+		ops = append(ops, op.Name)
+	}
+	return ops
+}
+
 // convertTable converts a sqlspec.Table to a schema.Table. Table conversion is done without converting
 // ForeignKeySpecs into ForeignKeys, as the target tables do not necessarily exist in the schema
 // at this point. Instead, the linking is done by the convertSchema function.
@@ -374,6 +446,171 @@ func convertTable(spec *sqlspec.Table, parent *schema.Schema) (*schema.Table, er
 }
 
 // convertUnique converts the unique constraints into indexes.
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, func(c *sqlspec.Column, _ *schema.View) (*schema.Column, error) {
+		return convertColumn(c, nil)
+	})
+}
+
+// convertMaterialized converts a sqlspec.View to a materialized schema.View.
+func convertMaterialized(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	v, err := convertView(spec, parent)
+	if err != nil {
+		return nil, err
+	}
+	m := &schema.Materialized{}
+	v.AddAttrs(m)
+	if len(spec.Indexes) > 0 {
+		// Index conversion resolves column references using its parent
+		// table. Hence, a table that holds the view columns is used.
+		t := schema.NewTable(v.Name).SetSchema(parent).AddColumns(v.Columns...)
+		for _, s := range spec.Indexes {
+			idx, err := convertIndex(s, t)
+			if err != nil {
+				return nil, fmt.Errorf("materialized view %q: %w", v.Name, err)
+			}
+			v.AddIndexes(idx)
+		}
+	}
+	if a, ok := spec.Attr("with_data"); ok {
+		b, err := a.Bool()
+		if err != nil {
+			return nil, fmt.Errorf("expect bool value for attribute materialized.%s.with_data: %w", spec.Name, err)
+		}
+		m.NoData = !b
+	}
+	if a, ok := spec.Attr("tablespace"); ok {
+		s, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("expect string value for attribute materialized.%s.tablespace: %w", spec.Name, err)
+		}
+		v.AddAttrs(&Tablespace{N: s})
+	}
+	return v, nil
+}
+
+// convertFunc converts a sqlspec.Func to a schema.Func.
+func convertFunc(spec *sqlspec.Func, parent *schema.Schema) (*schema.Func, error) {
+	f, err := specutil.Func(spec, parent, convertFuncType)
+	if err != nil {
+		return nil, err
+	}
+	if f.Lang, err = funcLang(spec.Remain(), "function", spec.Name); err != nil {
+		return nil, err
+	}
+	if a, ok := spec.Attr("volatility"); ok {
+		v, err := a.String()
+		if err != nil {
+			return nil, fmt.Errorf("expect string value for attribute function.%s.volatility: %w", spec.Name, err)
+		}
+		f.SetVolatility(strings.ToUpper(v))
+	}
+	return f, nil
+}
+
+// convertProc converts a sqlspec.Proc to a schema.Proc.
+func convertProc(spec *sqlspec.Proc, parent *schema.Schema) (*schema.Proc, error) {
+	p, err := specutil.Proc(spec, parent, convertFuncType)
+	if err != nil {
+		return nil, err
+	}
+	if p.Lang, err = funcLang(spec.Remain(), "procedure", spec.Name); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger. The trigger
+// body is either defined by the "execute" block that references the trigger
+// function, or by the "as" attribute.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	if t.For == "" {
+		t.For = schema.TriggerForStmt
+	}
+	e, ok := spec.Remain().Resource("execute")
+	switch {
+	case ok && t.Body != "":
+		return nil, errors.New(`"execute" and "as" cannot be defined together`)
+	case ok:
+		a, ok := e.Attr("function")
+		if !ok {
+			return nil, errors.New(`missing "function" attribute in "execute" block`)
+		}
+		ref, err := a.Ref()
+		if err != nil {
+			return nil, err
+		}
+		f, err := specutil.FuncByRef(triggerSchema(t), &schemahcl.Ref{V: ref})
+		if err != nil {
+			return nil, err
+		}
+		name := triggerIdent(f.Name)
+		if !sqlx.SameSchema(f.Schema, triggerSchema(t)) {
+			name = triggerIdent(f.Schema.Name) + "." + name
+		}
+		t.Body = fmt.Sprintf("EXECUTE FUNCTION %s()", name)
+		t.AddDeps(f)
+	case t.Body == "":
+		return nil, errors.New(`missing "execute" block or "as" attribute`)
+	}
+	return t, nil
+}
+
+// reSimpleIdent matches identifiers that are not quoted by PostgreSQL.
+var reSimpleIdent = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+
+// triggerIdent returns the identifier as it is printed by pg_get_triggerdef.
+func triggerIdent(s string) string {
+	if reSimpleIdent.MatchString(s) {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// triggerSchema returns the schema of the table or the view the trigger is defined on.
+func triggerSchema(t *schema.Trigger) *schema.Schema {
+	if t.View != nil {
+		return t.View.Schema
+	}
+	return t.Table.Schema
+}
+
+// funcLang returns the language of a function or a procedure.
+func funcLang(r *schemahcl.Resource, typ, name string) (string, error) {
+	a, ok := r.Attr("lang")
+	if !ok {
+		return "", fmt.Errorf("missing 'lang' attribute for %s %q", typ, name)
+	}
+	l, err := a.String()
+	if err != nil {
+		return "", fmt.Errorf("expect string value for attribute %s.%s.lang: %w", typ, name, err)
+	}
+	return l, nil
+}
+
+// convertFuncType converts the type of function argument or its return type. Enum
+// references are resolved by convertTypes, after all enums were added to the realm.
+func convertFuncType(spec *sqlspec.Column) (schema.Type, error) {
+	switch {
+	case spec.Type == nil:
+		return nil, errors.New("missing type definition")
+	case spec.Type.IsRefTo("enum"):
+		n, err := enumName(spec.Type)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.EnumType{T: n}, nil
+	case setOfType(spec.Type.T):
+		return &schema.UnsupportedType{T: spec.Type.T}, nil
+	}
+	return convertColumnType(spec)
+}
+
 func convertUnique(spec schemahcl.Resource, t *schema.Table) error {
 	rs := spec.Resources("unique")
 	for _, r := range rs {
@@ -447,6 +684,125 @@ func convertPartition(spec schemahcl.Resource, table *schema.Table) error {
 	return nil
 }
 
+// convertPartitions converts the partition_of blocks of the table specs, and links the
+// partitions to their partitioned tables. It is called after all tables were converted,
+// as partitions reference their parent tables. Partitions that do not define columns
+// inherit them from their parent tables.
+func convertPartitions(specs []*sqlspec.Table, r *schema.Realm) error {
+	for _, spec := range specs {
+		rs, ok := spec.Extra.Resource("partition_of")
+		if !ok {
+			continue
+		}
+		var p struct {
+			Table   *schemahcl.Ref `spec:"table"`
+			Values  string         `spec:"values"`
+			Default bool           `spec:"default"`
+		}
+		if err := rs.As(&p); err != nil {
+			return fmt.Errorf("parsing %s.partition_of: %w", spec.Name, err)
+		}
+		switch {
+		case p.Table == nil:
+			return fmt.Errorf("missing attribute %s.partition_of.table", spec.Name)
+		case p.Values == "" && !p.Default:
+			return fmt.Errorf(`missing "values" or "default" for %s.partition_of`, spec.Name)
+		case p.Values != "" && p.Default:
+			return fmt.Errorf(`multiple definitions for %s.partition_of, use "values" or "default"`, spec.Name)
+		}
+		sn, err := specutil.SchemaName(spec.Schema)
+		if err != nil {
+			return err
+		}
+		s, ok := r.Schema(sn)
+		if !ok {
+			return fmt.Errorf("schema %q was not found for table %q", sn, spec.Name)
+		}
+		t, ok := s.Table(spec.Name)
+		if !ok {
+			return fmt.Errorf("table %q was not found in schema %q", spec.Name, sn)
+		}
+		parent, err := specutil.TableByRef(s, p.Table)
+		if err != nil {
+			return fmt.Errorf("%s.partition_of: %w", spec.Name, err)
+		}
+		t.AddAttrs(&PartitionOf{T: parent, Values: p.Values, Default: p.Default}).AddDeps(parent)
+	}
+	var (
+		inherit func(*schema.Table) error
+		visited = make(map[*schema.Table]bool)
+	)
+	inherit = func(t *schema.Table) error {
+		p := partitionOf(t)
+		if p == nil || len(t.Columns) > 0 {
+			return nil
+		}
+		if visited[t] {
+			return fmt.Errorf("circular partition_of reference for table %q", t.Name)
+		}
+		visited[t] = true
+		if err := inherit(p.T); err != nil {
+			return err
+		}
+		for _, c := range p.T.Columns {
+			c1 := *c
+			c1.Indexes, c1.ForeignKeys = nil, nil
+			t.AddColumns(&c1)
+		}
+		return nil
+	}
+	for _, s := range r.Schemas {
+		for _, t := range s.Tables {
+			if err := inherit(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fromPartitionOf returns the resource spec for representing the partition_of block.
+func fromPartitionOf(p *PartitionOf) *schemahcl.Resource {
+	r := &schemahcl.Resource{
+		Type: "partition_of",
+		Attrs: []*schemahcl.Attr{
+			schemahcl.RefAttr("table", specutil.TableSpecRef(p.T)),
+		},
+	}
+	if p.Default {
+		r.Attrs = append(r.Attrs, schemahcl.BoolAttr("default", true))
+	} else {
+		r.Attrs = append(r.Attrs, schemahcl.StringAttr("values", p.Values))
+	}
+	return r
+}
+
+// skipParentDep removes the parent table from the depends_on attribute of
+// the partition spec, as it is implied by the partition_of block.
+func skipParentDep(spec *sqlspec.Table, p *PartitionOf) error {
+	parent := specutil.TableSpecRef(p.T)
+	for i, r := range spec.Extra.Children {
+		a, ok := r.Attr("depends_on")
+		if r.Type != "" || !ok {
+			continue
+		}
+		refs, err := a.Refs()
+		if err != nil {
+			return err
+		}
+		refs = slices.DeleteFunc(refs, func(r *schemahcl.Ref) bool {
+			return r.V == parent.V
+		})
+		if len(refs) == 0 {
+			spec.Extra.Children = slices.Delete(spec.Extra.Children, i, i+1)
+		} else {
+			r.SetAttr(schemahcl.RefsAttr("depends_on", refs...))
+		}
+		return nil
+	}
+	return nil
+}
+
 // fromPartition returns the resource spec for representing the partition block.
 func fromPartition(p Partition) *schemahcl.Resource {
 	key := &schemahcl.Resource{
@@ -703,11 +1059,16 @@ func schemaSpec(s *schema.Schema) (*doc, error) {
 		return nil, err
 	}
 	d := &doc{
-		Tables:     spec.Tables,
-		Schemas:    []*sqlspec.Schema{spec.Schema},
-		Enums:      make([]*enum, 0, len(s.Objects)),
-		Domains:    make([]*domain, 0, len(s.Objects)),
-		Composites: make([]*composite, 0, len(s.Objects)),
+		Tables:       spec.Tables,
+		Views:        spec.Views,
+		Materialized: spec.Materialized,
+		Funcs:        spec.Funcs,
+		Procs:        spec.Procs,
+		Triggers:     spec.Triggers,
+		Schemas:      []*sqlspec.Schema{spec.Schema},
+		Enums:        make([]*enum, 0, len(s.Objects)),
+		Domains:      make([]*domain, 0, len(s.Objects)),
+		Composites:   make([]*composite, 0, len(s.Objects)),
 	}
 	if err := objectSpec(d, spec, s); err != nil {
 		return nil, err
@@ -752,10 +1113,111 @@ func tableSpec(t *schema.Table) (*sqlspec.Table, error) {
 	if p := (Partition{}); sqlx.Has(t.Attrs, &p) {
 		spec.Extra.Children = append(spec.Extra.Children, fromPartition(p))
 	}
+	if p := partitionOf(t); p != nil {
+		if err := skipParentDep(spec, p); err != nil {
+			return nil, err
+		}
+		spec.Extra.Children = append(spec.Extra.Children, fromPartitionOf(p))
+	}
 	tableAttrsSpec(t, spec)
 	return spec, nil
 }
 
+// viewSpec converts from a concrete Postgres schema.View to a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, func(c *schema.Column, _ *schema.View) (*sqlspec.Column, error) {
+		return specutil.FromColumn(c, columnTypeSpec)
+	})
+}
+
+// materializedSpec converts from a concrete Postgres materialized schema.View to a sqlspec.View.
+func materializedSpec(v *schema.View) (*sqlspec.View, error) {
+	spec, err := viewSpec(v)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range v.Indexes {
+		s, err := indexSpec(idx)
+		if err != nil {
+			return nil, err
+		}
+		spec.Indexes = append(spec.Indexes, s)
+	}
+	var attrs []*schemahcl.Attr
+	if m := (schema.Materialized{}); sqlx.Has(v.Attrs, &m) && m.NoData {
+		attrs = append(attrs, schemahcl.BoolAttr("with_data", false))
+	}
+	if t := (Tablespace{}); sqlx.Has(v.Attrs, &t) && t.N != "" {
+		attrs = append(attrs, schemahcl.StringAttr("tablespace", t.N))
+	}
+	// Options are printed after the definition, which is
+	// the first attribute of the last embedded resource.
+	if n := len(spec.Extra.Children); n > 0 && len(attrs) > 0 {
+		embed := spec.Extra.Children[n-1]
+		embed.Attrs = append(embed.Attrs[:1], append(attrs, embed.Attrs[1:]...)...)
+	}
+	return spec, nil
+}
+
+// funcSpec converts from a concrete Postgres schema.Func to a sqlspec.Func.
+func funcSpec(f *schema.Func) (*sqlspec.Func, error) {
+	attrs := []*schemahcl.Attr{langAttr(f.Lang)}
+	if v := (schema.FuncVolatility{}); sqlx.Has(f.Attrs, &v) && v.V != "" && strings.ToUpper(v.V) != VolatilityVolatile {
+		attrs = append(attrs, specutil.VarAttr("volatility", strings.ToUpper(v.V)))
+	}
+	return specutil.FromFunc(f, columnTypeSpec, attrs...)
+}
+
+// procSpec converts from a concrete Postgres schema.Proc to a sqlspec.Proc.
+func procSpec(p *schema.Proc) (*sqlspec.Proc, error) {
+	return specutil.FromProc(p, columnTypeSpec, langAttr(p.Lang))
+}
+
+// triggerSpec converts from a concrete Postgres schema.Trigger to a sqlspec.Trigger.
+// Triggers that execute one of the schema functions without arguments are marshaled
+// using the "execute" block, and the rest are marshaled using the "as" attribute.
+func triggerSpec(t *schema.Trigger) (*sqlspec.Trigger, error) {
+	var body *schemahcl.Resource
+	if f, ok := triggerFunc(t); ok {
+		body = &schemahcl.Resource{
+			Type:  "execute",
+			Attrs: []*schemahcl.Attr{schemahcl.RefAttr("function", specutil.ObjectRef(f.Schema, f))},
+		}
+	}
+	return specutil.FromTrigger(t, body)
+}
+
+// reExecFunc matches the function execution clause of triggers without arguments.
+var reExecFunc = regexp.MustCompile(`(?i)^EXECUTE\s+(?:FUNCTION|PROCEDURE)\s+(?:"?([^".\s]+)"?\.)?"?([^"(\s]+)"?\(\)$`)
+
+// triggerFunc returns the schema function that is executed by the trigger, if exists.
+func triggerFunc(t *schema.Trigger) (*schema.Func, bool) {
+	matches := reExecFunc.FindStringSubmatch(strings.TrimSpace(t.Body))
+	if len(matches) != 3 {
+		return nil, false
+	}
+	s := triggerSchema(t)
+	if matches[1] != "" && matches[1] != s.Name {
+		if s.Realm == nil {
+			return nil, false
+		}
+		if s, _ = s.Realm.Schema(matches[1]); s == nil {
+			return nil, false
+		}
+	}
+	return s.Func(matches[2])
+}
+
+// langAttr returns the 'lang' attribute of a function or a procedure.
+func langAttr(l string) *schemahcl.Attr {
+	for _, v := range []string{LangSQL, LangPLpgSQL} {
+		if strings.EqualFold(l, v) {
+			return specutil.VarAttr("lang", v)
+		}
+	}
+	return schemahcl.StringAttr("lang", l)
+}
+
 func pkSpec(idx *schema.Index) (*sqlspec.PrimaryKey, error) {
 	spec, err := specutil.FromPrimaryKey(idx)
 	if err != nil {
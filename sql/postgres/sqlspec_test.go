@@ -13,6 +13,7 @@ import (
 	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/schema"
 	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestSQLSpec(t *testing.T) {
@@ -1319,6 +1320,593 @@ table "users" {
 	require.EqualValues(t, expected, &s)
 }
 
+func TestMarshalSpec_View(t *testing.T) {
+	s := schema.New("test")
+	users := schema.NewTable("users").
+		AddColumns(schema.NewIntColumn("id", "int"))
+	s.AddTables(users)
+	s.AddViews(
+		schema.NewView("active_users", "SELECT id\nFROM users\nWHERE active").
+			AddColumns(schema.NewNullIntColumn("id", "int")).
+			SetCheckOption(schema.ViewCheckOptionLocal).
+			SetComment("active users").
+			AddDeps(users),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `table "users" {
+  schema = schema.test
+  column "id" {
+    null = false
+    type = int
+  }
+}
+view "active_users" {
+  schema = schema.test
+  column "id" {
+    null = true
+    type = int
+  }
+  as           = <<-SQL
+  SELECT id
+  FROM users
+  WHERE active
+  SQL
+  check_option = LOCAL
+  comment      = "active users"
+  depends_on   = [table.users]
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+}
+
+func TestUnmarshalSpec_View(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `
+schema "test" {}
+table "users" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+}
+view "active_users" {
+	schema = schema.test
+	column "id" {
+		type = int
+	}
+	as           = "SELECT id FROM users WHERE active"
+	check_option = CASCADED
+	depends_on   = [table.users]
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), &s, nil)
+	require.NoError(t, err)
+	require.Len(t, s.Views, 1)
+	v := s.Views[0]
+	require.Equal(t, "active_users", v.Name)
+	require.Equal(t, "SELECT id FROM users WHERE active", v.Def)
+	require.Equal(t, &s, v.Schema)
+	require.Len(t, v.Columns, 1)
+	require.Equal(t, "id", v.Columns[0].Name)
+	opt := &schema.ViewCheckOption{}
+	require.True(t, sqlx.Has(v.Attrs, opt))
+	require.Equal(t, schema.ViewCheckOptionCascaded, opt.V)
+	require.Equal(t, []schema.Object{s.Tables[0]}, v.Deps)
+}
+
+func TestMarshalSpec_Func(t *testing.T) {
+	s := schema.New("test")
+	s.AddObjects(
+		schema.NewFunc("add", "SELECT a + b").
+			AddArgs(
+				&schema.FuncArg{Name: "a", Type: &schema.IntegerType{T: TypeInt}},
+				&schema.FuncArg{Name: "b", Type: &schema.IntegerType{T: TypeInt}, Default: &schema.Literal{V: "1"}},
+			).
+			SetReturn(&schema.IntegerType{T: TypeInt}).
+			SetLang(LangSQL).
+			SetVolatility(VolatilityImmutable).
+			SetComment("add two numbers"),
+		schema.NewProc("log", "BEGIN\n  INSERT INTO logs VALUES (msg);\nEND").
+			AddArgs(&schema.FuncArg{Name: "msg", Type: &schema.StringType{T: TypeText}}).
+			SetLang(LangPLpgSQL),
+	)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	const expected = `function "add" {
+  schema = schema.test
+  arg "a" {
+    type = int
+  }
+  arg "b" {
+    type    = int
+    default = 1
+  }
+  return     = int
+  lang       = SQL
+  volatility = IMMUTABLE
+  as         = "SELECT a + b"
+  comment    = "add two numbers"
+}
+procedure "log" {
+  schema = schema.test
+  arg "msg" {
+    type = text
+  }
+  lang = PLpgSQL
+  as   = <<-SQL
+  BEGIN
+    INSERT INTO logs VALUES (msg);
+  END
+  SQL
+}
+schema "test" {
+}
+`
+	require.EqualValues(t, expected, string(buf))
+}
+
+func TestUnmarshalSpec_Func(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `
+schema "test" {}
+enum "status" {
+	schema = schema.test
+	values = ["active", "inactive"]
+}
+function "is_active" {
+	schema = schema.test
+	arg "s" {
+		type = enum.status
+	}
+	arg "strict" {
+		type    = boolean
+		default = true
+	}
+	return     = boolean
+	lang       = SQL
+	volatility = STABLE
+	as         = "SELECT s = 'active'"
+}
+procedure "cleanup" {
+	schema = schema.test
+	arg "n" {
+		type = int
+		mode = INOUT
+	}
+	lang = PLpgSQL
+	as   = "BEGIN n := 0; END"
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), &s, nil)
+	require.NoError(t, err)
+	require.Len(t, s.Objects, 3)
+	fn, ok := s.Func("is_active")
+	require.True(t, ok)
+	require.Equal(t, &s, fn.Schema)
+	require.Equal(t, LangSQL, fn.Lang)
+	require.Equal(t, "SELECT s = 'active'", fn.Body)
+	require.Equal(t, &schema.BoolType{T: TypeBoolean}, fn.Ret)
+	require.Len(t, fn.Args, 2)
+	e, ok := s.Object(func(o schema.Object) bool {
+		e, ok := o.(*schema.EnumType)
+		return ok && e.T == "status"
+	})
+	require.True(t, ok)
+	require.Equal(t, e, fn.Args[0].Type)
+	require.Equal(t, &schema.Literal{V: "true"}, fn.Args[1].Default)
+	require.Equal(t, []schema.Attr{&schema.FuncVolatility{V: VolatilityStable}}, fn.Attrs)
+	p, ok := s.Proc("cleanup")
+	require.True(t, ok)
+	require.Equal(t, LangPLpgSQL, p.Lang)
+	require.Len(t, p.Args, 1)
+	require.Equal(t, schema.FuncArgModeInOut, p.Args[0].Mode)
+	require.Equal(t, &schema.IntegerType{T: TypeInt}, p.Args[0].Type)
+
+	err = EvalHCLBytes([]byte(`
+schema "test" {}
+function "f" {
+	schema = schema.test
+	return = int
+	as     = "SELECT 1"
+}
+`), &s, nil)
+	require.EqualError(t, err, `cannot convert function "f": missing 'lang' attribute for function "f"`)
+}
+
+func TestSpec_Trigger(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+}
+function "audit" {
+  schema = schema.public
+  return = trigger
+  lang   = PLpgSQL
+  as     = "BEGIN RETURN NEW; END"
+}
+trigger "users_audit" {
+  on   = table.users
+  for  = ROW
+  when = "NEW.id > 0"
+  after {
+    insert    = true
+    update_of = [table.users.column.name]
+  }
+  execute {
+    function = function.audit
+  }
+}
+schema "public" {
+}
+`
+	)
+	err := EvalHCLBytes([]byte(f), &s, nil)
+	require.NoError(t, err)
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, users.Triggers, 1)
+	tr := users.Triggers[0]
+	require.Equal(t, "users_audit", tr.Name)
+	require.Equal(t, users, tr.Table)
+	require.Equal(t, schema.TriggerTimeAfter, tr.ActionTime)
+	require.Equal(t, schema.TriggerForRow, tr.For)
+	require.Equal(t, "EXECUTE FUNCTION audit()", tr.Body)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventInsert, schema.TriggerEventUpdateOf(users.Columns[1])}, tr.Events)
+	require.Equal(t, []schema.Attr{&schema.TriggerWhen{X: "NEW.id > 0"}}, tr.Attrs)
+	fn, ok := s.Func("audit")
+	require.True(t, ok)
+	require.Equal(t, []schema.Object{fn}, tr.Deps)
+	buf, err := MarshalHCL(&s)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+}
+
+func TestSpec_Sequence(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `table "users" {
+  schema = schema.public
+  column "id" {
+    null    = false
+    type    = integer
+    default = sql("nextval('users_id')")
+  }
+}
+sequence "users_id" {
+  schema = schema.public
+  type   = integer
+  start  = 100
+  cache  = 10
+  owner  = table.users.column.id
+}
+sequence "down" {
+  schema    = schema.public
+  increment = -1
+  min_value = -100
+  cycle     = true
+  comment   = "descending"
+}
+schema "public" {
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, s.Objects, 2)
+	seq := s.Objects[0].(*Sequence)
+	require.Equal(t, "users_id", seq.Name)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, seq.Type)
+	require.EqualValues(t, 100, seq.Start)
+	require.EqualValues(t, 10, seq.Cache)
+	require.Equal(t, users, seq.Owner.T)
+	require.Equal(t, users.Columns[0], seq.Owner.C)
+	down := s.Objects[1].(*Sequence)
+	require.EqualValues(t, -1, down.Increment)
+	require.Equal(t, int64(-100), *down.Min)
+	require.True(t, down.Cycle)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "descending"}}, down.Attrs)
+	buf, err := MarshalHCL(&s)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+
+	err = EvalHCLBytes([]byte(`
+sequence "s" {
+  schema = schema.public
+  type   = text
+}
+schema "public" {}
+`), &schema.Schema{}, nil)
+	require.EqualError(t, err, `sequence "s": unexpected type "text", expect smallint, integer or bigint`)
+}
+
+func TestSpec_DomainComposite(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `table "users" {
+  schema = schema.public
+  column "code" {
+    null = false
+    type = domain.code
+  }
+  column "pair" {
+    null = true
+    type = composite.pair
+  }
+}
+enum "status" {
+  schema = schema.public
+  values = ["on", "off"]
+}
+domain "code" {
+  schema  = schema.public
+  type    = text
+  null    = false
+  default = "x"
+  comment = "codes"
+  check "len" {
+    expr = "(length(VALUE) > 0)"
+  }
+}
+domain "state" {
+  schema = schema.public
+  type   = enum.status
+  null   = true
+}
+composite "pair" {
+  schema = schema.public
+  field "c" {
+    type = domain.code
+  }
+  field "n" {
+    type = integer
+  }
+}
+schema "public" {
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, s.Objects, 4)
+	status := s.Objects[0].(*schema.EnumType)
+	code := s.Objects[1].(*DomainType)
+	require.Equal(t, "code", code.T)
+	require.Equal(t, &schema.StringType{T: TypeText}, code.Type)
+	require.False(t, code.Null)
+	require.Equal(t, &schema.Literal{V: "x"}, code.Default)
+	require.Equal(t, []*schema.Check{{Name: "len", Expr: "(length(VALUE) > 0)"}}, code.Checks)
+	require.Equal(t, []schema.Attr{&schema.Comment{Text: "codes"}}, code.Attrs)
+	state := s.Objects[2].(*DomainType)
+	require.Equal(t, status, state.Type)
+	pair := s.Objects[3].(*CompositeType)
+	require.Len(t, pair.Fields, 2)
+	require.Equal(t, code, pair.Fields[0].Type.Type)
+	require.Equal(t, &schema.IntegerType{T: TypeInteger}, pair.Fields[1].Type.Type)
+	require.Equal(t, code, users.Columns[0].Type.Type)
+	require.Equal(t, pair, users.Columns[1].Type.Type)
+	buf, err := MarshalHCL(&s)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+
+	err = EvalHCLBytes([]byte(`
+domain "d" {
+  schema = schema.public
+  type   = int
+}
+domain "d" {
+  schema = schema.public
+  type   = int
+}
+schema "public" {}
+`), &schema.Schema{}, nil)
+	require.Error(t, err)
+}
+
+func TestSpec_Extension(t *testing.T) {
+	var (
+		r schema.Realm
+		f = `extension "citext" {
+  schema  = schema.public
+  version = "1.6"
+  comment = "case-insensitive"
+}
+extension "hstore" {
+}
+schema "public" {
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &r, nil))
+	require.Equal(t, []schema.Object{
+		&Extension{T: "citext", Schema: r.Schemas[0], Version: "1.6", Attrs: []schema.Attr{&schema.Comment{Text: "case-insensitive"}}},
+		&Extension{T: "hstore"},
+	}, r.Objects)
+	buf, err := MarshalHCL(&r)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+}
+
+func TestSpec_Policy(t *testing.T) {
+	var (
+		s schema.Schema
+		f = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = integer
+  }
+  row_security {
+    enabled  = true
+    enforced = true
+  }
+}
+table "posts" {
+  schema = schema.public
+  column "owner" {
+    null = false
+    type = text
+  }
+}
+policy "read" {
+  on      = table.users
+  as      = RESTRICTIVE
+  for     = SELECT
+  to      = ["admin", "app"]
+  using   = "(id > 0)"
+  comment = "readers"
+}
+policy "own" {
+  on    = table.posts
+  using = "(owner = CURRENT_USER)"
+  check = "(owner = CURRENT_USER)"
+}
+schema "public" {
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	posts, ok := s.Table("posts")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&RowSecurity{Enabled: true, Enforced: true}}, users.Attrs)
+	require.Empty(t, posts.Attrs)
+	require.Equal(t, []schema.Object{
+		&Policy{Name: "read", Table: users, As: PolicyAsRestrictive, For: PolicyForSelect, To: []string{"admin", "app"}, Using: "(id > 0)", Attrs: []schema.Attr{&schema.Comment{Text: "readers"}}},
+		&Policy{Name: "own", Table: posts, Using: "(owner = CURRENT_USER)", Check: "(owner = CURRENT_USER)"},
+	}, s.Objects)
+	buf, err := MarshalHCL(&s)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+
+	err = EvalHCLBytes([]byte(`
+table "users" {
+  schema = schema.public
+}
+policy "p" {
+  on = table.users
+}
+policy "p" {
+  on = table.users
+}
+schema "public" {}
+`), &schema.Schema{}, nil)
+	require.EqualError(t, err, `duplicate policy "p" on table "users"`)
+}
+
+func TestSpec_Role(t *testing.T) {
+	var (
+		r schema.Realm
+		f = `table "users" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = integer
+  }
+  column "name" {
+    null = false
+    type = text
+  }
+}
+role "admin" {
+  superuser  = true
+  conn_limit = 5
+}
+role "reader" {
+  member_of = [role.admin]
+}
+user "app" {
+  member_of = [role.reader]
+  inherit   = false
+}
+permission "reader_public" {
+  to         = role.reader
+  for        = schema.public
+  privileges = [USAGE]
+}
+permission "reader_public_users" {
+  to         = role.reader
+  for        = table.users
+  grantable  = true
+  privileges = [INSERT, SELECT]
+}
+permission "app_public_users_name" {
+  to         = user.app
+  for        = table.users
+  columns    = [table.users.column.name]
+  privileges = [UPDATE]
+}
+schema "public" {
+}
+`
+	)
+	require.NoError(t, EvalHCLBytes([]byte(f), &r, nil))
+	users, ok := r.Schemas[0].Table("users")
+	require.True(t, ok)
+	name, ok := users.Column("name")
+	require.True(t, ok)
+	admin, ok := r.Role("admin")
+	require.True(t, ok)
+	require.Equal(t, []schema.Attr{&RoleOptions{Superuser: true}, &ConnLimit{V: 5}}, admin.Attrs)
+	reader, ok := r.Role("reader")
+	require.True(t, ok)
+	require.Equal(t, []*schema.Role{admin}, reader.MemberOf)
+	app, ok := r.User("app", "")
+	require.True(t, ok)
+	require.Equal(t, []*schema.Role{reader}, app.MemberOf)
+	require.Equal(t, []schema.Attr{&RoleOptions{NoInherit: true}}, app.Attrs)
+	require.Equal(t, []schema.Object{
+		admin, reader, app,
+		&schema.Permission{To: reader, Schema: r.Schemas[0], Privileges: []string{"USAGE"}},
+		&schema.Permission{To: reader, Schema: r.Schemas[0], Table: users, Privileges: []string{"INSERT", "SELECT"}, Grantable: true},
+		&schema.Permission{To: app, Schema: r.Schemas[0], Table: users, Columns: []*schema.Column{name}, Privileges: []string{"UPDATE"}},
+	}, r.Objects)
+
+	buf, err := MarshalHCL(&r)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+
+	err = EvalHCLBytes([]byte(`
+schema "public" {}
+role "r" {}
+permission "p" {
+  to  = role.r
+  for = schema.public
+}
+`), &schema.Realm{}, nil)
+	require.EqualError(t, err, `permission "p": missing "privileges" attribute`)
+
+	// Passwords are rejected, as they would be written to planned statements.
+	err = EvalHCLBytes([]byte(`
+variable "password" {
+  type = string
+}
+schema "public" {}
+user "admin" {
+  password = var.password
+}
+`), &schema.Realm{}, map[string]cty.Value{"password": cty.StringVal("pass")})
+	require.EqualError(t, err, `user "admin": passwords are not supported and should be set separately`)
+}
+
 func TestMarshalSpec_Enum(t *testing.T) {
 	stateE := &schema.EnumType{
 		T:      "state",
@@ -2252,3 +2840,190 @@ schema "public" {
 	require.Equal(t, &IndexInclude{Columns: []*schema.Column{s.Tables[0].Columns[1]}}, u3.Attrs[0])
 	require.Equal(t, UniqueConstraint("u3"), u3.Attrs[1].(*Constraint))
 }
+
+func TestMarshalSpec_Materialized(t *testing.T) {
+	s := schema.New("public")
+	mv := schema.NewMaterializedView("mv", "SELECT id FROM t").
+		AddAttrs(&Tablespace{N: "fast"}).
+		AddColumns(schema.NewIntColumn("id", "int"))
+	mv.AddIndexes(schema.NewUniqueIndex("mv_id").AddColumns(mv.Columns[0]))
+	mv.Attrs[0].(*schema.Materialized).NoData = true
+	s.AddViews(mv)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `materialized "mv" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  index "mv_id" {
+    unique  = true
+    columns = [column.id]
+  }
+  as         = "SELECT id FROM t"
+  with_data  = false
+  tablespace = "fast"
+}
+schema "public" {
+}
+`, string(buf))
+}
+
+func TestUnmarshalSpec_Materialized(t *testing.T) {
+	var s schema.Schema
+	require.NoError(t, EvalHCLBytes([]byte(`materialized "mv" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  index "mv_id" {
+    unique  = true
+    columns = [column.id]
+  }
+  as         = "SELECT id FROM t"
+  with_data  = false
+  tablespace = "fast"
+}
+schema "public" {
+}
+`), &s, nil))
+	require.Len(t, s.Views, 1)
+	mv := s.Views[0]
+	require.True(t, mv.Materialized())
+	require.Equal(t, "SELECT id FROM t", mv.Def)
+	require.Equal(t, []schema.Attr{&schema.Materialized{NoData: true}, &Tablespace{N: "fast"}}, mv.Attrs)
+	require.Len(t, mv.Columns, 1)
+	require.Len(t, mv.Indexes, 1)
+	require.True(t, mv.Indexes[0].Unique)
+	require.Equal(t, mv, mv.Indexes[0].View)
+	require.Nil(t, mv.Indexes[0].Table)
+	require.Equal(t, mv.Columns[0], mv.Indexes[0].Parts[0].C)
+}
+
+func TestMarshalSpec_PartitionOf(t *testing.T) {
+	s := schema.New("public")
+	logs := schema.NewTable("logs").
+		AddColumns(schema.NewIntColumn("id", "int"))
+	logs.AddAttrs(&Partition{T: PartitionTypeRange, Parts: []*PartitionPart{{C: logs.Columns[0]}}})
+	p1 := schema.NewTable("logs_1").
+		AddColumns(schema.NewIntColumn("id", "int")).
+		AddAttrs(&PartitionOf{T: logs, Values: "FROM (1) TO (10)"}).
+		AddDeps(logs)
+	p2 := schema.NewTable("logs_default").
+		AddColumns(schema.NewIntColumn("id", "int")).
+		AddAttrs(&PartitionOf{T: logs, Default: true}).
+		AddDeps(logs)
+	s.AddTables(logs, p1, p2)
+	buf, err := MarshalHCL(s)
+	require.NoError(t, err)
+	require.Equal(t, `table "logs" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  partition {
+    type    = RANGE
+    columns = [column.id]
+  }
+}
+table "logs_1" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  partition_of {
+    table  = table.logs
+    values = "FROM (1) TO (10)"
+  }
+}
+table "logs_default" {
+  schema = schema.public
+  column "id" {
+    null = false
+    type = int
+  }
+  partition_of {
+    table   = table.logs
+    default = true
+  }
+}
+schema "public" {
+}
+`, string(buf))
+}
+
+func TestUnmarshalSpec_PartitionOf(t *testing.T) {
+	var s schema.Schema
+	err := EvalHCLBytes([]byte(`
+table "logs_2" {
+  schema = schema.public
+  partition_of {
+    table  = table.logs_1
+    values = "FROM (1) TO (5)"
+  }
+}
+table "logs_1" {
+  schema = schema.public
+  partition_of {
+    table  = table.logs
+    values = "FROM (1) TO (10)"
+  }
+  partition {
+    type    = RANGE
+    columns = [column.id]
+  }
+  column "id" {
+    type = int
+  }
+}
+table "logs" {
+  schema = schema.public
+  column "id" {
+    type = int
+  }
+  column "name" {
+    type = text
+  }
+  partition {
+    type    = RANGE
+    columns = [column.id]
+  }
+}
+schema "public" {
+}
+`), &s, nil)
+	require.NoError(t, err)
+	logs1, ok := s.Table("logs_1")
+	require.True(t, ok)
+	logs, ok := s.Table("logs")
+	require.True(t, ok)
+	require.Equal(t, &PartitionOf{T: logs, Values: "FROM (1) TO (10)"}, partitionOf(logs1))
+	require.Equal(t, []schema.Object{logs}, logs1.Deps)
+	require.Len(t, logs1.Columns, 1, "columns defined explicitly")
+	// Partitions without columns inherit them from their parent tables.
+	logs2, ok := s.Table("logs_2")
+	require.True(t, ok)
+	require.Equal(t, &PartitionOf{T: logs1, Values: "FROM (1) TO (5)"}, partitionOf(logs2))
+	require.Len(t, logs2.Columns, 1)
+	require.Equal(t, "id", logs2.Columns[0].Name)
+	require.NotSame(t, logs1.Columns[0], logs2.Columns[0])
+
+	err = EvalHCLBytes([]byte(`
+table "logs_1" {
+  schema = schema.public
+  partition_of {
+    table = table.logs
+  }
+}
+table "logs" {
+  schema = schema.public
+}
+schema "public" {
+}
+`), &s, nil)
+	require.EqualError(t, err, `missing "values" or "default" for logs_1.partition_of`)
+}
@@ -147,6 +147,11 @@ func NewView(name, def string) *View {
 	return &View{Name: name, Def: def}
 }
 
+// NewMaterializedView creates a new materialized View with the given name and definition.
+func NewMaterializedView(name, def string) *View {
+	return NewView(name, def).AddAttrs(&Materialized{})
+}
+
 // SetComment sets or appends the Comment attribute to the view with the given value.
 func (v *View) SetComment(c string) *View {
 	ReplaceOrAppend(&v.Attrs, &Comment{Text: c})
@@ -159,12 +164,249 @@ func (v *View) AddColumns(columns ...*Column) *View {
 	return v
 }
 
+// AddIndexes appends the given indexes to the (materialized) view index list.
+func (v *View) AddIndexes(indexes ...*Index) *View {
+	for _, idx := range indexes {
+		idx.SetView(v)
+	}
+	v.Indexes = append(v.Indexes, indexes...)
+	return v
+}
+
+// AddTriggers adds triggers to the view.
+func (v *View) AddTriggers(triggers ...*Trigger) *View {
+	for _, tr := range triggers {
+		tr.SetView(v)
+	}
+	v.Triggers = append(v.Triggers, triggers...)
+	return v
+}
+
+// SetSchema sets the schema (named-database) of the view.
+func (v *View) SetSchema(s *Schema) *View {
+	v.Schema = s
+	return v
+}
+
+// SetCheckOption sets or appends the ViewCheckOption attribute to the view.
+func (v *View) SetCheckOption(opt string) *View {
+	ReplaceOrAppend(&v.Attrs, &ViewCheckOption{V: opt})
+	return v
+}
+
+// AddAttrs adds additional attributes to the view.
+func (v *View) AddAttrs(attrs ...Attr) *View {
+	v.Attrs = append(v.Attrs, attrs...)
+	return v
+}
+
 // AddDeps adds the given dependencies to the view.
 func (v *View) AddDeps(deps ...Object) *View {
 	v.Deps = append(v.Deps, deps...)
+	addRefs(v, deps)
 	return v
 }
 
+// AddRefs adds references to the view.
+func (v *View) AddRefs(refs ...Object) {
+	v.Refs = append(v.Refs, refs...)
+	SortRefs(v.Refs)
+}
+
+// RemoveDep removes the given object from the view dependencies.
+func (v *View) RemoveDep(o Object) {
+	v.Deps = removeObj(v.Deps, o)
+}
+
+// NewFunc creates a new Func with the given name and body.
+func NewFunc(name, body string) *Func {
+	return &Func{Name: name, Body: body}
+}
+
+// SetSchema sets the schema (named-database) of the function.
+func (f *Func) SetSchema(s *Schema) *Func {
+	f.Schema = s
+	return f
+}
+
+// AddArgs adds the given arguments to the function.
+func (f *Func) AddArgs(args ...*FuncArg) *Func {
+	f.Args = append(f.Args, args...)
+	return f
+}
+
+// SetReturn sets the return type of the function.
+func (f *Func) SetReturn(t Type) *Func {
+	f.Ret = t
+	return f
+}
+
+// SetLang sets the language of the function.
+func (f *Func) SetLang(l string) *Func {
+	f.Lang = l
+	return f
+}
+
+// SetVolatility sets or appends the FuncVolatility attribute to the function.
+func (f *Func) SetVolatility(v string) *Func {
+	ReplaceOrAppend(&f.Attrs, &FuncVolatility{V: v})
+	return f
+}
+
+// SetComment sets or appends the Comment attribute to the function with the given value.
+func (f *Func) SetComment(c string) *Func {
+	ReplaceOrAppend(&f.Attrs, &Comment{Text: c})
+	return f
+}
+
+// AddAttrs adds additional attributes to the function.
+func (f *Func) AddAttrs(attrs ...Attr) *Func {
+	f.Attrs = append(f.Attrs, attrs...)
+	return f
+}
+
+// AddDeps adds the given dependencies to the function.
+func (f *Func) AddDeps(deps ...Object) *Func {
+	f.Deps = append(f.Deps, deps...)
+	addRefs(f, deps)
+	return f
+}
+
+// AddRefs adds references to the function.
+func (f *Func) AddRefs(refs ...Object) {
+	f.Refs = append(f.Refs, refs...)
+	SortRefs(f.Refs)
+}
+
+// RemoveDep removes the given object from the function dependencies.
+func (f *Func) RemoveDep(o Object) {
+	f.Deps = removeObj(f.Deps, o)
+}
+
+// NewProc creates a new Proc with the given name and body.
+func NewProc(name, body string) *Proc {
+	return &Proc{Name: name, Body: body}
+}
+
+// SetSchema sets the schema (named-database) of the procedure.
+func (p *Proc) SetSchema(s *Schema) *Proc {
+	p.Schema = s
+	return p
+}
+
+// AddArgs adds the given arguments to the procedure.
+func (p *Proc) AddArgs(args ...*FuncArg) *Proc {
+	p.Args = append(p.Args, args...)
+	return p
+}
+
+// SetLang sets the language of the procedure.
+func (p *Proc) SetLang(l string) *Proc {
+	p.Lang = l
+	return p
+}
+
+// SetComment sets or appends the Comment attribute to the procedure with the given value.
+func (p *Proc) SetComment(c string) *Proc {
+	ReplaceOrAppend(&p.Attrs, &Comment{Text: c})
+	return p
+}
+
+// AddAttrs adds additional attributes to the procedure.
+func (p *Proc) AddAttrs(attrs ...Attr) *Proc {
+	p.Attrs = append(p.Attrs, attrs...)
+	return p
+}
+
+// AddDeps adds the given dependencies to the procedure.
+func (p *Proc) AddDeps(deps ...Object) *Proc {
+	p.Deps = append(p.Deps, deps...)
+	addRefs(p, deps)
+	return p
+}
+
+// AddRefs adds references to the procedure.
+func (p *Proc) AddRefs(refs ...Object) {
+	p.Refs = append(p.Refs, refs...)
+	SortRefs(p.Refs)
+}
+
+// RemoveDep removes the given object from the procedure dependencies.
+func (p *Proc) RemoveDep(o Object) {
+	p.Deps = removeObj(p.Deps, o)
+}
+
+// NewTrigger creates a new Trigger with the given name.
+func NewTrigger(name string) *Trigger {
+	return &Trigger{Name: name}
+}
+
+// SetTable sets the table the trigger is defined on.
+func (t *Trigger) SetTable(tb *Table) *Trigger {
+	t.Table, t.View = tb, nil
+	return t
+}
+
+// SetView sets the view the trigger is defined on.
+func (t *Trigger) SetView(v *View) *Trigger {
+	t.View, t.Table = v, nil
+	return t
+}
+
+// SetActionTime sets the action time of the trigger.
+func (t *Trigger) SetActionTime(at TriggerTime) *Trigger {
+	t.ActionTime = at
+	return t
+}
+
+// AddEvents adds the given events to the trigger.
+func (t *Trigger) AddEvents(events ...TriggerEvent) *Trigger {
+	t.Events = append(t.Events, events...)
+	return t
+}
+
+// SetFor sets the FOR EACH spec of the trigger.
+func (t *Trigger) SetFor(f TriggerFor) *Trigger {
+	t.For = f
+	return t
+}
+
+// SetBody sets the body of the trigger.
+func (t *Trigger) SetBody(b string) *Trigger {
+	t.Body = b
+	return t
+}
+
+// SetWhen sets or appends the TriggerWhen attribute to the trigger with the given condition.
+func (t *Trigger) SetWhen(x string) *Trigger {
+	ReplaceOrAppend(&t.Attrs, &TriggerWhen{X: x})
+	return t
+}
+
+// SetComment sets or appends the Comment attribute to the trigger with the given value.
+func (t *Trigger) SetComment(c string) *Trigger {
+	ReplaceOrAppend(&t.Attrs, &Comment{Text: c})
+	return t
+}
+
+// AddAttrs adds additional attributes to the trigger.
+func (t *Trigger) AddAttrs(attrs ...Attr) *Trigger {
+	t.Attrs = append(t.Attrs, attrs...)
+	return t
+}
+
+// AddDeps adds the given dependencies to the trigger.
+func (t *Trigger) AddDeps(deps ...Object) *Trigger {
+	t.Deps = append(t.Deps, deps...)
+	addRefs(t, deps)
+	return t
+}
+
+// RemoveDep removes the given object from the trigger dependencies.
+func (t *Trigger) RemoveDep(o Object) {
+	t.Deps = removeObj(t.Deps, o)
+}
+
 // SetCharset sets or appends the Charset attribute
 // to the table with the given value.
 func (t *Table) SetCharset(v string) *Table {
@@ -242,6 +484,15 @@ func (t *Table) AddIndexes(indexes ...*Index) *Table {
 	return t
 }
 
+// AddTriggers adds triggers to the table.
+func (t *Table) AddTriggers(triggers ...*Trigger) *Table {
+	for _, tr := range triggers {
+		tr.SetTable(t)
+	}
+	t.Triggers = append(t.Triggers, triggers...)
+	return t
+}
+
 // AddForeignKeys appends the given foreign-keys to the table foreign-key list.
 func (t *Table) AddForeignKeys(fks ...*ForeignKey) *Table {
 	for _, fk := range fks {
@@ -304,6 +555,14 @@ func SortRefs(refs []Object) {
 		switch o1 := a.(type) {
 		case *Table:
 			return strings.Compare(o1.Name, b.(*Table).Name)
+		case *View:
+			return strings.Compare(o1.Name, b.(*View).Name)
+		case *Func:
+			return strings.Compare(o1.Name, b.(*Func).Name)
+		case *Proc:
+			return strings.Compare(o1.Name, b.(*Proc).Name)
+		case *Trigger:
+			return strings.Compare(o1.Name, b.(*Trigger).Name)
 		default:
 			return 0
 		}
@@ -722,6 +981,12 @@ func (i *Index) SetTable(t *Table) *Index {
 	return i
 }
 
+// SetView configures the (materialized) view of the index.
+func (i *Index) SetView(v *View) *Index {
+	i.View, i.Table = v, nil
+	return i
+}
+
 // SetComment sets or appends the Comment attribute
 // to the index with the given value.
 func (i *Index) SetComment(v string) *Index {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -57,6 +58,7 @@ Filter:
 		schemas = append(schemas, s)
 	}
 	r.Schemas = schemas
+	prunePermissions(r)
 	return r, nil
 }
 
@@ -126,6 +128,23 @@ func excludeS(s *Schema, glob []string) (err error) {
 		}
 		s.Tables = tables
 	}
+	if globV, exclude := excludeType(typeV, glob[0]); exclude {
+		var views []*View
+		for _, v := range s.Views {
+			match, err := filepath.Match(globV, v.Name)
+			if err != nil {
+				return err
+			}
+			// Views are excluded only by single globs, as
+			// their columns are derived from the definition.
+			if match && len(glob) == 1 {
+				detachObject(v, v.Refs)
+				continue
+			}
+			views = append(views, v)
+		}
+		s.Views = views
+	}
 	return nil
 }
 
@@ -176,6 +195,11 @@ func excludeT(t *Table, pattern string) (err error) {
 			return true, nil
 		})
 	}
+	if p, exclude := excludeType(typeR, pattern); exclude {
+		t.Triggers, err = filter(t.Triggers, func(tr *Trigger) (bool, error) {
+			return filepath.Match(p, tr.Name)
+		})
+	}
 	return
 }
 
@@ -222,11 +246,13 @@ func excludeObjects(all []Object, glob []string) ([]Object, error) {
 
 const (
 	typeT = "table"
+	typeV = "view"
 	typeS = "schema"
 	typeC = "column"
 	typeI = "index"
 	typeF = "fk"
 	typeK = "check"
+	typeR = "trigger"
 )
 
 var reType = regexp.MustCompile(`\[type=([a-z|_]+)+\]$`)
@@ -270,12 +296,267 @@ func detachObject(o Object, refs []Object) {
 	}
 }
 
-// IncludeRealm is a no-op for the community version.
-func IncludeRealm(r *Realm, _ []string) (*Realm, error) {
-	return r, nil // Unimplemented.
+// IncludeRealm filters resources in the realm based on the given patterns. Only
+// resources that match at least one of the patterns are kept in the realm.
+func IncludeRealm(r *Realm, patterns []string) (*Realm, error) {
+	if len(patterns) == 0 {
+		return r, nil
+	}
+	globs, err := split(patterns)
+	if err != nil {
+		return nil, err
+	}
+	var single [][]string
+	for i, g := range globs {
+		if len(g) > 3 {
+			return nil, fmt.Errorf("too many parts in pattern: %q", patterns[i])
+		}
+		if len(g) == 1 {
+			single = append(single, g)
+		}
+	}
+	// Realm objects are top-level resources, much like
+	// schemas. Hence, only single globs can include them.
+	if r.Objects, err = includeObjects(r.Objects, single); err != nil {
+		return nil, err
+	}
+	var schemas []*Schema
+	for _, s := range r.Schemas {
+		var (
+			all  bool
+			subs [][]string
+		)
+		for _, g := range globs {
+			globS, include := excludeType(typeS, g[0])
+			if !include {
+				continue
+			}
+			match, err := filepath.Match(globS, s.Name)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				// In case there is a match, and it is a
+				// single glob, the schema is fully included.
+				all = all || len(g) == 1
+				if len(g) > 1 {
+					subs = append(subs, g[1:])
+				}
+			}
+		}
+		switch {
+		case all:
+			schemas = append(schemas, s)
+		case len(subs) > 0:
+			if err := includeS(s, subs); err != nil {
+				return nil, err
+			}
+			schemas = append(schemas, s)
+		}
+	}
+	r.Schemas = schemas
+	prunePermissions(r)
+	return r, nil
+}
+
+// prunePermissions removes the permissions that were granted
+// to roles, users or resources that were filtered from the realm.
+func prunePermissions(r *Realm) {
+	objects := make([]Object, 0, len(r.Objects))
+	for _, o := range r.Objects {
+		p, ok := o.(*Permission)
+		if !ok {
+			objects = append(objects, o)
+			continue
+		}
+		if !slices.Contains(r.Objects, p.To) || !slices.Contains(r.Schemas, p.Schema) {
+			continue
+		}
+		if p.Table != nil {
+			if !slices.Contains(p.Schema.Tables, p.Table) {
+				continue
+			}
+			if len(p.Columns) > 0 {
+				columns := slices.DeleteFunc(slices.Clone(p.Columns), func(c *Column) bool {
+					return !slices.Contains(p.Table.Columns, c)
+				})
+				if len(columns) == 0 {
+					continue
+				}
+				p.Columns = columns
+			}
+		}
+		objects = append(objects, p)
+	}
+	r.Objects = objects
+}
+
+// IncludeSchema filters resources in the schema based on the given patterns. Only
+// resources that match at least one of the patterns are kept in the schema.
+func IncludeSchema(s *Schema, patterns []string) (*Schema, error) {
+	if len(patterns) == 0 {
+		return s, nil
+	}
+	globs, err := split(patterns)
+	if err != nil {
+		return nil, err
+	}
+	for i, g := range globs {
+		if len(g) > 2 {
+			return nil, fmt.Errorf("too many parts in pattern: %q", patterns[i])
+		}
+	}
+	if err := includeS(s, globs); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func includeS(s *Schema, globs [][]string) (err error) {
+	if s.Objects, err = includeObjects(s.Objects, globs); err != nil {
+		return err
+	}
+	var tables []*Table
+	for _, t := range s.Tables {
+		var (
+			all  bool
+			subs []string
+		)
+		for _, g := range globs {
+			globT, include := excludeType(typeT, g[0])
+			if !include {
+				continue
+			}
+			match, err := filepath.Match(globT, t.Name)
+			if err != nil {
+				return err
+			}
+			if match {
+				// In case there is a match, and it is a
+				// single glob, the table is fully included.
+				all = all || len(g) == 1
+				if len(g) > 1 {
+					subs = append(subs, g[1])
+				}
+			}
+		}
+		switch {
+		case all:
+			tables = append(tables, t)
+		case len(subs) > 0:
+			if err := includeT(t, subs); err != nil {
+				return err
+			}
+			tables = append(tables, t)
+		default:
+			detachObject(t, t.Refs)
+		}
+	}
+	s.Tables = tables
+	s.Views, err = filter(s.Views, func(v *View) (bool, error) {
+		for _, g := range globs {
+			// Views are included only by single globs, as
+			// their columns are derived from the definition.
+			if globV, include := excludeType(typeV, g[0]); include && len(g) == 1 {
+				if match, err := filepath.Match(globV, v.Name); match || err != nil {
+					return false, err
+				}
+			}
+		}
+		detachObject(v, v.Refs)
+		return true, nil
+	})
+	return err
+}
+
+func includeT(t *Table, patterns []string) (err error) {
+	// matchAny reports if the name matches one of the patterns
+	// that select the given type of resource.
+	matchAny := func(typ, name string) (bool, error) {
+		for _, p := range patterns {
+			if p, include := excludeType(typ, p); include {
+				if match, err := filepath.Match(p, name); match || err != nil {
+					return match, err
+				}
+			}
+		}
+		return false, nil
+	}
+	included := make(map[*Column]struct{})
+	if t.Columns, err = filter(t.Columns, func(c *Column) (bool, error) {
+		match, err := matchAny(typeC, c.Name)
+		if match {
+			included[c] = struct{}{}
+		}
+		return !match, err
+	}); err != nil {
+		return err
+	}
+	// Indexes and foreign keys are included only if all their columns were included.
+	hasColumns := func(columns []*Column) bool {
+		for _, c := range columns {
+			if _, ok := included[c]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	if t.Indexes, err = filter(t.Indexes, func(idx *Index) (bool, error) {
+		match, err := matchAny(typeI, idx.Name)
+		if err != nil {
+			return false, err
+		}
+		columns := make([]*Column, 0, len(idx.Parts))
+		for _, p := range idx.Parts {
+			if p.C != nil {
+				columns = append(columns, p.C)
+			}
+		}
+		return !match || !hasColumns(columns), nil
+	}); err != nil {
+		return err
+	}
+	if t.ForeignKeys, err = filter(t.ForeignKeys, func(fk *ForeignKey) (bool, error) {
+		match, err := matchAny(typeF, fk.Symbol)
+		if err != nil {
+			return false, err
+		}
+		return !match || !hasColumns(fk.Columns), nil
+	}); err != nil {
+		return err
+	}
+	if t.Attrs, err = filter(t.Attrs, func(a Attr) (bool, error) {
+		c, ok := a.(*Check)
+		if !ok {
+			return false, nil
+		}
+		match, err := matchAny(typeK, c.Name)
+		return !match, err
+	}); err != nil {
+		return err
+	}
+	t.Triggers, err = filter(t.Triggers, func(tr *Trigger) (bool, error) {
+		match, err := matchAny(typeR, tr.Name)
+		return !match, err
+	})
+	return err
 }
 
-// IncludeSchema is a no-op for the community version.
-func IncludeSchema(s *Schema, _ []string) (*Schema, error) {
-	return s, nil // Unimplemented.
+// includeObjects returns the objects that match at least one of the given globs.
+// Objects that do not implement the SpecTypeNamer interface are always included.
+func includeObjects(all []Object, globs [][]string) ([]Object, error) {
+	return filter(all, func(o Object) (bool, error) {
+		nt, ok := o.(SpecTypeNamer)
+		if !ok {
+			return false, nil
+		}
+		for _, g := range globs {
+			if glob, include := excludeType(nt.SpecType(), g[0]); include {
+				if match, err := filepath.Match(glob, nt.SpecName()); match || err != nil {
+					return false, err
+				}
+			}
+		}
+		return true, nil
+	})
 }
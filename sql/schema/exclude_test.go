@@ -0,0 +1,171 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema_test
+
+import (
+	"testing"
+
+	"ariga.io/atlas/sql/schema"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testRealm returns a realm with two schemas, and a role.
+func testRealm() *schema.Realm {
+	users := schema.NewTable("users").
+		AddColumns(
+			schema.NewIntColumn("id", "int"),
+			schema.NewStringColumn("name", "text"),
+		)
+	users.AddIndexes(
+		schema.NewIndex("name").AddColumns(users.Columns[1]),
+		schema.NewIndex("id_name").AddColumns(users.Columns...),
+	)
+	posts := schema.NewTable("posts").
+		AddColumns(schema.NewIntColumn("id", "int"))
+	public := schema.New("public").
+		AddTables(users, posts).
+		AddViews(schema.NewView("active_users", "SELECT * FROM users"))
+	public.AddObjects(schema.NewFunc("now_utc", "SELECT now()").SetSchema(public))
+	internal := schema.New("internal").
+		AddTables(schema.NewTable("logs").AddColumns(schema.NewIntColumn("id", "int")))
+	return schema.NewRealm(public, internal).
+		AddObjects(&schema.Role{Name: "admin"})
+}
+
+func TestIncludeRealm(t *testing.T) {
+	r, err := schema.IncludeRealm(testRealm(), nil)
+	require.NoError(t, err)
+	require.Len(t, r.Schemas, 2)
+	require.Len(t, r.Objects, 1)
+
+	// Schemas are fully included.
+	r, err = schema.IncludeRealm(testRealm(), []string{"public"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public"}, schemaNames(r))
+	require.Equal(t, []string{"users", "posts"}, tableNames(r.Schemas[0]))
+	require.Len(t, r.Schemas[0].Views, 1)
+	require.Len(t, r.Schemas[0].Objects, 1)
+	require.Empty(t, r.Objects)
+
+	// Tables are selected by schema.table patterns.
+	r, err = schema.IncludeRealm(testRealm(), []string{"*.users", "internal.log*"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public", "internal"}, schemaNames(r))
+	require.Equal(t, []string{"users"}, tableNames(r.Schemas[0]))
+	require.Len(t, r.Schemas[0].Tables[0].Columns, 2)
+	require.Empty(t, r.Schemas[0].Views)
+	require.Empty(t, r.Schemas[0].Objects)
+	require.Equal(t, []string{"logs"}, tableNames(r.Schemas[1]))
+
+	// Columns and indexes are selected by schema.table.column patterns.
+	// Indexes are included only if all their columns were included.
+	r, err = schema.IncludeRealm(testRealm(), []string{"public.users.name", "public.users.id_name"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public"}, schemaNames(r))
+	users := r.Schemas[0].Tables[0]
+	require.Len(t, users.Columns, 1)
+	require.Equal(t, "name", users.Columns[0].Name)
+	require.Len(t, users.Indexes, 1)
+	require.Equal(t, "name", users.Indexes[0].Name)
+
+	// Type selectors.
+	r, err = schema.IncludeRealm(testRealm(), []string{"public.*[type=view|function]"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public"}, schemaNames(r))
+	require.Empty(t, r.Schemas[0].Tables)
+	require.Len(t, r.Schemas[0].Views, 1)
+	require.Len(t, r.Schemas[0].Objects, 1)
+
+	r, err = schema.IncludeRealm(testRealm(), []string{"*[type=role]"})
+	require.NoError(t, err)
+	require.Empty(t, r.Schemas)
+	require.Len(t, r.Objects, 1)
+
+	r, err = schema.IncludeRealm(testRealm(), []string{"*.*.id[type=column]"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public", "internal"}, schemaNames(r))
+	require.Equal(t, []string{"users", "posts"}, tableNames(r.Schemas[0]))
+	require.Len(t, r.Schemas[0].Tables[0].Columns, 1)
+	require.Empty(t, r.Schemas[0].Tables[0].Indexes)
+
+	_, err = schema.IncludeRealm(testRealm(), []string{"a.b.c.d"})
+	require.EqualError(t, err, `too many parts in pattern: "a.b.c.d"`)
+}
+
+func TestIncludeSchema(t *testing.T) {
+	s, err := schema.IncludeSchema(testRealm().Schemas[0], []string{"users"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"users"}, tableNames(s))
+	require.Empty(t, s.Views)
+	require.Empty(t, s.Objects)
+
+	s, err = schema.IncludeSchema(testRealm().Schemas[0], []string{"*[type=table]"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"users", "posts"}, tableNames(s))
+	require.Empty(t, s.Views)
+	require.Empty(t, s.Objects)
+
+	s, err = schema.IncludeSchema(testRealm().Schemas[0], []string{"active_*[type=view]", "*[type=function]"})
+	require.NoError(t, err)
+	require.Empty(t, s.Tables)
+	require.Len(t, s.Views, 1)
+	require.Len(t, s.Objects, 1)
+
+	s, err = schema.IncludeSchema(testRealm().Schemas[0], []string{"users.id"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"users"}, tableNames(s))
+	require.Len(t, s.Tables[0].Columns, 1)
+	require.Equal(t, "id", s.Tables[0].Columns[0].Name)
+
+	_, err = schema.IncludeSchema(testRealm().Schemas[0], []string{"a.b.c"})
+	require.EqualError(t, err, `too many parts in pattern: "a.b.c"`)
+}
+
+func TestIncludeExclude(t *testing.T) {
+	// Drivers apply the include patterns first, and
+	// then exclude resources from the filtered result.
+	r, err := schema.IncludeRealm(testRealm(), []string{"public"})
+	require.NoError(t, err)
+	r, err = schema.ExcludeRealm(r, []string{"public.posts", "public.users.name"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"public"}, schemaNames(r))
+	require.Equal(t, []string{"users"}, tableNames(r.Schemas[0]))
+	require.Len(t, r.Schemas[0].Tables[0].Columns, 1)
+	require.Equal(t, "id", r.Schemas[0].Tables[0].Columns[0].Name)
+	require.Len(t, r.Schemas[0].Views, 1)
+
+	// Excluding an included resource removes it.
+	r, err = schema.IncludeRealm(testRealm(), []string{"*.users"})
+	require.NoError(t, err)
+	r, err = schema.ExcludeRealm(r, []string{"public"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"internal"}, schemaNames(r))
+	require.Empty(t, r.Schemas[0].Tables)
+
+	s, err := schema.IncludeSchema(testRealm().Schemas[0], []string{"*[type=table|view]"})
+	require.NoError(t, err)
+	s, err = schema.ExcludeSchema(s, []string{"*[type=view]", "users.id"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"users", "posts"}, tableNames(s))
+	require.Len(t, s.Tables[0].Columns, 1)
+	require.Empty(t, s.Views)
+}
+
+func schemaNames(r *schema.Realm) []string {
+	names := make([]string, len(r.Schemas))
+	for i, s := range r.Schemas {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func tableNames(s *schema.Schema) []string {
+	names := make([]string, len(s.Tables))
+	for i, t := range s.Tables {
+		names[i] = t.Name
+	}
+	return names
+}
@@ -59,6 +59,11 @@ const (
 
 	// InspectTriggers enables schema triggers inspection.
 	InspectTriggers
+
+	// InspectRoles enables inspection of realm-level roles, users and
+	// their privileges. Unlike the other modes, it is not enabled by
+	// default and must be set explicitly.
+	InspectRoles
 )
 
 // Is reports whether the given mode is enabled.
@@ -87,6 +87,58 @@ type (
 		From, To *Table
 	}
 
+	// AddView describes a view creation change.
+	AddView struct {
+		V     *View
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropView describes a view removal change.
+	DropView struct {
+		V     *View
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyView describes a view modification change.
+	ModifyView struct {
+		From, To *View
+		// Changes that are extra to the view definition.
+		// For example, adding, dropping, or modifying the
+		// view comment.
+		Changes []Change
+	}
+
+	// RenameView describes a view rename change.
+	RenameView struct {
+		From, To *View
+	}
+
+	// AddTrigger describes a trigger creation change.
+	AddTrigger struct {
+		T     *Trigger
+		Extra []Clause // Extra clauses and options.
+	}
+
+	// DropTrigger describes a trigger removal change.
+	DropTrigger struct {
+		T     *Trigger
+		Extra []Clause // Extra clauses.
+	}
+
+	// ModifyTrigger describes a trigger modification change.
+	ModifyTrigger struct {
+		From, To *Trigger
+		// Changes that are extra to the trigger definition.
+		// For example, adding, dropping, or modifying the
+		// trigger comment.
+		Changes []Change
+	}
+
+	// RenameTrigger describes a trigger rename change.
+	RenameTrigger struct {
+		From, To *Trigger
+	}
+
 	// AddObject describes a generic object creation change.
 	AddObject struct {
 		O     Object
@@ -235,6 +287,10 @@ type (
 	// IfNotExists represents a clause in a schema change that is commonly
 	// supported by multiple statements (e.g. CREATE TABLE or CREATE SCHEMA).
 	IfNotExists struct{}
+
+	// OrReplace represents a clause in a schema change that is commonly
+	// supported by multiple statements (e.g. CREATE VIEW or CREATE FUNCTION).
+	OrReplace struct{}
 )
 
 // A ChangeKind describes a change kind that can be combined
@@ -475,6 +531,24 @@ func (c Changes) LastIndexDropTable(name string) int {
 	})
 }
 
+// IndexAddView returns the index of the first AddView in the changes
+// with the given name, or -1 if there is no such change in the Changes.
+func (c Changes) IndexAddView(name string) int {
+	return c.search(func(c Change) bool {
+		a, ok := c.(*AddView)
+		return ok && a.V.Name == name
+	})
+}
+
+// IndexDropView returns the index of the first DropView in the changes
+// with the given name, or -1 if there is no such change in the Changes.
+func (c Changes) IndexDropView(name string) int {
+	return c.search(func(c Change) bool {
+		d, ok := c.(*DropView)
+		return ok && d.V.Name == name
+	})
+}
+
 // IndexAddColumn returns the index of the first AddColumn in the changes
 // with the given name, or -1 if there is no such change in the Changes.
 func (c Changes) IndexAddColumn(name string) int {
@@ -567,6 +641,14 @@ func (*AddTable) change()         {}
 func (*DropTable) change()        {}
 func (*ModifyTable) change()      {}
 func (*RenameTable) change()      {}
+func (*AddView) change()          {}
+func (*DropView) change()         {}
+func (*ModifyView) change()       {}
+func (*RenameView) change()       {}
+func (*AddTrigger) change()       {}
+func (*DropTrigger) change()      {}
+func (*ModifyTrigger) change()    {}
+func (*RenameTrigger) change()    {}
 func (*AddObject) change()        {}
 func (*DropObject) change()       {}
 func (*ModifyObject) change()     {}
@@ -593,3 +675,4 @@ func (*RenameConstraint) change() {}
 // clauses.
 func (*IfExists) clause()    {}
 func (*IfNotExists) clause() {}
+func (*OrReplace) clause()   {}
@@ -36,6 +36,18 @@ func TestChanges_IndexDropTable(t *testing.T) {
 	require.Equal(t, -1, changes.IndexDropTable("post_tags"))
 }
 
+func TestChanges_IndexAddView(t *testing.T) {
+	changes := schema.Changes{
+		&schema.AddTable{T: schema.NewTable("users")},
+		&schema.DropView{V: schema.NewView("active", "")},
+		&schema.AddView{V: schema.NewView("active", "")},
+	}
+	require.Equal(t, 2, changes.IndexAddView("active"))
+	require.Equal(t, -1, changes.IndexAddView("users"))
+	require.Equal(t, 1, changes.IndexDropView("active"))
+	require.Equal(t, -1, changes.IndexDropView("inactive"))
+}
+
 func TestChanges_IndexAddColumn(t *testing.T) {
 	changes := schema.Changes{
 		&schema.AddColumn{C: schema.NewColumn("name")},
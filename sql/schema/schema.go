@@ -44,6 +44,7 @@ type (
 		Indexes     []*Index
 		PrimaryKey  *Index
 		ForeignKeys []*ForeignKey
+		Triggers    []*Trigger
 		Attrs       []Attr   // Attrs, constraints and options.
 		Deps        []Object // Objects this table depends on.
 		Refs        []Object // Objects that depends on this table.
@@ -51,12 +52,69 @@ type (
 
 	// A View represents a view definition.
 	View struct {
-		Name    string
-		Schema  *Schema
-		Def     string
-		Columns []*Column
+		Name     string
+		Schema   *Schema
+		Def      string
+		Columns  []*Column
+		Indexes  []*Index // Indexes of materialized views.
+		Triggers []*Trigger
+		Attrs    []Attr   // Attrs, comments and options.
+		Deps     []Object // Objects this view depends on.
+		Refs     []Object // Objects that depends on this view.
+	}
+
+	// A Func represents a function definition.
+	Func struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Ret    Type     // Return type.
+		Lang   string   // Language, if supported by the driver.
+		Body   string   // Function body only.
+		Attrs  []Attr   // Attrs, comments and options (e.g., volatility).
+		Deps   []Object // Objects this function depends on.
+		Refs   []Object // Objects that depends on this function.
+	}
+
+	// A Proc represents a procedure (stored procedure) definition.
+	Proc struct {
+		Name   string
+		Schema *Schema
+		Args   []*FuncArg
+		Lang   string   // Language, if supported by the driver.
+		Body   string   // Procedure body only.
+		Attrs  []Attr   // Attrs, comments and options.
+		Deps   []Object // Objects this procedure depends on.
+		Refs   []Object // Objects that depends on this procedure.
+	}
+
+	// A Trigger represents a trigger definition. A trigger is
+	// defined either on a table or on a view, but not both.
+	Trigger struct {
+		Name       string
+		Table      *Table         // Table the trigger is defined on.
+		View       *View          // View the trigger is defined on.
+		ActionTime TriggerTime    // BEFORE, AFTER or INSTEAD OF.
+		Events     []TriggerEvent // INSERT, UPDATE, DELETE, etc.
+		For        TriggerFor     // FOR EACH ROW or FOR EACH STATEMENT.
+		Body       string         // Trigger body, or the function execution.
+		Attrs      []Attr         // Attrs, comments and options (e.g., WHEN).
+		Deps       []Object       // Objects this trigger depends on.
+	}
+
+	// A TriggerEvent represents a trigger event. e.g., INSERT or UPDATE OF.
+	TriggerEvent struct {
+		Name    string    // e.g., INSERT, UPDATE, DELETE or TRUNCATE.
+		Columns []*Column // Columns of the UPDATE OF event, if exists.
+	}
+
+	// A FuncArg represents a single function or procedure argument.
+	FuncArg struct {
+		Name    string      // Optional name.
+		Type    Type        // Argument type.
+		Default Expr        // Default value, if exists.
+		Mode    FuncArgMode // Argument mode. Empty means IN.
 		Attrs   []Attr
-		Deps    []Object // Objects this view depends on.
 	}
 
 	// A Column represents a column definition.
@@ -88,6 +146,7 @@ type (
 		Name   string
 		Unique bool
 		Table  *Table
+		View   *View // Materialized view the index is defined on, if exists.
 		Attrs  []Attr
 		Parts  []*IndexPart
 	}
@@ -118,6 +177,35 @@ type (
 		Attrs      []Attr
 	}
 
+	// A Role represents a realm-level role, a named group of privileges
+	// that can be granted to users or to other roles.
+	Role struct {
+		Name     string
+		MemberOf []*Role // Roles this role is a member of.
+		Attrs    []Attr  // Attrs and options (e.g., connection limit).
+	}
+
+	// A User represents a realm-level account that can connect to the database.
+	User struct {
+		Name     string
+		Host     string  // Host the account connects from, if supported (e.g., MySQL).
+		MemberOf []*Role // Roles granted to the user.
+		Attrs    []Attr  // Attrs and options (e.g., connection limit).
+	}
+
+	// A Permission represents a set of privileges granted to a role or a user
+	// on a schema resource. Table-level privileges are granted if Table is set,
+	// and column-level privileges are granted if Columns are set as well.
+	// Otherwise, the privileges are granted on the schema itself.
+	Permission struct {
+		To         Object    // Grantee. Either a *Role or a *User.
+		Schema     *Schema   // Schema of the granted resource.
+		Table      *Table    // Optional table of the granted resource.
+		Columns    []*Column // Optional table columns the privileges are granted on.
+		Privileges []string  // Privileges, e.g., SELECT or INSERT.
+		Grantable  bool      // Grantee can grant these privileges to others.
+		Attrs      []Attr
+	}
 )
 
 // Schema returns the first schema that matched the given name.
@@ -140,6 +228,26 @@ func (r *Realm) Object(f func(Object) bool) (Object, bool) {
 	return nil, false
 }
 
+// Role returns the first role that matched the given name.
+func (r *Realm) Role(name string) (*Role, bool) {
+	for _, o := range r.Objects {
+		if r1, ok := o.(*Role); ok && r1.Name == name {
+			return r1, true
+		}
+	}
+	return nil, false
+}
+
+// User returns the first user that matched the given name and host.
+func (r *Realm) User(name, host string) (*User, bool) {
+	for _, o := range r.Objects {
+		if u, ok := o.(*User); ok && u.Name == name && u.Host == host {
+			return u, true
+		}
+	}
+	return nil, false
+}
+
 // PosSetter wraps the two methods for getting
 // and setting positions for schema objects.
 type PosSetter interface {
@@ -177,6 +285,36 @@ func (s *Schema) Table(name string) (*Table, bool) {
 	return nil, false
 }
 
+// View returns the first view that matched the given name.
+func (s *Schema) View(name string) (*View, bool) {
+	for _, v := range s.Views {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Func returns the first function that matched the given name.
+func (s *Schema) Func(name string) (*Func, bool) {
+	for _, o := range s.Objects {
+		if f, ok := o.(*Func); ok && f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Proc returns the first procedure that matched the given name.
+func (s *Schema) Proc(name string) (*Proc, bool) {
+	for _, o := range s.Objects {
+		if p, ok := o.(*Proc); ok && p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
 // Object returns the first object that matched the given predicate.
 func (s *Schema) Object(f func(Object) bool) (Object, bool) {
 	for _, o := range s.Objects {
@@ -207,6 +345,96 @@ func (t *Table) Pos() *Pos {
 	return nil
 }
 
+// Column returns the first column that matched the given name.
+func (v *View) Column(name string) (*Column, bool) {
+	for _, c := range v.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Trigger returns the first trigger that matched the given name.
+func (t *Table) Trigger(name string) (*Trigger, bool) {
+	for _, tr := range t.Triggers {
+		if tr.Name == name {
+			return tr, true
+		}
+	}
+	return nil, false
+}
+
+// Index returns the first index that matched the given name.
+func (v *View) Index(name string) (*Index, bool) {
+	for _, i := range v.Indexes {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return nil, false
+}
+
+// Materialized reports if the view is a materialized view.
+func (v *View) Materialized() bool {
+	for _, a := range v.Attrs {
+		if _, ok := a.(*Materialized); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Trigger returns the first trigger that matched the given name.
+func (v *View) Trigger(name string) (*Trigger, bool) {
+	for _, tr := range v.Triggers {
+		if tr.Name == name {
+			return tr, true
+		}
+	}
+	return nil, false
+}
+
+// Pos of the view, if exists.
+func (v *View) Pos() *Pos {
+	for _, a := range v.Attrs {
+		if p, ok := a.(*Pos); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// Pos of the function, if exists.
+func (f *Func) Pos() *Pos {
+	for _, a := range f.Attrs {
+		if p, ok := a.(*Pos); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// Pos of the procedure, if exists.
+func (p *Proc) Pos() *Pos {
+	for _, a := range p.Attrs {
+		if p1, ok := a.(*Pos); ok {
+			return p1
+		}
+	}
+	return nil
+}
+
+// Pos of the trigger, if exists.
+func (t *Trigger) Pos() *Pos {
+	for _, a := range t.Attrs {
+		if p, ok := a.(*Pos); ok {
+			return p
+		}
+	}
+	return nil
+}
+
 // Index returns the first index that matched the given name.
 func (t *Table) Index(name string) (*Index, bool) {
 	for _, i := range t.Indexes {
@@ -252,6 +480,26 @@ func (t *Table) SetPos(p *Pos) {
 	ReplaceOrAppend(&t.Attrs, p)
 }
 
+// SetPos sets the position of the view.
+func (v *View) SetPos(p *Pos) {
+	ReplaceOrAppend(&v.Attrs, p)
+}
+
+// SetPos sets the position of the function.
+func (f *Func) SetPos(p *Pos) {
+	ReplaceOrAppend(&f.Attrs, p)
+}
+
+// SetPos sets the position of the procedure.
+func (p *Proc) SetPos(p1 *Pos) {
+	ReplaceOrAppend(&p.Attrs, p1)
+}
+
+// SetPos sets the position of the trigger.
+func (t *Trigger) SetPos(p *Pos) {
+	ReplaceOrAppend(&t.Attrs, p)
+}
+
 // SetPos sets the position of the column.
 func (c *Column) SetPos(p *Pos) {
 	ReplaceOrAppend(&c.Attrs, p)
@@ -360,6 +608,57 @@ const (
 	SetDefault ReferenceOption = "SET DEFAULT"
 )
 
+// Check options specified by the WITH CHECK OPTION clause of a view.
+const (
+	ViewCheckOptionLocal    = "LOCAL"
+	ViewCheckOptionCascaded = "CASCADED"
+)
+
+type (
+	// TriggerTime represents the trigger action time.
+	TriggerTime string
+
+	// TriggerFor represents the trigger FOR EACH spec.
+	TriggerFor string
+)
+
+// List of trigger action times.
+const (
+	TriggerTimeBefore  TriggerTime = "BEFORE"
+	TriggerTimeAfter   TriggerTime = "AFTER"
+	TriggerTimeInstead TriggerTime = "INSTEAD OF"
+)
+
+// List of trigger FOR EACH specs.
+const (
+	TriggerForRow  TriggerFor = "ROW"
+	TriggerForStmt TriggerFor = "STATEMENT"
+)
+
+// List of trigger events.
+var (
+	TriggerEventInsert   = TriggerEvent{Name: "INSERT"}
+	TriggerEventUpdate   = TriggerEvent{Name: "UPDATE"}
+	TriggerEventDelete   = TriggerEvent{Name: "DELETE"}
+	TriggerEventTruncate = TriggerEvent{Name: "TRUNCATE"}
+)
+
+// TriggerEventUpdateOf returns an UPDATE OF trigger event for the given columns.
+func TriggerEventUpdateOf(columns ...*Column) TriggerEvent {
+	return TriggerEvent{Name: "UPDATE OF", Columns: columns}
+}
+
+// FuncArgMode represents the mode of a function or procedure argument.
+type FuncArgMode string
+
+// List of argument modes supported by the different drivers.
+const (
+	FuncArgModeIn       FuncArgMode = "IN"
+	FuncArgModeOut      FuncArgMode = "OUT"
+	FuncArgModeInOut    FuncArgMode = "INOUT"
+	FuncArgModeVariadic FuncArgMode = "VARIADIC"
+)
+
 type (
 	// A Type represents a database type. The types below implements this
 	// interface and can be used for describing schemas.
@@ -541,6 +840,32 @@ type (
 		Type string // Optional type. e.g. STORED or VIRTUAL.
 	}
 
+	// ViewCheckOption describes the standard 'WITH CHECK OPTION' clause of a view.
+	ViewCheckOption struct {
+		V string // LOCAL or CASCADED.
+	}
+
+	// TriggerWhen describes the WHEN condition of a trigger.
+	TriggerWhen struct {
+		X string
+	}
+
+	// Materialized describes a materialized view. That is, a view
+	// that its query results are persisted in a table-like form.
+	Materialized struct {
+		// NoData indicates the view is created without being populated
+		// (i.e., WITH NO DATA). On inspection, it indicates the view was
+		// not populated (or refreshed) yet.
+		NoData bool
+	}
+
+	// FuncVolatility describes the volatility (or determinism) classification
+	// of a function. e.g., IMMUTABLE, STABLE or VOLATILE in PostgreSQL, and
+	// DETERMINISTIC in MySQL.
+	FuncVolatility struct {
+		V string
+	}
+
 	// Pos is an attribute that holds the position of a schema element.
 	Pos struct {
 		// Filename is the name (or full path) of the file which loaded the schema element.
@@ -579,8 +904,16 @@ func (p *Pos) String() string {
 // objects.
 func (*Table) obj()    {}
 func (*View) obj()     {}
+func (*Func) obj()     {}
+func (*Proc) obj()     {}
+func (*Trigger) obj()  {}
 func (*EnumType) obj() {}
 
+// realm-level objects.
+func (*Role) obj()       {}
+func (*User) obj()       {}
+func (*Permission) obj() {}
+
 // constraints are objects.
 func (*Index) obj()        {}
 func (*Check) obj()        {}
@@ -612,6 +945,10 @@ func (*Comment) attr()         {}
 func (*Charset) attr()         {}
 func (*Collation) attr()       {}
 func (*GeneratedExpr) attr()   {}
+func (*ViewCheckOption) attr() {}
+func (*FuncVolatility) attr()  {}
+func (*TriggerWhen) attr()     {}
+func (*Materialized) attr()    {}
 
 // SpecType returns the type of the spec.
 func (e *EnumType) SpecType() string { return "enum" }
@@ -619,6 +956,33 @@ func (e *EnumType) SpecType() string { return "enum" }
 // SpecName returns the name of the spec.
 func (e *EnumType) SpecName() string { return e.T }
 
+// SpecType returns the type of the spec.
+func (*Materialized) SpecType() string { return "materialized" }
+
+// SpecType returns the type of the spec.
+func (*Func) SpecType() string { return "function" }
+
+// SpecName returns the name of the spec.
+func (f *Func) SpecName() string { return f.Name }
+
+// SpecType returns the type of the spec.
+func (*Proc) SpecType() string { return "procedure" }
+
+// SpecName returns the name of the spec.
+func (p *Proc) SpecName() string { return p.Name }
+
+// SpecType returns the type of the spec.
+func (*Role) SpecType() string { return "role" }
+
+// SpecName returns the name of the spec.
+func (r *Role) SpecName() string { return r.Name }
+
+// SpecType returns the type of the spec.
+func (*User) SpecType() string { return "user" }
+
+// SpecName returns the name of the spec.
+func (u *User) SpecName() string { return u.Name }
+
 // Underlying returns underlying the expression.
 func (n *NamedDefault) Underlying() Expr {
 	return n.Expr
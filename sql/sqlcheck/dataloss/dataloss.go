@@ -0,0 +1,377 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package dataloss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+type (
+	// Analyzer checks for column type changes that might lose data, such as shrinking
+	// strings or narrowing numeric types. If a dev database is available, the number of
+	// its rows that would be affected by the change is added to the diagnostic.
+	Analyzer struct {
+		sqlcheck.Options
+		Handler
+	}
+
+	// Handler holds the underlying driver handlers.
+	Handler struct {
+		// Length is the name of the database function that returns the
+		// number of characters in a string, e.g., CHAR_LENGTH. Existing
+		// rows of shrunk string columns are checked only if it is set.
+		Length string
+	}
+)
+
+// New creates a new data-loss Analyzer with the given options.
+func New(r *schemahcl.Resource, h Handler) (*Analyzer, error) {
+	az := &Analyzer{Handler: h}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing data_loss check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codeShrinkS  = sqlcheck.Code("DL101")
+	codeNarrowI  = sqlcheck.Code("DL102")
+	codeNarrowD  = sqlcheck.Code("DL103")
+	codeDropEnum = sqlcheck.Code("DL104")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*Analyzer) Name() string {
+	return "data_loss"
+}
+
+// Analyze implements sqlcheck.Analyzer.
+func (a *Analyzer) Analyze(ctx context.Context, p *sqlcheck.Pass) error {
+	var diags []sqlcheck.Diagnostic
+	for _, sc := range p.File.Changes {
+		for _, c := range sc.Changes {
+			switch c := c.(type) {
+			case *schema.ModifyTable:
+				if p.File.TableSpan(c.T)&sqlcheck.SpanAdded == 1 {
+					continue
+				}
+				for _, mc := range c.Changes {
+					mc, ok := mc.(*schema.ModifyColumn)
+					if !ok || !mc.Change.Is(schema.ChangeType) || p.File.ColumnSpan(c.T, mc.To)&sqlcheck.SpanAdded == 1 {
+						continue
+					}
+					n, ok := a.narrowed(p, c.T, mc)
+					if !ok {
+						continue
+					}
+					d := sqlcheck.Diagnostic{Code: n.code, Pos: sc.Stmt.Pos, Text: n.text}
+					// The count is informational only. The dev database is not expected to hold
+					// the data of the target database, and the change is reported regardless.
+					switch rows, err := a.affected(ctx, p, c.T, n.cond); {
+					case err != nil:
+					case rows == 0:
+						d.Text += ". No existing rows in the dev database are affected"
+					case rows == 1:
+						d.Text += ". 1 existing row in the dev database is affected"
+					default:
+						d.Text += fmt.Sprintf(". %d existing rows in the dev database are affected", rows)
+					}
+					diags = append(diags, d)
+				}
+			// Enum types that are defined as schema objects (e.g., PostgreSQL)
+			// are modified as objects, and not as the types of their columns.
+			case *schema.ModifyObject:
+				from, ok1 := c.From.(*schema.EnumType)
+				to, ok2 := c.To.(*schema.EnumType)
+				if !ok1 || !ok2 || enumAdded(p, to) {
+					continue
+				}
+				if removed := removedValues(from, to); len(removed) > 0 {
+					diags = append(diags, sqlcheck.Diagnostic{
+						Code: codeDropEnum,
+						Pos:  sc.Stmt.Pos,
+						Text: fmt.Sprintf("Removing enum value%s %s from enum type %q might fail on existing values", plural(removed), quoteValues(removed), to.T),
+					})
+				}
+			}
+		}
+	}
+	if len(diags) > 0 {
+		const reportText = "data loss changes detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+// narrowing describes a type change that might lose data.
+type narrowing struct {
+	code, text string
+	// cond is the condition that matches the affected
+	// rows, or nil if they cannot be detected.
+	cond func(b *sqlx.Builder)
+}
+
+// narrowed reports if the column type change might lose data.
+func (a *Analyzer) narrowed(p *sqlcheck.Pass, t *schema.Table, c *schema.ModifyColumn) (*narrowing, bool) {
+	if c.From.Type == nil || c.To.Type == nil {
+		return nil, false
+	}
+	var (
+		n        *narrowing
+		col      = func(b *sqlx.Builder) *sqlx.Builder { return b.Ident(c.From.Name) }
+		from, to = formatType(p, c.From.Type.Type), formatType(p, c.To.Type.Type)
+	)
+	switch ft := c.From.Type.Type.(type) {
+	case *schema.StringType:
+		tt, ok := c.To.Type.Type.(*schema.StringType)
+		// Unbounded strings cannot be shrunk.
+		if !ok || tt.Size == 0 || ft.Size != 0 && tt.Size >= ft.Size {
+			return nil, false
+		}
+		n = &narrowing{
+			code: codeShrinkS,
+			text: fmt.Sprintf("Shrinking column %q of table %q from %s to %s might truncate existing values", c.To.Name, t.Name, from, to),
+		}
+		if a.Length != "" {
+			n.cond = func(b *sqlx.Builder) {
+				call(b, a.Length, func(b *sqlx.Builder) { col(b) }).P(">", strconv.Itoa(tt.Size))
+			}
+		}
+	case *schema.IntegerType:
+		tt, ok := c.To.Type.Type.(*schema.IntegerType)
+		if !ok {
+			return nil, false
+		}
+		fMin, fMax, ok1 := intRange(ft)
+		tMin, tMax, ok2 := intRange(tt)
+		if !ok1 || !ok2 || tMin.Cmp(fMin) <= 0 && tMax.Cmp(fMax) >= 0 {
+			return nil, false
+		}
+		n = &narrowing{
+			code: codeNarrowI,
+			text: fmt.Sprintf("Narrowing column %q of table %q from %s to %s might fail on out-of-range values", c.To.Name, t.Name, from, to),
+			cond: func(b *sqlx.Builder) {
+				var conds []func(*sqlx.Builder)
+				if tMin.Cmp(fMin) > 0 {
+					conds = append(conds, func(b *sqlx.Builder) { col(b).P("<", tMin.String()) })
+				}
+				if tMax.Cmp(fMax) < 0 {
+					conds = append(conds, func(b *sqlx.Builder) { col(b).P(">", tMax.String()) })
+				}
+				or(b, conds)
+			},
+		}
+	case *schema.DecimalType:
+		tt, ok := c.To.Type.Type.(*schema.DecimalType)
+		if !ok || tt.Precision == 0 {
+			return nil, false
+		}
+		// Decimals without precision are unbounded.
+		overflow := ft.Precision == 0 || tt.Precision-tt.Scale < ft.Precision-ft.Scale
+		if !overflow && tt.Scale >= ft.Scale {
+			return nil, false
+		}
+		n = &narrowing{
+			code: codeNarrowD,
+			text: fmt.Sprintf("Lowering the precision of column %q of table %q from %s to %s might round or reject existing values", c.To.Name, t.Name, from, to),
+			cond: func(b *sqlx.Builder) {
+				var conds []func(*sqlx.Builder)
+				if overflow {
+					conds = append(conds, func(b *sqlx.Builder) {
+						call(b, "ABS", func(b *sqlx.Builder) { col(b) }).P(">=", "1"+strings.Repeat("0", tt.Precision-tt.Scale))
+					})
+				}
+				if ft.Precision == 0 || tt.Scale < ft.Scale {
+					conds = append(conds, func(b *sqlx.Builder) {
+						call(col(b).P("<>"), "ROUND", func(b *sqlx.Builder) {
+							col(b).Comma().P(strconv.Itoa(tt.Scale))
+						})
+					})
+				}
+				or(b, conds)
+			},
+		}
+	case *schema.EnumType:
+		tt, ok := c.To.Type.Type.(*schema.EnumType)
+		if !ok {
+			return nil, false
+		}
+		removed := removedValues(ft, tt)
+		if len(removed) == 0 {
+			return nil, false
+		}
+		n = &narrowing{
+			code: codeDropEnum,
+			text: fmt.Sprintf("Removing enum value%s %s from column %q of table %q might fail on existing values", plural(removed), quoteValues(removed), c.To.Name, t.Name),
+			cond: func(b *sqlx.Builder) {
+				col(b).P("IN").Wrap(func(b *sqlx.Builder) {
+					b.MapComma(removed, func(i int, b *sqlx.Builder) {
+						b.WriteString("'" + strings.ReplaceAll(removed[i], "'", "''") + "'")
+					})
+				})
+			},
+		}
+	default:
+		return nil, false
+	}
+	return n, true
+}
+
+// affected returns the number of rows in the table that match the given
+// condition, using the dev database. An error is returned in case the rows
+// cannot be counted, e.g., if the dev database is not available.
+func (a *Analyzer) affected(ctx context.Context, p *sqlcheck.Pass, t *schema.Table, cond func(*sqlx.Builder)) (int64, error) {
+	if p.Dev == nil || cond == nil {
+		return 0, errors.New("dev database is not available")
+	}
+	sb, ok := p.Dev.Driver.(interface {
+		StmtBuilder(migrate.PlanOptions) *sqlx.Builder
+	})
+	if !ok {
+		return 0, fmt.Errorf("unexpected driver type: %T", p.Dev.Driver)
+	}
+	b := sb.StmtBuilder(migrate.PlanOptions{}).P("SELECT COUNT(*) FROM").Table(t).P("WHERE")
+	cond(b)
+	rows, err := p.Dev.QueryContext(ctx, b.String())
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	if err := sqlx.ScanOne(rows, &n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// enumAdded reports if the enum type was created in the analyzed file.
+func enumAdded(p *sqlcheck.Pass, e *schema.EnumType) bool {
+	for _, sc := range p.File.Changes {
+		for _, c := range sc.Changes {
+			if a, ok := c.(*schema.AddObject); ok {
+				if a, ok := a.O.(*schema.EnumType); ok && a.T == e.T && (a.Schema == nil || e.Schema == nil || a.Schema.Name == e.Schema.Name) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// removedValues returns the values of the enum that were removed.
+func removedValues(from, to *schema.EnumType) []string {
+	var removed []string
+	for _, v := range from.Values {
+		if !slices.Contains(to.Values, v) {
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
+
+// quoteValues returns the quoted values joined by commas.
+func quoteValues(vs []string) string {
+	names := make([]string, len(vs))
+	for i, v := range vs {
+		names[i] = strconv.Quote(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// intRange returns the range of values the integer type can hold.
+func intRange(t *schema.IntegerType) (min, max *big.Int, ok bool) {
+	var bits uint
+	switch strings.ToLower(t.T) {
+	case "tinyint":
+		bits = 8
+	case "smallint", "int2":
+		bits = 16
+	case "mediumint":
+		bits = 24
+	case "int", "integer", "int4":
+		bits = 32
+	case "bigint", "int8":
+		bits = 64
+	default:
+		return nil, nil, false
+	}
+	if t.Unsigned {
+		max = new(big.Int).Lsh(big.NewInt(1), bits)
+		return big.NewInt(0), max.Sub(max, big.NewInt(1)), true
+	}
+	max = new(big.Int).Lsh(big.NewInt(1), bits-1)
+	min = new(big.Int).Neg(max)
+	return min, max.Sub(max, big.NewInt(1)), true
+}
+
+// formatType returns the database representation of the type, if possible.
+func formatType(p *sqlcheck.Pass, t schema.Type) string {
+	if p.Dev != nil {
+		if f, ok := p.Dev.Driver.(schema.TypeFormatter); ok {
+			if s, err := f.FormatType(t); err == nil {
+				return s
+			}
+		}
+	}
+	switch t := t.(type) {
+	case *schema.StringType:
+		if t.Size > 0 {
+			return fmt.Sprintf("%s(%d)", t.T, t.Size)
+		}
+		return t.T
+	case *schema.IntegerType:
+		if t.Unsigned {
+			return t.T + " unsigned"
+		}
+		return t.T
+	case *schema.DecimalType:
+		return fmt.Sprintf("%s(%d,%d)", t.T, t.Precision, t.Scale)
+	case *schema.EnumType:
+		return "enum"
+	}
+	return fmt.Sprintf("%T", t)
+}
+
+// call writes a function call to the builder. Unlike P, no whitespace is
+// written between the function name and its arguments, as some databases
+// (e.g., MySQL) do not accept it for all built-in functions.
+func call(b *sqlx.Builder, name string, args func(*sqlx.Builder)) *sqlx.Builder {
+	b.WriteString(name)
+	return b.Wrap(args)
+}
+
+// or joins the given conditions with OR.
+func or(b *sqlx.Builder, conds []func(*sqlx.Builder)) {
+	for i, c := range conds {
+		if i > 0 {
+			b.P("OR")
+		}
+		c(b)
+	}
+}
+
+func plural[T any](s []T) string {
+	if len(s) > 1 {
+		return "s"
+	}
+	return ""
+}
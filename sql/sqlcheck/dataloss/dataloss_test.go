@@ -0,0 +1,132 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package dataloss_test
+
+import (
+	"context"
+	"testing"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+	"ariga.io/atlas/sql/sqlcheck/dataloss"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzer_DataLoss(t *testing.T) {
+	var (
+		report *sqlcheck.Report
+		users  = schema.NewTable("users").SetSchema(schema.New("test"))
+		modify = func(from, to schema.Type) *schema.ModifyColumn {
+			return &schema.ModifyColumn{
+				From:   &schema.Column{Name: "c", Type: &schema.ColumnType{Type: from}},
+				To:     &schema.Column{Name: "c", Type: &schema.ColumnType{Type: to}},
+				Change: schema.ChangeType,
+			}
+		}
+		pass = &sqlcheck.Pass{
+			File: &sqlcheck.File{
+				File: migrate.NewLocalFile("1.sql", nil),
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: &migrate.Stmt{Text: "ALTER TABLE `users`"},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T: users,
+								Changes: schema.Changes{
+									modify(&schema.StringType{T: "varchar", Size: 255}, &schema.StringType{T: "varchar", Size: 50}),
+									// Widening strings is safe.
+									modify(&schema.StringType{T: "varchar", Size: 50}, &schema.StringType{T: "varchar", Size: 255}),
+									modify(&schema.StringType{T: "text"}, &schema.StringType{T: "varchar", Size: 10}),
+									modify(&schema.IntegerType{T: "bigint"}, &schema.IntegerType{T: "int"}),
+									modify(&schema.IntegerType{T: "int"}, &schema.IntegerType{T: "int", Unsigned: true}),
+									// Widening integers is safe.
+									modify(&schema.IntegerType{T: "int", Unsigned: true}, &schema.IntegerType{T: "bigint"}),
+									modify(&schema.DecimalType{T: "decimal", Precision: 10, Scale: 2}, &schema.DecimalType{T: "decimal", Precision: 8, Scale: 2}),
+									modify(&schema.DecimalType{T: "decimal", Precision: 10, Scale: 4}, &schema.DecimalType{T: "decimal", Precision: 12, Scale: 2}),
+									// Widening decimals is safe.
+									modify(&schema.DecimalType{T: "decimal", Precision: 10, Scale: 2}, &schema.DecimalType{T: "decimal", Precision: 12, Scale: 2}),
+									modify(&schema.EnumType{Values: []string{"a", "b", "c"}}, &schema.EnumType{Values: []string{"a"}}),
+									// Adding enum values is safe.
+									modify(&schema.EnumType{Values: []string{"a"}}, &schema.EnumType{Values: []string{"a", "b"}}),
+									// Non-type changes are ignored.
+									&schema.ModifyColumn{
+										From:   &schema.Column{Name: "c", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar", Size: 255}}},
+										To:     &schema.Column{Name: "c", Type: &schema.ColumnType{Type: &schema.StringType{T: "varchar", Size: 50}}},
+										Change: schema.ChangeNull,
+									},
+								},
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{Text: "ALTER TYPE \"status\""},
+						Changes: schema.Changes{
+							// Enum types that are defined as schema objects (e.g., PostgreSQL).
+							&schema.ModifyObject{
+								From: &schema.EnumType{T: "status", Values: []string{"active", "deleted"}},
+								To:   &schema.EnumType{T: "status", Values: []string{"active"}},
+							},
+							&schema.ModifyObject{
+								From: &schema.EnumType{T: "level", Values: []string{"low"}},
+								To:   &schema.EnumType{T: "level", Values: []string{"low", "high"}},
+							},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := dataloss.New(&schemahcl.Resource{}, dataloss.Handler{})
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Equal(t, "data loss changes detected", report.Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "DL101", Text: `Shrinking column "c" of table "users" from varchar(255) to varchar(50) might truncate existing values`},
+		{Code: "DL101", Text: `Shrinking column "c" of table "users" from text to varchar(10) might truncate existing values`},
+		{Code: "DL102", Text: `Narrowing column "c" of table "users" from bigint to int might fail on out-of-range values`},
+		{Code: "DL102", Text: `Narrowing column "c" of table "users" from int to int unsigned might fail on out-of-range values`},
+		{Code: "DL103", Text: `Lowering the precision of column "c" of table "users" from decimal(10,2) to decimal(8,2) might round or reject existing values`},
+		{Code: "DL103", Text: `Lowering the precision of column "c" of table "users" from decimal(10,4) to decimal(12,2) might round or reject existing values`},
+		{Code: "DL104", Text: `Removing enum values "b", "c" from column "c" of table "users" might fail on existing values`},
+		{Code: "DL104", Text: `Removing enum value "deleted" from enum type "status" might fail on existing values`},
+	}, report.Diagnostics)
+
+	// Columns of tables and enum types that were added in the same file are ignored.
+	report = nil
+	file := pass.File
+	pass.File = &sqlcheck.File{
+		File: file.File,
+		Changes: append([]*sqlcheck.Change{
+			{
+				Stmt:    &migrate.Stmt{Text: "CREATE TABLE `users`"},
+				Changes: schema.Changes{&schema.AddTable{T: users}},
+			},
+			{
+				Stmt:    &migrate.Stmt{Text: "CREATE TYPE \"status\""},
+				Changes: schema.Changes{&schema.AddObject{O: &schema.EnumType{T: "status", Values: []string{"active", "deleted"}}}},
+			},
+		}, file.Changes...),
+	}
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Nil(t, report)
+
+	pass.File = file
+	az, err = dataloss.New(&schemahcl.Resource{
+		Children: []*schemahcl.Resource{
+			{
+				Type:  "data_loss",
+				Attrs: []*schemahcl.Attr{schemahcl.BoolAttr("error", true)},
+			},
+		},
+	}, dataloss.Handler{})
+	require.NoError(t, err)
+	require.EqualError(t, az.Analyze(context.Background(), pass), "data loss changes detected")
+}
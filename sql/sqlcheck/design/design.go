@@ -0,0 +1,318 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package design
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+type (
+	// Analyzer checks the desired schema state for common design issues,
+	// such as tables without primary keys or redundant indexes.
+	Analyzer struct {
+		sqlcheck.Options
+		Handler
+	}
+
+	// Handler holds the underlying driver handlers.
+	Handler struct {
+		// ImplicitFKIndex indicates the database creates an index for
+		// foreign keys that do not have a supporting one (e.g., MySQL).
+		ImplicitFKIndex bool
+
+		// BoundedPart is an optional handler that reports if an index
+		// part is bounded by the driver, e.g., MySQL prefix length.
+		BoundedPart func(*schema.IndexPart) bool
+
+		// Partial is an optional handler that reports if an index is
+		// partial and should be skipped by the redundancy checks.
+		Partial func(*schema.Index) bool
+	}
+)
+
+// New creates a new schema design Analyzer with the given options.
+func New(r *schemahcl.Resource, h Handler) (*Analyzer, error) {
+	az := &Analyzer{Handler: h}
+	if r, ok := r.Resource(az.Name()); ok {
+		if err := r.As(&az.Options); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: parsing design check options: %w", err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codeNoPK           = sqlcheck.Code("SD101")
+	codeFKNoIndex      = sqlcheck.Code("SD102")
+	codeRedundantI     = sqlcheck.Code("SD103")
+	codeNullUnique     = sqlcheck.Code("SD104")
+	codeFKTypeMismatch = sqlcheck.Code("SD105")
+	codeUnboundedPart  = sqlcheck.Code("SD106")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*Analyzer) Name() string {
+	return "design"
+}
+
+// AnalyzeRealm implements sqlcheck.RealmAnalyzer.
+func (a *Analyzer) AnalyzeRealm(_ context.Context, p *sqlcheck.RealmPass) error {
+	var diags []sqlcheck.Diagnostic
+	for _, s := range p.Realm.Schemas {
+		for _, t := range s.Tables {
+			diags = append(diags, a.primaryKey(t)...)
+			diags = append(diags, a.redundantIndexes(t)...)
+			diags = append(diags, a.nullableUnique(t)...)
+			diags = append(diags, a.unboundedParts(t)...)
+			diags = append(diags, a.foreignKeys(p, t)...)
+		}
+	}
+	if len(diags) > 0 {
+		const reportText = "schema design issues detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if sqlx.V(a.Error) {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+func (a *Analyzer) primaryKey(t *schema.Table) []sqlcheck.Diagnostic {
+	if t.PrimaryKey != nil {
+		return nil
+	}
+	return []sqlcheck.Diagnostic{{
+		Code:      codeNoPK,
+		ObjectPos: t.Pos(),
+		Text:      fmt.Sprintf("Table %q has no primary key", t.Name),
+	}}
+}
+
+func (a *Analyzer) redundantIndexes(t *schema.Table) (diags []sqlcheck.Diagnostic) {
+	idxs := t.Indexes
+	if t.PrimaryKey != nil {
+		idxs = append([]*schema.Index{t.PrimaryKey}, idxs...)
+	}
+	for i, idx := range idxs {
+		if idx == t.PrimaryKey || !a.plain(idx) {
+			continue
+		}
+		for j, other := range idxs {
+			if i == j || !a.plain(other) || !isPrefix(idx.Parts, other.Parts) {
+				continue
+			}
+			// Primary keys are unique, regardless of how they were loaded.
+			unique := other.Unique || other == t.PrimaryKey
+			switch {
+			// Duplicates are reported once, on the latter index.
+			case len(idx.Parts) == len(other.Parts) && idx.Unique == unique:
+				if j > i {
+					continue
+				}
+				diags = append(diags, sqlcheck.Diagnostic{
+					Code:      codeRedundantI,
+					ObjectPos: posOr(idx.Pos(), t.Pos()),
+					Text:      fmt.Sprintf("Index %q on table %q is a duplicate of %s", idx.Name, t.Name, indexName(t, other)),
+				})
+			// A unique index enforces a constraint, and is
+			// not redundant unless it duplicates another one.
+			case idx.Unique:
+				continue
+			case unique && len(idx.Parts) == len(other.Parts), len(idx.Parts) < len(other.Parts):
+				diags = append(diags, sqlcheck.Diagnostic{
+					Code:      codeRedundantI,
+					ObjectPos: posOr(idx.Pos(), t.Pos()),
+					Text:      fmt.Sprintf("Index %q on table %q is redundant as it is covered by %s", idx.Name, t.Name, indexName(t, other)),
+				})
+			default:
+				continue
+			}
+			// Report each index only once.
+			break
+		}
+	}
+	return diags
+}
+
+func (a *Analyzer) nullableUnique(t *schema.Table) (diags []sqlcheck.Diagnostic) {
+	for _, idx := range t.Indexes {
+		if !idx.Unique {
+			continue
+		}
+		var names []string
+		for _, p := range idx.Parts {
+			if p.C != nil && p.C.Type != nil && p.C.Type.Null {
+				names = append(names, fmt.Sprintf("%q", p.C.Name))
+			}
+		}
+		if len(names) > 0 {
+			diags = append(diags, sqlcheck.Diagnostic{
+				Code:      codeNullUnique,
+				ObjectPos: posOr(idx.Pos(), t.Pos()),
+				Text:      fmt.Sprintf("Unique index %q on table %q allows duplicate NULL values in nullable column %s", idx.Name, t.Name, strings.Join(names, ", ")),
+			})
+		}
+	}
+	return diags
+}
+
+func (a *Analyzer) unboundedParts(t *schema.Table) (diags []sqlcheck.Diagnostic) {
+	idxs := t.Indexes
+	if t.PrimaryKey != nil {
+		idxs = append([]*schema.Index{t.PrimaryKey}, idxs...)
+	}
+	for _, idx := range idxs {
+		for _, p := range idx.Parts {
+			if p.C == nil || p.C.Type == nil || !unbounded(p.C.Type.Type) || a.BoundedPart != nil && a.BoundedPart(p) {
+				continue
+			}
+			diags = append(diags, sqlcheck.Diagnostic{
+				Code:      codeUnboundedPart,
+				ObjectPos: posOr(idx.Pos(), t.Pos()),
+				Text:      fmt.Sprintf("Index %s indexes unbounded column %q", indexName(t, idx), p.C.Name),
+			})
+		}
+	}
+	return diags
+}
+
+func (a *Analyzer) foreignKeys(p *sqlcheck.RealmPass, t *schema.Table) (diags []sqlcheck.Diagnostic) {
+	for _, fk := range t.ForeignKeys {
+		if !a.ImplicitFKIndex && !hasIndex(t, fk.Columns) {
+			diags = append(diags, sqlcheck.Diagnostic{
+				Code:      codeFKNoIndex,
+				ObjectPos: posOr(fk.Pos(), t.Pos()),
+				Text:      fmt.Sprintf("Foreign key %q on table %q has no supporting index on column %s", fk.Symbol, t.Name, columnNames(fk.Columns)),
+			})
+		}
+		for i, c := range fk.Columns {
+			if i >= len(fk.RefColumns) {
+				break
+			}
+			r := fk.RefColumns[i]
+			if c.Type == nil || r.Type == nil || c.Type.Type == nil || r.Type.Type == nil || sameType(p, c.Type.Type, r.Type.Type) {
+				continue
+			}
+			diags = append(diags, sqlcheck.Diagnostic{
+				Code:      codeFKTypeMismatch,
+				ObjectPos: posOr(fk.Pos(), t.Pos()),
+				Text:      fmt.Sprintf("Foreign key %q column %q type does not match referenced column %q type", fk.Symbol, c.Name, r.Name),
+			})
+		}
+	}
+	return diags
+}
+
+// plain reports if the index is a non-partial index with only column parts.
+func (a *Analyzer) plain(idx *schema.Index) bool {
+	if len(idx.Parts) == 0 || a.Partial != nil && a.Partial(idx) {
+		return false
+	}
+	for _, p := range idx.Parts {
+		if p.C == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isPrefix reports if the parts of p1 are a prefix of the parts of p2.
+func isPrefix(p1, p2 []*schema.IndexPart) bool {
+	if len(p1) > len(p2) {
+		return false
+	}
+	for i := range p1 {
+		if p1[i].C.Name != p2[i].C.Name || p1[i].Desc != p2[i].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+// hasIndex reports if the table has an index (or a primary key)
+// whose leading parts are the given columns, in any order.
+func hasIndex(t *schema.Table, columns []*schema.Column) bool {
+	idxs := t.Indexes
+	if t.PrimaryKey != nil {
+		idxs = append([]*schema.Index{t.PrimaryKey}, idxs...)
+	}
+	for _, idx := range idxs {
+		if len(idx.Parts) < len(columns) {
+			continue
+		}
+		names := make(map[string]bool, len(columns))
+		for _, p := range idx.Parts[:len(columns)] {
+			if p.C != nil {
+				names[p.C.Name] = true
+			}
+		}
+		covered := true
+		for _, c := range columns {
+			covered = covered && names[c.Name]
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// unbounded reports if the given type has no length limit.
+func unbounded(t schema.Type) bool {
+	switch t := t.(type) {
+	case *schema.StringType:
+		return t.Size == 0
+	case *schema.BinaryType:
+		return t.Size == nil
+	}
+	return false
+}
+
+// sameType reports if the two column types are the same. Types are compared by their
+// database form in case the dev-database driver is available, and structurally otherwise.
+func sameType(p *sqlcheck.RealmPass, t1, t2 schema.Type) bool {
+	if p.Dev != nil {
+		if f, ok := p.Dev.Driver.(schema.TypeFormatter); ok {
+			s1, err1 := f.FormatType(t1)
+			s2, err2 := f.FormatType(t2)
+			if err1 == nil && err2 == nil {
+				return strings.EqualFold(s1, s2)
+			}
+		}
+	}
+	return reflect.DeepEqual(t1, t2)
+}
+
+func indexName(t *schema.Table, idx *schema.Index) string {
+	if idx == t.PrimaryKey {
+		return fmt.Sprintf("the primary key of table %q", t.Name)
+	}
+	return fmt.Sprintf("%q", idx.Name)
+}
+
+func columnNames(columns []*schema.Column) string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = fmt.Sprintf("%q", c.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func posOr(p, or *schema.Pos) *schema.Pos {
+	if p != nil {
+		return p
+	}
+	return or
+}
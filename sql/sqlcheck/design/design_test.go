@@ -0,0 +1,105 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package design_test
+
+import (
+	"context"
+	"testing"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+	"ariga.io/atlas/sql/sqlcheck/design"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzer_Design(t *testing.T) {
+	var (
+		report *sqlcheck.Report
+		users  = schema.NewTable("users").
+			AddColumns(
+				schema.NewIntColumn("id", "int"),
+				schema.NewNullStringColumn("email", "varchar", schema.StringSize(255)),
+				schema.NewStringColumn("bio", "text"),
+			)
+		posts = schema.NewTable("posts").
+			AddColumns(
+				schema.NewIntColumn("id", "int"),
+				schema.NewIntColumn("author_id", "bigint"),
+				schema.NewStringColumn("title", "varchar", schema.StringSize(100)),
+			)
+		logs = schema.NewTable("logs").
+			AddColumns(schema.NewStringColumn("text", "text"))
+		pos  = &schema.Pos{Filename: "schema.hcl"}
+		pass = &sqlcheck.RealmPass{
+			Realm: schema.NewRealm(schema.New("public").AddTables(users, posts, logs)),
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	users.SetPrimaryKey(schema.NewPrimaryKey(users.Columns[0])).
+		AddIndexes(
+			schema.NewUniqueIndex("users_email").AddColumns(users.Columns[1]),
+			schema.NewIndex("users_bio").AddColumns(users.Columns[2]),
+		)
+	posts.SetPrimaryKey(schema.NewPrimaryKey(posts.Columns[0])).
+		AddIndexes(
+			schema.NewIndex("posts_id").AddColumns(posts.Columns[0]),
+			schema.NewIndex("posts_title").AddColumns(posts.Columns[2]),
+			schema.NewIndex("posts_title_2").AddColumns(posts.Columns[2]),
+		).
+		AddForeignKeys(
+			schema.NewForeignKey("posts_author").AddColumns(posts.Columns[1]).SetRefTable(users).AddRefColumns(users.Columns[0]),
+		)
+	pos.Start.Line = 10
+	logs.AddAttrs(pos)
+
+	az, err := design.New(&schemahcl.Resource{}, design.Handler{})
+	require.NoError(t, err)
+	require.NoError(t, az.AnalyzeRealm(context.Background(), pass))
+	require.Equal(t, "schema design issues detected", report.Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{Code: "SD104", Text: `Unique index "users_email" on table "users" allows duplicate NULL values in nullable column "email"`},
+		{Code: "SD106", Text: `Index "users_bio" indexes unbounded column "bio"`},
+		{Code: "SD103", Text: `Index "posts_id" on table "posts" is redundant as it is covered by the primary key of table "posts"`},
+		{Code: "SD103", Text: `Index "posts_title_2" on table "posts" is a duplicate of "posts_title"`},
+		{Code: "SD102", Text: `Foreign key "posts_author" on table "posts" has no supporting index on column "author_id"`},
+		{Code: "SD105", Text: `Foreign key "posts_author" column "author_id" type does not match referenced column "id" type`},
+		{Code: "SD101", ObjectPos: pos, Text: `Table "logs" has no primary key`},
+	}, report.Diagnostics)
+
+	// Driver handlers.
+	report = nil
+	users.Indexes = users.Indexes[1:]
+	users.Columns[1].Type.Null = false
+	posts.Indexes = posts.Indexes[2:]
+	posts.Columns[1].Type.Type = &schema.IntegerType{T: "int"}
+	posts.AddIndexes(schema.NewIndex("posts_author").AddColumns(posts.Columns[1]))
+	logs.SetPrimaryKey(schema.NewPrimaryKey(logs.Columns[0]))
+	az, err = design.New(&schemahcl.Resource{}, design.Handler{
+		BoundedPart: func(*schema.IndexPart) bool { return true },
+	})
+	require.NoError(t, err)
+	require.NoError(t, az.AnalyzeRealm(context.Background(), pass))
+	require.Nil(t, report)
+
+	// Errors.
+	logs.PrimaryKey = nil
+	az, err = design.New(&schemahcl.Resource{
+		Children: []*schemahcl.Resource{
+			{
+				Type:  "design",
+				Attrs: []*schemahcl.Attr{schemahcl.BoolAttr("error", true)},
+			},
+		},
+	}, design.Handler{
+		BoundedPart: func(*schema.IndexPart) bool { return true },
+	})
+	require.NoError(t, err)
+	require.EqualError(t, az.AnalyzeRealm(context.Background(), pass), "schema design issues detected")
+	require.Len(t, report.Diagnostics, 1)
+}
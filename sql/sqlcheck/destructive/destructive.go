@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -40,8 +41,16 @@ var (
 	codeDropS = sqlcheck.Code("DS101")
 	codeDropT = sqlcheck.Code("DS102")
 	codeDropC = sqlcheck.Code("DS103")
+	codeMatV  = sqlcheck.Code("DS104")
+	codeDropP = sqlcheck.Code("DS105")
 )
 
+// partitionDropper is implemented by driver-specific changes that drop
+// table partitions, and the data stored in them. e.g., mysql.DropPartition.
+type partitionDropper interface {
+	DroppedPartitions() []string
+}
+
 // Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
 func (*Analyzer) Name() string {
 	return "destructive"
@@ -52,10 +61,38 @@ func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
 	var (
 		edits []*migrate.Stmt
 		diags []sqlcheck.Diagnostic
+		// Recreation of materialized views loses their
+		// computed data, but not the data they are based
+		// on. Hence, they are reported only as warnings.
+		warns int
 	)
-	for _, sc := range p.File.Changes {
+	for i, sc := range p.File.Changes {
 		for _, c := range sc.Changes {
 			switch c := c.(type) {
+			case *schema.DropView:
+				if !populated(c.V) || p.File.SchemaSpan(c.V.Schema) == sqlcheck.SpanDropped {
+					continue
+				}
+				text := fmt.Sprintf("Dropping populated materialized view %q", c.V.Name)
+				if readded(p.File.Changes[i+1:], c.V) {
+					text = fmt.Sprintf("Recreating populated materialized view %q", c.V.Name)
+				}
+				warns++
+				diags = append(diags, sqlcheck.Diagnostic{
+					Code: codeMatV,
+					Pos:  sc.Stmt.Pos,
+					Text: text,
+				})
+			case *schema.ModifyView:
+				if !populated(c.From) || !sqlx.ViewDefChanged(c) {
+					continue
+				}
+				warns++
+				diags = append(diags, sqlcheck.Diagnostic{
+					Code: codeMatV,
+					Pos:  sc.Stmt.Pos,
+					Text: fmt.Sprintf("Recreating populated materialized view %q", c.From.Name),
+				})
 			case *schema.DropSchema:
 				if p.File.SchemaSpan(c.S) != sqlcheck.SpanTemporary {
 					var text string
@@ -92,6 +129,10 @@ func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
 			case *schema.ModifyTable:
 				var names []string
 				for i := range c.Changes {
+					if d, ok := c.Changes[i].(partitionDropper); ok && p.File.TableSpan(c.T) != sqlcheck.SpanTemporary {
+						diags = append(diags, dropPartitions(sc, c.T, d.DroppedPartitions()))
+						continue
+					}
 					d, ok := c.Changes[i].(*schema.DropColumn)
 					if !ok || p.File.ColumnSpan(c.T, d.C) == sqlcheck.SpanTemporary {
 						continue
@@ -136,13 +177,50 @@ func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
 		p.Reporter.WriteReport(
 			withSuggestion(p, sqlcheck.Report{Text: reportText, Diagnostics: diags}, edits),
 		)
-		if sqlx.V(a.Error) {
+		if sqlx.V(a.Error) && len(diags) > warns {
 			return errors.New(reportText)
 		}
 	}
 	return nil
 }
 
+// dropPartitions returns the diagnostic for dropping the given partitions of the table.
+func dropPartitions(sc *sqlcheck.Change, t *schema.Table, names []string) sqlcheck.Diagnostic {
+	names = slices.Clone(names)
+	for i := range names {
+		names[i] = strconv.Quote(names[i])
+	}
+	text := fmt.Sprintf("Dropping partition %s of table %q", strings.Join(names, ", "), t.Name)
+	if n := len(names); n > 1 {
+		text = fmt.Sprintf("Dropping partitions %s and %s of table %q", strings.Join(names[:n-1], ", "), names[n-1], t.Name)
+	}
+	return sqlcheck.Diagnostic{
+		Code: codeDropP,
+		Pos:  sc.Stmt.Pos,
+		Text: text,
+	}
+}
+
+// populated reports if the given view is a populated materialized view. Materialized
+// views that were created using the WITH NO DATA clause are not populated, and their
+// recreation does not require recomputing their data.
+func populated(v *schema.View) bool {
+	m := schema.Materialized{}
+	return sqlx.Has(v.Attrs, &m) && !m.NoData
+}
+
+// readded reports if the given view is created again by one of the changes.
+func readded(changes []*sqlcheck.Change, v *schema.View) bool {
+	for _, sc := range changes {
+		for _, c := range sc.Changes {
+			if a, ok := c.(*schema.AddView); ok && sqlx.SameView(a.V, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (*Analyzer) hasEmptyTableCheck(*sqlcheck.Pass, *schema.Table) bool {
 	return false // unimplemented.
 }
@@ -249,6 +249,145 @@ func TestAnalyzer_DropColumn(t *testing.T) {
 	require.Equal(t, "Add a pre-migration check to ensure column \"c\" is NULL before dropping it", report.Diagnostics[0].SuggestedFixes[0].Message)
 }
 
+func TestAnalyzer_MaterializedView(t *testing.T) {
+	var (
+		report *sqlcheck.Report
+		public = schema.New("public")
+		pass   = &sqlcheck.Pass{
+			Dev: &sqlclient.Client{Name: "postgres"},
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql"},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: &migrate.Stmt{
+							Text: `DROP MATERIALIZED VIEW "mv1"`,
+						},
+						Changes: schema.Changes{
+							&schema.DropView{
+								V: schema.NewMaterializedView("mv1", "SELECT 1").SetSchema(public),
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{
+							Text: `CREATE MATERIALIZED VIEW "mv1" AS SELECT 2`,
+						},
+						Changes: schema.Changes{
+							&schema.AddView{
+								V: schema.NewMaterializedView("mv1", "SELECT 2").SetSchema(public),
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{
+							Text: `DROP MATERIALIZED VIEW "mv2"`,
+						},
+						Changes: schema.Changes{
+							&schema.DropView{
+								V: schema.NewMaterializedView("mv2", "SELECT 1").SetSchema(public),
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{
+							Text: `DROP MATERIALIZED VIEW "mv3"`,
+						},
+						Changes: schema.Changes{
+							&schema.DropView{
+								V: schema.NewView("mv3", "SELECT 1").
+									SetSchema(public).
+									AddAttrs(&schema.Materialized{NoData: true}),
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{
+							Text: `DROP VIEW "v1"`,
+						},
+						Changes: schema.Changes{
+							&schema.DropView{
+								V: schema.NewView("v1", "SELECT 1").SetSchema(public),
+							},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := destructive.New(nil)
+	require.NoError(t, err)
+	// Materialized views are reported as warnings.
+	err = az.Analyze(context.Background(), pass)
+	require.NoError(t, err)
+	require.Equal(t, "destructive changes detected", report.Text)
+	require.Len(t, report.Diagnostics, 2)
+	require.Equal(t, `Recreating populated materialized view "mv1"`, report.Diagnostics[0].Text)
+	require.Equal(t, `Dropping populated materialized view "mv2"`, report.Diagnostics[1].Text)
+	require.Equal(t, "DS104", report.Diagnostics[0].Code)
+}
+
+func TestAnalyzer_DropPartition(t *testing.T) {
+	var (
+		report *sqlcheck.Report
+		logs   = schema.NewTable("logs").SetSchema(schema.New("test"))
+		pass   = &sqlcheck.Pass{
+			Dev: &sqlclient.Client{Name: "mysql"},
+			File: &sqlcheck.File{
+				File: testFile{name: "1.sql"},
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: &migrate.Stmt{
+							Text: "ALTER TABLE `logs` DROP PARTITION `p0`",
+						},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T:       logs,
+								Changes: schema.Changes{&dropPartition{names: []string{"p0"}}},
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{
+							Text: "ALTER TABLE `logs` DROP PARTITION `p1`, `p2`",
+						},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T:       logs,
+								Changes: schema.Changes{&dropPartition{names: []string{"p1", "p2"}}},
+							},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	az, err := destructive.New(nil)
+	require.NoError(t, err)
+	err = az.Analyze(context.Background(), pass)
+	require.Error(t, err)
+	require.Equal(t, "destructive changes detected", report.Text)
+	require.Len(t, report.Diagnostics, 2)
+	require.Equal(t, `Dropping partition "p0" of table "logs"`, report.Diagnostics[0].Text)
+	require.Equal(t, `Dropping partitions "p1" and "p2" of table "logs"`, report.Diagnostics[1].Text)
+	require.Equal(t, "DS105", report.Diagnostics[1].Code)
+}
+
+// dropPartition mimics a driver-specific change that drops table partitions.
+type dropPartition struct {
+	schema.Change
+	names []string
+}
+
+func (d *dropPartition) DroppedPartitions() []string {
+	return d.names
+}
+
 type testFile struct {
 	name string
 	migrate.File
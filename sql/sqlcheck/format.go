@@ -0,0 +1,429 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcheck
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"ariga.io/atlas/sql/schema"
+)
+
+type (
+	// Findings is a format-agnostic view of the analysis reports, with
+	// diagnostics resolved to file lines. It is the common input of the
+	// SARIF, JUnit, GitHub and GitLab encoders below.
+	Findings struct {
+		Files     []string  // Paths of the analyzed files, including files without findings.
+		Analyzers []string  // Names of the analyzers that ran on the files, if known.
+		List      []Finding // List of findings.
+	}
+
+	// A Finding is a diagnostic (or an error) resolved to a file line.
+	Finding struct {
+		Path     string // Path of the file.
+		Line     int    // Line in the file, starting from 1. Zero if unknown.
+		Analyzer string // Text of the report, identifies the analyzer.
+		Name     string // Name of the analyzer (e.g., data_loss), if known.
+		Code     string // Diagnostic code, if exists.
+		Text     string // Diagnostic text.
+		Error    bool   // Finding is reported as an error (e.g., failed analysis).
+	}
+
+	// A LintFile holds the analysis result of a single migration file.
+	LintFile struct {
+		Path    string            // Path of the file.
+		Reports []Report          // Reports of the analyzers.
+		Error   string            // Error of the analysis, if exists.
+		Line    func(pos int) int // Resolves diagnostic positions to file lines.
+	}
+)
+
+// LintFindings returns the findings of a migration directory analysis. The given analyzers
+// are the names of the analyzers that ran on the files, and reports that are not related to
+// a specific file (e.g., non-linear changes) are attached to the migration directory itself.
+func LintFindings(dir string, analyzers []string, files []LintFile, dirReports []Report) Findings {
+	fs := Findings{Analyzers: analyzers}
+	for _, f := range files {
+		fs.AddReports(f.Path, f.Reports, f.Error, f.Line)
+	}
+	if len(dirReports) > 0 {
+		if dir == "" {
+			dir = "."
+		}
+		fs.Add(dir, dirReports, func(int) int { return 0 })
+	}
+	return fs
+}
+
+// Add adds the reports of the given file to the findings.
+// The line function resolves the diagnostic positions to file lines.
+func (fs *Findings) Add(path string, reports []Report, line func(pos int) int) {
+	fs.AddFile(path)
+	for _, r := range reports {
+		for _, d := range r.Diagnostics {
+			fs.List = append(fs.List, Finding{
+				Path:     path,
+				Line:     line(d.Pos),
+				Analyzer: r.Text,
+				Name:     r.Analyzer,
+				Code:     d.Code,
+				Text:     d.Text,
+			})
+		}
+	}
+}
+
+// AddReports adds the reports and the error of the given file to the findings. Analyzers
+// that are configured to fail on findings report their report text as an error, and file
+// errors are joined using "; ". Therefore, the diagnostics of these reports are marked as
+// errors, and the rest of the errors (e.g., failed analysis) are added as file errors.
+func (fs *Findings) AddReports(path string, reports []Report, err string, line func(pos int) int) {
+	n := len(fs.List)
+	fs.Add(path, reports, line)
+	if err == "" {
+		return
+	}
+	es := strings.Split(err, "; ")
+	for i := n; i < len(fs.List); i++ {
+		if slices.Contains(es, fs.List[i].Analyzer) {
+			fs.List[i].Error = true
+		}
+	}
+	for _, e := range es {
+		if !slices.ContainsFunc(reports, func(r Report) bool { return r.Text == e }) {
+			fs.AddError(path, e)
+		}
+	}
+}
+
+// AddError adds a file error to the findings.
+func (fs *Findings) AddError(path, text string) {
+	fs.AddFile(path)
+	fs.List = append(fs.List, Finding{Path: path, Text: text, Error: true})
+}
+
+// AddObject adds a finding located by the position of the schema element it points to.
+// Findings without a position are attached to the current directory.
+func (fs *Findings) AddObject(pos *schema.Pos, f Finding) {
+	f.Path, f.Line = ".", 0
+	if pos != nil && pos.Filename != "" {
+		f.Path, f.Line = pos.Filename, pos.Start.Line
+	}
+	fs.AddFile(f.Path)
+	fs.List = append(fs.List, f)
+}
+
+// AddFile records the given file as analyzed.
+func (fs *Findings) AddFile(path string) {
+	if !slices.Contains(fs.Files, path) {
+		fs.Files = append(fs.Files, path)
+	}
+}
+
+// CodeURL returns the documentation URL of the given analyzer code.
+func CodeURL(code string) string {
+	return "https://atlasgo.io/lint/analyzers#" + code
+}
+
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool struct {
+			Driver struct {
+				Name           string      `json:"name"`
+				InformationURI string      `json:"informationUri"`
+				Rules          []sarifRule `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifRule struct {
+		ID               string        `json:"id"`
+		ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+		HelpURI          string        `json:"helpUri"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId,omitempty"`
+		RuleIndex *int            `json:"ruleIndex,omitempty"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifLocation struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region *sarifRegion `json:"region,omitempty"`
+		} `json:"physicalLocation"`
+	}
+	sarifRegion struct {
+		StartLine int `json:"startLine"`
+	}
+)
+
+// WriteSARIF writes the findings to w as a SARIF 2.1.0 log. The rules of the
+// log are the codes registered with Code, and the codes found in the findings.
+func WriteSARIF(w io.Writer, fs Findings) error {
+	var (
+		run  sarifRun
+		ids  = Codes()
+		desc = make(map[string]string)
+	)
+	for _, f := range fs.List {
+		if f.Code == "" {
+			continue
+		}
+		if !slices.Contains(ids, f.Code) {
+			ids = append(ids, f.Code)
+		}
+		if _, ok := desc[f.Code]; !ok && f.Analyzer != "" {
+			desc[f.Code] = f.Analyzer
+		}
+	}
+	slices.Sort(ids)
+	run.Tool.Driver.Name = "atlas"
+	run.Tool.Driver.InformationURI = "https://atlasgo.io"
+	run.Tool.Driver.Rules = make([]sarifRule, len(ids))
+	for i, id := range ids {
+		run.Tool.Driver.Rules[i] = sarifRule{ID: id, HelpURI: CodeURL(id)}
+		if d, ok := desc[id]; ok {
+			run.Tool.Driver.Rules[i].ShortDescription = &sarifMessage{Text: d}
+		}
+	}
+	run.Results = make([]sarifResult, len(fs.List))
+	for i, f := range fs.List {
+		r := sarifResult{
+			Level:     "warning",
+			Message:   sarifMessage{Text: f.Text},
+			Locations: make([]sarifLocation, 1),
+		}
+		if f.Error {
+			r.Level = "error"
+		}
+		if idx := slices.Index(ids, f.Code); idx != -1 {
+			r.RuleID, r.RuleIndex = f.Code, &idx
+		}
+		r.Locations[0].PhysicalLocation.ArtifactLocation.URI = f.Path
+		if f.Line > 0 {
+			r.Locations[0].PhysicalLocation.Region = &sarifRegion{StartLine: f.Line}
+		}
+		run.Results[i] = r
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
+
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Errors   int          `xml:"errors,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Errors   int         `xml:"errors,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		Error     *junitFailure `xml:"error,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr,omitempty"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteJUnit writes the findings to w as a JUnit XML report. Each file is reported
+// as a test suite, with one test case per analyzer. Analyzers that ran on the file
+// without reporting on it are reported as passing test cases, and files without
+// findings and known analyzers are reported with a single passing test case.
+func WriteJUnit(w io.Writer, fs Findings) error {
+	root := junitSuites{Name: "atlas"}
+	for _, path := range fs.Files {
+		s := junitSuite{Name: path}
+		for _, f := range fs.List {
+			if f.Path != path {
+				continue
+			}
+			name := f.Name
+			if name == "" {
+				name = f.Analyzer
+			}
+			if name == "" {
+				name = "error"
+			}
+			idx := slices.IndexFunc(s.Cases, func(c junitCase) bool { return c.Name == name })
+			if idx == -1 {
+				s.Cases = append(s.Cases, junitCase{Name: name, ClassName: path})
+				idx = len(s.Cases) - 1
+			}
+			c := &s.Cases[idx]
+			// Errors that are not related to an analyzer.
+			if f.Analyzer == "" {
+				c.Error = &junitFailure{Message: f.Text, Text: f.Text}
+				continue
+			}
+			if c.Failure == nil {
+				c.Failure = &junitFailure{Message: f.Analyzer, Type: f.Code}
+			}
+			c.Failure.Text += junitLine(f)
+		}
+		for _, name := range fs.Analyzers {
+			if !slices.ContainsFunc(s.Cases, func(c junitCase) bool { return c.Name == name }) {
+				s.Cases = append(s.Cases, junitCase{Name: name, ClassName: path})
+			}
+		}
+		if len(s.Cases) == 0 {
+			s.Cases = append(s.Cases, junitCase{Name: "lint", ClassName: path})
+		}
+		s.Tests = len(s.Cases)
+		for _, c := range s.Cases {
+			if c.Failure != nil {
+				s.Failures++
+			}
+			if c.Error != nil {
+				s.Errors++
+			}
+		}
+		root.Tests += s.Tests
+		root.Failures += s.Failures
+		root.Errors += s.Errors
+		root.Suites = append(root.Suites, s)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitLine returns the failure line of a finding.
+func junitLine(f Finding) string {
+	var b strings.Builder
+	if f.Line > 0 {
+		fmt.Fprintf(&b, "L%d: ", f.Line)
+	}
+	b.WriteString(f.Text)
+	if f.Code != "" {
+		fmt.Fprintf(&b, " %s", CodeURL(f.Code))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// WriteGitHub writes the findings to w as GitHub Actions workflow commands, which
+// are rendered as annotations on the files of the pull request.
+func WriteGitHub(w io.Writer, fs Findings) error {
+	for _, f := range fs.List {
+		cmd, props := "warning", []string{"file=" + githubEscapeProp(f.Path)}
+		if f.Error {
+			cmd = "error"
+		}
+		if f.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", f.Line))
+		}
+		if f.Code != "" {
+			props = append(props, "title="+githubEscapeProp(f.Code))
+		} else if f.Analyzer != "" {
+			props = append(props, "title="+githubEscapeProp(f.Analyzer))
+		}
+		text := f.Text
+		if f.Code != "" {
+			text += " " + CodeURL(f.Code)
+		}
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", cmd, strings.Join(props, ","), githubEscape(text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubEscape escapes the data of a workflow command.
+func githubEscape(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubEscapeProp escapes a property value of a workflow command.
+func githubEscapeProp(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(githubEscape(s))
+}
+
+type gitlabIssue struct {
+	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
+	Fingerprint string `json:"fingerprint"`
+	Severity    string `json:"severity"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+// WriteGitLab writes the findings to w as a GitLab Code Quality report.
+func WriteGitLab(w io.Writer, fs Findings) error {
+	issues := make([]gitlabIssue, len(fs.List))
+	for i, f := range fs.List {
+		issue := gitlabIssue{
+			Description: f.Text,
+			CheckName:   f.Code,
+			Severity:    "major",
+		}
+		if issue.CheckName == "" {
+			issue.CheckName = f.Analyzer
+		}
+		if issue.CheckName == "" {
+			issue.CheckName = "error"
+		}
+		if f.Error {
+			issue.Severity = "critical"
+		}
+		issue.Location.Path = f.Path
+		// GitLab requires a line number. Findings
+		// that are not attached to a line use the first.
+		issue.Location.Lines.Begin = max(f.Line, 1)
+		h := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%s:%s", f.Path, f.Line, f.Code, f.Text)))
+		issue.Fingerprint = hex.EncodeToString(h[:])
+		issues[i] = issue
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(issues)
+}
@@ -0,0 +1,217 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcheck_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"ariga.io/atlas/sql/sqlcheck"
+
+	"github.com/stretchr/testify/require"
+)
+
+func findings() sqlcheck.Findings {
+	var fs sqlcheck.Findings
+	text := "CREATE TABLE t(c int);\nDROP TABLE t;\n"
+	fs.Add("migrations/1.sql", nil, nil)
+	fs.Add("migrations/2.sql", []sqlcheck.Report{
+		{
+			Text: "destructive changes detected",
+			Diagnostics: []sqlcheck.Diagnostic{
+				{Pos: strings.Index(text, "DROP"), Text: `Dropping table "t"`, Code: "DS102"},
+			},
+		},
+	}, func(pos int) int { return strings.Count(text[:pos], "\n") + 1 })
+	fs.AddError("migrations/3.sql", "executing statement: syntax error")
+	return fs
+}
+
+func TestLintFindings(t *testing.T) {
+	text := "CREATE TABLE t(c int);\nDROP TABLE t;\n"
+	line := func(pos int) int {
+		if pos < 0 || pos > len(text) {
+			return 0
+		}
+		return strings.Count(text[:pos], "\n") + 1
+	}
+	fs := sqlcheck.LintFindings("", []string{"destructive", "data_depend"}, []sqlcheck.LintFile{
+		{
+			Path: "migrations/1.sql",
+			Reports: []sqlcheck.Report{
+				{Text: "destructive changes detected", Analyzer: "destructive", Diagnostics: []sqlcheck.Diagnostic{{Pos: strings.Index(text, "DROP"), Text: `Dropping table "t"`, Code: "DS102"}}},
+				{Text: "data dependent changes detected", Diagnostics: []sqlcheck.Diagnostic{{Pos: len(text) + 1, Text: "Out of range"}}},
+			},
+			Error: "destructive changes detected; executing statement",
+			Line:  line,
+		},
+	}, []sqlcheck.Report{
+		{Text: "non-linear changes detected", Diagnostics: []sqlcheck.Diagnostic{{Text: "File 2.sql is not linear"}}},
+	})
+	require.Equal(t, []string{"migrations/1.sql", "."}, fs.Files)
+	require.Equal(t, []string{"destructive", "data_depend"}, fs.Analyzers)
+	require.Equal(t, []sqlcheck.Finding{
+		{Path: "migrations/1.sql", Line: 2, Analyzer: "destructive changes detected", Name: "destructive", Code: "DS102", Text: `Dropping table "t"`, Error: true},
+		{Path: "migrations/1.sql", Analyzer: "data dependent changes detected", Text: "Out of range"},
+		{Path: "migrations/1.sql", Text: "executing statement", Error: true},
+		{Path: ".", Analyzer: "non-linear changes detected", Text: "File 2.sql is not linear"},
+	}, fs.List)
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, sqlcheck.WriteSARIF(&b, findings()))
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID               string `json:"id"`
+						HelpURI          string `json:"helpUri"`
+						ShortDescription *struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex *int   `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region *struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(b.String()), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	require.Len(t, run.Results, 2)
+
+	r := run.Results[0]
+	require.Equal(t, "DS102", r.RuleID)
+	require.Equal(t, "warning", r.Level)
+	require.Equal(t, `Dropping table "t"`, r.Message.Text)
+	require.Equal(t, "migrations/2.sql", r.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, 2, r.Locations[0].PhysicalLocation.Region.StartLine)
+	rule := run.Tool.Driver.Rules[*r.RuleIndex]
+	require.Equal(t, "DS102", rule.ID)
+	require.Equal(t, "https://atlasgo.io/lint/analyzers#DS102", rule.HelpURI)
+	require.Equal(t, "destructive changes detected", rule.ShortDescription.Text)
+
+	r = run.Results[1]
+	require.Empty(t, r.RuleID)
+	require.Nil(t, r.RuleIndex)
+	require.Equal(t, "error", r.Level)
+	require.Equal(t, "migrations/3.sql", r.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Nil(t, r.Locations[0].PhysicalLocation.Region)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, sqlcheck.WriteJUnit(&b, findings()))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="atlas" tests="3" failures="1" errors="1">
+  <testsuite name="migrations/1.sql" tests="1" failures="0" errors="0">
+    <testcase name="lint" classname="migrations/1.sql"></testcase>
+  </testsuite>
+  <testsuite name="migrations/2.sql" tests="1" failures="1" errors="0">
+    <testcase name="destructive changes detected" classname="migrations/2.sql">
+      <failure message="destructive changes detected" type="DS102">L2: Dropping table &#34;t&#34; https://atlasgo.io/lint/analyzers#DS102&#xA;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="migrations/3.sql" tests="1" failures="0" errors="1">
+    <testcase name="error" classname="migrations/3.sql">
+      <error message="executing statement: syntax error">executing statement: syntax error</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, b.String())
+}
+
+func TestWriteJUnit_Analyzers(t *testing.T) {
+	var b strings.Builder
+	fs := findings()
+	fs.Analyzers = []string{"destructive", "naming"}
+	fs.List[0].Name = "destructive"
+	require.NoError(t, sqlcheck.WriteJUnit(&b, fs))
+	require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="atlas" tests="7" failures="1" errors="1">
+  <testsuite name="migrations/1.sql" tests="2" failures="0" errors="0">
+    <testcase name="destructive" classname="migrations/1.sql"></testcase>
+    <testcase name="naming" classname="migrations/1.sql"></testcase>
+  </testsuite>
+  <testsuite name="migrations/2.sql" tests="2" failures="1" errors="0">
+    <testcase name="destructive" classname="migrations/2.sql">
+      <failure message="destructive changes detected" type="DS102">L2: Dropping table &#34;t&#34; https://atlasgo.io/lint/analyzers#DS102&#xA;</failure>
+    </testcase>
+    <testcase name="naming" classname="migrations/2.sql"></testcase>
+  </testsuite>
+  <testsuite name="migrations/3.sql" tests="3" failures="0" errors="1">
+    <testcase name="error" classname="migrations/3.sql">
+      <error message="executing statement: syntax error">executing statement: syntax error</error>
+    </testcase>
+    <testcase name="destructive" classname="migrations/3.sql"></testcase>
+    <testcase name="naming" classname="migrations/3.sql"></testcase>
+  </testsuite>
+</testsuites>
+`, b.String())
+}
+
+func TestWriteGitHub(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, sqlcheck.WriteGitHub(&b, findings()))
+	require.Equal(t, `::warning file=migrations/2.sql,line=2,title=DS102::Dropping table "t" https://atlasgo.io/lint/analyzers#DS102
+::error file=migrations/3.sql::executing statement: syntax error
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, sqlcheck.WriteGitHub(&b, sqlcheck.Findings{
+		List: []sqlcheck.Finding{{Path: "a,b:c.sql", Text: "multi\nline 100%"}},
+	}))
+	require.Equal(t, "::warning file=a%2Cb%3Ac.sql::multi%0Aline 100%25\n", b.String())
+}
+
+func TestWriteGitLab(t *testing.T) {
+	var b strings.Builder
+	require.NoError(t, sqlcheck.WriteGitLab(&b, findings()))
+	var issues []struct {
+		Description string `json:"description"`
+		CheckName   string `json:"check_name"`
+		Fingerprint string `json:"fingerprint"`
+		Severity    string `json:"severity"`
+		Location    struct {
+			Path  string `json:"path"`
+			Lines struct {
+				Begin int `json:"begin"`
+			} `json:"lines"`
+		} `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(b.String()), &issues))
+	require.Len(t, issues, 2)
+	require.Equal(t, "DS102", issues[0].CheckName)
+	require.Equal(t, "major", issues[0].Severity)
+	require.Equal(t, "migrations/2.sql", issues[0].Location.Path)
+	require.Equal(t, 2, issues[0].Location.Lines.Begin)
+	require.Equal(t, "error", issues[1].CheckName)
+	require.Equal(t, "critical", issues[1].Severity)
+	require.Equal(t, 1, issues[1].Location.Lines.Begin)
+	require.NotEmpty(t, issues[0].Fingerprint)
+	require.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
@@ -0,0 +1,394 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package naming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+)
+
+type (
+	// Analyzer checks that the names of new or renamed objects follow the
+	// naming conventions configured in the `naming` block of the lint policy.
+	// The analyzer is disabled unless the block is defined. For example:
+	//
+	//	lint {
+	//	  naming {
+	//	    match   = "^[a-z][a-z0-9_]*$"
+	//	    message = "must be snake_case"
+	//	    index {
+	//	      match = "^idx_"
+	//	    }
+	//	    foreign_key {
+	//	      match = "^fk_{table}_{ref}$"
+	//	      error = false
+	//	    }
+	//	  }
+	//	}
+	Analyzer struct {
+		sqlcheck.Options
+
+		// Default rule for all objects. Object-specific
+		// rules without a pattern fall back to it.
+		Default Rule
+
+		// MaxLength is the maximum length of identifiers. Drivers
+		// set it to the limit of their dialect, and the policy
+		// can override it. Zero means no limit.
+		MaxLength int
+
+		// Table, Column, Index and ForeignKey are optional
+		// rules for the names of these objects.
+		Table, Column, Index, ForeignKey *Rule
+
+		enabled bool
+	}
+
+	// Rule describes the naming rule of a specific object type.
+	Rule struct {
+		// Match is the regular expression the name must match. The {table}
+		// placeholder is replaced with the name of the table the object
+		// belongs to, and {ref} with the referenced table of a foreign key.
+		Match string `spec:"match"`
+
+		// Message describes the rule in case the name does not match it.
+		Message string `spec:"message"`
+
+		// Error overrides the level of the analyzer for this rule.
+		Error *bool `spec:"error"`
+	}
+
+	// config is the HCL representation of the analyzer options.
+	config struct {
+		Match      string `spec:"match"`
+		Message    string `spec:"message"`
+		MaxLength  int    `spec:"max_length"`
+		Table      *Rule  `spec:"table"`
+		Column     *Rule  `spec:"column"`
+		Index      *Rule  `spec:"index"`
+		ForeignKey *Rule  `spec:"foreign_key"`
+	}
+)
+
+// New creates a new naming-convention Analyzer with the given options. The maxLen
+// argument is the default maximum identifier length of the dialect (0 for no limit).
+func New(r *schemahcl.Resource, maxLen int) (*Analyzer, error) {
+	az := &Analyzer{MaxLength: maxLen}
+	r, ok := r.Resource(az.Name())
+	if !ok {
+		return az, nil
+	}
+	var c config
+	if err := r.As(&az.Options); err != nil {
+		return nil, fmt.Errorf("sql/sqlcheck: parsing naming check options: %w", err)
+	}
+	if err := r.As(&c); err != nil {
+		return nil, fmt.Errorf("sql/sqlcheck: parsing naming check options: %w", err)
+	}
+	az.enabled = true
+	az.Default = Rule{Match: c.Match, Message: c.Message}
+	az.Table, az.Column, az.Index, az.ForeignKey = c.Table, c.Column, c.Index, c.ForeignKey
+	if _, ok := r.Attr("max_length"); ok {
+		az.MaxLength = c.MaxLength
+	}
+	for _, rule := range []*Rule{&az.Default, az.Table, az.Column, az.Index, az.ForeignKey} {
+		if rule == nil || rule.Match == "" {
+			continue
+		}
+		if _, err := rule.compile("", ""); err != nil {
+			return nil, fmt.Errorf("sql/sqlcheck: invalid naming pattern %q: %w", rule.Match, err)
+		}
+	}
+	return az, nil
+}
+
+// List of codes.
+var (
+	codeTable      = sqlcheck.Code("NM101")
+	codeColumn     = sqlcheck.Code("NM102")
+	codeIndex      = sqlcheck.Code("NM103")
+	codeForeignKey = sqlcheck.Code("NM104")
+	codeMaxLength  = sqlcheck.Code("NM105")
+)
+
+// Name of the analyzer. Implements the sqlcheck.NamedAnalyzer interface.
+func (*Analyzer) Name() string {
+	return "naming"
+}
+
+// object describes a named object to check.
+type object struct {
+	kind, code string
+	name       string
+	rule       *Rule
+	table, ref string   // Table and referenced table of the object.
+	candidates []string // Derived names, used for suggested fixes.
+	// Renames are applied as text edits only for objects that are not likely to be referenced
+	// by other statements or files, i.e., indexes and constraints, but not tables or columns.
+	editable bool
+}
+
+// Analyze implements sqlcheck.Analyzer.
+func (a *Analyzer) Analyze(_ context.Context, p *sqlcheck.Pass) error {
+	if !a.enabled {
+		return nil
+	}
+	var (
+		fail  bool
+		diags []sqlcheck.Diagnostic
+	)
+	for _, sc := range p.File.Changes {
+		var objs []*object
+		for _, c := range sc.Changes {
+			switch c := c.(type) {
+			case *schema.AddTable:
+				objs = append(objs, a.table(c.T))
+				for _, col := range c.T.Columns {
+					objs = append(objs, a.column(c.T, col))
+				}
+				for _, idx := range c.T.Indexes {
+					objs = append(objs, a.index(c.T, idx))
+				}
+				for _, fk := range c.T.ForeignKeys {
+					objs = append(objs, a.foreignKey(c.T, fk))
+				}
+			case *schema.RenameTable:
+				objs = append(objs, a.table(c.To))
+			case *schema.ModifyTable:
+				for _, mc := range c.Changes {
+					switch mc := mc.(type) {
+					case *schema.AddColumn:
+						objs = append(objs, a.column(c.T, mc.C))
+					case *schema.RenameColumn:
+						objs = append(objs, a.column(c.T, mc.To))
+					case *schema.AddIndex:
+						objs = append(objs, a.index(c.T, mc.I))
+					case *schema.RenameIndex:
+						objs = append(objs, a.index(c.T, mc.To))
+					case *schema.AddForeignKey:
+						objs = append(objs, a.foreignKey(c.T, mc.F))
+					case *schema.RenameConstraint:
+						if fk, ok := mc.To.(*schema.ForeignKey); ok {
+							objs = append(objs, a.foreignKey(c.T, fk))
+						}
+					}
+				}
+			}
+		}
+		for _, o := range objs {
+			// Unnamed objects are named by the database.
+			if o.name == "" {
+				continue
+			}
+			if d, ok := a.checkLength(sc.Stmt, o); ok {
+				diags = append(diags, d)
+				fail = fail || sqlx.V(a.Error)
+			}
+			if d, ok := a.checkMatch(p, sc.Stmt, o); ok {
+				diags = append(diags, d)
+				fail = fail || o.level(a)
+			}
+		}
+	}
+	if len(diags) > 0 {
+		const reportText = "naming convention violations detected"
+		p.Reporter.WriteReport(sqlcheck.Report{Text: reportText, Diagnostics: diags})
+		if fail {
+			return errors.New(reportText)
+		}
+	}
+	return nil
+}
+
+func (a *Analyzer) table(t *schema.Table) *object {
+	return &object{
+		kind:       "table",
+		code:       codeTable,
+		name:       t.Name,
+		rule:       a.Table,
+		table:      t.Name,
+		candidates: []string{snakeCase(t.Name)},
+	}
+}
+
+func (a *Analyzer) column(t *schema.Table, c *schema.Column) *object {
+	return &object{
+		kind:       "column",
+		code:       codeColumn,
+		name:       c.Name,
+		rule:       a.Column,
+		table:      t.Name,
+		candidates: []string{snakeCase(c.Name)},
+	}
+}
+
+func (a *Analyzer) index(t *schema.Table, idx *schema.Index) *object {
+	o := &object{
+		kind:     "index",
+		code:     codeIndex,
+		name:     idx.Name,
+		rule:     a.Index,
+		table:    t.Name,
+		editable: true,
+	}
+	name := snakeCase(idx.Name)
+	o.candidates = append(o.candidates, name, "idx_"+name)
+	var cols []string
+	for _, p := range idx.Parts {
+		if p.C == nil {
+			cols = nil
+			break
+		}
+		cols = append(cols, snakeCase(p.C.Name))
+	}
+	if len(cols) > 0 {
+		o.candidates = append(o.candidates, fmt.Sprintf("idx_%s_%s", snakeCase(t.Name), strings.Join(cols, "_")))
+	}
+	return o
+}
+
+func (a *Analyzer) foreignKey(t *schema.Table, fk *schema.ForeignKey) *object {
+	o := &object{
+		kind:     "foreign-key constraint",
+		code:     codeForeignKey,
+		name:     fk.Symbol,
+		rule:     a.ForeignKey,
+		table:    t.Name,
+		editable: true,
+	}
+	o.candidates = append(o.candidates, snakeCase(fk.Symbol))
+	if fk.RefTable != nil {
+		o.ref = fk.RefTable.Name
+		o.candidates = append(o.candidates, fmt.Sprintf("fk_%s_%s", snakeCase(t.Name), snakeCase(fk.RefTable.Name)))
+	}
+	return o
+}
+
+// level reports if a violation of the object rule is an error.
+func (o *object) level(a *Analyzer) bool {
+	if o.rule != nil && o.rule.Error != nil {
+		return *o.rule.Error
+	}
+	return sqlx.V(a.Error)
+}
+
+// effective returns the rule that applies to the object.
+func (o *object) effective(a *Analyzer) *Rule {
+	if o.rule != nil && o.rule.Match != "" {
+		return o.rule
+	}
+	if a.Default.Match != "" {
+		return &a.Default
+	}
+	return nil
+}
+
+// valid reports if the given name is valid for the object.
+func (a *Analyzer) valid(o *object, name string) bool {
+	if a.MaxLength > 0 && len(name) > a.MaxLength {
+		return false
+	}
+	r := o.effective(a)
+	if r == nil {
+		return true
+	}
+	re, err := r.compile(o.table, o.ref)
+	return err == nil && re.MatchString(name)
+}
+
+func (a *Analyzer) checkLength(stmt *migrate.Stmt, o *object) (sqlcheck.Diagnostic, bool) {
+	if a.MaxLength <= 0 || len(o.name) <= a.MaxLength {
+		return sqlcheck.Diagnostic{}, false
+	}
+	return sqlcheck.Diagnostic{
+		Code: codeMaxLength,
+		Pos:  stmt.Pos,
+		Text: fmt.Sprintf("Name of %s %q exceeds the maximum identifier length of %d characters", o.kind, o.name, a.MaxLength),
+	}, true
+}
+
+func (a *Analyzer) checkMatch(p *sqlcheck.Pass, stmt *migrate.Stmt, o *object) (sqlcheck.Diagnostic, bool) {
+	r := o.effective(a)
+	if r == nil {
+		return sqlcheck.Diagnostic{}, false
+	}
+	re, err := r.compile(o.table, o.ref)
+	if err != nil || re.MatchString(o.name) {
+		return sqlcheck.Diagnostic{}, false
+	}
+	text := fmt.Sprintf("Name of %s %q does not match the naming convention %q", o.kind, o.name, re.String())
+	if msg := r.Message; msg != "" {
+		text = fmt.Sprintf("Name of %s %q %s", o.kind, o.name, msg)
+	}
+	d := sqlcheck.Diagnostic{Code: o.code, Pos: stmt.Pos, Text: text}
+	for _, c := range o.candidates {
+		if c != "" && c != o.name && a.valid(o, c) {
+			var edit *sqlcheck.TextEdit
+			if o.editable {
+				edit = renameEdit(p, stmt, o.name, c)
+			}
+			d.SuggestFix(fmt.Sprintf("Rename %s %q to %q", o.kind, o.name, c), edit)
+			break
+		}
+	}
+	return d, true
+}
+
+// compile compiles the rule pattern after expanding its placeholders.
+func (r *Rule) compile(table, ref string) (*regexp.Regexp, error) {
+	return regexp.Compile(strings.NewReplacer(
+		"{table}", regexp.QuoteMeta(table),
+		"{ref}", regexp.QuoteMeta(ref),
+	).Replace(r.Match))
+}
+
+// renameEdit returns a text edit that renames the given identifier in the statement, or nil
+// if it cannot be done safely, i.e., the identifier does not appear exactly once in the file.
+func renameEdit(p *sqlcheck.Pass, stmt *migrate.Stmt, from, to string) *sqlcheck.TextEdit {
+	// Statements that were combined or rewritten
+	// by the driver do not exist in the file as is.
+	b := p.File.Bytes()
+	if stmt.Pos < 0 || stmt.Pos+len(stmt.Text) > len(b) || string(b[stmt.Pos:stmt.Pos+len(stmt.Text)]) != stmt.Text {
+		return nil
+	}
+	re := regexp.MustCompile(fmt.Sprintf("([\"`]?)\\b%s\\b([\"`]?)", regexp.QuoteMeta(from)))
+	if len(re.FindAllIndex(b, -1)) != 1 {
+		return nil
+	}
+	return p.File.StmtTextEdit(stmt, re.ReplaceAllString(stmt.Text, "${1}"+to+"${2}"))
+}
+
+// snakeCase converts the given identifier to snake_case.
+func snakeCase(s string) string {
+	var (
+		b  strings.Builder
+		rs = []rune(s)
+	)
+	for i, r := range rs {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) || unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1])) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	parts := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '_' })
+	return strings.Join(parts, "_")
+}
@@ -0,0 +1,186 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package naming_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlcheck"
+	"ariga.io/atlas/sql/sqlcheck/naming"
+	"ariga.io/atlas/sql/sqlclient"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzer_Naming(t *testing.T) {
+	var (
+		report *sqlcheck.Report
+		stmts  = []string{
+			"CREATE TABLE `UserAccounts` (`id` int, `FirstName` varchar(255));",
+			"ALTER TABLE `users` ADD INDEX `email` (`email`), ADD CONSTRAINT `users_orgs` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`);",
+			"ALTER TABLE `users` ADD COLUMN `a_very_long_column_name` int;",
+		}
+		text  = strings.Join(stmts, "\n")
+		users = schema.NewTable("users").AddColumns(schema.NewIntColumn("email", "int"), schema.NewIntColumn("org_id", "int"))
+		orgs  = schema.NewTable("orgs")
+		pass  = &sqlcheck.Pass{
+			Dev: &sqlclient.Client{Name: "mysql"},
+			File: &sqlcheck.File{
+				File: migrate.NewLocalFile("1.sql", []byte(text)),
+				Changes: []*sqlcheck.Change{
+					{
+						Stmt: &migrate.Stmt{Pos: 0, Text: stmts[0]},
+						Changes: schema.Changes{
+							&schema.AddTable{
+								T: schema.NewTable("UserAccounts").AddColumns(schema.NewIntColumn("id", "int"), schema.NewStringColumn("FirstName", "varchar(255)")),
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{Pos: strings.Index(text, stmts[1]), Text: stmts[1]},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T: users,
+								Changes: schema.Changes{
+									&schema.AddIndex{I: schema.NewIndex("email").AddColumns(users.Columns[0])},
+									&schema.AddForeignKey{F: schema.NewForeignKey("users_orgs").SetTable(users).AddColumns(users.Columns[1]).SetRefTable(orgs)},
+								},
+							},
+						},
+					},
+					{
+						Stmt: &migrate.Stmt{Pos: strings.Index(text, stmts[2]), Text: stmts[2]},
+						Changes: schema.Changes{
+							&schema.ModifyTable{
+								T: users,
+								Changes: schema.Changes{
+									&schema.AddColumn{C: schema.NewIntColumn("a_very_long_column_name", "int")},
+								},
+							},
+						},
+					},
+				},
+			},
+			Reporter: sqlcheck.ReportWriterFunc(func(r sqlcheck.Report) {
+				report = &r
+			}),
+		}
+	)
+	// Disabled by default.
+	az, err := naming.New(&schemahcl.Resource{}, 64)
+	require.NoError(t, err)
+	require.NoError(t, az.Analyze(context.Background(), pass))
+	require.Nil(t, report)
+
+	az, err = naming.New(&schemahcl.Resource{
+		Children: []*schemahcl.Resource{
+			{
+				Type: "naming",
+				Attrs: []*schemahcl.Attr{
+					schemahcl.BoolAttr("error", true),
+					schemahcl.StringAttr("match", "^[a-z][a-z0-9_]*$"),
+					schemahcl.StringAttr("message", "must be snake_case"),
+					schemahcl.IntAttr("max_length", 20),
+				},
+				Children: []*schemahcl.Resource{
+					{
+						Type: "index",
+						Attrs: []*schemahcl.Attr{
+							schemahcl.StringAttr("match", "^idx_"),
+							schemahcl.BoolAttr("error", false),
+						},
+					},
+					{
+						Type: "foreign_key",
+						Attrs: []*schemahcl.Attr{
+							schemahcl.StringAttr("match", "^fk_{table}_{ref}$"),
+							schemahcl.BoolAttr("error", false),
+						},
+					},
+				},
+			},
+		},
+	}, 64)
+	require.NoError(t, err)
+	require.Equal(t, 20, az.MaxLength)
+	err = az.Analyze(context.Background(), pass)
+	require.EqualError(t, err, "naming convention violations detected")
+	require.Equal(t, "naming convention violations detected", report.Text)
+	require.Equal(t, []sqlcheck.Diagnostic{
+		{
+			Code: "NM101",
+			Text: `Name of table "UserAccounts" must be snake_case`,
+			SuggestedFixes: []sqlcheck.SuggestedFix{
+				// Tables and columns might be referenced by other
+				// statements or files, and are not renamed in place.
+				{Message: `Rename table "UserAccounts" to "user_accounts"`},
+			},
+		},
+		{
+			Code: "NM102",
+			Text: `Name of column "FirstName" must be snake_case`,
+			SuggestedFixes: []sqlcheck.SuggestedFix{
+				{Message: `Rename column "FirstName" to "first_name"`},
+			},
+		},
+		{
+			Code: "NM103",
+			Pos:  pass.File.Changes[1].Stmt.Pos,
+			Text: `Name of index "email" does not match the naming convention "^idx_"`,
+			SuggestedFixes: []sqlcheck.SuggestedFix{
+				// Index name appears twice in the statement.
+				{Message: `Rename index "email" to "idx_email"`},
+			},
+		},
+		{
+			Code: "NM104",
+			Pos:  pass.File.Changes[1].Stmt.Pos,
+			Text: `Name of foreign-key constraint "users_orgs" does not match the naming convention "^fk_users_orgs$"`,
+			SuggestedFixes: []sqlcheck.SuggestedFix{
+				{
+					Message: `Rename foreign-key constraint "users_orgs" to "fk_users_orgs"`,
+					TextEdit: &sqlcheck.TextEdit{
+						Line:    2,
+						End:     2,
+						NewText: "ALTER TABLE `users` ADD INDEX `email` (`email`), ADD CONSTRAINT `fk_users_orgs` FOREIGN KEY (`org_id`) REFERENCES `orgs` (`id`);",
+					},
+				},
+			},
+		},
+		{
+			Code: "NM105",
+			Pos:  pass.File.Changes[2].Stmt.Pos,
+			Text: `Name of column "a_very_long_column_name" exceeds the maximum identifier length of 20 characters`,
+		},
+	}, report.Diagnostics)
+
+	// Constraints that are referenced elsewhere in the file are not renamed in place.
+	file := pass.File.File
+	pass.File.File = migrate.NewLocalFile("1.sql", []byte(text+"\nALTER TABLE `users` DROP FOREIGN KEY `users_orgs`;"))
+	require.Error(t, az.Analyze(context.Background(), pass))
+	require.Equal(t, []sqlcheck.SuggestedFix{{Message: `Rename foreign-key constraint "users_orgs" to "fk_users_orgs"`}}, report.Diagnostics[3].SuggestedFixes)
+	pass.File.File = file
+
+	// Warnings only.
+	pass.File.Changes = pass.File.Changes[1:2]
+	err = az.Analyze(context.Background(), pass)
+	require.NoError(t, err)
+	require.Len(t, report.Diagnostics, 2)
+
+	_, err = naming.New(&schemahcl.Resource{
+		Children: []*schemahcl.Resource{
+			{
+				Type:  "naming",
+				Attrs: []*schemahcl.Attr{schemahcl.StringAttr("match", "^[a-z")},
+			},
+		},
+	}, 64)
+	require.ErrorContains(t, err, `invalid naming pattern "^[a-z"`)
+}
@@ -0,0 +1,67 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcheck
+
+import (
+	"context"
+	"sync"
+
+	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/schema"
+	"ariga.io/atlas/sql/sqlclient"
+)
+
+type (
+	// A RealmAnalyzer describes an analyzer of a desired schema state, as opposed
+	// to an Analyzer that inspects the changes of a single migration file.
+	RealmAnalyzer interface {
+		// AnalyzeRealm executes the analysis function.
+		AnalyzeRealm(context.Context, *RealmPass) error
+	}
+
+	// A RealmPass provides information to the RealmAnalyzer.AnalyzeRealm
+	// function that applies a specific analyzer to a schema realm.
+	RealmPass struct {
+		// Realm is the schema state to analyze. The schema objects hold their
+		// source positions (schema.Pos) in case the state was loaded from HCL.
+		Realm *schema.Realm
+
+		// Dev is a driver-specific environment used to execute analysis work.
+		// It might be nil in case the state was loaded directly from a database.
+		Dev *sqlclient.Client
+
+		// Reporter reports analysis reports. The diagnostics of realm analyzers
+		// point to schema objects (Diagnostic.ObjectPos) instead of positions
+		// in migration files.
+		Reporter ReportWriter
+	}
+)
+
+// RealmAnalyzerFunc allows using ordinary functions as realm analyzers.
+type RealmAnalyzerFunc func(ctx context.Context, p *RealmPass) error
+
+// AnalyzeRealm calls f.
+func (f RealmAnalyzerFunc) AnalyzeRealm(ctx context.Context, p *RealmPass) error {
+	return f(ctx, p)
+}
+
+// drivers specific realm analyzers.
+var realmDrivers sync.Map
+
+// RegisterRealm allows drivers to register a constructor function for creating
+// realm analyzers from the given HCL resource.
+func RegisterRealm(name string, f func(*schemahcl.Resource) ([]RealmAnalyzer, error)) {
+	realmDrivers.Store(name, f)
+}
+
+// RealmAnalyzerFor instantiates a new RealmAnalyzer from the given HCL
+// resource based on the registered constructor function.
+func RealmAnalyzerFor(name string, r *schemahcl.Resource) ([]RealmAnalyzer, error) {
+	f, ok := realmDrivers.Load(name)
+	if ok {
+		return f.(func(*schemahcl.Resource) ([]RealmAnalyzer, error))(r)
+	}
+	return nil, nil
+}
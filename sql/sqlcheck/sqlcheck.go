@@ -12,6 +12,8 @@ package sqlcheck
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 
 	"ariga.io/atlas/schemahcl"
@@ -83,16 +85,19 @@ type (
 	Report struct {
 		Text           string         `json:"Text"`                     // Report text.
 		Desc           string         `json:"Desc,omitempty"`           // Optional description (secondary text).
+		Analyzer       string         `json:"Analyzer,omitempty"`       // Name of the analyzer that created the report, if known.
 		Diagnostics    []Diagnostic   `json:"Diagnostics,omitempty"`    // Report diagnostics.
 		SuggestedFixes []SuggestedFix `json:"SuggestedFixes,omitempty"` // Report-level suggested fixes.
 	}
 
-	// A Diagnostic is a text associated with a specific position of a statement in a file.
+	// A Diagnostic is a text associated with a specific position of a statement in a file,
+	// or with a specific schema object in case it was reported by a realm analyzer.
 	Diagnostic struct {
 		Pos            int            `json:"Pos"`                      // Diagnostic position.
 		Text           string         `json:"Text"`                     // Diagnostic text.
 		Code           string         `json:"Code"`                     // Code describes the check. For example, DS101
 		SuggestedFixes []SuggestedFix `json:"SuggestedFixes,omitempty"` // Fixes to this specific diagnostics (statement-level).
+		ObjectPos      *schema.Pos    `json:"ObjectPos,omitempty"`      // Position of the schema object, reported by realm analyzers.
 	}
 
 	// A SuggestedFix is a change associated with a diagnostic that can
@@ -161,6 +166,29 @@ func (f ReportWriterFunc) WriteReport(r Report) {
 	f(r)
 }
 
+// StmtTextEdit returns a TextEdit that replaces the lines of the given statement
+// with the given text. Since edits replace whole lines, a nil edit is returned if
+// the statement is not in the file, or if its lines contain other text, such as
+// other statements or trailing comments.
+func (f *File) StmtTextEdit(s *migrate.Stmt, text string) *TextEdit {
+	b := string(f.Bytes())
+	end := s.Pos + len(s.Text)
+	if s.Pos < 0 || end > len(b) || b[s.Pos:end] != s.Text {
+		return nil
+	}
+	before := b[strings.LastIndexByte(b[:s.Pos], '\n')+1 : s.Pos]
+	after, _, _ := strings.Cut(b[end:], "\n")
+	if strings.TrimSpace(before) != "" || strings.TrimSpace(after) != "" {
+		return nil
+	}
+	line := strings.Count(b[:s.Pos], "\n") + 1
+	return &TextEdit{
+		Line:    line,
+		End:     line + strings.Count(s.Text, "\n"),
+		NewText: text,
+	}
+}
+
 // ResourceSpan describes the lifespan of a resource
 // in perspective to the migration file.
 type ResourceSpan uint
@@ -303,6 +331,17 @@ func Code(code string) string {
 	return code
 }
 
+// Codes returns the sorted list of codes stored in the registry.
+func Codes() []string {
+	var list []string
+	codes.Range(func(k, _ any) bool {
+		list = append(list, k.(string))
+		return true
+	})
+	slices.Sort(list)
+	return list
+}
+
 // drivers specific analyzers.
 var drivers sync.Map
 
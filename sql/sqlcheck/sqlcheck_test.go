@@ -0,0 +1,43 @@
+// Copyright 2021-present The Atlas Authors. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcheck_test
+
+import (
+	"strings"
+	"testing"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqlcheck"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_StmtTextEdit(t *testing.T) {
+	text := strings.Join([]string{
+		"CREATE TABLE t1 (c int);",
+		"  CREATE TABLE t2 (",
+		"    c int",
+		"  );",
+		"CREATE TABLE t3 (c int); CREATE TABLE t4 (c int);",
+		"CREATE TABLE t5 (c int); -- comment",
+	}, "\n")
+	var (
+		f    = &sqlcheck.File{File: migrate.NewLocalFile("1.sql", []byte(text))}
+		stmt = func(s string) *migrate.Stmt {
+			return &migrate.Stmt{Pos: strings.Index(text, s), Text: s}
+		}
+	)
+	require.Equal(t, &sqlcheck.TextEdit{Line: 1, End: 1, NewText: "x"}, f.StmtTextEdit(stmt("CREATE TABLE t1 (c int);"), "x"))
+	require.Equal(t, &sqlcheck.TextEdit{Line: 2, End: 4, NewText: "x"}, f.StmtTextEdit(stmt("CREATE TABLE t2 (\n    c int\n  );"), "x"))
+
+	// Statements that share their lines with other text.
+	require.Nil(t, f.StmtTextEdit(stmt("CREATE TABLE t3 (c int);"), "x"))
+	require.Nil(t, f.StmtTextEdit(stmt("CREATE TABLE t4 (c int);"), "x"))
+	require.Nil(t, f.StmtTextEdit(stmt("CREATE TABLE t5 (c int);"), "x"))
+
+	// Statements that are not in the file.
+	require.Nil(t, f.StmtTextEdit(&migrate.Stmt{Pos: len(text), Text: "DROP TABLE t1;"}, "x"))
+	require.Nil(t, f.StmtTextEdit(&migrate.Stmt{Pos: 0, Text: "DROP TABLE t1;"}, "x"))
+}
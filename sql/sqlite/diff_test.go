@@ -346,6 +346,66 @@ func TestDiff_SchemaDiff(t *testing.T) {
 	}, changes)
 }
 
+func TestDiff_SchemaDiffViews(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	drv, err := Open(db)
+	require.NoError(t, err)
+	from := schema.New("main").AddViews(
+		schema.NewView("v1", "SELECT 1"),
+		schema.NewView("v2", "SELECT 1\n  FROM users"),
+		schema.NewView("v3", "SELECT 1").SetComment("c"),
+		schema.NewView("v4", "SELECT 1"),
+	)
+	to := schema.New("main").AddViews(
+		schema.NewView("v2", "SELECT 1 FROM users"),
+		schema.NewView("v3", "SELECT 1").SetComment("d"),
+		schema.NewView("v4", "SELECT 2"),
+		schema.NewView("v5", "SELECT 1"),
+	)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	require.Equal(t, &schema.DropView{V: from.Views[0]}, changes[0])
+	require.Equal(t, &schema.ModifyView{
+		From:    from.Views[2],
+		To:      to.Views[1],
+		Changes: []schema.Change{&schema.ModifyAttr{From: &schema.Comment{Text: "c"}, To: &schema.Comment{Text: "d"}}},
+	}, changes[1])
+	require.Equal(t, &schema.ModifyView{From: from.Views[3], To: to.Views[2]}, changes[2])
+	require.Equal(t, &schema.AddView{V: to.Views[3]}, changes[3])
+}
+
+func TestDiff_SchemaDiffTriggers(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	drv, err := Open(db)
+	require.NoError(t, err)
+	trigger := func(name, body string) *schema.Trigger {
+		return schema.NewTrigger(name).
+			SetActionTime(schema.TriggerTimeAfter).
+			AddEvents(schema.TriggerEventInsert).
+			SetFor(schema.TriggerForRow).
+			SetBody(body)
+	}
+	users1 := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+	users1.AddTriggers(trigger("t1", "BEGIN SELECT 1; END"), trigger("t2", "BEGIN SELECT 1; END"), trigger("t3", "BEGIN SELECT 1; END"))
+	users2 := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+	users2.AddTriggers(trigger("t2", "BEGIN SELECT 1; END;"), trigger("t3", "BEGIN SELECT 2; END"), trigger("t4", "BEGIN SELECT 1; END"))
+	logs := schema.NewTable("logs").AddColumns(schema.NewIntColumn("id", "int"))
+	logs.AddTriggers(trigger("t5", "BEGIN SELECT 1; END"))
+	from := schema.New("main").AddTables(users1)
+	to := schema.New("main").AddTables(users2, logs)
+	changes, err := drv.SchemaDiff(from, to)
+	require.NoError(t, err)
+	require.Len(t, changes, 5)
+	require.Equal(t, &schema.AddTable{T: logs}, changes[0])
+	require.Equal(t, &schema.DropTrigger{T: users1.Triggers[0]}, changes[1])
+	require.Equal(t, &schema.ModifyTrigger{From: users1.Triggers[2], To: users2.Triggers[1]}, changes[2])
+	require.Equal(t, &schema.AddTrigger{T: users2.Triggers[2]}, changes[3])
+	require.Equal(t, &schema.AddTrigger{T: logs.Triggers[0]}, changes[4])
+}
+
 func TestDefaultDiff(t *testing.T) {
 	changes, err := DefaultDiff.SchemaDiff(
 		schema.New("main").
@@ -420,6 +420,23 @@ func (i *inspect) InspectRealm(ctx context.Context, opts *schema.InspectRealmOpt
 		}
 		sqlx.LinkSchemaTables(r.Schemas)
 	}
+	if mode.Is(schema.InspectViews) {
+		for _, s := range schemas {
+			if err := i.inspectViews(ctx, s, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if mode.Is(schema.InspectTriggers) {
+		for _, s := range schemas {
+			if err := i.inspectTriggers(ctx, s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r, err = schema.IncludeRealm(r, opts.Include); err != nil {
+		return nil, err
+	}
 	return schema.ExcludeRealm(r, opts.Exclude)
 }
 
@@ -460,13 +477,29 @@ func (i *inspect) InspectSchema(ctx context.Context, name string, opts *schema.I
 		}
 		sqlx.LinkSchemaTables(schemas)
 	}
-	return schema.ExcludeSchema(r.Schemas[0], opts.Exclude)
+	if mode.Is(schema.InspectViews) {
+		if err := i.inspectViews(ctx, r.Schemas[0], opts); err != nil {
+			return nil, err
+		}
+	}
+	if mode.Is(schema.InspectTriggers) {
+		if err := i.inspectTriggers(ctx, r.Schemas[0]); err != nil {
+			return nil, err
+		}
+	}
+	s, err := schema.IncludeSchema(r.Schemas[0], opts.Include)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ExcludeSchema(s, opts.Exclude)
 }
 
 var (
 	specOptions []schemahcl.Option
 	scanFuncs   = &specutil.ScanFuncs{
-		Table: convertTable,
+		Table:   convertTable,
+		View:    convertView,
+		Trigger: convertTrigger,
 	}
 )
 
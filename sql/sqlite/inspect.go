@@ -51,6 +51,37 @@ func (i *inspect) columns(ctx context.Context, t *schema.Table) error {
 
 // addColumn scans the current row and adds a new column from it to the table.
 func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
+	c, primary, hidden, err := scanColumn(rows)
+	if err != nil {
+		return err
+	}
+	// The hidden flag is set to 2 for VIRTUAL columns, and to
+	// 3 for STORED columns. See: sqlite/pragma.c#sqlite3Pragma.
+	if hidden >= 2 {
+		if err := setGenExpr(t, c, hidden); err != nil {
+			return err
+		}
+	}
+	t.Columns = append(t.Columns, c)
+	if primary {
+		if t.PrimaryKey == nil {
+			t.SetPrimaryKey(&schema.Index{
+				Name:   "PRIMARY",
+				Unique: true,
+				Table:  t,
+			})
+		}
+		// Columns are ordered by the `pk` field.
+		t.PrimaryKey.Parts = append(t.PrimaryKey.Parts, &schema.IndexPart{
+			C:     c,
+			SeqNo: len(t.PrimaryKey.Parts) + 1,
+		})
+	}
+	return nil
+}
+
+// scanColumn scans the current row of the table_xinfo pragma into a column.
+func scanColumn(rows *sql.Rows) (*schema.Column, bool, int64, error) {
 	var (
 		nullable, primary   bool
 		hidden              sql.NullInt64
@@ -58,7 +89,7 @@ func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 		err                 error
 	)
 	if err = rows.Scan(&name, &typ, &nullable, &defaults, &primary, &hidden); err != nil {
-		return err
+		return nil, false, 0, err
 	}
 	c := &schema.Column{
 		Name: name.String,
@@ -69,36 +100,170 @@ func (i *inspect) addColumn(t *schema.Table, rows *sql.Rows) error {
 	}
 	c.Type.Type, err = ParseType(typ.String)
 	if err != nil {
-		return err
+		return nil, false, 0, err
 	}
 	if defaults.Valid {
 		c.Default = defaultExpr(defaults.String)
 	}
-	// The hidden flag is set to 2 for VIRTUAL columns, and to
-	// 3 for STORED columns. See: sqlite/pragma.c#sqlite3Pragma.
-	if hidden.Int64 >= 2 {
-		if err := setGenExpr(t, c, hidden.Int64); err != nil {
-			return err
+	return c, primary, hidden.Int64, nil
+}
+
+// views returns a list of all views exist in the schema.
+func (i *inspect) views(ctx context.Context, opts *schema.InspectOptions) ([]*schema.View, error) {
+	var (
+		args  []any
+		query = viewsQuery
+	)
+	if opts != nil && len(opts.Tables) > 0 {
+		query += " AND name IN (" + strings.Repeat("?, ", len(opts.Tables)-1) + "?)"
+		for _, s := range opts.Tables {
+			args = append(args, s)
 		}
 	}
-	t.Columns = append(t.Columns, c)
-	if primary {
-		if t.PrimaryKey == nil {
-			t.SetPrimaryKey(&schema.Index{
-				Name:   "PRIMARY",
-				Unique: true,
-				Table:  t,
-			})
+	rows, err := i.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite: querying schema views: %w", err)
+	}
+	defer rows.Close()
+	var views []*schema.View
+	for rows.Next() {
+		var name, stmt string
+		if err := rows.Scan(&name, &stmt); err != nil {
+			return nil, fmt.Errorf("sqlite: scanning view: %w", err)
 		}
-		// Columns are ordered by the `pk` field.
-		t.PrimaryKey.Parts = append(t.PrimaryKey.Parts, &schema.IndexPart{
-			C:     c,
-			SeqNo: len(t.PrimaryKey.Parts) + 1,
+		stmt = strings.TrimSpace(stmt)
+		matches := reViewDef.FindStringSubmatch(stmt)
+		if len(matches) != 2 {
+			return nil, fmt.Errorf("sqlite: unexpected definition for view %q: %s", name, stmt)
+		}
+		views = append(views, &schema.View{
+			Name:  name,
+			Def:   strings.TrimSpace(matches[1]),
+			Attrs: []schema.Attr{&CreateStmt{S: stmt}},
 		})
 	}
+	return views, rows.Err()
+}
+
+// viewColumns queries and appends the columns of the given view.
+func (i *inspect) viewColumns(ctx context.Context, v *schema.View) error {
+	rows, err := i.QueryContext(ctx, fmt.Sprintf(columnsQuery, v.Name))
+	if err != nil {
+		return fmt.Errorf("sqlite: querying %q columns: %w", v.Name, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c, _, _, err := scanColumn(rows)
+		if err != nil {
+			return fmt.Errorf("sqlite: %w", err)
+		}
+		v.AddColumns(c)
+	}
+	return rows.Err()
+}
+
+// inspectViews inspects the views of the given schema and links them to
+// the tables and views they depend on. SQLite does not record dependencies
+// between views and tables, and therefore they are extracted from the view
+// definitions.
+func (i *inspect) inspectViews(ctx context.Context, s *schema.Schema, opts *schema.InspectOptions) error {
+	views, err := i.views(ctx, opts)
+	if err != nil {
+		return err
+	}
+	s.AddViews(views...)
+	for _, v := range views {
+		if err := i.viewColumns(ctx, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range views {
+		for _, t := range s.Tables {
+			if refersTo(v.Def, t.Name) {
+				v.AddDeps(t)
+			}
+		}
+		for _, dv := range views {
+			if dv != v && refersTo(v.Def, dv.Name) {
+				v.AddDeps(dv)
+			}
+		}
+	}
 	return nil
 }
 
+// inspectTriggers inspects the triggers of the given schema and attaches
+// them to their tables and views. Triggers of tables or views that were
+// not inspected are skipped.
+func (i *inspect) inspectTriggers(ctx context.Context, s *schema.Schema) error {
+	rows, err := i.QueryContext(ctx, triggersQuery)
+	if err != nil {
+		return fmt.Errorf("sqlite: querying schema triggers: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, table, stmt string
+		if err := rows.Scan(&name, &table, &stmt); err != nil {
+			return fmt.Errorf("sqlite: scanning trigger: %w", err)
+		}
+		t := schema.NewTrigger(name)
+		if tb, ok := s.Table(table); ok {
+			tb.AddTriggers(t)
+		} else if v, ok := s.View(table); ok {
+			v.AddTriggers(t)
+		} else {
+			continue
+		}
+		if err := parseTrigger(t, stmt); err != nil {
+			return fmt.Errorf("sqlite: parse trigger %q definition: %w", name, err)
+		}
+	}
+	return rows.Err()
+}
+
+// parseTrigger parses the CREATE statement of the trigger and sets its
+// action time, event, WHEN condition, and body. SQLite supports only
+// row-level triggers, and BEFORE is the default action time.
+func parseTrigger(t *schema.Trigger, stmt string) error {
+	matches := reTriggerDef.FindStringSubmatch(strings.TrimSpace(stmt))
+	if len(matches) != 6 {
+		return fmt.Errorf("unexpected definition: %q", stmt)
+	}
+	t.SetActionTime(schema.TriggerTimeBefore).SetFor(schema.TriggerForRow)
+	if matches[1] != "" {
+		t.SetActionTime(schema.TriggerTime(strings.ToUpper(strings.Join(strings.Fields(matches[1]), " "))))
+	}
+	e := schema.TriggerEvent{Name: strings.ToUpper(matches[2])}
+	if matches[3] != "" {
+		if t.Table == nil {
+			return fmt.Errorf("unexpected UPDATE OF event on view %q", t.View.Name)
+		}
+		var cs []*schema.Column
+		for _, c := range strings.Split(matches[3], ",") {
+			c = strings.Trim(strings.TrimSpace(c), "`\"[]")
+			column, ok := t.Table.Column(c)
+			if !ok {
+				return fmt.Errorf("column %q was not found in table %q", c, t.Table.Name)
+			}
+			cs = append(cs, column)
+		}
+		e = schema.TriggerEventUpdateOf(cs...)
+	}
+	t.AddEvents(e)
+	if matches[4] != "" {
+		t.SetWhen(strings.TrimSpace(matches[4]))
+	}
+	t.SetBody(strings.TrimSpace(matches[5]))
+	return nil
+}
+
+// refersTo reports if the given definition refers to an object
+// with the given name, quoted or unquoted, in a case-insensitive way.
+func refersTo(def, name string) bool {
+	re, err := regexp.Compile(`(?i)(?:^|[^\w$])["\x60\[]?` + regexp.QuoteMeta(name) + `["\x60\]]?(?:[^\w$]|$)`)
+	return err == nil && re.MatchString(def)
+}
+
 // indexes queries and appends the indexes of the given table.
 func (i *inspect) indexes(ctx context.Context, t *schema.Table) error {
 	rows, err := i.QueryContext(ctx, fmt.Sprintf(indexesQuery, t.Name))
@@ -468,6 +633,12 @@ func isBlob(s string) bool {
 
 var reAutoinc = regexp.MustCompile("(?i)(?:[(,]\\s*)[\"`]?(\\w+)[\"`]?\\s+INTEGER\\s+[^,]*PRIMARY\\s+KEY\\s+[^,]*AUTOINCREMENT")
 
+// reViewDef extracts the view definition from its CREATE statement.
+var reViewDef = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?VIEW\s+(?:IF\s+NOT\s+EXISTS\s+)?.+?\s+AS\s+(.+)$`)
+
+// reTriggerDef extracts the trigger definition parts from its CREATE statement.
+var reTriggerDef = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:"[^"]+"|\x60[^\x60]+\x60|\[[^\]]+\]|\S+)\s+(?:(BEFORE|AFTER|INSTEAD\s+OF)\s+)?(DELETE|INSERT|UPDATE)(?:\s+OF\s+(.+?))?\s+ON\s+(?:"[^"]+"|\x60[^\x60]+\x60|\[[^\]]+\]|\S+)(?:\s+FOR\s+EACH\s+ROW)?(?:\s+WHEN\s+(.+?))?\s+(BEGIN\b.+)$`)
+
 // autoinc checks if the table contains a "PRIMARY KEY AUTOINCREMENT" on its
 // CREATE statement, according to https://www.sqlite.org/syntax/column-constraint.html.
 // This is a workaround until we will embed a proper SQLite parser in atlas.
@@ -662,6 +833,12 @@ WHERE
 	AND sqlite_master.name NOT LIKE 'sqlite_%'
 	AND sqlite_master.name NOT LIKE 'libsql_%'
 `
+	// Query to list database views.
+	viewsQuery = "SELECT `name`, `sql` FROM sqlite_master WHERE `type` = 'view' AND `name` NOT LIKE 'sqlite_%'"
+
+	// Query to list schema triggers.
+	triggersQuery = "SELECT `name`, `tbl_name`, `sql` FROM sqlite_master WHERE `type` = 'trigger' ORDER BY `tbl_name`, `name`"
+
 	// Query to list table information.
 	columnsQuery = "SELECT `name`, `type`, (not `notnull`) AS `nullable`, `dflt_value`, (`pk` <> 0) AS `pk`, `hidden` FROM pragma_table_xinfo('%s') ORDER BY `cid`"
 	// Query to list table indexes.
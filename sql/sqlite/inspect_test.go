@@ -289,7 +289,7 @@ CREATE TABLE users(
 			tt.before(mk)
 			s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
 				Tables: []string{"users"},
-				Mode:   ^schema.InspectViews,
+				Mode:   ^(schema.InspectViews | schema.InspectTriggers),
 			})
 			require.NoError(t, err)
 			tt.expect(require.New(t), s.Tables[0], err)
@@ -481,7 +481,7 @@ func TestRegex_Checks(t *testing.T) {
 		require.NoError(t, err)
 		s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
 			Tables: []string{name},
-			Mode:   ^schema.InspectViews,
+			Mode:   ^(schema.InspectViews | schema.InspectTriggers),
 		})
 		require.NoError(t, err)
 		table := s.Tables[0]
@@ -546,7 +546,7 @@ func TestRegex_GeneratedExpr(t *testing.T) {
 		require.NoError(t, err)
 		s, err := drv.InspectSchema(context.Background(), "", &schema.InspectOptions{
 			Tables: []string{name},
-			Mode:   ^schema.InspectViews,
+			Mode:   ^(schema.InspectViews | schema.InspectTriggers),
 		})
 		require.NoError(t, err)
 		require.Equal(t, tt.column.Attrs, s.Tables[0].Columns[0].Attrs)
@@ -574,6 +574,59 @@ func (m mock) tableExists(table string, exists bool, stmt ...string) {
 		WillReturnRows(rows)
 }
 
+func TestRegex_Triggers(t *testing.T) {
+	users := schema.NewTable("users").
+		AddColumns(
+			schema.NewIntColumn("id", "integer"),
+			schema.NewStringColumn("name", "text"),
+		)
+	v := schema.NewView("active", "SELECT * FROM users")
+	tests := []struct {
+		stmt  string
+		owner func(*schema.Trigger)
+		want  *schema.Trigger
+	}{
+		{
+			stmt:  "CREATE TRIGGER t1 AFTER INSERT ON users BEGIN INSERT INTO logs VALUES (NEW.id); END",
+			owner: func(t *schema.Trigger) { t.SetTable(users) },
+			want: schema.NewTrigger("t1").
+				SetTable(users).
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventInsert).
+				SetFor(schema.TriggerForRow).
+				SetBody("BEGIN INSERT INTO logs VALUES (NEW.id); END"),
+		},
+		{
+			stmt:  "CREATE TRIGGER IF NOT EXISTS `t 2` UPDATE OF `name`, id ON \"users\" FOR EACH ROW WHEN NEW.id > 0 BEGIN\n  SELECT 1;\nEND",
+			owner: func(t *schema.Trigger) { t.SetTable(users) },
+			want: schema.NewTrigger("t 2").
+				SetTable(users).
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventUpdateOf(users.Columns[1], users.Columns[0])).
+				SetFor(schema.TriggerForRow).
+				SetWhen("NEW.id > 0").
+				SetBody("BEGIN\n  SELECT 1;\nEND"),
+		},
+		{
+			stmt:  "create temp trigger t3 instead  of delete on active begin delete from users where id = old.id; end",
+			owner: func(t *schema.Trigger) { t.SetView(v) },
+			want: schema.NewTrigger("t3").
+				SetView(v).
+				SetActionTime(schema.TriggerTimeInstead).
+				AddEvents(schema.TriggerEventDelete).
+				SetFor(schema.TriggerForRow).
+				SetBody("begin delete from users where id = old.id; end"),
+		},
+	}
+	for _, tt := range tests {
+		tr := schema.NewTrigger(tt.want.Name)
+		tt.owner(tr)
+		require.NoError(t, parseTrigger(tr, tt.stmt))
+		require.Equal(t, tt.want, tr)
+	}
+	require.Error(t, parseTrigger(schema.NewTrigger("t").SetTable(users), "CREATE TRIGGER t INSERT ON users SELECT 1"))
+}
+
 func (m mock) noColumns(table string) {
 	m.ExpectQuery(sqltest.Escape(fmt.Sprintf(columnsQuery, table))).
 		WillReturnRows(sqlmock.NewRows([]string{"name", "type", "nullable", "dflt_value", "primary"}))
@@ -72,11 +72,23 @@ type state struct {
 	migrate.Plan
 	migrate.PlanOptions
 	skipFKs bool
+	// Tables that are recreated by the plan using a temporary
+	// table. Their triggers are recreated along with them.
+	recreated map[string]bool
 }
 
 // Exec executes the changes on the database. An error is returned
 // if one of the operations fail, or a change is not supported.
 func (s *state) plan(ctx context.Context, changes []schema.Change) (err error) {
+	if s.PlanOptions.Mode != migrate.PlanModeUnsortedDump {
+		changes = sortViews(changes)
+	}
+	s.recreated = make(map[string]bool)
+	for _, c := range changes {
+		if m, ok := c.(*schema.ModifyTable); ok && !alterable(m) {
+			s.recreated[m.T.Name] = true
+		}
+	}
 	for _, c := range changes {
 		switch c := c.(type) {
 		case *schema.AddTable:
@@ -87,6 +99,22 @@ func (s *state) plan(ctx context.Context, changes []schema.Change) (err error) {
 			err = s.modifyTable(ctx, c)
 		case *schema.RenameTable:
 			s.renameTable(c)
+		case *schema.AddView:
+			s.addView(c)
+		case *schema.DropView:
+			s.dropView(c)
+		case *schema.ModifyView:
+			s.modifyView(c)
+		case *schema.RenameView:
+			s.renameView(c)
+		case *schema.AddTrigger:
+			err = s.addTrigger(c)
+		case *schema.DropTrigger:
+			err = s.dropTrigger(c)
+		case *schema.ModifyTrigger:
+			err = s.modifyTrigger(c)
+		case *schema.RenameTrigger:
+			err = s.modifyTrigger(&schema.ModifyTrigger{From: c.From, To: c.To})
 		default:
 			err = fmt.Errorf("unsupported change %T", c)
 		}
@@ -222,7 +250,16 @@ func (s *state) modifyTable(ctx context.Context, modify *schema.ModifyTable) err
 		Source:  modify,
 		Comment: fmt.Sprintf("rename temporary table %q to %q", newT.Name, modify.T.Name),
 	})
-	return s.addIndexes(modify.T, indexes...)
+	if err := s.addIndexes(modify.T, indexes...); err != nil {
+		return err
+	}
+	// Triggers are dropped along with the table, and therefore, they are recreated.
+	for _, t := range modify.T.Triggers {
+		if err := s.createTrigger(&schema.AddTrigger{T: t}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *state) renameTable(c *schema.RenameTable) {
@@ -234,6 +271,169 @@ func (s *state) renameTable(c *schema.RenameTable) {
 	})
 }
 
+// sortViews returns the changes sorted such that views and triggers are dropped before any
+// table change, and created after them, as tables cannot depend on views, and triggers
+// depend on their tables. Table changes keep their order.
+func sortViews(changes []schema.Change) []schema.Change {
+	var drop, add, other []schema.Change
+	for _, c := range changes {
+		switch c.(type) {
+		case *schema.DropView, *schema.DropTrigger:
+			drop = append(drop, c)
+		case *schema.AddView, *schema.ModifyView, *schema.RenameView,
+			*schema.AddTrigger, *schema.ModifyTrigger, *schema.RenameTrigger:
+			add = append(add, c)
+		default:
+			other = append(other, c)
+		}
+	}
+	if len(drop) == 0 && len(add) == 0 {
+		return changes
+	}
+	sorted := append(sqlx.SortChanges(drop, nil), other...)
+	return append(sorted, sqlx.SortChanges(add, nil)...)
+}
+
+// addView builds and executes the query for creating a view.
+func (s *state) addView(add *schema.AddView) {
+	b := s.Build("CREATE VIEW")
+	if sqlx.Has(add.Extra, &schema.IfNotExists{}) {
+		b.P("IF NOT EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(add.V.Name).P("AS", add.V.Def).String(),
+		Source:  add,
+		Comment: fmt.Sprintf("create %q view", add.V.Name),
+		Reverse: s.Build("DROP VIEW").Ident(add.V.Name).String(),
+	})
+}
+
+// dropView builds and executes the query for dropping a view.
+func (s *state) dropView(drop *schema.DropView) {
+	b := s.Build("DROP VIEW")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(drop.V.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop %q view", drop.V.Name),
+		Reverse: s.Build("CREATE VIEW").Ident(drop.V.Name).P("AS", drop.V.Def).String(),
+	})
+}
+
+// modifyView recreates the view, as SQLite does not support
+// the 'CREATE OR REPLACE VIEW' and 'ALTER VIEW' statements.
+func (s *state) modifyView(modify *schema.ModifyView) {
+	if !sqlx.ViewDefChanged(modify) {
+		return
+	}
+	s.dropView(&schema.DropView{V: modify.From})
+	s.addView(&schema.AddView{V: modify.To})
+}
+
+// renameView recreates the view with its new name.
+func (s *state) renameView(c *schema.RenameView) {
+	s.dropView(&schema.DropView{V: c.From})
+	s.addView(&schema.AddView{V: c.To})
+}
+
+// addTrigger builds and executes the query for creating a trigger. Triggers
+// of recreated tables are skipped, as they are created along with the table.
+func (s *state) addTrigger(add *schema.AddTrigger) error {
+	if s.onRecreated(add.T) {
+		return nil
+	}
+	return s.createTrigger(add)
+}
+
+// createTrigger appends the 'CREATE TRIGGER' change of the given trigger.
+func (s *state) createTrigger(add *schema.AddTrigger) error {
+	create, err := s.triggerDef(add.T)
+	if err != nil {
+		return err
+	}
+	s.append(&migrate.Change{
+		Cmd:     create,
+		Source:  add,
+		Comment: fmt.Sprintf("create trigger %q", add.T.Name),
+		Reverse: s.Build("DROP TRIGGER").Ident(add.T.Name).String(),
+	})
+	return nil
+}
+
+// dropTrigger builds and executes the query for dropping a trigger. Triggers
+// of recreated tables are skipped, as they are dropped along with the table.
+func (s *state) dropTrigger(drop *schema.DropTrigger) error {
+	if s.onRecreated(drop.T) {
+		return nil
+	}
+	create, err := s.triggerDef(drop.T)
+	if err != nil {
+		return err
+	}
+	b := s.Build("DROP TRIGGER")
+	if sqlx.Has(drop.Extra, &schema.IfExists{}) {
+		b.P("IF EXISTS")
+	}
+	s.append(&migrate.Change{
+		Cmd:     b.Ident(drop.T.Name).String(),
+		Source:  drop,
+		Comment: fmt.Sprintf("drop trigger %q", drop.T.Name),
+		Reverse: create,
+	})
+	return nil
+}
+
+// modifyTrigger recreates the trigger, as SQLite does not support
+// the 'CREATE OR REPLACE TRIGGER' and 'ALTER TRIGGER' statements.
+func (s *state) modifyTrigger(modify *schema.ModifyTrigger) error {
+	if err := s.dropTrigger(&schema.DropTrigger{T: modify.From}); err != nil {
+		return err
+	}
+	return s.addTrigger(&schema.AddTrigger{T: modify.To})
+}
+
+// onRecreated reports if the trigger is defined on a recreated table.
+func (s *state) onRecreated(t *schema.Trigger) bool {
+	return t.Table != nil && s.recreated[t.Table.Name]
+}
+
+// triggerDef returns the 'CREATE TRIGGER' statement of the given trigger.
+func (s *state) triggerDef(t *schema.Trigger) (string, error) {
+	switch {
+	case t.Table == nil && t.View == nil:
+		return "", fmt.Errorf("missing table or view for trigger %q", t.Name)
+	case t.Body == "":
+		return "", fmt.Errorf("incomplete trigger definition: %q", t.Name)
+	case len(t.Events) != 1:
+		return "", fmt.Errorf("trigger %q must be defined with exactly one event, got %d", t.Name, len(t.Events))
+	case t.For == schema.TriggerForStmt:
+		return "", fmt.Errorf("statement-level triggers are not supported by SQLite: %q", t.Name)
+	}
+	b := s.Build("CREATE TRIGGER").Ident(t.Name)
+	if t.ActionTime != "" {
+		b.P(string(t.ActionTime))
+	}
+	b.P(t.Events[0].Name)
+	if cs := t.Events[0].Columns; len(cs) > 0 {
+		b.MapComma(cs, func(i int, b *sqlx.Builder) {
+			b.Ident(cs[i].Name)
+		})
+	}
+	b.P("ON")
+	if t.View != nil {
+		b.Ident(t.View.Name)
+	} else {
+		b.Ident(t.Table.Name)
+	}
+	b.P("FOR EACH ROW")
+	if w := (schema.TriggerWhen{}); sqlx.Has(t.Attrs, &w) && w.X != "" {
+		b.P("WHEN", w.X)
+	}
+	return b.P(strings.TrimSuffix(strings.TrimSpace(t.Body), ";")).String(), nil
+}
+
 func (s *state) column(b *sqlx.Builder, c *schema.Column) error {
 	t, err := FormatType(c.Type.Type)
 	if err != nil {
@@ -20,12 +20,13 @@ import (
 )
 
 func TestPlanChanges(t *testing.T) {
-	tests := []struct {
+	type testCase struct {
 		changes []schema.Change
 		options []migrate.PlanOption
 		mock    func(mock)
 		plan    *migrate.Plan
-	}{
+	}
+	tests := []testCase{
 		{
 			changes: []schema.Change{
 				&schema.AddTable{
@@ -478,6 +479,149 @@ func TestPlanChanges(t *testing.T) {
 				},
 			},
 		},
+		// Views are dropped before table changes, and created after them.
+		func() testCase {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+			v1 := schema.NewView("v1", "SELECT id FROM users").AddDeps(users)
+			v2 := schema.NewView("v2", "SELECT id FROM v1").AddDeps(v1)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddView{V: v2},
+					&schema.AddView{V: v1},
+					&schema.AddTable{T: users},
+					&schema.DropView{V: schema.NewView("v3", "SELECT 1")},
+					&schema.ModifyView{From: schema.NewView("v4", "SELECT 1"), To: schema.NewView("v4", "SELECT 2")},
+				},
+				plan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "DROP VIEW `v3`",
+							Reverse: "CREATE VIEW `v3` AS SELECT 1",
+						},
+						{
+							Cmd:     "CREATE TABLE `users` (`id` int NOT NULL)",
+							Reverse: "DROP TABLE `users`",
+						},
+						{
+							Cmd:     "CREATE VIEW `v1` AS SELECT id FROM users",
+							Reverse: "DROP VIEW `v1`",
+						},
+						{
+							Cmd:     "CREATE VIEW `v2` AS SELECT id FROM v1",
+							Reverse: "DROP VIEW `v2`",
+						},
+						{
+							Cmd:     "DROP VIEW `v4`",
+							Reverse: "CREATE VIEW `v4` AS SELECT 1",
+						},
+						{
+							Cmd:     "CREATE VIEW `v4` AS SELECT 2",
+							Reverse: "DROP VIEW `v4`",
+						},
+					},
+				},
+			}
+		}(),
+		// Triggers are dropped before table changes, and created after them.
+		func() testCase {
+			users := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+			t1 := schema.NewTrigger("t1").
+				SetActionTime(schema.TriggerTimeAfter).
+				AddEvents(schema.TriggerEventUpdateOf(users.Columns[0])).
+				SetFor(schema.TriggerForRow).
+				SetWhen("NEW.id > 0").
+				SetBody("BEGIN SELECT 1; END;")
+			users.AddTriggers(t1)
+			logs := schema.NewTable("logs").AddColumns(schema.NewIntColumn("id", "int"))
+			t2 := schema.NewTrigger("t2").
+				SetActionTime(schema.TriggerTimeBefore).
+				AddEvents(schema.TriggerEventDelete).
+				SetFor(schema.TriggerForRow).
+				SetBody("BEGIN SELECT 2; END")
+			logs.AddTriggers(t2)
+			return testCase{
+				changes: []schema.Change{
+					&schema.AddTrigger{T: t1},
+					&schema.AddTable{T: users},
+					&schema.DropTrigger{T: t2},
+				},
+				plan: &migrate.Plan{
+					Reversible:    true,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd:     "DROP TRIGGER `t2`",
+							Reverse: "CREATE TRIGGER `t2` BEFORE DELETE ON `logs` FOR EACH ROW BEGIN SELECT 2; END",
+						},
+						{
+							Cmd:     "CREATE TABLE `users` (`id` int NOT NULL)",
+							Reverse: "DROP TABLE `users`",
+						},
+						{
+							Cmd:     "CREATE TRIGGER `t1` AFTER UPDATE OF `id` ON `users` FOR EACH ROW WHEN NEW.id > 0 BEGIN SELECT 1; END",
+							Reverse: "DROP TRIGGER `t1`",
+						},
+					},
+				},
+			}
+		}(),
+		// Triggers of recreated tables are recreated along with them.
+		func() testCase {
+			from := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"))
+			to := schema.NewTable("users").AddColumns(schema.NewIntColumn("id", "int"), schema.NewIntColumn("c", "int"))
+			from.AddTriggers(schema.NewTrigger("t1").SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEventInsert).SetFor(schema.TriggerForRow).SetBody("BEGIN SELECT 1; END"))
+			to.AddTriggers(
+				schema.NewTrigger("t1").SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEventInsert).SetFor(schema.TriggerForRow).SetBody("BEGIN SELECT 1; END"),
+				schema.NewTrigger("t2").SetActionTime(schema.TriggerTimeAfter).AddEvents(schema.TriggerEventDelete).SetFor(schema.TriggerForRow).SetBody("BEGIN SELECT 2; END"),
+			)
+			return testCase{
+				changes: []schema.Change{
+					&schema.ModifyTable{
+						T: to,
+						Changes: []schema.Change{
+							&schema.ModifyColumn{From: from.Columns[0], To: to.Columns[0], Change: schema.ChangeNull},
+							&schema.AddColumn{C: to.Columns[1]},
+						},
+					},
+					&schema.AddTrigger{T: to.Triggers[1]},
+				},
+				plan: &migrate.Plan{
+					Reversible:    false,
+					Transactional: true,
+					Changes: []*migrate.Change{
+						{
+							Cmd: "PRAGMA foreign_keys = off",
+						},
+						{
+							Cmd:     "CREATE TABLE `new_users` (`id` int NOT NULL, `c` int NOT NULL)",
+							Reverse: "DROP TABLE `new_users`",
+						},
+						{
+							Cmd: "INSERT INTO `new_users` (`id`) SELECT `id` FROM `users`",
+						},
+						{
+							Cmd: "DROP TABLE `users`",
+						},
+						{
+							Cmd: "ALTER TABLE `new_users` RENAME TO `users`",
+						},
+						{
+							Cmd:     "CREATE TRIGGER `t1` AFTER INSERT ON `users` FOR EACH ROW BEGIN SELECT 1; END",
+							Reverse: "DROP TRIGGER `t1`",
+						},
+						{
+							Cmd:     "CREATE TRIGGER `t2` AFTER DELETE ON `users` FOR EACH ROW BEGIN SELECT 2; END",
+							Reverse: "DROP TRIGGER `t2`",
+						},
+						{
+							Cmd: "PRAGMA foreign_keys = on",
+						},
+					},
+				},
+			}
+		}(),
 	}
 	for i, tt := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -10,13 +10,16 @@ import (
 	"strings"
 
 	"ariga.io/atlas/schemahcl"
+	"ariga.io/atlas/sql/internal/sqlx"
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 	"ariga.io/atlas/sql/sqlcheck"
 	"ariga.io/atlas/sql/sqlcheck/condrop"
 	"ariga.io/atlas/sql/sqlcheck/datadepend"
+	"ariga.io/atlas/sql/sqlcheck/design"
 	"ariga.io/atlas/sql/sqlcheck/destructive"
 	"ariga.io/atlas/sql/sqlcheck/incompatible"
+	"ariga.io/atlas/sql/sqlcheck/naming"
 	"ariga.io/atlas/sql/sqlite"
 )
 
@@ -72,6 +75,11 @@ func analyzers(r *schemahcl.Resource) ([]sqlcheck.Analyzer, error) {
 	if err != nil {
 		return nil, err
 	}
+	// SQLite does not limit the length of identifiers.
+	nm, err := naming.New(r, 0)
+	if err != nil {
+		return nil, err
+	}
 	return []sqlcheck.Analyzer{
 		sqlcheck.AnalyzerFunc(func(_ context.Context, p *sqlcheck.Pass) error {
 			var changes []*sqlcheck.Change
@@ -114,7 +122,7 @@ func analyzers(r *schemahcl.Resource) ([]sqlcheck.Analyzer, error) {
 			p.File.Changes = changes
 			return nil
 		}),
-		ds, dd, cd, bc,
+		ds, dd, cd, bc, nm,
 	}, nil
 }
 
@@ -159,6 +167,21 @@ func isRenameT(c schema.Change, from, to string) bool {
 	return ok && r.From.Name == from && r.To.Name == to
 }
 
+func realmAnalyzers(r *schemahcl.Resource) ([]sqlcheck.RealmAnalyzer, error) {
+	sd, err := design.New(r, design.Handler{
+		// SQLite does not limit the size of index keys.
+		BoundedPart: func(*schema.IndexPart) bool { return true },
+		Partial: func(idx *schema.Index) bool {
+			return sqlx.Has(idx.Attrs, &sqlite.IndexPredicate{})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []sqlcheck.RealmAnalyzer{sd}, nil
+}
+
 func init() {
 	sqlcheck.Register(sqlite.DriverName, analyzers)
+	sqlcheck.RegisterRealm(sqlite.DriverName, realmAnalyzers)
 }
@@ -20,8 +20,10 @@ import (
 )
 
 type doc struct {
-	Tables  []*sqlspec.Table  `spec:"table"`
-	Schemas []*sqlspec.Schema `spec:"schema"`
+	Tables   []*sqlspec.Table   `spec:"table"`
+	Views    []*sqlspec.View    `spec:"view"`
+	Triggers []*sqlspec.Trigger `spec:"trigger"`
+	Schemas  []*sqlspec.Schema  `spec:"schema"`
 }
 
 // Codec for schemahcl.
@@ -43,7 +45,7 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 			return err
 		}
 		if err := specutil.Scan(v,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
 			scanFuncs,
 		); err != nil {
 			return fmt.Errorf("sqlite: failed converting to *schema.Realm: %w", err)
@@ -58,7 +60,7 @@ func (c *Codec) EvalOptions(p *hclparse.Parser, v any, opts *schemahcl.EvalOptio
 		}
 		r := &schema.Realm{}
 		if err := specutil.Scan(r,
-			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables},
+			&specutil.ScanDoc{Schemas: d.Schemas, Tables: d.Tables, Views: d.Views, Triggers: d.Triggers},
 			scanFuncs,
 		); err != nil {
 			return err
@@ -124,6 +126,13 @@ func convertIndex(spec *sqlspec.Index, t *schema.Table) (*schema.Index, error) {
 	return idx, nil
 }
 
+// convertView converts a sqlspec.View to a schema.View.
+func convertView(spec *sqlspec.View, parent *schema.Schema) (*schema.View, error) {
+	return specutil.View(spec, parent, func(c *sqlspec.Column, _ *schema.View) (*schema.Column, error) {
+		return specutil.Column(c, convertColumnType)
+	})
+}
+
 // convertColumn converts a sqlspec.Column into a schema.Column.
 func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error) {
 	c, err := specutil.Column(spec, convertColumnType)
@@ -145,6 +154,19 @@ func convertColumn(spec *sqlspec.Column, _ *schema.Table) (*schema.Column, error
 	return c, nil
 }
 
+// convertTrigger converts a sqlspec.Trigger to a schema.Trigger. SQLite supports
+// only row-level triggers, and therefore, the "for" attribute defaults to ROW.
+func convertTrigger(spec *sqlspec.Trigger, r *schema.Realm) (*schema.Trigger, error) {
+	t, err := specutil.Trigger(spec, r)
+	if err != nil {
+		return nil, err
+	}
+	if t.For == "" {
+		t.For = schema.TriggerForRow
+	}
+	return t, nil
+}
+
 // convertColumnType converts a sqlspec.Column into a concrete SQLite schema.Type.
 func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 	return TypeRegistry.Type(spec.Type, spec.Extra.Attrs)
@@ -153,7 +175,21 @@ func convertColumnType(spec *sqlspec.Column) (schema.Type, error) {
 // schemaSpec converts from a concrete SQLite schema to Atlas specification.
 func schemaSpec(s *schema.Schema) (*specutil.SchemaSpec, error) {
 	return specutil.FromSchema(s, &specutil.SchemaFuncs{
-		Table: tableSpec,
+		Table:   tableSpec,
+		View:    viewSpec,
+		Trigger: triggerSpec,
+	})
+}
+
+// triggerSpec converts from a concrete SQLite schema.Trigger to a sqlspec.Trigger.
+func triggerSpec(t *schema.Trigger) (*sqlspec.Trigger, error) {
+	return specutil.FromTrigger(t, nil)
+}
+
+// viewSpec converts from a concrete SQLite schema.View to a sqlspec.View.
+func viewSpec(v *schema.View) (*sqlspec.View, error) {
+	return specutil.FromView(v, func(c *schema.Column, _ *schema.View) (*sqlspec.Column, error) {
+		return specutil.FromColumn(c, columnTypeSpec)
 	})
 }
 
@@ -263,7 +299,9 @@ var (
 		State: schemahcl.New(append(
 			specOptions,
 			schemahcl.WithTypes("table.column.type", TypeRegistry.Specs()),
+			schemahcl.WithTypes("view.column.type", TypeRegistry.Specs()),
 			schemahcl.WithScopedEnums("table.column.as.type", stored, virtual),
+			schemahcl.WithScopedEnums("trigger.for", schema.TriggerForRow),
 			schemahcl.WithScopedEnums("table.foreign_key.on_update", specutil.ReferenceVars...),
 			schemahcl.WithScopedEnums("table.foreign_key.on_delete", specutil.ReferenceVars...),
 		)...),
@@ -466,6 +466,60 @@ schema "test" {
 	require.EqualValues(t, expected, string(buf))
 }
 
+func TestSpec_Trigger(t *testing.T) {
+	const f = `table "users" {
+  schema = schema.main
+  column "id" {
+    null = false
+    type = int
+  }
+}
+view "active" {
+  schema = schema.main
+  column "id" {
+    null = false
+    type = int
+  }
+  as = "SELECT id FROM users"
+}
+trigger "users_upd" {
+  on   = table.users
+  for  = ROW
+  when = "NEW.id > 0"
+  as   = "BEGIN SELECT 1; END"
+  after {
+    update_of = [table.users.column.id]
+  }
+}
+trigger "active_del" {
+  on  = view.active
+  for = ROW
+  as  = "BEGIN DELETE FROM users WHERE id = OLD.id; END"
+  instead_of {
+    delete = true
+  }
+}
+schema "main" {
+}
+`
+	var s schema.Schema
+	require.NoError(t, EvalHCLBytes([]byte(f), &s, nil))
+	users, ok := s.Table("users")
+	require.True(t, ok)
+	require.Len(t, users.Triggers, 1)
+	require.Equal(t, schema.TriggerTimeAfter, users.Triggers[0].ActionTime)
+	require.Equal(t, []schema.TriggerEvent{schema.TriggerEventUpdateOf(users.Columns[0])}, users.Triggers[0].Events)
+	active, ok := s.View("active")
+	require.True(t, ok)
+	require.Len(t, active.Triggers, 1)
+	require.Equal(t, active, active.Triggers[0].View)
+	require.Equal(t, schema.TriggerTimeInstead, active.Triggers[0].ActionTime)
+	require.Equal(t, schema.TriggerForRow, active.Triggers[0].For)
+	buf, err := MarshalHCL(&s)
+	require.NoError(t, err)
+	require.Equal(t, f, string(buf))
+}
+
 func TestInputVars(t *testing.T) {
 	spectest.TestInputVars(t, EvalHCL)
 }
@@ -35,6 +35,65 @@ type (
 		Range *hcl.Range `spec:",range"`
 	}
 
+	// View holds a specification for an SQL view.
+	View struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Columns   []*Column      `spec:"column"`
+		Indexes   []*Index       `spec:"index"` // Materialized views only.
+		// The definition is appended as additional attribute
+		// by the spec creator to marshal it after the columns.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// Func holds a specification for a function.
+	Func struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Args      []*FuncArg     `spec:"arg"`
+		// The language, return type, body and the rest of the
+		// attributes are appended by the spec creator to marshal
+		// them after the arguments.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// Proc holds a specification for a procedure.
+	Proc struct {
+		Name      string         `spec:",name"`
+		Qualifier string         `spec:",qualifier"`
+		Schema    *schemahcl.Ref `spec:"schema"`
+		Args      []*FuncArg     `spec:"arg"`
+		// The language, body and the rest of the attributes are
+		// appended by the spec creator to marshal them after the
+		// arguments.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// Trigger holds a specification for a trigger. The schema of the
+	// trigger is derived from the table or the view it is defined on.
+	Trigger struct {
+		Name string         `spec:",name"`
+		On   *schemahcl.Ref `spec:"on"`
+		// The action time, events, condition, body and the rest
+		// of the attributes are appended by the spec creator.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// FuncArg holds a specification for a function or procedure argument.
+	// The argument mode and default value are optionally added to the spec.
+	FuncArg struct {
+		Name string          `spec:",name"`
+		Type *schemahcl.Type `spec:"type"`
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
 	// Column holds a specification for a column in an SQL table.
 	Column struct {
 		Name string          `spec:",name"`
@@ -101,6 +160,42 @@ type (
 		schemahcl.DefaultExtension
 		Range *hcl.Range `spec:",range"`
 	}
+
+	// Role holds a specification for a realm-level role.
+	Role struct {
+		Name     string           `spec:",name"`
+		MemberOf []*schemahcl.Ref `spec:"member_of"`
+		// Driver-specific options (e.g., superuser or connection
+		// limit) are optionally added to the role definition.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// User holds a specification for a realm-level user. Note, passwords are
+	// not supported, as they would be written to planned statements. Instead,
+	// they should be set separately.
+	User struct {
+		Name     string           `spec:",name"`
+		Host     string           `spec:"host,omitempty"`
+		MemberOf []*schemahcl.Ref `spec:"member_of"`
+		// Driver-specific options (e.g., connection limit)
+		// are optionally added to the user definition.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
+
+	// Permission holds a specification for privileges granted
+	// to a role or a user on a schema, a table or its columns.
+	Permission struct {
+		Name      string           `spec:",name"`
+		To        *schemahcl.Ref   `spec:"to"`
+		For       *schemahcl.Ref   `spec:"for"`
+		Columns   []*schemahcl.Ref `spec:"columns"`
+		Grantable bool             `spec:"grantable,omitempty"`
+		// The privileges are added to the permission definition.
+		schemahcl.DefaultExtension
+		Range *hcl.Range `spec:",range"`
+	}
 )
 
 // Label returns the defaults label used for the table resource.
@@ -115,6 +210,42 @@ func (t *Table) SetQualifier(q string) { t.Qualifier = q }
 // SchemaRef returns the schema reference for the table.
 func (t *Table) SchemaRef() *schemahcl.Ref { return t.Schema }
 
+// Label returns the defaults label used for the view resource.
+func (v *View) Label() string { return v.Name }
+
+// QualifierLabel returns the qualifier label used for the view resource, if any.
+func (v *View) QualifierLabel() string { return v.Qualifier }
+
+// SetQualifier sets the qualifier label used for the view resource.
+func (v *View) SetQualifier(q string) { v.Qualifier = q }
+
+// SchemaRef returns the schema reference for the view.
+func (v *View) SchemaRef() *schemahcl.Ref { return v.Schema }
+
+// Label returns the defaults label used for the function resource.
+func (f *Func) Label() string { return f.Name }
+
+// QualifierLabel returns the qualifier label used for the function resource, if any.
+func (f *Func) QualifierLabel() string { return f.Qualifier }
+
+// SetQualifier sets the qualifier label used for the function resource.
+func (f *Func) SetQualifier(q string) { f.Qualifier = q }
+
+// SchemaRef returns the schema reference for the function.
+func (f *Func) SchemaRef() *schemahcl.Ref { return f.Schema }
+
+// Label returns the defaults label used for the procedure resource.
+func (p *Proc) Label() string { return p.Name }
+
+// QualifierLabel returns the qualifier label used for the procedure resource, if any.
+func (p *Proc) QualifierLabel() string { return p.Qualifier }
+
+// SetQualifier sets the qualifier label used for the procedure resource.
+func (p *Proc) SetQualifier(q string) { p.Qualifier = q }
+
+// SchemaRef returns the schema reference for the procedure.
+func (p *Proc) SchemaRef() *schemahcl.Ref { return p.Schema }
+
 // Label returns the defaults label used for the sequence resource.
 func (s *Sequence) Label() string { return s.Name }
 
@@ -129,8 +260,15 @@ func (s *Sequence) SchemaRef() *schemahcl.Ref { return s.Schema }
 
 func init() {
 	schemahcl.Register("table", &Table{})
+	schemahcl.Register("view", &View{})
+	schemahcl.Register("function", &Func{})
+	schemahcl.Register("procedure", &Proc{})
+	schemahcl.Register("trigger", &Trigger{})
 	schemahcl.Register("sequence", &Sequence{})
 	schemahcl.Register("schema", &Schema{})
+	schemahcl.Register("role", &Role{})
+	schemahcl.Register("user", &User{})
+	schemahcl.Register("permission", &Permission{})
 }
 
 // normalizeCRLF for heredoc strings that inspected and printed in the HCL as-is to